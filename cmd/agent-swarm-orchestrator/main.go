@@ -19,6 +19,7 @@ func main() {
 	agentCount := flag.Int("agents", 24, "Number of concurrent agents to spawn")
 	taskLimit := flag.Int("tasks", 30, "Max tasks to process")
 	timeout := flag.Duration("timeout", 5*time.Minute, "Timeout per agent execution")
+	learningsPath := flag.String("learnings", "", "Learning store to read and record lessons (e.g. "+orchestration.DefaultLearningStorePath+"); empty disables it")
 	flag.Parse()
 
 	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
@@ -27,10 +28,21 @@ func main() {
 	// Create logger
 	logger := &SimpleLogger{}
 
+	// Persist lessons across runs when a learning store is configured
+	var mem0 orchestration.Mem0Client = &MockMem0Client{}
+	if *learningsPath != "" {
+		store, err := orchestration.NewLearningStore(*learningsPath)
+		if err != nil {
+			log.Fatalf("Failed to open learning store: %v", err)
+		}
+		logger.Infof("Loaded %d learnings from %s", store.Len(), *learningsPath)
+		mem0 = store
+	}
+
 	// Create coordinator
 	coordinator := orchestration.NewCoordinator(
 		mockSpawner(logger),
-		&MockMem0Client{},
+		mem0,
 		logger,
 	)
 
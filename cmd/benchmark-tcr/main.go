@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"open-swarm/internal/agent"
 	"open-swarm/internal/temporal"
 
 	"go.temporal.io/sdk/client"
@@ -24,6 +25,7 @@ func main() {
 	prompt := flag.String("prompt", "", "Coding challenge prompt")
 	branch := flag.String("branch", "main", "Git branch")
 	concurrency := flag.Int("concurrency", 0, "Max concurrent runs (0 = unlimited)")
+	backends := flag.String("backends", "", "Comma-separated agent backends to compare (opencode, claude-code, aider, anthropic)")
 
 	flag.Parse()
 
@@ -35,6 +37,17 @@ func main() {
 		log.Fatal("❌ Strategy must be 'basic' or 'enhanced'")
 	}
 
+	var backendList []string
+	if *backends != "" {
+		for _, name := range strings.Split(*backends, ",") {
+			backend, err := agent.ParseBackend(name)
+			if err != nil {
+				log.Fatalf("❌ %v", err)
+			}
+			backendList = append(backendList, string(backend))
+		}
+	}
+
 	if *concurrency == 0 {
 		*concurrency = *runs
 	}
@@ -57,6 +70,7 @@ func main() {
 		Prompt:      *prompt,
 		Description: "Benchmark Evaluation",
 		RepoBranch:  *branch,
+		Backends:    backendList,
 	}
 
 	we, err := c.ExecuteWorkflow(context.Background(), client.StartWorkflowOptions{
@@ -90,6 +104,24 @@ func printReport(r temporal.BenchmarkResult) {
 	fmt.Printf("⏱️  Avg Time:  %s\n", r.AvgDuration.Round(time.Second))
 	fmt.Println("------------------------------------------")
 
+	// Per-backend comparison
+	if len(r.BackendStats) > 1 {
+		fmt.Println("\n🤖 Backends:")
+		for _, b := range r.BackendStats {
+			fmt.Printf("  %-12s %d/%d passed (%.1f%%), avg %s\n",
+				b.Backend, b.SuccessCount, b.Runs, pct(b.SuccessCount, b.Runs), b.AvgDuration.Round(time.Second))
+		}
+	}
+
+	// Per-template comparison
+	if len(r.TemplateStats) > 0 {
+		fmt.Println("\n📝 Prompt Templates:")
+		for _, t := range r.TemplateStats {
+			fmt.Printf("  %-24s %d/%d passed (%.1f%%)\n",
+				t.Template, t.SuccessCount, t.Runs, t.SuccessRate*100)
+		}
+	}
+
 	// Detailed run results
 	if len(r.RunResults) > 0 {
 		fmt.Println("\n📋 Individual Run Results:")
@@ -99,6 +131,9 @@ func printReport(r temporal.BenchmarkResult) {
 				status = "❌"
 			}
 			fmt.Printf("  %s Run #%d: %s", status, run.RunID, run.Duration.Round(time.Second))
+			if run.Backend != "" {
+				fmt.Printf(" [%s]", run.Backend)
+			}
 			if !run.Success && run.Error != "" {
 				fmt.Printf(" - Error: %s", run.Error)
 			}
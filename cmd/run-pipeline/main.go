@@ -0,0 +1,163 @@
+// Run a declarative pipeline file as a TDD DAG workflow and stream progress
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/client"
+
+	"open-swarm/pkg/dag"
+)
+
+func main() {
+	// Parse command line flags
+	file := flag.String("file", "pipeline.yaml", "Pipeline file")
+	workflowID := flag.String("id", "", "Workflow ID (default: pipeline name and start time)")
+	branch := flag.String("branch", "main", "Git branch")
+	taskQueue := flag.String("task-queue", "reactor-task-queue", "Temporal task queue")
+	validateOnly := flag.Bool("validate", false, "Validate the pipeline file and exit")
+	poll := flag.Duration("poll", 2*time.Second, "Progress polling interval")
+	flag.Parse()
+
+	pipeline, err := dag.LoadPipeline(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *validateOnly {
+		fmt.Printf("✅ %s is valid: %d tasks\n", *file, len(pipeline.Tasks))
+		return
+	}
+
+	if *workflowID == "" {
+		name := pipeline.Name
+		if name == "" {
+			name = "pipeline"
+		}
+		*workflowID = fmt.Sprintf("%s-%d", name, time.Now().Unix())
+	}
+
+	// Connect to Temporal server
+	c, err := client.Dial(client.Options{
+		HostPort: client.DefaultHostPort, // localhost:7233
+	})
+	if err != nil {
+		log.Fatalln("❌ Unable to connect to Temporal server:", err)
+	}
+	defer c.Close()
+
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Printf("🚀 Pipeline %s - %d tasks\n", pipeline.Name, len(pipeline.Tasks))
+	fmt.Println(strings.Repeat("=", 80))
+
+	ctx := context.Background()
+	workflowRun, err := c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:        *workflowID,
+		TaskQueue: *taskQueue,
+	}, dag.TddDagWorkflow, dag.WorkflowInput{
+		WorkflowID: *workflowID,
+		Branch:     *branch,
+		Tasks:      pipeline.Tasks,
+	})
+	if err != nil {
+		log.Fatalln("❌ Failed to start workflow:", err)
+	}
+	fmt.Printf("✅ Workflow started with ID: %s\n\n", workflowRun.GetID())
+
+	done := make(chan error, 1)
+	go func() { done <- workflowRun.Get(ctx, nil) }()
+
+	ticker := time.NewTicker(*poll)
+	defer ticker.Stop()
+	progress := &progressPrinter{workflowID: workflowRun.GetID(), last: make(map[string]dag.TaskState)}
+	for {
+		select {
+		case err := <-done:
+			progress.update(ctx, c)
+			fmt.Println(strings.Repeat("=", 80))
+			if err != nil {
+				log.Fatalln("❌ Pipeline failed:", err)
+			}
+			fmt.Println("🎉 Pipeline completed successfully!")
+			return
+		case <-ticker.C:
+			progress.update(ctx, c)
+		}
+	}
+}
+
+// progressPrinter prints task state changes between polls
+type progressPrinter struct {
+	workflowID string
+	last       map[string]dag.TaskState
+	waiting    bool
+}
+
+func (p *progressPrinter) update(ctx context.Context, c client.Client) {
+	value, err := c.QueryWorkflow(ctx, p.workflowID, "", dag.StateQuery)
+	if err != nil {
+		return // Not started yet or already closed
+	}
+	var states []dag.TaskState
+	if err := value.Get(&states); err != nil {
+		return
+	}
+
+	running, failed := 0, 0
+	for _, state := range states {
+		switch state.Status {
+		case dag.TaskRunning:
+			running++
+		case dag.TaskFailed:
+			failed++
+		}
+		if previous, ok := p.last[state.Name]; ok && previous.Status == state.Status && previous.Runs == state.Runs {
+			continue
+		}
+		p.last[state.Name] = state
+		if state.Status == dag.TaskPending {
+			continue
+		}
+		cached := ""
+		if state.Status == dag.TaskCompleted && state.Cached {
+			cached = ", cached"
+		}
+		fmt.Printf("%s %-30s %s (run %d%s)\n", statusIcon(state.Status), state.Name, state.Status, state.Runs, cached)
+		if state.Status == dag.TaskFailed && state.Error != "" {
+			fmt.Printf("   Error: %s\n", state.Error)
+		}
+	}
+
+	// Failed tasks with nothing running: the workflow waits for a fix
+	if failed > 0 && running == 0 {
+		if !p.waiting {
+			p.waiting = true
+			fmt.Printf("\n⏸️  Waiting for a fix. After fixing, resume with:\n")
+			fmt.Printf("   temporal workflow signal --workflow-id %s --name %s --input '{\"Files\": [\"path/you/changed.go\"]}'\n\n",
+				p.workflowID, dag.FixAppliedSignal)
+		}
+	} else {
+		p.waiting = false
+	}
+}
+
+func statusIcon(status string) string {
+	switch status {
+	case dag.TaskRunning:
+		return "⏳"
+	case dag.TaskCompleted:
+		return "✅"
+	case dag.TaskFailed:
+		return "❌"
+	case dag.TaskSkipped:
+		return "⏭️ "
+	default:
+		return "  "
+	}
+}
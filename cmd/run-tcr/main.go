@@ -6,10 +6,12 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"go.temporal.io/sdk/client"
 
+	"open-swarm/internal/config"
 	"open-swarm/internal/temporal"
 )
 
@@ -23,8 +25,29 @@ func main() {
 	maxRetries := flag.Int("retries", 2, "Max regeneration attempts")
 	maxFixes := flag.Int("fixes", 5, "Max fix attempts per regeneration")
 	reviewers := flag.Int("reviewers", 2, "Number of reviewers")
+	backend := flag.String("backend", "", "Agent backend: opencode, claude-code, aider, anthropic (default from model config)")
+	reportDir := flag.String("report-dir", "", "Write SARIF, checkstyle and JUnit reports and review comments of the gate results to this directory")
+	mergeInto := flag.String("merge-into", "", "Enqueue the finished cell branch in the merge queue for this target branch")
+	models := flag.String("models", "", "Comma-separated provider/model ladder for implementation attempts, e.g. a fast model, then a stronger one")
+	session := flag.String("session", "per_task", "Agent sessions: per_task shares one across test, implementation and fixes; per_gate starts one per gate")
+	maxTurns := flag.Int("session-turns", 0, "Turns before a shared session is summarized into a new one (default 50)")
 	flag.Parse()
 
+	sessionPolicy, err := temporal.ParseSessionPolicy(*session)
+	if err != nil {
+		log.Fatalln("❌", err)
+	}
+
+	// Fall back to the implementation agent's backend from .claude/opencode.yaml;
+	// the configured fmt command drives lint auto-fix
+	fmtCommand := ""
+	if cfg, err := config.Load(); err == nil {
+		if *backend == "" {
+			*backend, _ = cfg.Model.AgentBackend("implementation")
+		}
+		fmtCommand = cfg.Build.Commands.Fmt
+	}
+
 	// Connect to Temporal server
 	c, err := client.Dial(client.Options{
 		HostPort: client.DefaultHostPort, // localhost:7233
@@ -34,9 +57,9 @@ func main() {
 	}
 	defer c.Close()
 
-	fmt.Println("\n" + "="*80)
+	fmt.Println("\n" + strings.Repeat("=", 80))
 	fmt.Println("🚀 Enhanced TCR Workflow - Real Execution")
-	fmt.Println("="*80)
+	fmt.Println(strings.Repeat("=", 80))
 	fmt.Printf("Task ID:           %s\n", *taskID)
 	fmt.Printf("Cell ID:           %s\n", *cellID)
 	fmt.Printf("Branch:            %s\n", *branch)
@@ -44,7 +67,12 @@ func main() {
 	fmt.Printf("Max Retries:       %d\n", *maxRetries)
 	fmt.Printf("Max Fix Attempts:  %d\n", *maxFixes)
 	fmt.Printf("Reviewers:         %d\n", *reviewers)
-	fmt.Println("="*80 + "\n")
+	fmt.Printf("Backend:           %s\n", *backend)
+	fmt.Printf("Sessions:          %s\n", sessionPolicy)
+	if *models != "" {
+		fmt.Printf("Model Ladder:      %s\n", *models)
+	}
+	fmt.Println(strings.Repeat("=", 80) + "\n")
 
 	// Prepare workflow input
 	input := temporal.EnhancedTCRInput{
@@ -56,6 +84,11 @@ func main() {
 		MaxRetries:         *maxRetries,
 		MaxFixAttempts:     *maxFixes,
 		ReviewersCount:     *reviewers,
+		Backend:            *backend,
+		FmtCommand:         fmtCommand,
+		ModelLadder:        splitModels(*models),
+		SessionPolicy:      sessionPolicy,
+		SessionMaxTurns:    *maxTurns,
 	}
 
 	// Start workflow
@@ -82,9 +115,9 @@ func main() {
 	}
 
 	// Display results
-	fmt.Println("\n" + "="*80)
+	fmt.Println("\n" + strings.Repeat("=", 80))
 	fmt.Println("📊 Workflow Results")
-	fmt.Println("="*80)
+	fmt.Println(strings.Repeat("=", 80))
 	fmt.Printf("Status:          %v\n", result.Success)
 	fmt.Printf("Error:           %s\n", result.Error)
 	fmt.Printf("Files Changed:   %d files\n", len(result.FilesChanged))
@@ -92,7 +125,7 @@ func main() {
 
 	// Display gate-by-gate results
 	fmt.Println("Gate Execution Details:")
-	fmt.Println("-" * 80)
+	fmt.Println(strings.Repeat("-", 80))
 	for i, gate := range result.GateResults {
 		status := "✅ PASS"
 		if !gate.Passed {
@@ -104,11 +137,51 @@ func main() {
 		}
 	}
 
-	fmt.Println("="*80)
+	if *reportDir != "" {
+		paths, err := temporal.WriteGateReports(*reportDir, result)
+		if err != nil {
+			log.Println("⚠️  Failed to write reports:", err)
+		}
+		for _, path := range paths {
+			fmt.Printf("📄 Report written: %s\n", path)
+		}
+	}
+
+	if *mergeInto != "" && result.Success && result.Branch != "" {
+		// One queue per target branch serializes integration
+		queueID := "merge-queue-" + *mergeInto
+		request := temporal.MergeRequest{TaskID: *taskID, Branch: result.Branch, Commit: result.Commit, Task: &input}
+		_, err := c.SignalWithStartWorkflow(ctx, queueID, temporal.MergeQueueEnqueueSignal, request,
+			client.StartWorkflowOptions{ID: queueID, TaskQueue: "reactor-task-queue"},
+			temporal.MergeQueueWorkflow, temporal.MergeQueueInput{
+				TargetBranch: *mergeInto,
+				Backend:      *backend,
+				FmtCommand:   fmtCommand,
+				IdleTimeout:  10 * time.Minute,
+			})
+		if err != nil {
+			log.Println("⚠️  Failed to enqueue merge:", err)
+		} else {
+			fmt.Printf("🔀 Enqueued %s for merge into %s (workflow %s)\n", result.Branch, *mergeInto, queueID)
+		}
+	}
+
+	fmt.Println(strings.Repeat("=", 80))
 	if result.Success {
 		fmt.Println("🎉 Workflow completed successfully!")
 	} else {
 		fmt.Println("⚠️  Workflow failed - check errors above")
 	}
-	fmt.Println("="*80 + "\n")
+	fmt.Println(strings.Repeat("=", 80) + "\n")
+}
+
+// splitModels parses the -models flag into a model ladder
+func splitModels(flagValue string) []string {
+	var models []string
+	for _, model := range strings.Split(flagValue, ",") {
+		if model = strings.TrimSpace(model); model != "" {
+			models = append(models, model)
+		}
+	}
+	return models
 }
@@ -10,14 +10,19 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/worker"
 
+	"open-swarm/internal/agent"
+	"open-swarm/internal/config"
+	"open-swarm/internal/prompts/templates"
 	"open-swarm/internal/telemetry"
 	"open-swarm/internal/temporal"
+	"open-swarm/internal/workflow"
 	"open-swarm/pkg/dag"
 )
 
@@ -26,6 +31,7 @@ const (
 	maxConcurrentWorkflowTaskExecutionSize  = 10
 	maxConcurrentLocalActivityExecutionSize = 100
 	workerStopTimeout                       = 30 * time.Second
+	taskQueue                               = "reactor-task-queue"
 )
 
 func main() {
@@ -65,6 +71,31 @@ func main() {
 	log.Println("🔧 Initializing global managers...")
 	temporal.InitializeGlobals(8000, 9000, ".", "./worktrees")
 
+	// Route gate models over the providers of the cells' OpenCode servers,
+	// with the model preferences of .claude/opencode.yaml applied
+	temporal.InitializeModelRouting(loadModelConfig())
+
+	// Optional cassette: record live agent traffic, or replay it offline (e.g. in CI)
+	if cassettePath := os.Getenv("OPEN_SWARM_CASSETTE"); cassettePath != "" {
+		mode, err := agent.ParseCassetteMode(os.Getenv("OPEN_SWARM_CASSETTE_MODE"))
+		if err != nil {
+			log.Fatalln("❌ Invalid cassette mode:", err)
+		}
+		cassette, err := temporal.InitializeCassette(cassettePath, mode)
+		if err != nil {
+			log.Fatalln("❌ Unable to load cassette:", err)
+		}
+		log.Printf("📼 Cassette %s mode: %s (%d interactions)", mode, cassettePath, cassette.Len())
+	}
+
+	// Optional prompt templates: tune prompts without a rebuild, A/B test variants
+	if promptDir := os.Getenv(templates.DirEnv); promptDir != "" {
+		if err := templates.LoadDefault(promptDir); err != nil {
+			log.Fatalln("❌ Unable to load prompt templates:", err)
+		}
+		log.Printf("📝 Prompt templates loaded from %s", promptDir)
+	}
+
 	// Connect to Temporal server
 	c, err := client.Dial(client.Options{
 		HostPort: client.DefaultHostPort, // localhost:7233
@@ -81,8 +112,21 @@ func main() {
 
 	log.Println("✅ Connected to Temporal server")
 
+	// Optional warm pool of pre-booted cells, sized from the activity backlog
+	if poolConfig, ok := warmPoolConfigFromEnv(); ok {
+		pool := temporal.InitializeWarmPool(poolConfig)
+		poolCtx, cancelPool := context.WithCancel(ctx)
+		defer func() {
+			cancelPool()
+			log.Println("🧊 Shutting down warm pool...")
+			pool.Shutdown()
+		}()
+		go pool.Autoscale(poolCtx, activityBacklog(c, taskQueue))
+		log.Printf("🔥 Warm pool enabled (min %d, max %d)", poolConfig.MinSize, poolConfig.MaxSize)
+	}
+
 	// Create worker on task queue
-	w := worker.New(c, "reactor-task-queue", worker.Options{
+	w := worker.New(c, taskQueue, worker.Options{
 		MaxConcurrentActivityExecutionSize:      maxConcurrentActivityExecutionSize,
 		MaxConcurrentWorkflowTaskExecutionSize:  maxConcurrentWorkflowTaskExecutionSize,
 		MaxConcurrentLocalActivityExecutionSize: maxConcurrentLocalActivityExecutionSize,
@@ -93,6 +137,8 @@ func main() {
 	w.RegisterWorkflow(temporal.TCRWorkflow)
 	w.RegisterWorkflow(temporal.EnhancedTCRWorkflow)
 	w.RegisterWorkflow(temporal.BenchmarkWorkflow)
+	w.RegisterWorkflow(temporal.MergeQueueWorkflow)
+	w.RegisterWorkflow(temporal.TCRDAGWorkflow)
 	w.RegisterWorkflow(dag.TddDagWorkflow)
 
 	// Register activities
@@ -100,6 +146,7 @@ func main() {
 	enhancedActivities := temporal.NewEnhancedActivities()
 	shellActivities := &temporal.ShellActivities{}
 	agentActivities := temporal.NewAgentActivities()
+	mergeActivities := temporal.NewMergeActivities()
 	dagActivities := &dag.ShellActivities{}
 
 	w.RegisterActivity(cellActivities.BootstrapCell)
@@ -108,10 +155,13 @@ func main() {
 	w.RegisterActivity(cellActivities.CommitChanges)
 	w.RegisterActivity(cellActivities.RevertChanges)
 	w.RegisterActivity(cellActivities.TeardownCell)
+	w.RegisterActivity(cellActivities.CellHead)
 	w.RegisterActivity(enhancedActivities.AcquireFileLocks)
 	w.RegisterActivity(enhancedActivities.ReleaseFileLocks)
 	w.RegisterActivity(enhancedActivities.ExecuteGenTest)
 	w.RegisterActivity(enhancedActivities.ExecuteLintTest)
+	w.RegisterActivity(enhancedActivities.ExecuteLintAutoFix)
+	w.RegisterActivity(enhancedActivities.ExecuteLintFix)
 	w.RegisterActivity(enhancedActivities.ExecuteVerifyRED)
 	w.RegisterActivity(enhancedActivities.ExecuteGenImpl)
 	w.RegisterActivity(enhancedActivities.ExecuteFixFromFeedback)
@@ -121,6 +171,7 @@ func main() {
 	w.RegisterActivity(shellActivities.RunScriptInDir)
 	w.RegisterActivity(agentActivities.InvokeAgent)
 	w.RegisterActivity(agentActivities.StreamedInvokeAgent)
+	w.RegisterActivity(mergeActivities)
 	w.RegisterActivity(dagActivities)
 
 	log.Println("📋 Registered workflows and activities")
@@ -146,3 +197,53 @@ func main() {
 
 	log.Println("✅ Worker stopped")
 }
+
+// warmPoolConfigFromEnv reads OPEN_SWARM_WARM_POOL_MIN/MAX.
+// The pool is disabled unless a positive maximum is configured.
+func warmPoolConfigFromEnv() (workflow.WarmPoolConfig, bool) {
+	minSize, _ := strconv.Atoi(os.Getenv("OPEN_SWARM_WARM_POOL_MIN"))
+	maxSize, _ := strconv.Atoi(os.Getenv("OPEN_SWARM_WARM_POOL_MAX"))
+	if maxSize <= 0 {
+		return workflow.WarmPoolConfig{}, false
+	}
+	return workflow.WarmPoolConfig{
+		MinSize: minSize,
+		MaxSize: maxSize,
+		BaseRef: os.Getenv("OPEN_SWARM_WARM_POOL_REF"),
+	}, true
+}
+
+// activityBacklog reports the approximate number of activity tasks waiting on the queue
+func activityBacklog(c client.Client, queue string) workflow.QueueDepthFunc {
+	return func(ctx context.Context) (int, error) {
+		desc, err := c.DescribeTaskQueueEnhanced(ctx, client.DescribeTaskQueueEnhancedOptions{
+			TaskQueue:      queue,
+			TaskQueueTypes: []client.TaskQueueType{client.TaskQueueTypeActivity},
+			ReportStats:    true,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		var backlog int64
+		//nolint:staticcheck // VersionsInfo is the only per-type stats source for unversioned workers
+		for _, version := range desc.VersionsInfo {
+			for _, info := range version.TypesInfo {
+				if info.Stats != nil {
+					backlog += info.Stats.ApproximateBacklogCount
+				}
+			}
+		}
+		return int(backlog), nil
+	}
+}
+
+// loadModelConfig returns the model preferences of .claude/opencode.yaml, or
+// none when the config cannot be loaded
+func loadModelConfig() config.ModelConfig {
+	cfg, err := config.Load()
+	if err != nil {
+		return config.ModelConfig{}
+	}
+	return cfg.Model
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/sst/opencode-sdk-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"open-swarm/internal/telemetry"
+)
+
+// Ensure AiderClient implements ClientInterface
+var _ ClientInterface = (*AiderClient)(nil)
+
+// AiderClient runs prompts through Aider as a one-shot subprocess.
+// Aider has no server-side sessions, so each session ID maps to a chat
+// history file that is restored on the next prompt. Histories live outside
+// the worktree so they never end up in a task's commit.
+type AiderClient struct {
+	workDir    string
+	sessionDir string
+	command    string
+	run        commandRunner
+	seq        atomic.Int64
+}
+
+// NewAiderClient creates an Aider client for the given worktree
+func NewAiderClient(workDir string) *AiderClient {
+	return &AiderClient{
+		workDir:    workDir,
+		sessionDir: filepath.Join(os.TempDir(), "open-swarm-aider-sessions"),
+		command:    "aider",
+		run:        execRunner,
+	}
+}
+
+// SetCommand overrides the aider executable (useful for testing)
+func (c *AiderClient) SetCommand(command string) {
+	c.command = command
+}
+
+// GetBaseURL returns an empty string; Aider has no server
+func (c *AiderClient) GetBaseURL() string {
+	return ""
+}
+
+// GetPort returns 0; Aider has no server
+func (c *AiderClient) GetPort() int {
+	return 0
+}
+
+// ExecutePrompt runs 'aider --message' in the worktree.
+// Commits are left to the TCR workflow, so auto-commits are disabled.
+func (c *AiderClient) ExecutePrompt(ctx context.Context, prompt string, opts *PromptOptions) (*PromptResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "agent.aider", "ExecutePrompt",
+		trace.WithAttributes(
+			attribute.String("agent.workdir", c.workDir),
+			attribute.Int("prompt.length", len(prompt)),
+		),
+	)
+	defer span.End()
+
+	startTime := time.Now()
+	if opts == nil {
+		opts = &PromptOptions{}
+	}
+
+	sessionID := opts.SessionID
+	restore := sessionID != ""
+	if sessionID == "" {
+		sessionID = fmt.Sprintf("aider-%d-%d", time.Now().UnixNano(), c.seq.Add(1))
+	}
+
+	message := prompt
+	if opts.SystemPrompt != "" {
+		message = opts.SystemPrompt + "\n\n" + prompt
+	}
+
+	args := []string{
+		"--message", message,
+		"--yes-always",
+		"--no-auto-commits",
+		"--no-pretty",
+		"--no-stream",
+		"--chat-history-file", filepath.Join(c.sessionDir, sessionID+".md"),
+	}
+	if restore {
+		args = append(args, "--restore-chat-history")
+	}
+	if opts.Model != "" {
+		args = append(args, "--model", opts.Model)
+	}
+
+	if err := os.MkdirAll(c.sessionDir, 0o750); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to create session directory")
+		return nil, fmt.Errorf("failed to create aider session directory: %w", err)
+	}
+
+	output, err := c.run(ctx, c.workDir, c.command, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "aider invocation failed")
+		return nil, fmt.Errorf("failed to run aider: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("agent.session_id", sessionID),
+		attribute.Int64("duration_ms", time.Since(startTime).Milliseconds()),
+	)
+	span.SetStatus(codes.Ok, "prompt executed successfully")
+
+	return &PromptResult{
+		SessionID: sessionID,
+		Parts:     []ResultPart{{Type: "text", Text: string(output)}},
+	}, nil
+}
+
+// ExecuteCommand runs a "shell" command in the worktree
+func (c *AiderClient) ExecuteCommand(ctx context.Context, sessionID string, command string, args []string) (*PromptResult, error) {
+	return runShellCommand(ctx, c.run, c.workDir, sessionID, command, args)
+}
+
+// GetFileStatus reports files modified in the worktree
+func (c *AiderClient) GetFileStatus(ctx context.Context) ([]opencode.File, error) {
+	return gitFileStatus(ctx, c.run, c.workDir)
+}
@@ -0,0 +1,399 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sst/opencode-sdk-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"open-swarm/internal/telemetry"
+)
+
+// Ensure AnthropicClient implements ClientInterface
+var _ ClientInterface = (*AnthropicClient)(nil)
+
+const (
+	// defaultAnthropicBaseURL is the Messages API endpoint host
+	defaultAnthropicBaseURL = "https://api.anthropic.com"
+	// anthropicAPIVersion is the API version header value
+	anthropicAPIVersion = "2023-06-01"
+	// defaultAnthropicModel is used when PromptOptions.Model is empty
+	defaultAnthropicModel = "claude-sonnet-4-5"
+	// defaultAnthropicMaxTokens bounds each model response
+	defaultAnthropicMaxTokens = 8192
+	// defaultAnthropicMaxTurns bounds the tool loop per prompt
+	defaultAnthropicMaxTurns = 40
+	// anthropicBashTimeout bounds each bash tool invocation
+	anthropicBashTimeout = 2 * time.Minute
+	// anthropicToolOutputLimit truncates tool output sent back to the model
+	anthropicToolOutputLimit = 30000
+)
+
+// anthropicTools are the worktree-scoped tools offered to the model
+var anthropicTools = []anthropicTool{
+	{
+		Name:        "read_file",
+		Description: "Read a file from the repository. Paths are relative to the repository root.",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{"path":{"type":"string"}},"required":["path"]}`),
+	},
+	{
+		Name:        "write_file",
+		Description: "Create or overwrite a file in the repository with the given content. Paths are relative to the repository root.",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{"path":{"type":"string"},"content":{"type":"string"}},"required":["path","content"]}`),
+	},
+	{
+		Name:        "bash",
+		Description: "Run a bash command with the repository root as working directory and return its output.",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{"command":{"type":"string"}},"required":["command"]}`),
+	},
+}
+
+// anthropicTool describes a tool in the Messages API request
+type anthropicTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"input_schema"`
+}
+
+// anthropicContent is a Messages API content block (text, tool_use or tool_result)
+type anthropicContent struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   string          `json:"content,omitempty"`
+	IsError   bool            `json:"is_error,omitempty"`
+}
+
+// anthropicMessage is a single conversation turn
+type anthropicMessage struct {
+	Role    string             `json:"role"`
+	Content []anthropicContent `json:"content"`
+}
+
+// anthropicRequest is the Messages API request body
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	MaxTokens int                `json:"max_tokens"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+	Tools     []anthropicTool    `json:"tools,omitempty"`
+}
+
+// anthropicResponse is the Messages API response body
+type anthropicResponse struct {
+	ID         string             `json:"id"`
+	Content    []anthropicContent `json:"content"`
+	StopReason string             `json:"stop_reason"`
+	Error      *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// AnthropicClient runs a Messages API tool loop with read_file, write_file
+// and bash tools scoped to the worktree. Conversation history is kept in
+// memory per session ID so follow-up prompts continue the same session.
+type AnthropicClient struct {
+	workDir    string
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	maxTurns   int
+	run        commandRunner
+
+	mu       sync.Mutex
+	sessions map[string][]anthropicMessage
+	seq      int
+}
+
+// NewAnthropicClient creates a Messages API client for the given worktree.
+// Credentials come from ANTHROPIC_API_KEY (and optionally ANTHROPIC_BASE_URL).
+func NewAnthropicClient(workDir string) *AnthropicClient {
+	baseURL := os.Getenv("ANTHROPIC_BASE_URL")
+	if baseURL == "" {
+		baseURL = defaultAnthropicBaseURL
+	}
+	return &AnthropicClient{
+		workDir:    workDir,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     os.Getenv("ANTHROPIC_API_KEY"),
+		httpClient: &http.Client{Timeout: 10 * time.Minute},
+		maxTurns:   defaultAnthropicMaxTurns,
+		run:        execRunner,
+		sessions:   make(map[string][]anthropicMessage),
+	}
+}
+
+// SetBaseURL overrides the API endpoint (useful for testing)
+func (c *AnthropicClient) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// SetAPIKey overrides the API key
+func (c *AnthropicClient) SetAPIKey(apiKey string) {
+	c.apiKey = apiKey
+}
+
+// GetBaseURL returns the Messages API endpoint
+func (c *AnthropicClient) GetBaseURL() string {
+	return c.baseURL
+}
+
+// GetPort returns 0; there is no local server
+func (c *AnthropicClient) GetPort() int {
+	return 0
+}
+
+// ExecutePrompt sends the prompt and executes tool calls until the model stops
+func (c *AnthropicClient) ExecutePrompt(ctx context.Context, prompt string, opts *PromptOptions) (*PromptResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "agent.anthropic", "ExecutePrompt",
+		trace.WithAttributes(
+			attribute.String("agent.workdir", c.workDir),
+			attribute.Int("prompt.length", len(prompt)),
+		),
+	)
+	defer span.End()
+
+	if opts == nil {
+		opts = &PromptOptions{}
+	}
+	if c.apiKey == "" {
+		err := fmt.Errorf("ANTHROPIC_API_KEY is not set")
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	model := defaultAnthropicModel
+	if opts.Model != "" {
+		model = modelID(opts.Model)
+	}
+
+	sessionID, history := c.session(opts.SessionID)
+	history = append(history, anthropicMessage{
+		Role:    "user",
+		Content: []anthropicContent{{Type: "text", Text: prompt}},
+	})
+
+	result := &PromptResult{SessionID: sessionID}
+	var tools []anthropicTool
+	if !opts.NoReply {
+		tools = anthropicTools
+	}
+
+	for turn := 0; turn < c.maxTurns; turn++ {
+		resp, err := c.createMessage(ctx, anthropicRequest{
+			Model:     model,
+			MaxTokens: defaultAnthropicMaxTokens,
+			System:    opts.SystemPrompt,
+			Messages:  history,
+			Tools:     tools,
+		})
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "messages request failed")
+			return nil, err
+		}
+
+		result.MessageID = resp.ID
+		history = append(history, anthropicMessage{Role: "assistant", Content: resp.Content})
+
+		var toolResults []anthropicContent
+		for _, block := range resp.Content {
+			switch block.Type {
+			case "text":
+				result.Parts = append(result.Parts, ResultPart{Type: "text", Text: block.Text})
+			case "tool_use":
+				output, toolErr := c.runTool(ctx, block.Name, block.Input)
+				result.Parts = append(result.Parts, ResultPart{Type: "tool", ToolName: block.Name, ToolResult: output})
+				toolResults = append(toolResults, anthropicContent{
+					Type:      "tool_result",
+					ToolUseID: block.ID,
+					Content:   truncateString(output, anthropicToolOutputLimit),
+					IsError:   toolErr != nil,
+				})
+			}
+		}
+
+		if resp.StopReason != "tool_use" || len(toolResults) == 0 {
+			c.saveSession(sessionID, history)
+			span.SetAttributes(attribute.Int("agent.turns", turn+1))
+			span.SetStatus(codes.Ok, "prompt executed successfully")
+			return result, nil
+		}
+
+		history = append(history, anthropicMessage{Role: "user", Content: toolResults})
+	}
+
+	c.saveSession(sessionID, history)
+	err := fmt.Errorf("tool loop exceeded %d turns", c.maxTurns)
+	span.SetStatus(codes.Error, err.Error())
+	return result, err
+}
+
+// ExecuteCommand runs a "shell" command in the worktree
+func (c *AnthropicClient) ExecuteCommand(ctx context.Context, sessionID string, command string, args []string) (*PromptResult, error) {
+	return runShellCommand(ctx, c.run, c.workDir, sessionID, command, args)
+}
+
+// GetFileStatus reports files modified in the worktree
+func (c *AnthropicClient) GetFileStatus(ctx context.Context) ([]opencode.File, error) {
+	return gitFileStatus(ctx, c.run, c.workDir)
+}
+
+// session returns the history for sessionID, creating a new session if empty or unknown
+func (c *AnthropicClient) session(sessionID string) (string, []anthropicMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if history, ok := c.sessions[sessionID]; ok && sessionID != "" {
+		return sessionID, append([]anthropicMessage(nil), history...)
+	}
+	if sessionID == "" {
+		c.seq++
+		sessionID = fmt.Sprintf("anthropic-%d-%d", time.Now().UnixNano(), c.seq)
+	}
+	return sessionID, nil
+}
+
+// saveSession stores the conversation for follow-up prompts
+func (c *AnthropicClient) saveSession(sessionID string, history []anthropicMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessions[sessionID] = history
+}
+
+// createMessage performs a single Messages API call
+func (c *AnthropicClient) createMessage(ctx context.Context, body anthropicRequest) (*anthropicResponse, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/messages", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("x-api-key", c.apiKey)
+	req.Header.Set("anthropic-version", anthropicAPIVersion)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("messages request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var parsed anthropicResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || parsed.Error != nil {
+		msg := string(data)
+		if parsed.Error != nil {
+			msg = parsed.Error.Type + ": " + parsed.Error.Message
+		}
+		return nil, fmt.Errorf("messages API returned status %d: %s", resp.StatusCode, msg)
+	}
+	return &parsed, nil
+}
+
+// runTool executes a tool call and returns the text sent back to the model
+func (c *AnthropicClient) runTool(ctx context.Context, name string, rawInput json.RawMessage) (string, error) {
+	var input struct {
+		Path    string `json:"path"`
+		Content string `json:"content"`
+		Command string `json:"command"`
+	}
+	if err := json.Unmarshal(rawInput, &input); err != nil {
+		return fmt.Sprintf("invalid tool input: %v", err), err
+	}
+
+	switch name {
+	case "read_file":
+		path, err := c.resolvePath(input.Path)
+		if err != nil {
+			return err.Error(), err
+		}
+		data, err := os.ReadFile(path) //nolint:gosec // Path is confined to the worktree by resolvePath
+		if err != nil {
+			return err.Error(), err
+		}
+		return string(data), nil
+
+	case "write_file":
+		path, err := c.resolvePath(input.Path)
+		if err != nil {
+			return err.Error(), err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err.Error(), err
+		}
+		if err := os.WriteFile(path, []byte(input.Content), 0644); err != nil { //nolint:gosec // Generated source files are world-readable
+			return err.Error(), err
+		}
+		return fmt.Sprintf("wrote %d bytes to %s", len(input.Content), input.Path), nil
+
+	case "bash":
+		bashCtx, cancel := context.WithTimeout(ctx, anthropicBashTimeout)
+		defer cancel()
+		output, err := c.run(bashCtx, c.workDir, "bash", "-c", input.Command)
+		if err != nil {
+			return string(output) + "\n" + err.Error(), err
+		}
+		return string(output), nil
+
+	default:
+		err := fmt.Errorf("unknown tool: %s", name)
+		return err.Error(), err
+	}
+}
+
+// resolvePath maps a repository-relative path into the worktree, rejecting
+// paths that would escape it
+func (c *AnthropicClient) resolvePath(path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("path is required")
+	}
+	root, err := filepath.Abs(c.workDir)
+	if err != nil {
+		return "", fmt.Errorf("invalid worktree: %w", err)
+	}
+
+	rel := path
+	if filepath.IsAbs(path) {
+		rel, err = filepath.Rel(root, path)
+		if err != nil {
+			return "", fmt.Errorf("path %s is outside the worktree", path)
+		}
+	}
+	rel = filepath.Clean(rel)
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside the worktree", path)
+	}
+	return filepath.Join(root, rel), nil
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/sst/opencode-sdk-go"
+)
+
+// Backend identifies which coding agent executes prompts in a cell
+type Backend string
+
+const (
+	// BackendOpenCode runs prompts through an 'opencode serve' instance (default)
+	BackendOpenCode Backend = "opencode"
+	// BackendClaudeCode runs the Claude Code CLI headless as a subprocess
+	BackendClaudeCode Backend = "claude-code"
+	// BackendAider runs Aider as a subprocess in the worktree
+	BackendAider Backend = "aider"
+	// BackendAnthropic runs a Messages API tool loop directly against the worktree
+	BackendAnthropic Backend = "anthropic"
+)
+
+// ParseBackend validates a backend name. An empty name selects OpenCode.
+func ParseBackend(name string) (Backend, error) {
+	switch Backend(strings.ToLower(strings.TrimSpace(name))) {
+	case "", BackendOpenCode:
+		return BackendOpenCode, nil
+	case BackendClaudeCode:
+		return BackendClaudeCode, nil
+	case BackendAider:
+		return BackendAider, nil
+	case BackendAnthropic:
+		return BackendAnthropic, nil
+	default:
+		return "", fmt.Errorf("unknown agent backend: %q", name)
+	}
+}
+
+// NeedsServer reports whether the backend requires an 'opencode serve' process
+func (b Backend) NeedsServer() bool {
+	return b == "" || b == BackendOpenCode
+}
+
+// NewBackendClient creates a client for a serverless backend rooted at workDir.
+// OpenCode clients need a running server and are created with NewClient instead.
+func NewBackendClient(backend Backend, workDir string) (ClientInterface, error) {
+	switch backend {
+	case BackendClaudeCode:
+		return NewClaudeCodeClient(workDir), nil
+	case BackendAider:
+		return NewAiderClient(workDir), nil
+	case BackendAnthropic:
+		return NewAnthropicClient(workDir), nil
+	case "", BackendOpenCode:
+		return nil, fmt.Errorf("opencode backend requires a server; use NewClient")
+	default:
+		return nil, fmt.Errorf("unknown agent backend: %q", backend)
+	}
+}
+
+// commandRunner executes a process in dir and returns its combined output
+type commandRunner func(ctx context.Context, dir string, name string, args ...string) ([]byte, error)
+
+// execRunner is the default commandRunner backed by os/exec
+func execRunner(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return append(stdout.Bytes(), stderr.Bytes()...), fmt.Errorf("%s failed: %w\nstderr: %s", name, err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// runShellCommand implements ExecuteCommand for subprocess backends.
+// Only the "shell" command is supported; its args are executed in the worktree.
+func runShellCommand(ctx context.Context, run commandRunner, workDir string, sessionID string, command string, args []string) (*PromptResult, error) {
+	if command != "shell" {
+		return nil, fmt.Errorf("command %q is not supported by this backend", command)
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("shell command requires arguments")
+	}
+
+	output, err := run(ctx, workDir, args[0], args[1:]...)
+	result := &PromptResult{
+		SessionID: sessionID,
+		Parts:     []ResultPart{{Type: "text", Text: string(output)}},
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to execute command: %w", err)
+	}
+	return result, nil
+}
+
+// gitFileStatus reports modified files in workDir using git, mirroring the
+// file status endpoint of an OpenCode server
+func gitFileStatus(ctx context.Context, run commandRunner, workDir string) ([]opencode.File, error) {
+	output, err := run(ctx, workDir, "git", "status", "--porcelain", "--untracked-files=all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file status: %w", err)
+	}
+
+	numstat, _ := run(ctx, workDir, "git", "diff", "HEAD", "--numstat")
+	lineCounts := parseNumstat(string(numstat))
+
+	files := []opencode.File{}
+	for _, line := range strings.Split(string(output), "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		code := line[:2]
+		path := strings.TrimSpace(line[3:])
+		if idx := strings.Index(path, " -> "); idx >= 0 {
+			path = path[idx+4:]
+		}
+
+		status := opencode.FileStatusModified
+		switch {
+		case strings.Contains(code, "?"), strings.Contains(code, "A"):
+			status = opencode.FileStatusAdded
+		case strings.Contains(code, "D"):
+			status = opencode.FileStatusDeleted
+		}
+
+		counts := lineCounts[path]
+		files = append(files, opencode.File{
+			Path:    path,
+			Status:  status,
+			Added:   counts[0],
+			Removed: counts[1],
+		})
+	}
+	return files, nil
+}
+
+// parseNumstat parses 'git diff --numstat' output into added/removed counts per path
+func parseNumstat(output string) map[string][2]int64 {
+	counts := make(map[string][2]int64)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		added, _ := strconv.ParseInt(fields[0], 10, 64)
+		removed, _ := strconv.ParseInt(fields[1], 10, 64)
+		counts[fields[2]] = [2]int64{added, removed}
+	}
+	return counts
+}
+
+// modelID strips the provider prefix from "provider/model" identifiers
+func modelID(model string) string {
+	if idx := strings.Index(model, "/"); idx >= 0 {
+		return model[idx+1:]
+	}
+	return model
+}
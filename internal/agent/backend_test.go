@@ -0,0 +1,214 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunner records invocations and returns canned output per executable
+type fakeRunner struct {
+	calls   [][]string
+	outputs map[string]string
+}
+
+func (f *fakeRunner) run(_ context.Context, _ string, name string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, append([]string{name}, args...))
+	key := name
+	if name == "git" && len(args) > 0 {
+		key = "git " + args[0]
+	}
+	out, ok := f.outputs[key]
+	if !ok {
+		return nil, fmt.Errorf("unexpected command: %s", name)
+	}
+	return []byte(out), nil
+}
+
+func TestParseBackend(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Backend
+		wantErr bool
+	}{
+		{"", BackendOpenCode, false},
+		{"opencode", BackendOpenCode, false},
+		{"Claude-Code", BackendClaudeCode, false},
+		{" aider ", BackendAider, false},
+		{"anthropic", BackendAnthropic, false},
+		{"cursor", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBackend(tt.name)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewBackendClient(t *testing.T) {
+	assert.True(t, BackendOpenCode.NeedsServer())
+	assert.False(t, BackendClaudeCode.NeedsServer())
+
+	client, err := NewBackendClient(BackendAider, "/tmp/wt")
+	require.NoError(t, err)
+	assert.IsType(t, &AiderClient{}, client)
+
+	_, err = NewBackendClient(BackendOpenCode, "/tmp/wt")
+	assert.Error(t, err)
+}
+
+func TestClaudeCodeClient_ExecutePrompt(t *testing.T) {
+	runner := &fakeRunner{outputs: map[string]string{
+		"claude": `{"type":"result","subtype":"success","is_error":false,"result":"Done","session_id":"sess-42","num_turns":3}`,
+	}}
+	client := NewClaudeCodeClient("/tmp/wt")
+	client.run = runner.run
+
+	result, err := client.ExecutePrompt(context.Background(), "Write tests", &PromptOptions{
+		SessionID: "sess-41",
+		Model:     "anthropic/claude-haiku-4-5",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "sess-42", result.SessionID)
+	assert.Equal(t, "Done", result.GetText())
+
+	args := strings.Join(runner.calls[0], " ")
+	assert.Contains(t, args, "-p Write tests")
+	assert.Contains(t, args, "--resume sess-41")
+	assert.Contains(t, args, "--model claude-haiku-4-5")
+}
+
+func TestClaudeCodeClient_ExecutePromptError(t *testing.T) {
+	runner := &fakeRunner{outputs: map[string]string{
+		"claude": `{"type":"result","subtype":"error_max_turns","is_error":true,"result":"gave up"}`,
+	}}
+	client := NewClaudeCodeClient("/tmp/wt")
+	client.run = runner.run
+
+	_, err := client.ExecutePrompt(context.Background(), "Write tests", nil)
+	assert.ErrorContains(t, err, "error_max_turns")
+}
+
+func TestAiderClient_SessionHistory(t *testing.T) {
+	runner := &fakeRunner{outputs: map[string]string{"aider": "Applied edit to main.go"}}
+	client := NewAiderClient("/tmp/wt")
+	client.run = runner.run
+
+	first, err := client.ExecutePrompt(context.Background(), "Implement", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Applied edit to main.go", first.GetText())
+	assert.NotContains(t, runner.calls[0], "--restore-chat-history")
+
+	_, err = client.ExecutePrompt(context.Background(), "Fix", &PromptOptions{SessionID: first.SessionID})
+	require.NoError(t, err)
+	assert.Contains(t, runner.calls[1], "--restore-chat-history")
+	history := filepath.Join(client.sessionDir, first.SessionID+".md")
+	assert.Contains(t, runner.calls[1], history)
+	assert.False(t, strings.HasPrefix(history, "/tmp/wt"), "chat history must stay out of the worktree")
+}
+
+func TestGitFileStatus(t *testing.T) {
+	runner := &fakeRunner{outputs: map[string]string{
+		"git status": " M main.go\n?? main_test.go\n D old.go\nR  a.go -> b.go\n",
+		"git diff":   "4\t1\tmain.go\n0\t12\told.go\n",
+	}}
+
+	files, err := gitFileStatus(context.Background(), runner.run, "/tmp/wt")
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+	assert.Equal(t, opencode.File{Path: "main.go", Status: opencode.FileStatusModified, Added: 4, Removed: 1}, files[0])
+	assert.Equal(t, opencode.FileStatusAdded, files[1].Status)
+	assert.Equal(t, opencode.FileStatusDeleted, files[2].Status)
+	assert.Equal(t, "b.go", files[3].Path)
+}
+
+func TestRunShellCommand(t *testing.T) {
+	runner := &fakeRunner{outputs: map[string]string{"golangci-lint": "ok"}}
+
+	result, err := runShellCommand(context.Background(), runner.run, "/tmp/wt", "s1", "shell", []string{"golangci-lint", "run"})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result.GetText())
+
+	_, err = runShellCommand(context.Background(), runner.run, "/tmp/wt", "s1", "test", nil)
+	assert.Error(t, err)
+}
+
+func TestAnthropicClient_ToolLoop(t *testing.T) {
+	workDir := t.TempDir()
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "test-key", r.Header.Get("x-api-key"))
+
+		var req anthropicRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var resp anthropicResponse
+		if requests == 1 {
+			resp = anthropicResponse{ID: "msg_1", StopReason: "tool_use", Content: []anthropicContent{
+				{Type: "tool_use", ID: "tu_1", Name: "write_file", Input: json.RawMessage(`{"path":"pkg/add.go","content":"package pkg\n"}`)},
+			}}
+		} else {
+			last := req.Messages[len(req.Messages)-1]
+			assert.Equal(t, "tool_result", last.Content[0].Type)
+			assert.Equal(t, "tu_1", last.Content[0].ToolUseID)
+			resp = anthropicResponse{ID: "msg_2", StopReason: "end_turn", Content: []anthropicContent{
+				{Type: "text", Text: "Created pkg/add.go"},
+			}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewAnthropicClient(workDir)
+	client.SetBaseURL(server.URL)
+	client.SetAPIKey("test-key")
+
+	result, err := client.ExecutePrompt(context.Background(), "Create add.go", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, "Created pkg/add.go", result.GetText())
+	require.Len(t, result.Parts, 2)
+	assert.Equal(t, "write_file", result.Parts[0].ToolName)
+
+	data, err := os.ReadFile(filepath.Join(workDir, "pkg", "add.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package pkg\n", string(data))
+}
+
+func TestAnthropicClient_ResolvePathStaysInWorktree(t *testing.T) {
+	client := NewAnthropicClient("/tmp/wt")
+
+	path, err := client.resolvePath("pkg/../main.go")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/wt/main.go", path)
+
+	_, err = client.resolvePath("../../etc/passwd")
+	assert.Error(t, err)
+
+	_, err = client.resolvePath("/etc/passwd")
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sst/opencode-sdk-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"open-swarm/internal/telemetry"
+)
+
+// Ensure ClaudeCodeClient implements ClientInterface
+var _ ClientInterface = (*ClaudeCodeClient)(nil)
+
+// ClaudeCodeClient runs prompts through the Claude Code CLI in headless mode.
+// Each prompt is a 'claude -p' subprocess in the worktree; sessions are
+// continued with '--resume'.
+type ClaudeCodeClient struct {
+	workDir        string
+	command        string
+	permissionMode string
+	run            commandRunner
+}
+
+// claudeCodeResult is the JSON document printed by 'claude -p --output-format json'
+type claudeCodeResult struct {
+	Type      string  `json:"type"`
+	Subtype   string  `json:"subtype"`
+	IsError   bool    `json:"is_error"`
+	Result    string  `json:"result"`
+	SessionID string  `json:"session_id"`
+	NumTurns  int     `json:"num_turns"`
+	CostUSD   float64 `json:"total_cost_usd"`
+}
+
+// NewClaudeCodeClient creates a Claude Code client for the given worktree.
+// Permissions are bypassed by default because cells are isolated worktrees.
+func NewClaudeCodeClient(workDir string) *ClaudeCodeClient {
+	return &ClaudeCodeClient{
+		workDir:        workDir,
+		command:        "claude",
+		permissionMode: "bypassPermissions",
+		run:            execRunner,
+	}
+}
+
+// SetCommand overrides the claude executable (useful for testing)
+func (c *ClaudeCodeClient) SetCommand(command string) {
+	c.command = command
+}
+
+// SetPermissionMode sets the --permission-mode passed to the CLI
+func (c *ClaudeCodeClient) SetPermissionMode(mode string) {
+	c.permissionMode = mode
+}
+
+// GetBaseURL returns an empty string; the CLI has no server
+func (c *ClaudeCodeClient) GetBaseURL() string {
+	return ""
+}
+
+// GetPort returns 0; the CLI has no server
+func (c *ClaudeCodeClient) GetPort() int {
+	return 0
+}
+
+// ExecutePrompt runs a single headless Claude Code invocation
+func (c *ClaudeCodeClient) ExecutePrompt(ctx context.Context, prompt string, opts *PromptOptions) (*PromptResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "agent.claude_code", "ExecutePrompt",
+		trace.WithAttributes(
+			attribute.String("agent.workdir", c.workDir),
+			attribute.Int("prompt.length", len(prompt)),
+		),
+	)
+	defer span.End()
+
+	startTime := time.Now()
+	if opts == nil {
+		opts = &PromptOptions{}
+	}
+
+	args := []string{"-p", prompt, "--output-format", "json"}
+	if c.permissionMode != "" {
+		args = append(args, "--permission-mode", c.permissionMode)
+	}
+	if opts.SessionID != "" {
+		args = append(args, "--resume", opts.SessionID)
+	}
+	if opts.Model != "" {
+		args = append(args, "--model", modelID(opts.Model))
+	}
+	if opts.SystemPrompt != "" {
+		args = append(args, "--append-system-prompt", opts.SystemPrompt)
+	}
+	if len(opts.Tools) > 0 {
+		args = append(args, "--allowedTools", strings.Join(opts.Tools, ","))
+	}
+
+	output, err := c.run(ctx, c.workDir, c.command, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "claude invocation failed")
+		return nil, fmt.Errorf("failed to run claude: %w", err)
+	}
+
+	var parsed claudeCodeResult
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid claude output")
+		return nil, fmt.Errorf("failed to parse claude output: %w", err)
+	}
+	if parsed.IsError {
+		err := fmt.Errorf("claude returned %s: %s", parsed.Subtype, truncateString(parsed.Result, 200))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "claude returned an error")
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("agent.session_id", parsed.SessionID),
+		attribute.Int("agent.turns", parsed.NumTurns),
+		attribute.Float64("agent.cost_usd", parsed.CostUSD),
+		attribute.Int64("duration_ms", time.Since(startTime).Milliseconds()),
+	)
+	span.SetStatus(codes.Ok, "prompt executed successfully")
+
+	return &PromptResult{
+		SessionID: parsed.SessionID,
+		Parts:     []ResultPart{{Type: "text", Text: parsed.Result}},
+	}, nil
+}
+
+// ExecuteCommand runs a "shell" command in the worktree
+func (c *ClaudeCodeClient) ExecuteCommand(ctx context.Context, sessionID string, command string, args []string) (*PromptResult, error) {
+	return runShellCommand(ctx, c.run, c.workDir, sessionID, command, args)
+}
+
+// GetFileStatus reports files modified in the worktree
+func (c *ClaudeCodeClient) GetFileStatus(ctx context.Context) ([]opencode.File, error) {
+	return gitFileStatus(ctx, c.run, c.workDir)
+}
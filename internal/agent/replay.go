@@ -0,0 +1,493 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sst/opencode-sdk-go"
+)
+
+// Ensure record/replay clients implement ClientInterface
+var (
+	_ ClientInterface = (*RecordingClient)(nil)
+	_ ClientInterface = (*ReplayClient)(nil)
+)
+
+// CassetteMode selects whether agent interactions are recorded or replayed
+type CassetteMode string
+
+const (
+	// CassetteRecord forwards prompts to a live backend and stores the results
+	CassetteRecord CassetteMode = "record"
+	// CassetteReplay serves stored results without any network access
+	CassetteReplay CassetteMode = "replay"
+)
+
+// ParseCassetteMode validates a cassette mode name. An empty name selects replay.
+func ParseCassetteMode(name string) (CassetteMode, error) {
+	switch CassetteMode(strings.ToLower(strings.TrimSpace(name))) {
+	case "", CassetteReplay:
+		return CassetteReplay, nil
+	case CassetteRecord:
+		return CassetteRecord, nil
+	default:
+		return "", fmt.Errorf("unknown cassette mode: %q", name)
+	}
+}
+
+// cassetteVersion is bumped when the on-disk format changes incompatibly
+const cassetteVersion = 1
+
+// maxRecordedFileSize skips large (usually generated or binary) files when recording edits
+const maxRecordedFileSize = 1 << 20
+
+// ErrCassetteMiss is returned in replay mode when no interaction matches a prompt
+var ErrCassetteMiss = errors.New("no recorded interaction for prompt")
+
+// CassetteMissError identifies the prompt that had no recorded interaction
+type CassetteMissError struct {
+	Key    string
+	Prompt string
+}
+
+func (e *CassetteMissError) Error() string {
+	return fmt.Sprintf("%v (key %s): %s", ErrCassetteMiss, e.Key[:12], truncateString(e.Prompt, 80))
+}
+
+// Is makes CassetteMissError match ErrCassetteMiss
+func (e *CassetteMissError) Is(target error) bool {
+	return target == ErrCassetteMiss
+}
+
+// Interaction is a single recorded prompt and its effects on the worktree
+type Interaction struct {
+	Key       string         `json:"key"`
+	Prompt    string         `json:"prompt"`
+	Model     string         `json:"model,omitempty"`
+	Agent     string         `json:"agent,omitempty"`
+	SessionID string         `json:"session_id,omitempty"`
+	MessageID string         `json:"message_id,omitempty"`
+	Parts     []CassettePart `json:"parts"`
+	Files     []FileWrite    `json:"files,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// CassettePart is the serializable form of a ResultPart
+type CassettePart struct {
+	Type       string          `json:"type"`
+	Text       string          `json:"text,omitempty"`
+	ToolName   string          `json:"tool_name,omitempty"`
+	ToolResult json.RawMessage `json:"tool_result,omitempty"`
+}
+
+// FileWrite is a file edit made by the agent while handling a prompt
+type FileWrite struct {
+	Path    string `json:"path"`
+	Content string `json:"content,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// Cassette stores recorded interactions keyed by prompt hash.
+// Identical prompts are replayed in recording order; once exhausted the
+// last recording is repeated so retries stay deterministic.
+// A Cassette is safe for concurrent use by multiple cells.
+type Cassette struct {
+	path string
+
+	mu           sync.Mutex
+	interactions map[string][]Interaction
+	order        []string
+	cursor       map[string]int
+}
+
+// cassetteFile is the on-disk JSON layout
+type cassetteFile struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// NewCassette creates an empty cassette that saves to path
+func NewCassette(path string) *Cassette {
+	return &Cassette{
+		path:         path,
+		interactions: make(map[string][]Interaction),
+		cursor:       make(map[string]int),
+	}
+}
+
+// LoadCassette reads a cassette from path.
+// A missing file yields an empty cassette so recording can start from scratch.
+func LoadCassette(path string) (*Cassette, error) {
+	c := NewCassette(path)
+
+	data, err := os.ReadFile(path) //nolint:gosec // Cassette path is operator-provided
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	if file.Version != cassetteVersion {
+		return nil, fmt.Errorf("unsupported cassette version %d in %s", file.Version, path)
+	}
+
+	for _, in := range file.Interactions {
+		c.add(in)
+	}
+	return c, nil
+}
+
+// Path returns the file the cassette is saved to
+func (c *Cassette) Path() string {
+	return c.path
+}
+
+// Len returns the number of recorded interactions
+func (c *Cassette) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.order)
+}
+
+// Record appends an interaction and saves the cassette
+func (c *Cassette) Record(in Interaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(in)
+	return c.save()
+}
+
+// Next returns the next recorded interaction for key
+func (c *Cassette) Next(key string) (Interaction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	recorded := c.interactions[key]
+	if len(recorded) == 0 {
+		return Interaction{}, false
+	}
+	idx := c.cursor[key]
+	if idx >= len(recorded) {
+		idx = len(recorded) - 1
+	} else {
+		c.cursor[key] = idx + 1
+	}
+	return recorded[idx], true
+}
+
+// Save writes the cassette to disk
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.save()
+}
+
+func (c *Cassette) add(in Interaction) {
+	c.interactions[in.Key] = append(c.interactions[in.Key], in)
+	c.order = append(c.order, in.Key)
+}
+
+// save writes interactions in recording order; caller must hold mu
+func (c *Cassette) save() error {
+	file := cassetteFile{Version: cassetteVersion}
+	seen := make(map[string]int)
+	for _, key := range c.order {
+		file.Interactions = append(file.Interactions, c.interactions[key][seen[key]])
+		seen[key]++
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+
+	// Write atomically so a crash mid-recording never corrupts the cassette
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// PromptKey hashes everything that determines a prompt's response.
+// Session IDs are excluded because they differ between runs.
+func PromptKey(prompt string, opts *PromptOptions) string {
+	h := sha256.New()
+	if opts != nil {
+		fmt.Fprintf(h, "model=%s\x00agent=%s\x00system=%s\x00noreply=%t\x00", opts.Model, opts.Agent, opts.SystemPrompt, opts.NoReply)
+	}
+	h.Write([]byte(prompt))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// RecordingClient forwards prompts to a live client and records the
+// response and resulting worktree edits into a cassette
+type RecordingClient struct {
+	inner    ClientInterface
+	workDir  string
+	cassette *Cassette
+}
+
+// NewRecordingClient wraps inner, recording prompts executed in workDir
+func NewRecordingClient(inner ClientInterface, workDir string, cassette *Cassette) *RecordingClient {
+	return &RecordingClient{
+		inner:    inner,
+		workDir:  workDir,
+		cassette: cassette,
+	}
+}
+
+// ExecutePrompt runs the prompt on the live client and records the result
+func (c *RecordingClient) ExecutePrompt(ctx context.Context, prompt string, opts *PromptOptions) (*PromptResult, error) {
+	before, err := snapshotWorktree(c.workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot worktree: %w", err)
+	}
+
+	result, promptErr := c.inner.ExecutePrompt(ctx, prompt, opts)
+
+	after, err := snapshotWorktree(c.workDir)
+	if err != nil {
+		return result, fmt.Errorf("failed to snapshot worktree: %w", err)
+	}
+
+	in := Interaction{
+		Key:    PromptKey(prompt, opts),
+		Prompt: prompt,
+		Files:  diffSnapshots(c.workDir, before, after),
+	}
+	if opts != nil {
+		in.Model = opts.Model
+		in.Agent = opts.Agent
+	}
+	if result != nil {
+		in.SessionID = result.SessionID
+		in.MessageID = result.MessageID
+		in.Parts = toCassetteParts(result.Parts)
+	}
+	if promptErr != nil {
+		in.Error = promptErr.Error()
+	}
+
+	if err := c.cassette.Record(in); err != nil {
+		return result, err
+	}
+	return result, promptErr
+}
+
+// ExecuteCommand is passed through; commands run against the real worktree in both modes
+func (c *RecordingClient) ExecuteCommand(ctx context.Context, sessionID string, command string, args []string) (*PromptResult, error) {
+	return c.inner.ExecuteCommand(ctx, sessionID, command, args)
+}
+
+// GetFileStatus is passed through to the live client
+func (c *RecordingClient) GetFileStatus(ctx context.Context) ([]opencode.File, error) {
+	return c.inner.GetFileStatus(ctx)
+}
+
+// GetBaseURL returns the live client's base URL
+func (c *RecordingClient) GetBaseURL() string {
+	return c.inner.GetBaseURL()
+}
+
+// GetPort returns the live client's port
+func (c *RecordingClient) GetPort() int {
+	return c.inner.GetPort()
+}
+
+// ReplayClient serves prompts from a cassette and applies the recorded file
+// edits to the worktree. Commands (tests, lint, git) run for real.
+type ReplayClient struct {
+	workDir  string
+	cassette *Cassette
+	run      commandRunner
+}
+
+// NewReplayClient creates a replay client for the given worktree
+func NewReplayClient(workDir string, cassette *Cassette) *ReplayClient {
+	return &ReplayClient{
+		workDir:  workDir,
+		cassette: cassette,
+		run:      execRunner,
+	}
+}
+
+// ExecutePrompt returns the recorded response and replays its file edits
+func (c *ReplayClient) ExecutePrompt(_ context.Context, prompt string, opts *PromptOptions) (*PromptResult, error) {
+	key := PromptKey(prompt, opts)
+	in, ok := c.cassette.Next(key)
+	if !ok {
+		return nil, &CassetteMissError{Key: key, Prompt: prompt}
+	}
+
+	if err := applyFileWrites(c.workDir, in.Files); err != nil {
+		return nil, fmt.Errorf("failed to replay file edits: %w", err)
+	}
+
+	result := &PromptResult{
+		SessionID: in.SessionID,
+		MessageID: in.MessageID,
+		Parts:     fromCassetteParts(in.Parts),
+	}
+	if in.Error != "" {
+		return result, errors.New(in.Error)
+	}
+	return result, nil
+}
+
+// ExecuteCommand runs a "shell" command in the worktree
+func (c *ReplayClient) ExecuteCommand(ctx context.Context, sessionID string, command string, args []string) (*PromptResult, error) {
+	return runShellCommand(ctx, c.run, c.workDir, sessionID, command, args)
+}
+
+// GetFileStatus reports files modified in the worktree
+func (c *ReplayClient) GetFileStatus(ctx context.Context) ([]opencode.File, error) {
+	return gitFileStatus(ctx, c.run, c.workDir)
+}
+
+// GetBaseURL returns an empty string; replay has no server
+func (c *ReplayClient) GetBaseURL() string {
+	return ""
+}
+
+// GetPort returns 0; replay has no server
+func (c *ReplayClient) GetPort() int {
+	return 0
+}
+
+// snapshotWorktree hashes every file under dir except git metadata
+func snapshotWorktree(dir string) (map[string]string, error) {
+	snapshot := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == ".git" || !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() > maxRecordedFileSize {
+			return nil //nolint:nilerr // Unreadable or oversized files are not recorded
+		}
+
+		data, err := os.ReadFile(path) //nolint:gosec // Walking the cell's own worktree
+		if err != nil {
+			return nil //nolint:nilerr // File vanished during the walk
+		}
+		rel, _ := filepath.Rel(dir, path)
+		sum := sha256.Sum256(data)
+		snapshot[filepath.ToSlash(rel)] = hex.EncodeToString(sum[:])
+		return nil
+	})
+	return snapshot, err
+}
+
+// diffSnapshots returns the writes and deletions between two snapshots, sorted by path
+func diffSnapshots(dir string, before, after map[string]string) []FileWrite {
+	var writes []FileWrite
+	for path, sum := range after {
+		if before[path] == sum {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if err != nil {
+			continue
+		}
+		writes = append(writes, FileWrite{Path: path, Content: string(data)})
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			writes = append(writes, FileWrite{Path: path, Deleted: true})
+		}
+	}
+	sort.Slice(writes, func(i, j int) bool { return writes[i].Path < writes[j].Path })
+	return writes
+}
+
+// applyFileWrites replays recorded edits into dir
+func applyFileWrites(dir string, writes []FileWrite) error {
+	for _, w := range writes {
+		rel := filepath.Clean(filepath.FromSlash(w.Path))
+		if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("recorded path %s escapes the worktree", w.Path)
+		}
+		path := filepath.Join(dir, rel)
+
+		if w.Deleted {
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(w.Content), 0644); err != nil { //nolint:gosec // Replayed source files are world-readable
+			return err
+		}
+	}
+	return nil
+}
+
+// toCassetteParts converts result parts to their serializable form
+func toCassetteParts(parts []ResultPart) []CassettePart {
+	out := make([]CassettePart, 0, len(parts))
+	for _, p := range parts {
+		cp := CassettePart{Type: p.Type, Text: p.Text, ToolName: p.ToolName}
+		if p.ToolResult != nil {
+			if data, err := json.Marshal(p.ToolResult); err == nil {
+				cp.ToolResult = data
+			}
+		}
+		out = append(out, cp)
+	}
+	return out
+}
+
+// fromCassetteParts restores result parts; tool results decode to generic JSON values
+func fromCassetteParts(parts []CassettePart) []ResultPart {
+	out := make([]ResultPart, 0, len(parts))
+	for _, p := range parts {
+		rp := ResultPart{Type: p.Type, Text: p.Text, ToolName: p.ToolName}
+		if len(p.ToolResult) > 0 {
+			var v interface{}
+			if err := json.Unmarshal(p.ToolResult, &v); err == nil {
+				rp.ToolResult = v
+			}
+		}
+		out = append(out, rp)
+	}
+	return out
+}
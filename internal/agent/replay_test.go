@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sst/opencode-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedClient edits its worktree and answers prompts from a script
+type scriptedClient struct {
+	workDir string
+	calls   int
+}
+
+func (c *scriptedClient) ExecutePrompt(_ context.Context, prompt string, _ *PromptOptions) (*PromptResult, error) {
+	c.calls++
+	switch prompt {
+	case "implement":
+		_ = os.MkdirAll(filepath.Join(c.workDir, "pkg"), 0755)
+		_ = os.WriteFile(filepath.Join(c.workDir, "pkg", "add.go"), []byte("package pkg\n"), 0644)
+		_ = os.Remove(filepath.Join(c.workDir, "stale.go"))
+		return &PromptResult{SessionID: "live-1", Parts: []ResultPart{
+			{Type: "tool", ToolName: "write", ToolResult: map[string]interface{}{"path": "pkg/add.go"}},
+			{Type: "text", Text: "Implemented add"},
+		}}, nil
+	case "review":
+		return &PromptResult{SessionID: "live-1", Parts: []ResultPart{{Type: "text", Text: "VOTE: APPROVE"}}}, nil
+	default:
+		return nil, errors.New("model overloaded")
+	}
+}
+
+func (c *scriptedClient) ExecuteCommand(context.Context, string, string, []string) (*PromptResult, error) {
+	return &PromptResult{}, nil
+}
+
+func (c *scriptedClient) GetFileStatus(context.Context) ([]opencode.File, error) {
+	return nil, nil
+}
+
+func (c *scriptedClient) GetBaseURL() string { return "http://localhost:8080" }
+
+func (c *scriptedClient) GetPort() int { return 8080 }
+
+func TestRecordAndReplay(t *testing.T) {
+	cassettePath := filepath.Join(t.TempDir(), "cassettes", "tcr.json")
+	opts := &PromptOptions{Agent: "implementation", Model: "anthropic/claude-haiku-4-5"}
+
+	// Record against a live (scripted) client
+	recordDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(recordDir, "stale.go"), []byte("package stale\n"), 0644))
+	live := &scriptedClient{workDir: recordDir}
+	recorder := NewRecordingClient(live, recordDir, NewCassette(cassettePath))
+
+	_, err := recorder.ExecutePrompt(context.Background(), "implement", opts)
+	require.NoError(t, err)
+	_, err = recorder.ExecutePrompt(context.Background(), "review", nil)
+	require.NoError(t, err)
+	_, err = recorder.ExecutePrompt(context.Background(), "crash", nil)
+	require.Error(t, err)
+
+	// Replay into a fresh worktree without the live client
+	cassette, err := LoadCassette(cassettePath)
+	require.NoError(t, err)
+	assert.Equal(t, 3, cassette.Len())
+
+	replayDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(replayDir, "stale.go"), []byte("package stale\n"), 0644))
+	replay := NewReplayClient(replayDir, cassette)
+
+	result, err := replay.ExecutePrompt(context.Background(), "implement", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "Implemented add", result.GetText())
+	assert.Equal(t, "live-1", result.SessionID)
+	require.Len(t, result.GetToolResults(), 1)
+	assert.Equal(t, map[string]interface{}{"path": "pkg/add.go"}, result.GetToolResults()[0].ToolResult)
+
+	data, err := os.ReadFile(filepath.Join(replayDir, "pkg", "add.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package pkg\n", string(data))
+	assert.NoFileExists(t, filepath.Join(replayDir, "stale.go"))
+
+	_, err = replay.ExecutePrompt(context.Background(), "crash", nil)
+	assert.EqualError(t, err, "model overloaded")
+	assert.Equal(t, 3, live.calls, "replay must not reach the live client")
+}
+
+func TestReplayClient_Miss(t *testing.T) {
+	replay := NewReplayClient(t.TempDir(), NewCassette(filepath.Join(t.TempDir(), "c.json")))
+
+	_, err := replay.ExecutePrompt(context.Background(), "never recorded", nil)
+	assert.ErrorIs(t, err, ErrCassetteMiss)
+
+	var miss *CassetteMissError
+	require.ErrorAs(t, err, &miss)
+	assert.Equal(t, PromptKey("never recorded", nil), miss.Key)
+}
+
+func TestCassette_RepeatedPromptsReplayInOrder(t *testing.T) {
+	cassette := NewCassette(filepath.Join(t.TempDir(), "c.json"))
+	key := PromptKey("fix", nil)
+	require.NoError(t, cassette.Record(Interaction{Key: key, Parts: []CassettePart{{Type: "text", Text: "first"}}}))
+	require.NoError(t, cassette.Record(Interaction{Key: key, Parts: []CassettePart{{Type: "text", Text: "second"}}}))
+
+	var texts []string
+	for i := 0; i < 3; i++ {
+		in, ok := cassette.Next(key)
+		require.True(t, ok)
+		texts = append(texts, in.Parts[0].Text)
+	}
+	assert.Equal(t, []string{"first", "second", "second"}, texts)
+}
+
+func TestPromptKey_IgnoresSession(t *testing.T) {
+	a := PromptKey("prompt", &PromptOptions{SessionID: "s1", Model: "m"})
+	b := PromptKey("prompt", &PromptOptions{SessionID: "s2", Model: "m"})
+	c := PromptKey("prompt", &PromptOptions{SessionID: "s1", Model: "other"})
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestApplyFileWrites_RejectsEscapes(t *testing.T) {
+	err := applyFileWrites(t.TempDir(), []FileWrite{{Path: "../outside.go", Content: "x"}})
+	assert.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -30,12 +31,29 @@ type ProjectConfig struct {
 	WorkingDirectory string `yaml:"working_directory"`
 }
 
-// ModelConfig specifies model preferences
+// ModelConfig specifies model preferences.
+// Agent entries may be prefixed with a backend, e.g.
+// "claude-code:anthropic/claude-sonnet-4-5", to run that agent outside OpenCode.
 type ModelConfig struct {
 	Default string            `yaml:"default"`
+	Backend string            `yaml:"backend"`
 	Agents  map[string]string `yaml:"agents"`
 }
 
+// AgentBackend returns the backend and model configured for an agent,
+// falling back to the default backend and model
+func (m ModelConfig) AgentBackend(agentName string) (backend string, model string) {
+	backend, model = m.Backend, m.Default
+	entry, ok := m.Agents[agentName]
+	if !ok || entry == "" {
+		return backend, model
+	}
+	if prefix, rest, found := strings.Cut(entry, ":"); found && !strings.Contains(prefix, "/") {
+		return prefix, rest
+	}
+	return backend, entry
+}
+
 // MCPServersConfig configures MCP server connections
 type MCPServersConfig map[string]MCPServerConfig
 
@@ -579,3 +579,34 @@ func TestBuildConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestModelConfig_AgentBackend(t *testing.T) {
+	cfg := ModelConfig{
+		Default: "anthropic/claude-sonnet-4-5",
+		Backend: "opencode",
+		Agents: map[string]string{
+			"implementation": "claude-code:anthropic/claude-opus-4-1",
+			"reviewer":       "anthropic/claude-haiku-4-5",
+			"tester":         "aider:",
+		},
+	}
+
+	tests := []struct {
+		agent       string
+		wantBackend string
+		wantModel   string
+	}{
+		{"implementation", "claude-code", "anthropic/claude-opus-4-1"},
+		{"reviewer", "opencode", "anthropic/claude-haiku-4-5"},
+		{"tester", "aider", ""},
+		{"unknown", "opencode", "anthropic/claude-sonnet-4-5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.agent, func(t *testing.T) {
+			backend, model := cfg.AgentBackend(tt.agent)
+			assert.Equal(t, tt.wantBackend, backend)
+			assert.Equal(t, tt.wantModel, model)
+		})
+	}
+}
@@ -18,6 +18,8 @@ type RequirementDriftDetectionGate struct {
 	tokenBudget           int // Re-check every N tokens
 	tokensSinceCheck      int
 	driftDetected         bool
+	analyzer              *DriftAnalyzer // Semantic analysis of the worktree diff, if set
+	report                *DriftReport
 }
 
 // DriftCheckpoint records a verification of requirement alignment.
@@ -52,6 +54,13 @@ func (rdd *RequirementDriftDetectionGate) SetCurrentImplementation(code string)
 	rdd.currentImplementation = code
 }
 
+// SetDriftAnalyzer enables semantic drift analysis of the worktree diff.
+// When set, Check maps criteria to tests and inspects added symbols instead of
+// matching requirement keywords against the implementation text.
+func (rdd *RequirementDriftDetectionGate) SetDriftAnalyzer(analyzer *DriftAnalyzer) {
+	rdd.analyzer = analyzer
+}
+
 // AddTokens tracks token consumption for re-checking periodically.
 func (rdd *RequirementDriftDetectionGate) AddTokens(count int) {
 	rdd.tokensSinceCheck += count
@@ -74,7 +83,7 @@ func (rdd *RequirementDriftDetectionGate) Name() string {
 }
 
 // Check verifies the implementation is still aligned with original requirements.
-func (rdd *RequirementDriftDetectionGate) Check(_ context.Context) error {
+func (rdd *RequirementDriftDetectionGate) Check(ctx context.Context) error {
 	// Validate inputs
 	if rdd.originalRequirement == nil {
 		return &GateError{
@@ -86,6 +95,10 @@ func (rdd *RequirementDriftDetectionGate) Check(_ context.Context) error {
 		}
 	}
 
+	if rdd.analyzer != nil {
+		return rdd.checkSemantic(ctx)
+	}
+
 	if rdd.currentImplementation == "" {
 		return &GateError{
 			Gate:      rdd.Type(),
@@ -123,6 +136,42 @@ func (rdd *RequirementDriftDetectionGate) Check(_ context.Context) error {
 	return nil
 }
 
+// checkSemantic runs the drift analyzer and records its report as a checkpoint.
+func (rdd *RequirementDriftDetectionGate) checkSemantic(ctx context.Context) error {
+	report, err := rdd.analyzer.Analyze(ctx, rdd.originalRequirement)
+	if err != nil {
+		return &GateError{
+			Gate:      rdd.Type(),
+			TaskID:    rdd.taskID,
+			Message:   "drift analysis failed",
+			Details:   err.Error(),
+			Timestamp: time.Now().Unix(),
+		}
+	}
+	rdd.report = report
+
+	checkpoint := DriftCheckpoint{
+		Timestamp:      time.Now().Unix(),
+		TokensUsed:     rdd.tokensSinceCheck,
+		AlignmentScore: report.Score,
+		Issues:         report.Issues(),
+		Passed:         report.Passed,
+	}
+	rdd.checkpoints = append(rdd.checkpoints, checkpoint)
+
+	if !report.Passed {
+		rdd.driftDetected = true
+		return &GateError{
+			Gate:      rdd.Type(),
+			TaskID:    rdd.taskID,
+			Message:   fmt.Sprintf("requirement drift detected: %.1f%% of criteria covered by passing tests, %d symbols out of scope", report.Score*100, len(report.OutOfScope)),
+			Details:   report.String(),
+			Timestamp: time.Now().Unix(),
+		}
+	}
+	return nil
+}
+
 // checkAlignment performs the actual alignment verification.
 func (rdd *RequirementDriftDetectionGate) checkAlignment() DriftCheckpoint {
 	checkpoint := DriftCheckpoint{
@@ -330,3 +379,8 @@ func (rdd *RequirementDriftDetectionGate) GetCheckpoints() []DriftCheckpoint {
 func (rdd *RequirementDriftDetectionGate) IsDriftDetected() bool {
 	return rdd.driftDetected
 }
+
+// Report returns the latest semantic drift report, or nil if the analyzer is not in use.
+func (rdd *RequirementDriftDetectionGate) Report() *DriftReport {
+	return rdd.report
+}
@@ -80,6 +80,7 @@ type Requirement struct {
 	Acceptance  string   // Acceptance criteria
 	Scenarios   []string // Expected test scenarios
 	EdgeCases   []string // Known edge cases to test
+	Scope       []string // Directories, files or globs the task may change (empty = unrestricted)
 }
 
 // Gate is the interface for all verification gates.
@@ -0,0 +1,503 @@
+package gates
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"open-swarm/internal/git"
+	"open-swarm/internal/opencode"
+)
+
+// Criterion kinds mapped by the drift analyzer.
+const (
+	CriterionAcceptance = "acceptance"
+	CriterionScenario   = "scenario"
+)
+
+// TestRunFunc runs the named tests in the given package directories and
+// returns whether each top-level test passed.
+type TestRunFunc func(ctx context.Context, workDir string, pkgDirs []string, tests []string) (map[string]bool, error)
+
+// DriftAnalyzer detects requirement drift from the actual git diff of a worktree.
+// Instead of looking for requirement words in the code it maps acceptance
+// criteria and scenarios to the tests that exercise them, runs those tests,
+// and flags exported symbols added outside the task's declared scope.
+type DriftAnalyzer struct {
+	workDir  string
+	baseRef  string
+	analyzer opencode.CodeAnalyzer
+	runTests TestRunFunc
+}
+
+// SymbolChange is an exported symbol introduced by the diff.
+type SymbolChange struct {
+	Name string
+	Kind string
+	File string
+	Line int
+}
+
+// CriterionCoverage records which tests exercise a criterion and whether they pass.
+type CriterionCoverage struct {
+	Criterion string
+	Kind      string   // CriterionAcceptance or CriterionScenario
+	Tests     []string // Tests whose names match the criterion
+	Failing   []string // Matched tests that did not pass
+	Covered   bool     // At least one matched test, all passing
+}
+
+// DriftReport is the structured result of a drift analysis.
+type DriftReport struct {
+	TaskID       string
+	BaseRef      string
+	ChangedFiles []string
+	AddedSymbols []SymbolChange      // Exported symbols added by the diff
+	OutOfScope   []SymbolChange      // Added symbols outside Requirement.Scope
+	Criteria     []CriterionCoverage // One entry per acceptance criterion and scenario
+	Score        float64             // Fraction of criteria covered (1.0 when there are none)
+	Passed       bool
+}
+
+// Uncovered returns the criteria without passing tests.
+func (r *DriftReport) Uncovered() []CriterionCoverage {
+	var uncovered []CriterionCoverage
+	for _, c := range r.Criteria {
+		if !c.Covered {
+			uncovered = append(uncovered, c)
+		}
+	}
+	return uncovered
+}
+
+// Issues summarizes why the report did not pass.
+func (r *DriftReport) Issues() []string {
+	var issues []string
+	for _, c := range r.Uncovered() {
+		switch {
+		case len(c.Tests) == 0:
+			issues = append(issues, fmt.Sprintf("No test exercises %s %q", c.Kind, c.Criterion))
+		default:
+			issues = append(issues, fmt.Sprintf("Tests for %s %q fail: %s", c.Kind, c.Criterion, strings.Join(c.Failing, ", ")))
+		}
+	}
+	for _, s := range r.OutOfScope {
+		issues = append(issues, fmt.Sprintf("Exported %s %s added outside declared scope (%s:%d)", s.Kind, s.Name, s.File, s.Line))
+	}
+	return issues
+}
+
+// String renders the report for gate error details.
+func (r *DriftReport) String() string {
+	var report strings.Builder
+
+	report.WriteString("=== Semantic Drift Report ===\n\n")
+	report.WriteString(fmt.Sprintf("Task: %s (diff against %s)\n", r.TaskID, r.BaseRef))
+	report.WriteString(fmt.Sprintf("Changed files: %d, exported symbols added: %d\n", len(r.ChangedFiles), len(r.AddedSymbols)))
+	report.WriteString(fmt.Sprintf("Criteria covered: %.1f%%\n\n", r.Score*100))
+
+	if len(r.Criteria) > 0 {
+		report.WriteString("CRITERIA:\n")
+		for _, c := range r.Criteria {
+			mark := "✗"
+			if c.Covered {
+				mark = "✓"
+			}
+			tests := "no tests"
+			if len(c.Tests) > 0 {
+				tests = strings.Join(c.Tests, ", ")
+			}
+			report.WriteString(fmt.Sprintf("  %s [%s] %s — %s\n", mark, c.Kind, c.Criterion, tests))
+		}
+		report.WriteString("\n")
+	}
+
+	if issues := r.Issues(); len(issues) > 0 {
+		report.WriteString("ISSUES:\n")
+		for _, issue := range issues {
+			report.WriteString(fmt.Sprintf("  • %s\n", issue))
+		}
+	}
+
+	return report.String()
+}
+
+// NewDriftAnalyzer creates an analyzer for the git worktree at workDir.
+func NewDriftAnalyzer(workDir string) *DriftAnalyzer {
+	return &DriftAnalyzer{
+		workDir:  workDir,
+		baseRef:  "HEAD",
+		analyzer: opencode.NewCodeAnalyzer(),
+		runTests: GoTestRunner,
+	}
+}
+
+// SetBaseRef sets the git revision the diff is taken against (default HEAD).
+func (da *DriftAnalyzer) SetBaseRef(ref string) {
+	if ref != "" {
+		da.baseRef = ref
+	}
+}
+
+// SetCodeAnalyzer replaces the symbol analyzer.
+func (da *DriftAnalyzer) SetCodeAnalyzer(analyzer opencode.CodeAnalyzer) {
+	da.analyzer = analyzer
+}
+
+// SetTestRunner replaces how mapped tests are executed.
+func (da *DriftAnalyzer) SetTestRunner(run TestRunFunc) {
+	da.runTests = run
+}
+
+// Analyze compares the worktree against the base revision and builds a drift report.
+func (da *DriftAnalyzer) Analyze(ctx context.Context, req *Requirement) (*DriftReport, error) {
+	report := &DriftReport{TaskID: req.TaskID, BaseRef: da.baseRef}
+
+	changed, err := da.changedSourceFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.ChangedFiles = changed
+
+	// Exported symbols the diff introduced
+	for _, file := range changed {
+		if opencode.IsTestFile(file) {
+			continue
+		}
+		added, err := da.addedSymbols(ctx, file)
+		if err != nil {
+			return nil, err
+		}
+		report.AddedSymbols = append(report.AddedSymbols, added...)
+	}
+	if len(req.Scope) > 0 {
+		for _, sym := range report.AddedSymbols {
+			if !inScope(sym.File, req.Scope) {
+				report.OutOfScope = append(report.OutOfScope, sym)
+			}
+		}
+	}
+
+	// Map criteria to tests in the packages the task touched or declared
+	criteria := splitCriteria(req)
+	if len(criteria) > 0 {
+		pkgDirs := da.testPackages(changed, req.Scope)
+		tests, err := da.findTests(ctx, pkgDirs)
+		if err != nil {
+			return nil, err
+		}
+
+		mapped := make(map[string]bool)
+		for _, c := range criteria {
+			c.Tests = matchTests(c.Criterion, tests)
+			for _, t := range c.Tests {
+				mapped[t] = true
+			}
+			report.Criteria = append(report.Criteria, c)
+		}
+
+		results := map[string]bool{}
+		if len(mapped) > 0 {
+			names := make([]string, 0, len(mapped))
+			for name := range mapped {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			results, err = da.runTests(ctx, da.workDir, pkgDirs, names)
+			if err != nil {
+				return nil, fmt.Errorf("failed to run mapped tests: %w", err)
+			}
+		}
+
+		covered := 0
+		for i := range report.Criteria {
+			c := &report.Criteria[i]
+			for _, t := range c.Tests {
+				if !results[t] {
+					c.Failing = append(c.Failing, t)
+				}
+			}
+			c.Covered = len(c.Tests) > 0 && len(c.Failing) == 0
+			if c.Covered {
+				covered++
+			}
+		}
+		report.Score = float64(covered) / float64(len(report.Criteria))
+	} else {
+		report.Score = 1.0
+	}
+
+	report.Passed = report.Score == 1.0 && len(report.OutOfScope) == 0
+	return report, nil
+}
+
+// changedSourceFiles lists modified and untracked files in a language the code
+// analyzer supports, relative to the worktree.
+func (da *DriftAnalyzer) changedSourceFiles(ctx context.Context) ([]string, error) {
+	diff, err := git.Open(da.workDir).Diff(ctx, git.DiffOptions{From: da.baseRef, IncludeUntracked: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff against %s: %w", da.baseRef, err)
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, file := range diff.Files {
+		path := file.Path()
+		if file.Status != git.FileDeleted && opencode.LanguageForFile(path) != "" && !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// addedSymbols returns exported symbols in file that do not exist at the base revision.
+func (da *DriftAnalyzer) addedSymbols(ctx context.Context, file string) ([]SymbolChange, error) {
+	current, err := da.analyzer.FindSymbols(ctx, filepath.Join(da.workDir, file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze %s: %w", file, err)
+	}
+
+	existing := make(map[string]bool)
+	if base, err := git.Open(da.workDir).ReadFile(ctx, da.baseRef, file); err == nil {
+		tmp, err := os.CreateTemp("", "drift-base-*"+filepath.Ext(file))
+		if err != nil {
+			return nil, fmt.Errorf("failed to stage base revision of %s: %w", file, err)
+		}
+		defer os.Remove(tmp.Name())
+		_, _ = tmp.Write(base)
+		_ = tmp.Close()
+
+		baseSymbols, err := da.analyzer.FindSymbols(ctx, tmp.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze base revision of %s: %w", file, err)
+		}
+		for _, sym := range baseSymbols {
+			existing[string(sym.Kind)+":"+sym.Name] = true
+		}
+	}
+
+	var added []SymbolChange
+	for _, sym := range current {
+		if !sym.Exported || existing[string(sym.Kind)+":"+sym.Name] {
+			continue
+		}
+		added = append(added, SymbolChange{Name: sym.Name, Kind: string(sym.Kind), File: file, Line: sym.LineNumber})
+	}
+	return added, nil
+}
+
+// testPackages returns the package directories whose tests may exercise the task.
+func (da *DriftAnalyzer) testPackages(changed, scope []string) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	add := func(dir string) {
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	for _, file := range changed {
+		add(path.Dir(file))
+	}
+	for _, s := range scope {
+		if !strings.ContainsAny(s, "*?[") && !strings.HasSuffix(s, ".go") {
+			if info, err := os.Stat(filepath.Join(da.workDir, s)); err == nil && info.IsDir() {
+				add(path.Clean(s))
+			}
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// findTests returns the top-level Test functions declared in the given package directories.
+func (da *DriftAnalyzer) findTests(ctx context.Context, pkgDirs []string) ([]string, error) {
+	var tests []string
+	for _, dir := range pkgDirs {
+		files, err := filepath.Glob(filepath.Join(da.workDir, dir, "*_test.go"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			symbols, err := da.analyzer.FindSymbols(ctx, file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to analyze %s: %w", file, err)
+			}
+			for _, sym := range symbols {
+				if sym.Kind == opencode.SymbolFunction && strings.HasPrefix(sym.Name, "Test") && sym.Name != "TestMain" {
+					tests = append(tests, sym.Name)
+				}
+			}
+		}
+	}
+	return tests, nil
+}
+
+// GoTestRunner runs tests with `go test -json -run` and reports per-test outcomes.
+func GoTestRunner(ctx context.Context, workDir string, pkgDirs []string, tests []string) (map[string]bool, error) {
+	args := []string{"test", "-json", "-count=1", "-run", "^(" + strings.Join(tests, "|") + ")$"}
+	for _, dir := range pkgDirs {
+		args = append(args, "./"+dir)
+	}
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = workDir
+	out, runErr := cmd.Output()
+
+	results := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		var event struct {
+			Action string
+			Test   string
+		}
+		if json.Unmarshal(scanner.Bytes(), &event) != nil || event.Test == "" || strings.Contains(event.Test, "/") {
+			continue
+		}
+		switch event.Action {
+		case "pass":
+			results[event.Test] = true
+		case "fail":
+			results[event.Test] = false
+		}
+	}
+	// A non-zero exit is expected when tests fail; only a run without results is an error
+	if runErr != nil && len(results) == 0 {
+		return nil, fmt.Errorf("go test produced no results: %w", runErr)
+	}
+	return results, nil
+}
+
+// splitCriteria turns acceptance criteria and scenarios into individual criteria.
+func splitCriteria(req *Requirement) []CriterionCoverage {
+	var criteria []CriterionCoverage
+	for _, part := range strings.FieldsFunc(req.Acceptance, func(r rune) bool { return r == ';' || r == '\n' }) {
+		part = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(part), "-*•"))
+		if part != "" {
+			criteria = append(criteria, CriterionCoverage{Criterion: part, Kind: CriterionAcceptance})
+		}
+	}
+	for _, scenario := range req.Scenarios {
+		if scenario = strings.TrimSpace(scenario); scenario != "" {
+			criteria = append(criteria, CriterionCoverage{Criterion: scenario, Kind: CriterionScenario})
+		}
+	}
+	return criteria
+}
+
+// criterionStopWords are ignored when matching criteria to test names.
+var criterionStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "that": true, "this": true,
+	"from": true, "are": true, "not": true, "but": true, "can": true, "must": true,
+	"should": true, "will": true, "when": true, "then": true, "given": true, "test": true,
+	"tests": true, "function": true, "returns": true, "return": true,
+}
+
+// matchTests returns the tests whose name words cover at least half of the
+// criterion's significant words.
+func matchTests(criterion string, tests []string) []string {
+	words := significantWords(strings.Fields(strings.ToLower(criterion)))
+	if len(words) == 0 {
+		return nil
+	}
+
+	var matched []string
+	for _, test := range tests {
+		nameWords := make(map[string]bool)
+		for _, w := range splitIdentifier(strings.TrimPrefix(test, "Test")) {
+			nameWords[stem(w)] = true
+		}
+		hits := 0
+		for _, w := range words {
+			if nameWords[stem(w)] {
+				hits++
+			}
+		}
+		if hits > 0 && hits*2 >= len(words) {
+			matched = append(matched, test)
+		}
+	}
+	return matched
+}
+
+func significantWords(fields []string) []string {
+	var words []string
+	for _, f := range fields {
+		f = strings.TrimFunc(f, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+		if len(f) > 2 && !criterionStopWords[f] {
+			words = append(words, f)
+		}
+	}
+	return words
+}
+
+// splitIdentifier splits CamelCase and snake_case names into lower-case words.
+func splitIdentifier(name string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(name)
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+	for i, r := range runes {
+		switch {
+		case r == '_' || !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])):
+			flush()
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+	return words
+}
+
+// stem strips common English suffixes so "validates" matches "Validate".
+func stem(word string) string {
+	switch {
+	case len(word) > 5 && strings.HasSuffix(word, "ing"):
+		word = strings.TrimSuffix(word, "ing")
+	case len(word) > 4 && strings.HasSuffix(word, "ed"):
+		word = strings.TrimSuffix(word, "ed")
+	case len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		word = strings.TrimSuffix(word, "s")
+	}
+	if len(word) > 3 {
+		word = strings.TrimSuffix(word, "e")
+	}
+	return word
+}
+
+// inScope reports whether file falls under one of the declared scope entries,
+// which may be directories, files or glob patterns.
+func inScope(file string, scope []string) bool {
+	for _, s := range scope {
+		s = path.Clean(filepath.ToSlash(s))
+		if s == "." || file == s || strings.HasPrefix(file, s+"/") {
+			return true
+		}
+		if ok, _ := path.Match(s, file); ok {
+			return true
+		}
+		if ok, _ := path.Match(s, path.Dir(file)); ok {
+			return true
+		}
+	}
+	return false
+}
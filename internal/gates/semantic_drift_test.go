@@ -0,0 +1,297 @@
+package gates
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newDriftRepo creates a git repository with a committed validation package.
+func newDriftRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	writeRepoFile(t, dir, "go.mod", "module example.com/drift\n\ngo 1.21\n")
+	writeRepoFile(t, dir, "validate/validate.go", `package validate
+
+// Email reports whether s looks like an email address.
+func Email(s string) bool { return len(s) > 0 }
+`)
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.email=t@example.com", "-c", "user.name=t", "commit", "-q", "-m", "base"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+	return dir
+}
+
+func writeRepoFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// passingRunner reports every requested test as passing except those listed.
+func passingRunner(failing ...string) TestRunFunc {
+	return func(_ context.Context, _ string, _ []string, tests []string) (map[string]bool, error) {
+		results := make(map[string]bool)
+		for _, name := range tests {
+			results[name] = true
+		}
+		for _, name := range failing {
+			results[name] = false
+		}
+		return results, nil
+	}
+}
+
+func TestDriftAnalyzer_MapsCriteriaToTests(t *testing.T) {
+	dir := newDriftRepo(t)
+	writeRepoFile(t, dir, "validate/validate.go", `package validate
+
+// Email reports whether s looks like an email address.
+func Email(s string) bool { return len(s) > 0 }
+
+// Phone reports whether s looks like a phone number.
+func Phone(s string) bool { return len(s) >= 10 }
+`)
+	writeRepoFile(t, dir, "validate/validate_test.go", `package validate
+
+import "testing"
+
+func TestValidatesEmail(t *testing.T) {}
+func TestPhone_RejectsShortNumbers(t *testing.T) {}
+`)
+
+	analyzer := NewDriftAnalyzer(dir)
+	analyzer.SetTestRunner(passingRunner())
+	report, err := analyzer.Analyze(context.Background(), &Requirement{
+		TaskID:     "task-1",
+		Acceptance: "must validate email; rejects short phone numbers",
+		Scenarios:  []string{"parse international prefixes"},
+		Scope:      []string{"validate"},
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if len(report.Criteria) != 3 {
+		t.Fatalf("expected 3 criteria, got %d", len(report.Criteria))
+	}
+	if got := report.Criteria[0].Tests; len(got) != 1 || got[0] != "TestValidatesEmail" {
+		t.Errorf("email criterion mapped to %v", got)
+	}
+	if got := report.Criteria[1].Tests; len(got) != 1 || got[0] != "TestPhone_RejectsShortNumbers" {
+		t.Errorf("phone criterion mapped to %v", got)
+	}
+	if report.Criteria[2].Covered {
+		t.Error("scenario without a test should not be covered")
+	}
+	if report.Passed {
+		t.Error("report should fail with an uncovered scenario")
+	}
+	if len(report.AddedSymbols) != 1 || report.AddedSymbols[0].Name != "Phone" {
+		t.Errorf("expected Phone to be the only added symbol, got %+v", report.AddedSymbols)
+	}
+	if len(report.OutOfScope) != 0 {
+		t.Errorf("Phone is inside the declared scope, got %+v", report.OutOfScope)
+	}
+	if !strings.Contains(report.String(), `No test exercises scenario "parse international prefixes"`) {
+		t.Errorf("report should explain the uncovered scenario:\n%s", report)
+	}
+}
+
+func TestDriftAnalyzer_RenamesDoNotFlipVerdict(t *testing.T) {
+	dir := newDriftRepo(t)
+	writeRepoFile(t, dir, "validate/validate.go", `package validate
+
+// Email reports whether s looks like an email address.
+func Email(x string) bool { n := len(x); return n > 0 }
+`)
+	writeRepoFile(t, dir, "validate/validate_test.go", `package validate
+
+import "testing"
+
+func TestValidateEmail(t *testing.T) {}
+`)
+
+	analyzer := NewDriftAnalyzer(dir)
+	analyzer.SetTestRunner(passingRunner())
+	report, err := analyzer.Analyze(context.Background(), &Requirement{
+		TaskID:     "task-1",
+		Acceptance: "validate email addresses",
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if !report.Passed || report.Score != 1.0 {
+		t.Errorf("renaming a local variable must not cause drift:\n%s", report)
+	}
+}
+
+func TestDriftAnalyzer_FlagsOutOfScopeSymbols(t *testing.T) {
+	dir := newDriftRepo(t)
+	writeRepoFile(t, dir, "metrics/metrics.go", `package metrics
+
+// Counter is unrelated to the task.
+type Counter struct{}
+`)
+
+	analyzer := NewDriftAnalyzer(dir)
+	analyzer.SetTestRunner(passingRunner())
+	report, err := analyzer.Analyze(context.Background(), &Requirement{
+		TaskID: "task-1",
+		Scope:  []string{"validate/*.go"},
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(report.OutOfScope) != 1 || report.OutOfScope[0].Name != "Counter" {
+		t.Fatalf("expected Counter out of scope, got %+v", report.OutOfScope)
+	}
+	if report.Passed {
+		t.Error("out of scope symbols should fail the report")
+	}
+}
+
+func TestDriftAnalyzer_FlagsSymbolsInOtherLanguages(t *testing.T) {
+	dir := newDriftRepo(t)
+	writeRepoFile(t, dir, "web/format.ts", `export function formatEmail(s: string): string { return s; }
+function helper() {}
+`)
+	writeRepoFile(t, dir, "web/format.test.ts", `export const fixture = "a@b";
+`)
+	writeRepoFile(t, dir, "scripts/report.py", `def summarize(rows):
+    return len(rows)
+
+
+def _private():
+    pass
+`)
+	writeRepoFile(t, dir, "README.md", "# drift\n")
+
+	analyzer := NewDriftAnalyzer(dir)
+	analyzer.SetTestRunner(passingRunner())
+	report, err := analyzer.Analyze(context.Background(), &Requirement{
+		TaskID: "task-1",
+		Scope:  []string{"validate"},
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	var names []string
+	for _, s := range report.OutOfScope {
+		names = append(names, s.File+":"+s.Name)
+	}
+	if got := strings.Join(names, " "); got != "scripts/report.py:summarize web/format.ts:formatEmail" {
+		t.Errorf("out of scope symbols = %q", got)
+	}
+	if strings.Contains(strings.Join(report.ChangedFiles, " "), "README.md") {
+		t.Errorf("unsupported files should not be analyzed: %v", report.ChangedFiles)
+	}
+}
+
+func TestDriftAnalyzer_FailingTestsLeaveCriterionUncovered(t *testing.T) {
+	dir := newDriftRepo(t)
+	writeRepoFile(t, dir, "validate/validate_test.go", `package validate
+
+import "testing"
+
+func TestEmail(t *testing.T) {}
+`)
+
+	analyzer := NewDriftAnalyzer(dir)
+	analyzer.SetTestRunner(passingRunner("TestEmail"))
+	report, err := analyzer.Analyze(context.Background(), &Requirement{TaskID: "task-1", Acceptance: "validate email"})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if report.Criteria[0].Covered || len(report.Criteria[0].Failing) != 1 {
+		t.Errorf("criterion with failing test should be uncovered: %+v", report.Criteria[0])
+	}
+}
+
+func TestDriftAnalyzer_GoTestRunner(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	dir := newDriftRepo(t)
+	writeRepoFile(t, dir, "validate/validate_test.go", `package validate
+
+import "testing"
+
+func TestEmail_Accepts(t *testing.T) {
+	if !Email("a@b.c") {
+		t.Fatal("rejected")
+	}
+}
+
+func TestEmail_RejectsEmpty(t *testing.T) {
+	if !Email("") {
+		t.Fatal("deliberately failing")
+	}
+}
+`)
+
+	results, err := GoTestRunner(context.Background(), dir, []string{"validate"}, []string{"TestEmail_Accepts", "TestEmail_RejectsEmpty"})
+	if err != nil {
+		t.Fatalf("GoTestRunner failed: %v", err)
+	}
+	if !results["TestEmail_Accepts"] || results["TestEmail_RejectsEmpty"] {
+		t.Errorf("unexpected results: %v", results)
+	}
+}
+
+func TestRequirementDriftDetectionGate_SemanticAnalyzer(t *testing.T) {
+	dir := newDriftRepo(t)
+	writeRepoFile(t, dir, "validate/validate_test.go", `package validate
+
+import "testing"
+
+func TestEmail(t *testing.T) {}
+`)
+
+	req := &Requirement{TaskID: "task-1", Acceptance: "validate email", Scenarios: []string{"validate phone"}}
+	gate := NewRequirementDriftDetectionGate("task-1", req)
+	analyzer := NewDriftAnalyzer(dir)
+	analyzer.SetTestRunner(passingRunner())
+	gate.SetDriftAnalyzer(analyzer)
+
+	err := gate.Check(context.Background())
+	var gateErr *GateError
+	if !errors.As(err, &gateErr) || gateErr.Gate != GateDriftDetection {
+		t.Fatalf("expected drift gate error, got %v", err)
+	}
+	if gate.Report() == nil || gate.Report().Score != 0.5 {
+		t.Errorf("expected half the criteria covered, got %+v", gate.Report())
+	}
+	if !gate.IsDriftDetected() {
+		t.Error("drift should be recorded")
+	}
+}
+
+func TestSplitIdentifier(t *testing.T) {
+	got := strings.Join(splitIdentifier("HTTPClient_RetriesOn503"), ",")
+	if got != "http,client,retries,on503" {
+		t.Errorf("splitIdentifier = %s", got)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Trailer is a "Key: value" line at the end of a commit message
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// Commit describes a commit
+type Commit struct {
+	Hash        string
+	Parents     []string
+	AuthorName  string
+	AuthorEmail string
+	Subject     string
+	Body        string // Message after the subject, trailers included
+	Trailers    []Trailer
+	Files       []string // Paths changed relative to the first parent
+}
+
+// Trailer returns the value of the last trailer with key (case-insensitive)
+func (c *Commit) Trailer(key string) string {
+	value := ""
+	for _, trailer := range c.Trailers {
+		if strings.EqualFold(trailer.Key, key) {
+			value = trailer.Value
+		}
+	}
+	return value
+}
+
+// CommitOptions configures Commit
+type CommitOptions struct {
+	Message  string
+	Trailers []Trailer
+	Author   string   // Optional "Name <email>"
+	Paths    []string // Paths to stage first (empty = all changes)
+	// AllowEmpty records a commit even when nothing is staged
+	AllowEmpty bool
+}
+
+// Commit stages opts.Paths (or everything), then commits. Returns
+// ErrNothingToCommit when nothing is staged.
+func (r *Repo) Commit(ctx context.Context, opts CommitOptions) (*Commit, error) {
+	if err := r.Add(ctx, opts.Paths...); err != nil {
+		return nil, err
+	}
+
+	if !opts.AllowEmpty {
+		// --quiet exits 1 when the index differs from HEAD
+		_, err := r.run(ctx, "diff", "--cached", "--quiet")
+		if err == nil {
+			return nil, ErrNothingToCommit
+		}
+		if exitCode(err) != 1 {
+			return nil, fmt.Errorf("failed to check staged changes: %w", err)
+		}
+	}
+
+	args := []string{"commit", "--quiet", "-m", FormatMessage(opts.Message, opts.Trailers)}
+	if opts.Author != "" {
+		args = append(args, "--author", opts.Author)
+	}
+	if opts.AllowEmpty {
+		args = append(args, "--allow-empty")
+	}
+	if _, err := r.run(ctx, args...); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return r.ShowCommit(ctx, "HEAD")
+}
+
+// commitFormat separates fields with NUL; %B is the raw message
+const commitFormat = "%H%x00%P%x00%an%x00%ae%x00%B"
+
+// ShowCommit returns the commit at rev with its trailers and changed files
+func (r *Repo) ShowCommit(ctx context.Context, rev string) (*Commit, error) {
+	out, err := r.run(ctx, "show", "-s", "--format="+commitFormat, "--end-of-options", rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit %s: %w", rev, err)
+	}
+	fields := strings.SplitN(out, "\x00", 5)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("unexpected commit format for %s", rev)
+	}
+
+	message := strings.TrimRight(fields[4], "\n")
+	subject, body, _ := strings.Cut(message, "\n")
+	commit := &Commit{
+		Hash:        fields[0],
+		Parents:     strings.Fields(fields[1]),
+		AuthorName:  fields[2],
+		AuthorEmail: fields[3],
+		Subject:     subject,
+		Body:        strings.TrimLeft(body, "\n"),
+		Trailers:    ParseTrailers(message),
+	}
+
+	files, err := r.run(ctx, "diff-tree", "--no-commit-id", "--name-only", "-r", "-z", "--root", "--find-renames", commit.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of %s: %w", rev, err)
+	}
+	commit.Files = []string{}
+	for _, file := range strings.Split(files, "\x00") {
+		if file != "" {
+			commit.Files = append(commit.Files, file)
+		}
+	}
+	return commit, nil
+}
+
+// FormatMessage appends trailers to message as a final paragraph
+func FormatMessage(message string, trailers []Trailer) string {
+	message = strings.TrimRight(message, "\n")
+	if len(trailers) == 0 {
+		return message
+	}
+	var sb strings.Builder
+	sb.WriteString(message)
+	if len(ParseTrailers(message)) == 0 {
+		sb.WriteString("\n")
+	}
+	for _, trailer := range trailers {
+		sb.WriteString(fmt.Sprintf("\n%s: %s", trailer.Key, trailer.Value))
+	}
+	return sb.String()
+}
+
+// trailerLine matches "Key: value" with a token key
+var trailerLine = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*):\s*(.*)$`)
+
+// ParseTrailers returns the trailers in the last paragraph of message. The
+// subject line never holds trailers; indented lines continue the previous
+// value.
+func ParseTrailers(message string) []Trailer {
+	paragraphs := strings.Split(strings.TrimSpace(message), "\n\n")
+	if len(paragraphs) < 2 {
+		return nil
+	}
+
+	var trailers []Trailer
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(trailers) > 0 {
+			trailers[len(trailers)-1].Value += " " + strings.TrimSpace(line)
+			continue
+		}
+		m := trailerLine.FindStringSubmatch(line)
+		if m == nil {
+			return nil
+		}
+		trailers = append(trailers, Trailer{Key: m[1], Value: strings.TrimSpace(m[2])})
+	}
+	return trailers
+}
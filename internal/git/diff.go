@@ -0,0 +1,453 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FileStatus is how a file changed in a diff
+type FileStatus string
+
+const (
+	// FileAdded is a new file
+	FileAdded FileStatus = "A"
+	// FileModified is a changed file
+	FileModified FileStatus = "M"
+	// FileDeleted is a removed file
+	FileDeleted FileStatus = "D"
+	// FileRenamed is a moved file, possibly with changes
+	FileRenamed FileStatus = "R"
+	// FileCopied is a copy of another file, possibly with changes
+	FileCopied FileStatus = "C"
+)
+
+// LineKind marks a line in a hunk
+type LineKind string
+
+const (
+	// LineContext is an unchanged line
+	LineContext LineKind = "context"
+	// LineAdded is a line present only on the new side
+	LineAdded LineKind = "added"
+	// LineDeleted is a line present only on the old side
+	LineDeleted LineKind = "deleted"
+)
+
+// DiffLine is one line of a hunk. OldLine and NewLine are 0 on the side the
+// line does not exist.
+type DiffLine struct {
+	Kind    LineKind
+	Content string
+	OldLine int
+	NewLine int
+}
+
+// Hunk is one @@ section of a file diff
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Section  string // Text after the closing @@, usually the enclosing function
+	Lines    []DiffLine
+}
+
+// LineRange is an inclusive range of line numbers
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// FileDiff is the change to one file
+type FileDiff struct {
+	OldPath    string // Empty for added files
+	NewPath    string // Empty for deleted files
+	Status     FileStatus
+	Similarity int // Rename or copy score, 0-100
+	Binary     bool
+	Insertions int
+	Deletions  int
+	Hunks      []Hunk
+}
+
+// Path returns the new path, or the old path of a deleted file
+func (f FileDiff) Path() string {
+	if f.NewPath != "" {
+		return f.NewPath
+	}
+	return f.OldPath
+}
+
+// AddedLines returns the new-side line ranges added by the diff, merging
+// consecutive lines
+func (f FileDiff) AddedLines() []LineRange {
+	var ranges []LineRange
+	for _, hunk := range f.Hunks {
+		for _, line := range hunk.Lines {
+			if line.Kind != LineAdded {
+				continue
+			}
+			if n := len(ranges); n > 0 && ranges[n-1].End == line.NewLine-1 {
+				ranges[n-1].End = line.NewLine
+				continue
+			}
+			ranges = append(ranges, LineRange{Start: line.NewLine, End: line.NewLine})
+		}
+	}
+	return ranges
+}
+
+// IsPureRename reports a rename or copy that changed no content
+func (f FileDiff) IsPureRename() bool {
+	return (f.Status == FileRenamed || f.Status == FileCopied) && len(f.Hunks) == 0 && !f.Binary
+}
+
+// Diff is a parsed diff
+type Diff struct {
+	Files []FileDiff
+	Patch string // Raw unified diff (untracked files are not included)
+}
+
+// Paths returns the path of every changed file
+func (d *Diff) Paths() []string {
+	paths := make([]string, 0, len(d.Files))
+	for _, file := range d.Files {
+		paths = append(paths, file.Path())
+	}
+	return paths
+}
+
+// Insertions returns the number of added lines across all files
+func (d *Diff) Insertions() int {
+	total := 0
+	for _, file := range d.Files {
+		total += file.Insertions
+	}
+	return total
+}
+
+// Deletions returns the number of deleted lines across all files
+func (d *Diff) Deletions() int {
+	total := 0
+	for _, file := range d.Files {
+		total += file.Deletions
+	}
+	return total
+}
+
+// File returns the diff of path, or nil
+func (d *Diff) File(path string) *FileDiff {
+	for i := range d.Files {
+		if d.Files[i].Path() == path {
+			return &d.Files[i]
+		}
+	}
+	return nil
+}
+
+// AddedLines returns the added line ranges per new path
+func (d *Diff) AddedLines() map[string][]LineRange {
+	added := make(map[string][]LineRange)
+	for _, file := range d.Files {
+		if file.NewPath == "" {
+			continue
+		}
+		if ranges := file.AddedLines(); len(ranges) > 0 {
+			added[file.NewPath] = ranges
+		}
+	}
+	return added
+}
+
+// DiffOptions selects what to compare. With neither From nor Staged set the
+// work tree is compared with the index.
+type DiffOptions struct {
+	From   string // Base revision, e.g. "HEAD" to include all uncommitted changes
+	To     string // Target revision (empty = work tree, or index when Staged)
+	Staged bool   // Compare the index instead of the work tree
+	Paths  []string
+	// IncludeUntracked adds untracked files as additions (work tree diffs only)
+	IncludeUntracked bool
+}
+
+// Diff returns the structured diff selected by opts, with rename detection
+func (r *Repo) Diff(ctx context.Context, opts DiffOptions) (*Diff, error) {
+	revs := []string{"--find-renames"}
+	if opts.Staged {
+		revs = append(revs, "--cached")
+	}
+	if opts.From != "" {
+		revs = append(revs, opts.From)
+	}
+	if opts.To != "" {
+		revs = append(revs, opts.To)
+	}
+	revs = append(revs, "--")
+	revs = append(revs, opts.Paths...)
+
+	patch, err := r.run(ctx, append([]string{"-c", "core.quotePath=false", "diff", "--no-color", "--no-ext-diff"}, revs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff: %w", err)
+	}
+	numstat, err := r.run(ctx, append([]string{"diff", "--numstat", "-z"}, revs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff: %w", err)
+	}
+
+	diff := &Diff{Files: ParsePatch(patch), Patch: patch}
+	for _, stat := range ParseNumstat(numstat) {
+		if file := diff.File(stat.Path); file != nil {
+			file.Insertions, file.Deletions, file.Binary = stat.Insertions, stat.Deletions, stat.Binary
+		}
+	}
+
+	if opts.IncludeUntracked && opts.To == "" && !opts.Staged {
+		if err := r.addUntracked(ctx, diff, opts.Paths); err != nil {
+			return nil, err
+		}
+	}
+	return diff, nil
+}
+
+// addUntracked appends untracked files as whole-file additions
+func (r *Repo) addUntracked(ctx context.Context, diff *Diff, paths []string) error {
+	out, err := r.run(ctx, append([]string{"ls-files", "--others", "--exclude-standard", "-z", "--"}, paths...)...)
+	if err != nil {
+		return fmt.Errorf("failed to list untracked files: %w", err)
+	}
+	for _, path := range strings.Split(out, "\x00") {
+		if path == "" {
+			continue
+		}
+		file := FileDiff{NewPath: path, Status: FileAdded}
+		content, err := os.ReadFile(filepath.Join(r.dir, path)) //nolint:gosec // Path is listed by git inside the repository
+		if err != nil {
+			return fmt.Errorf("failed to read untracked file %s: %w", path, err)
+		}
+		if bytes.IndexByte(content, 0) >= 0 {
+			file.Binary = true
+		} else if len(content) > 0 {
+			lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+			hunk := Hunk{NewStart: 1, NewLines: len(lines)}
+			for i, line := range lines {
+				hunk.Lines = append(hunk.Lines, DiffLine{Kind: LineAdded, Content: line, NewLine: i + 1})
+			}
+			file.Hunks = []Hunk{hunk}
+			file.Insertions = len(lines)
+		}
+		diff.Files = append(diff.Files, file)
+	}
+	return nil
+}
+
+// NumstatEntry is one record of git diff --numstat -z
+type NumstatEntry struct {
+	Path       string
+	OrigPath   string // Set for renames and copies
+	Insertions int
+	Deletions  int
+	Binary     bool
+}
+
+// ParseNumstat parses git diff --numstat -z output. Renames are encoded as
+// "added TAB deleted TAB NUL old NUL new NUL".
+func ParseNumstat(output string) []NumstatEntry {
+	var entries []NumstatEntry
+	records := strings.Split(output, "\x00")
+	for i := 0; i < len(records); i++ {
+		fields := strings.SplitN(records[i], "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		entry := NumstatEntry{Path: fields[2]}
+		if fields[0] == "-" && fields[1] == "-" {
+			entry.Binary = true
+		} else {
+			entry.Insertions, _ = strconv.Atoi(fields[0])
+			entry.Deletions, _ = strconv.Atoi(fields[1])
+		}
+		if entry.Path == "" && i+2 < len(records) {
+			entry.OrigPath, entry.Path = records[i+1], records[i+2]
+			i += 2
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// hunkHeader matches "@@ -a,b +c,d @@ section"
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// ParsePatch parses unified diff output of git diff into file diffs
+func ParsePatch(patch string) []FileDiff {
+	var files []FileDiff
+	var file *FileDiff
+	var hunk *Hunk
+	oldLine, newLine := 0, 0
+
+	flush := func() {
+		if file == nil {
+			return
+		}
+		if hunk != nil {
+			file.Hunks = append(file.Hunks, *hunk)
+			hunk = nil
+		}
+		files = append(files, *file)
+		file = nil
+	}
+
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			flush()
+			oldPath, newPath := splitDiffGitPaths(strings.TrimPrefix(line, "diff --git "))
+			file = &FileDiff{OldPath: oldPath, NewPath: newPath, Status: FileModified}
+			continue
+		}
+		if file == nil {
+			continue
+		}
+
+		if hunk != nil {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				hunk.Lines = append(hunk.Lines, DiffLine{Kind: LineAdded, Content: line[1:], NewLine: newLine})
+				file.Insertions++
+				newLine++
+				continue
+			case strings.HasPrefix(line, "-"):
+				hunk.Lines = append(hunk.Lines, DiffLine{Kind: LineDeleted, Content: line[1:], OldLine: oldLine})
+				file.Deletions++
+				oldLine++
+				continue
+			case strings.HasPrefix(line, " "),
+				// Editors strip the space from blank context lines
+				line == "" && oldLine < hunk.OldStart+hunk.OldLines:
+				hunk.Lines = append(hunk.Lines, DiffLine{Kind: LineContext, Content: strings.TrimPrefix(line, " "), OldLine: oldLine, NewLine: newLine})
+				oldLine++
+				newLine++
+				continue
+			case strings.HasPrefix(line, `\`):
+				continue // "\ No newline at end of file"
+			}
+		}
+
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			if hunk != nil {
+				file.Hunks = append(file.Hunks, *hunk)
+			}
+			hunk = nil
+			if m := hunkHeader.FindStringSubmatch(line); m != nil {
+				hunk = &Hunk{
+					OldStart: atoi(m[1]), OldLines: countOrOne(m[2]),
+					NewStart: atoi(m[3]), NewLines: countOrOne(m[4]),
+					Section: m[5],
+				}
+				oldLine, newLine = hunk.OldStart, hunk.NewStart
+			}
+		case strings.HasPrefix(line, "new file mode"):
+			file.Status = FileAdded
+			file.OldPath = ""
+		case strings.HasPrefix(line, "deleted file mode"):
+			file.Status = FileDeleted
+			file.NewPath = ""
+		case strings.HasPrefix(line, "rename from "):
+			file.Status = FileRenamed
+			file.OldPath = unquotePath(strings.TrimPrefix(line, "rename from "))
+		case strings.HasPrefix(line, "rename to "):
+			file.NewPath = unquotePath(strings.TrimPrefix(line, "rename to "))
+		case strings.HasPrefix(line, "copy from "):
+			file.Status = FileCopied
+			file.OldPath = unquotePath(strings.TrimPrefix(line, "copy from "))
+		case strings.HasPrefix(line, "copy to "):
+			file.NewPath = unquotePath(strings.TrimPrefix(line, "copy to "))
+		case strings.HasPrefix(line, "similarity index "):
+			file.Similarity = atoi(strings.TrimSuffix(strings.TrimPrefix(line, "similarity index "), "%"))
+		case strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch":
+			file.Binary = true
+		case strings.HasPrefix(line, "--- "):
+			if path := stripPrefix(unquotePath(strings.TrimPrefix(line, "--- ")), "a/"); path != "" {
+				file.OldPath = path
+			}
+		case strings.HasPrefix(line, "+++ "):
+			if path := stripPrefix(unquotePath(strings.TrimPrefix(line, "+++ ")), "b/"); path != "" {
+				file.NewPath = path
+			}
+		}
+	}
+	flush()
+	return files
+}
+
+// splitDiffGitPaths splits "a/x b/x". Paths with spaces are ambiguous here;
+// the ---/+++ and rename headers that follow override the guess.
+func splitDiffGitPaths(s string) (string, string) {
+	if strings.HasPrefix(s, `"`) {
+		if end := closingQuote(s); end > 0 {
+			return stripPrefix(unquotePath(s[:end+1]), "a/"), stripPrefix(unquotePath(strings.TrimSpace(s[end+1:])), "b/")
+		}
+	}
+	// Identical old and new paths split evenly around the middle space
+	if half := len(s) / 2; len(s)%2 == 1 && s[half] == ' ' && s[2:half] == s[half+3:] {
+		return s[2:half], s[half+3:]
+	}
+	if i := strings.Index(s, " b/"); i >= 0 {
+		return stripPrefix(s[:i], "a/"), s[i+3:]
+	}
+	return s, s
+}
+
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// unquotePath decodes git's C-style quoted paths
+func unquotePath(path string) string {
+	if strings.HasPrefix(path, `"`) {
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			return unquoted
+		}
+	}
+	return path
+}
+
+// stripPrefix removes the a/ or b/ prefix; /dev/null becomes ""
+func stripPrefix(path, prefix string) string {
+	if path == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(path, prefix)
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// countOrOne parses a hunk line count, which is omitted when it is 1
+func countOrOne(s string) int {
+	if s == "" {
+		return 1
+	}
+	return atoi(s)
+}
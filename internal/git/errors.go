@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors classified from git failures. Use errors.Is on errors
+// returned by this package.
+var (
+	// ErrNotRepository is returned when the directory is not inside a repository
+	ErrNotRepository = errors.New("not a git repository")
+	// ErrRefNotFound is returned when a branch, tag, commit or remote ref does not exist
+	ErrRefNotFound = errors.New("ref not found")
+	// ErrBranchExists is returned when creating a branch that already exists
+	ErrBranchExists = errors.New("branch already exists")
+	// ErrDirtyTree is returned when local changes block the operation
+	ErrDirtyTree = errors.New("working tree has uncommitted changes")
+	// ErrConflict is returned when a merge, rebase or cherry-pick stops on conflicts
+	ErrConflict = errors.New("conflict")
+	// ErrNothingToCommit is returned by Commit when nothing is staged
+	ErrNothingToCommit = errors.New("nothing to commit")
+	// ErrNonFastForward is returned when a push or branch update is rejected
+	// because the branch moved
+	ErrNonFastForward = errors.New("non-fast-forward")
+)
+
+// CommandError describes a failed git invocation
+type CommandError struct {
+	Args     []string
+	ExitCode int
+	Stderr   string
+	// Kind is the classified sentinel error, nil when unclassified
+	Kind error
+	Err  error
+}
+
+func (e *CommandError) Error() string {
+	cmd := "git"
+	if len(e.Args) > 0 {
+		cmd += " " + e.Args[0]
+	}
+	msg := firstLine(strings.TrimSpace(e.Stderr))
+	if msg == "" {
+		return fmt.Sprintf("%s: exit status %d", cmd, e.ExitCode)
+	}
+	return fmt.Sprintf("%s: exit status %d: %s", cmd, e.ExitCode, msg)
+}
+
+// Unwrap exposes both the classified sentinel and the underlying exec error
+func (e *CommandError) Unwrap() []error {
+	errs := make([]error, 0, 2)
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// errorPatterns maps git messages (LC_ALL=C) onto sentinel errors, checked in order
+var errorPatterns = []struct {
+	kind    error
+	needles []string
+}{
+	{ErrNotRepository, []string{"not a git repository"}},
+	{ErrConflict, []string{
+		"CONFLICT", "Merge conflict", "needs merge", "unmerged files",
+		"resolve your current index first", "could not apply", "Resolve all conflicts",
+	}},
+	{ErrDirtyTree, []string{
+		"would be overwritten by", "Please commit your changes or stash them",
+		"contains modified or untracked files", "You have unstaged changes",
+		"Your index contains uncommitted changes",
+	}},
+	{ErrNonFastForward, []string{"non-fast-forward", "[rejected]", "fetch first", "stale info", "but expected"}},
+	{ErrBranchExists, []string{"already exists"}},
+	{ErrNothingToCommit, []string{"nothing to commit", "nothing added to commit"}},
+	{ErrRefNotFound, []string{
+		"unknown revision", "bad revision", "invalid reference", "not a valid object name",
+		"couldn't find remote ref", "did not match any file(s) known to git", "not found",
+		"does not exist", "Needed a single revision", "not a valid ref", "exists on disk, but not in",
+		"src refspec", "Remote branch",
+	}},
+}
+
+// classify returns the sentinel error matching git's output, or nil
+func classify(output string) error {
+	for _, pattern := range errorPatterns {
+		for _, needle := range pattern.needles {
+			if strings.Contains(output, needle) {
+				return pattern.kind
+			}
+		}
+	}
+	return nil
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
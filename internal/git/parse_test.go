@@ -0,0 +1,196 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStatus(t *testing.T) {
+	output := "# branch.oid 1234abcd\x00" +
+		"# branch.head feature\x00" +
+		"# branch.upstream origin/feature\x00" +
+		"# branch.ab +2 -1\x00" +
+		"1 .M N... 100644 100644 100644 aaaa bbbb README.md\x00" +
+		"1 A. N... 000000 100644 100644 0000 cccc dir with space/new.go\x00" +
+		"2 R. N... 100644 100644 100644 dddd eeee R95 pkg/new_name.go\x00pkg/old_name.go\x00" +
+		"u UU N... 100644 100644 100644 100644 ffff 1111 2222 conflict.go\x00" +
+		"? notes.txt\x00"
+
+	status, err := ParseStatus(output)
+	if err != nil {
+		t.Fatalf("ParseStatus() error = %v", err)
+	}
+	if status.Branch != "feature" || status.Upstream != "origin/feature" || status.Ahead != 2 || status.Behind != 1 {
+		t.Errorf("branch header = %+v", status)
+	}
+	if status.Commit != "1234abcd" || status.Detached {
+		t.Errorf("commit = %q, detached = %v", status.Commit, status.Detached)
+	}
+
+	rename := status.Entries[2]
+	if rename.Kind != EntryRenamed || rename.Path != "pkg/new_name.go" || rename.OrigPath != "pkg/old_name.go" || rename.Similarity != 95 {
+		t.Errorf("rename entry = %+v", rename)
+	}
+
+	checks := map[string]struct{ got, want []string }{
+		"Staged":     {status.Staged(), []string{"dir with space/new.go", "pkg/new_name.go"}},
+		"Modified":   {status.Modified(), []string{"README.md"}},
+		"Untracked":  {status.Untracked(), []string{"notes.txt"}},
+		"Conflicted": {status.Conflicted(), []string{"conflict.go"}},
+	}
+	for name, check := range checks {
+		if !reflect.DeepEqual(check.got, check.want) {
+			t.Errorf("%s() = %v, want %v", name, check.got, check.want)
+		}
+	}
+	if status.IsClean() {
+		t.Error("IsClean() = true, want false")
+	}
+}
+
+func TestParseStatus_DetachedInitial(t *testing.T) {
+	status, err := ParseStatus("# branch.oid (initial)\x00# branch.head (detached)\x00")
+	if err != nil {
+		t.Fatalf("ParseStatus() error = %v", err)
+	}
+	if !status.Detached || status.Branch != "" || status.Commit != "" || !status.IsClean() {
+		t.Errorf("status = %+v", status)
+	}
+}
+
+func TestParseNumstat(t *testing.T) {
+	output := "3\t1\tmain.go\x00" +
+		"-\t-\tlogo.png\x00" +
+		"0\t0\t\x00old.go\x00new.go\x00"
+
+	want := []NumstatEntry{
+		{Path: "main.go", Insertions: 3, Deletions: 1},
+		{Path: "logo.png", Binary: true},
+		{Path: "new.go", OrigPath: "old.go"},
+	}
+	if got := ParseNumstat(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseNumstat() = %+v, want %+v", got, want)
+	}
+}
+
+const samplePatch = `diff --git a/calc.go b/calc.go
+index 1111111..2222222 100644
+--- a/calc.go
++++ b/calc.go
+@@ -1,4 +1,5 @@ package calc
+ package calc
+
+-func Add(a, b int) int { return a - b }
++// Add returns the sum
++func Add(a, b int) int { return a + b }
+
+@@ -10 +11,2 @@ func Sub(a, b int) int {
++	// trailing
++	return 0
+diff --git a/old name.go b/new name.go
+similarity index 100%
+rename from old name.go
+rename to new name.go
+diff --git a/gone.go b/gone.go
+deleted file mode 100644
+index 3333333..0000000
+--- a/gone.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package gone
+diff --git a/logo.png b/logo.png
+new file mode 100644
+index 0000000..4444444
+Binary files /dev/null and b/logo.png differ
+`
+
+func TestParsePatch(t *testing.T) {
+	files := ParsePatch(samplePatch)
+	if len(files) != 4 {
+		t.Fatalf("ParsePatch() returned %d files, want 4", len(files))
+	}
+
+	calc := files[0]
+	if calc.Status != FileModified || calc.Path() != "calc.go" || calc.Insertions != 4 || calc.Deletions != 1 {
+		t.Errorf("calc.go = %+v", calc)
+	}
+	if len(calc.Hunks) != 2 || calc.Hunks[0].Section != "package calc" || calc.Hunks[1].NewStart != 11 || calc.Hunks[1].OldLines != 1 {
+		t.Errorf("calc.go hunks = %+v", calc.Hunks)
+	}
+	if want := []LineRange{{3, 4}, {11, 12}}; !reflect.DeepEqual(calc.AddedLines(), want) {
+		t.Errorf("AddedLines() = %v, want %v", calc.AddedLines(), want)
+	}
+	deleted := calc.Hunks[0].Lines[2]
+	if deleted.Kind != LineDeleted || deleted.OldLine != 3 || deleted.NewLine != 0 {
+		t.Errorf("deleted line = %+v", deleted)
+	}
+
+	rename := files[1]
+	if rename.Status != FileRenamed || rename.OldPath != "old name.go" || rename.NewPath != "new name.go" || !rename.IsPureRename() {
+		t.Errorf("rename = %+v", rename)
+	}
+
+	gone := files[2]
+	if gone.Status != FileDeleted || gone.Path() != "gone.go" || gone.NewPath != "" {
+		t.Errorf("deleted = %+v", gone)
+	}
+
+	logo := files[3]
+	if logo.Status != FileAdded || !logo.Binary || logo.Path() != "logo.png" {
+		t.Errorf("binary = %+v", logo)
+	}
+
+	diff := &Diff{Files: files}
+	if got := diff.AddedLines(); len(got) != 1 || len(got["calc.go"]) != 2 {
+		t.Errorf("Diff.AddedLines() = %v", got)
+	}
+}
+
+func TestParseTrailers(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []Trailer
+	}{
+		{
+			name:    "trailer block",
+			message: "Add parser\n\nLonger body.\n\nTask-ID: T-42\nReviewed-by: Alice\n  and Bob",
+			want:    []Trailer{{"Task-ID", "T-42"}, {"Reviewed-by", "Alice and Bob"}},
+		},
+		{name: "subject only", message: "Fix: handle nil"},
+		{name: "prose last paragraph", message: "Add parser\n\nNote: this is prose\nthat spans lines."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseTrailers(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTrailers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	message := FormatMessage("Add parser\n", []Trailer{{"Task-ID", "T-42"}})
+	if message != "Add parser\n\nTask-ID: T-42" {
+		t.Errorf("FormatMessage() = %q", message)
+	}
+	if got := FormatMessage(message, []Trailer{{"Gate", "lint"}}); got != "Add parser\n\nTask-ID: T-42\nGate: lint" {
+		t.Errorf("FormatMessage() appended = %q", got)
+	}
+}
+
+func TestParseWorktreeList(t *testing.T) {
+	output := "worktree /repo\x00HEAD aaaa\x00branch refs/heads/main\x00\x00" +
+		"worktree /wt/cell-1\x00HEAD bbbb\x00detached\x00locked\x00\x00"
+
+	want := []Worktree{
+		{Path: "/repo", Commit: "aaaa", Branch: "main"},
+		{Path: "/wt/cell-1", Commit: "bbbb", Detached: true, Locked: true},
+	}
+	if got := ParseWorktreeList(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseWorktreeList() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MergeBase returns the best common ancestor of a and b
+func (r *Repo) MergeBase(ctx context.Context, a, b string) (string, error) {
+	out, err := r.run(ctx, "merge-base", "--end-of-options", a, b)
+	if err != nil {
+		if exitCode(err) == 1 {
+			return "", fmt.Errorf("no merge base for %s and %s: %w", a, b, ErrRefNotFound)
+		}
+		return "", fmt.Errorf("failed to find merge base of %s and %s: %w", a, b, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Merge merges commits into HEAD with a merge commit, or fast-forwards when
+// HEAD is an ancestor of them. When they conflict the merge is aborted, the
+// work tree is left as it was and ErrConflict is returned.
+func (r *Repo) Merge(ctx context.Context, message string, commits ...string) error {
+	if len(commits) == 0 {
+		return nil
+	}
+	args := append([]string{"merge", "--quiet", "--no-edit", "-m", message, "--end-of-options"}, commits...)
+	if _, err := r.run(ctx, args...); err != nil {
+		if errors.Is(err, ErrConflict) {
+			_, _ = r.run(ctx, "merge", "--abort")
+		}
+		return fmt.Errorf("failed to merge %s: %w", strings.Join(commits, ", "), err)
+	}
+	return nil
+}
+
+// Rebase replays the commits of HEAD that are not in upstream on top of it.
+// When a commit does not apply, the rebase stops in progress and ErrConflict
+// is returned; resolve the files listed by Status().Conflicted(), then call
+// ContinueRebase, or give up with AbortRebase.
+func (r *Repo) Rebase(ctx context.Context, upstream string) error {
+	if _, err := r.run(ctx, "rebase", "--quiet", "--end-of-options", upstream); err != nil {
+		return fmt.Errorf("failed to rebase onto %s: %w", upstream, err)
+	}
+	return nil
+}
+
+// ContinueRebase stages every change and resumes a stopped rebase. Returns
+// ErrConflict when the next commit stops on conflicts as well.
+func (r *Repo) ContinueRebase(ctx context.Context) error {
+	if err := r.Add(ctx); err != nil {
+		return err
+	}
+	if _, err := r.run(ctx, "rebase", "--continue"); err != nil {
+		return fmt.Errorf("failed to continue rebase: %w", err)
+	}
+	return nil
+}
+
+// AbortRebase stops a rebase in progress and restores the original HEAD.
+// It is a no-op when no rebase is in progress.
+func (r *Repo) AbortRebase(ctx context.Context) error {
+	if !r.RebaseInProgress(ctx) {
+		return nil
+	}
+	if _, err := r.run(ctx, "rebase", "--abort"); err != nil {
+		return fmt.Errorf("failed to abort rebase: %w", err)
+	}
+	return nil
+}
+
+// RebaseInProgress reports whether a rebase is stopped in the work tree
+func (r *Repo) RebaseInProgress(ctx context.Context) bool {
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		out, err := r.run(ctx, "rev-parse", "--git-path", dir)
+		if err != nil {
+			return false
+		}
+		path := strings.TrimSpace(out)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(r.dir, path)
+		}
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ResetSoft moves HEAD to ref, keeping the index and work tree
+func (r *Repo) ResetSoft(ctx context.Context, ref string) error {
+	if _, err := r.run(ctx, "reset", "--soft", "--quiet", ref); err != nil {
+		return fmt.Errorf("failed to reset to %s: %w", ref, err)
+	}
+	return nil
+}
+
+// UpdateRef points ref at newValue, but only if it currently points at
+// oldValue; otherwise ErrNonFastForward is returned
+func (r *Repo) UpdateRef(ctx context.Context, ref, newValue, oldValue string) error {
+	if _, err := r.run(ctx, "update-ref", ref, newValue, oldValue); err != nil {
+		return fmt.Errorf("failed to update %s: %w", ref, err)
+	}
+	return nil
+}
+
+// FastForward advances branch to commit. Returns ErrNonFastForward when the
+// branch has commits that commit does not contain. A branch checked out in
+// some worktree is advanced there with merge --ff-only so its files follow;
+// local changes in that worktree that would be overwritten return ErrDirtyTree.
+func (r *Repo) FastForward(ctx context.Context, branch, commit string) error {
+	ref := "refs/heads/" + branch
+	head, err := r.ResolveRef(ctx, ref)
+	if err != nil {
+		return err
+	}
+	ok, err := r.IsAncestor(ctx, head, commit)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s is not an ancestor of %s: %w", branch, commit, ErrNonFastForward)
+	}
+
+	worktrees, err := r.Worktrees(ctx)
+	if err != nil {
+		return err
+	}
+	for _, wt := range worktrees {
+		if wt.Branch != branch || wt.Bare {
+			continue
+		}
+		if _, err := Open(wt.Path).run(ctx, "merge", "--ff-only", "--quiet", commit); err != nil {
+			if !errors.Is(err, ErrDirtyTree) {
+				err = fmt.Errorf("%w: %w", ErrNonFastForward, err)
+			}
+			return fmt.Errorf("failed to fast-forward %s in %s: %w", branch, wt.Path, err)
+		}
+		return nil
+	}
+	return r.UpdateRef(ctx, ref, commit, head)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package git
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// commitOnBranch creates branch at start and commits one file on it
+func commitOnBranch(t *testing.T, repo *Repo, branch, start, file, content string) string {
+	t.Helper()
+	ctx := context.Background()
+	if err := repo.Checkout(ctx, CheckoutOptions{Branch: branch, Reset: true, StartPoint: start}); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, repo.Dir(), file, content)
+	commit, err := repo.Commit(ctx, CommitOptions{Message: "Change " + file + " on " + branch})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return commit.Hash
+}
+
+func TestRepo_RebaseAndFastForward(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	commitOnBranch(t, repo, "task", "main", "task.go", "package task\n")
+	mainHead := commitOnBranch(t, repo, "other", "main", "other.go", "package other\n")
+	if err := repo.FastForward(ctx, "main", mainHead); err != nil {
+		t.Fatalf("FastForward() error = %v", err)
+	}
+
+	// Rebase the task in a detached worktree, as the merge queue does
+	wt, err := repo.AddWorktree(ctx, filepath.Join(t.TempDir(), "integration"), AddWorktreeOptions{Detach: true, Ref: "task"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wt.Rebase(ctx, "main"); err != nil {
+		t.Fatalf("Rebase() error = %v", err)
+	}
+	rebased, _ := wt.Head(ctx)
+	if ok, _ := wt.IsAncestor(ctx, mainHead, rebased); !ok {
+		t.Fatal("rebased head does not contain main")
+	}
+
+	// main is checked out in the main worktree, so its files must follow
+	if err := repo.Checkout(ctx, CheckoutOptions{Branch: "main"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.FastForward(ctx, "main", rebased); err != nil {
+		t.Fatalf("FastForward() error = %v", err)
+	}
+	status, _ := repo.Status(ctx)
+	if status.Commit != rebased || !status.IsClean() {
+		t.Errorf("main worktree = %s clean=%v, want %s clean", status.Commit, status.IsClean(), rebased)
+	}
+
+	if err := repo.FastForward(ctx, "main", mainHead); !errors.Is(err, ErrNonFastForward) {
+		t.Errorf("FastForward() backwards error = %v, want ErrNonFastForward", err)
+	}
+	if err := repo.UpdateRef(ctx, "refs/heads/other", rebased, rebased); !errors.Is(err, ErrNonFastForward) {
+		t.Errorf("UpdateRef() with stale old value error = %v, want ErrNonFastForward", err)
+	}
+}
+
+func TestRepo_RebaseConflict(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	commitOnBranch(t, repo, "task", "main", "README.md", "# Task\n")
+	commitOnBranch(t, repo, "main", "main", "README.md", "# Main\n")
+	if err := repo.Checkout(ctx, CheckoutOptions{Branch: "task"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.Rebase(ctx, "main"); !errors.Is(err, ErrConflict) {
+		t.Fatalf("Rebase() error = %v, want ErrConflict", err)
+	}
+	if !repo.RebaseInProgress(ctx) {
+		t.Fatal("RebaseInProgress() = false during a stopped rebase")
+	}
+	status, _ := repo.Status(ctx)
+	if !reflect.DeepEqual(status.Conflicted(), []string{"README.md"}) {
+		t.Errorf("Conflicted() = %v", status.Conflicted())
+	}
+
+	writeFile(t, repo.Dir(), "README.md", "# Main and task\n")
+	if err := repo.ContinueRebase(ctx); err != nil {
+		t.Fatalf("ContinueRebase() error = %v", err)
+	}
+	if repo.RebaseInProgress(ctx) {
+		t.Error("RebaseInProgress() = true after the rebase finished")
+	}
+	if err := repo.AbortRebase(ctx); err != nil {
+		t.Errorf("AbortRebase() without a rebase error = %v", err)
+	}
+}
+
+func TestRepo_Merge(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	left := commitOnBranch(t, repo, "left", "main", "left.go", "package left\n")
+	right := commitOnBranch(t, repo, "right", "main", "right.go", "package right\n")
+	clash := commitOnBranch(t, repo, "clash", "main", "left.go", "package clash\n")
+	if err := repo.Checkout(ctx, CheckoutOptions{Branch: "combined", Reset: true, StartPoint: left}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.Merge(ctx, "Merge right", right); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	head, _ := repo.Head(ctx)
+	for _, commit := range []string{left, right} {
+		if ok, _ := repo.IsAncestor(ctx, commit, head); !ok {
+			t.Errorf("merged head does not contain %s", commit)
+		}
+	}
+
+	if err := repo.Merge(ctx, "Merge clash", clash); !errors.Is(err, ErrConflict) {
+		t.Fatalf("Merge() error = %v, want ErrConflict", err)
+	}
+	status, _ := repo.Status(ctx)
+	if status.Commit != head || !status.IsClean() {
+		t.Errorf("after a conflict HEAD = %s clean=%v, want %s clean", status.Commit, status.IsClean(), head)
+	}
+}
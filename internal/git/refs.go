@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Branch is a local branch
+type Branch struct {
+	Name     string
+	Commit   string
+	Upstream string
+	Current  bool
+}
+
+// Branches returns the local branches
+func (r *Repo) Branches(ctx context.Context) ([]Branch, error) {
+	out, err := r.run(ctx, "for-each-ref", "--format=%(refname:short)%00%(objectname)%00%(upstream:short)%00%(HEAD)", "refs/heads")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	branches := []Branch{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 4 {
+			continue
+		}
+		branches = append(branches, Branch{
+			Name: fields[0], Commit: fields[1], Upstream: fields[2], Current: fields[3] == "*",
+		})
+	}
+	return branches, nil
+}
+
+// BranchExists reports whether a local branch exists
+func (r *Repo) BranchExists(ctx context.Context, name string) (bool, error) {
+	_, err := r.run(ctx, "show-ref", "--verify", "--quiet", "refs/heads/"+name)
+	if err == nil {
+		return true, nil
+	}
+	if exitCode(err) == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to look up branch %s: %w", name, err)
+}
+
+// CreateBranch creates name at start (empty = HEAD). Returns ErrBranchExists
+// when the branch is already there.
+func (r *Repo) CreateBranch(ctx context.Context, name, start string) error {
+	args := []string{"branch", "--end-of-options", name}
+	if start != "" {
+		args = append(args, start)
+	}
+	if _, err := r.run(ctx, args...); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteBranch deletes a local branch. Returns ErrRefNotFound when it does
+// not exist.
+func (r *Repo) DeleteBranch(ctx context.Context, name string, force bool) error {
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+	if _, err := r.run(ctx, "branch", flag, "--end-of-options", name); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", name, err)
+	}
+	return nil
+}
+
+// IsAncestor reports whether ancestor is reachable from descendant
+func (r *Repo) IsAncestor(ctx context.Context, ancestor, descendant string) (bool, error) {
+	_, err := r.run(ctx, "merge-base", "--is-ancestor", "--end-of-options", ancestor, descendant)
+	if err == nil {
+		return true, nil
+	}
+	if exitCode(err) == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to compare %s and %s: %w", ancestor, descendant, err)
+}
+
+// CheckoutOptions configures Checkout
+type CheckoutOptions struct {
+	Branch     string // Branch to switch to, or the commit to detach at
+	Create     bool   // Create Branch at StartPoint; fails if it exists
+	Reset      bool   // Create or reset Branch at StartPoint
+	Detach     bool   // Detach HEAD at Branch
+	StartPoint string
+}
+
+// Checkout switches the work tree. Local changes that would be lost return
+// ErrDirtyTree; a missing branch or start point returns ErrRefNotFound.
+func (r *Repo) Checkout(ctx context.Context, opts CheckoutOptions) error {
+	args := []string{"checkout", "--quiet"}
+	switch {
+	case opts.Detach:
+		args = append(args, "--detach")
+	case opts.Reset:
+		args = append(args, "-B")
+	case opts.Create:
+		args = append(args, "-b")
+	}
+	args = append(args, opts.Branch)
+	if opts.StartPoint != "" {
+		args = append(args, opts.StartPoint)
+	}
+	// Disambiguate refs from paths
+	args = append(args, "--")
+	if _, err := r.run(ctx, args...); err != nil {
+		if !opts.Create && !opts.Reset && !errors.Is(err, ErrDirtyTree) && !errors.Is(err, ErrConflict) {
+			if _, resolveErr := r.ResolveRef(ctx, opts.Branch); errors.Is(resolveErr, ErrRefNotFound) {
+				return fmt.Errorf("failed to check out %s: %w", opts.Branch, ErrRefNotFound)
+			}
+		}
+		return fmt.Errorf("failed to check out %s: %w", opts.Branch, err)
+	}
+	return nil
+}
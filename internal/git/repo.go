@@ -0,0 +1,220 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+// Package git is a typed wrapper around the git command-line tool, not a
+// native Git implementation: every operation runs the git binary found on
+// PATH in the repository directory. Each one reads a machine-readable format
+// (porcelain v2, -z, numstat, for-each-ref) and returns structured results;
+// failures are classified into sentinel errors such as ErrConflict,
+// ErrDirtyTree and ErrRefNotFound. Callers never parse git's human-readable
+// output or build git command lines themselves.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Repo is a repository or worktree rooted at a directory
+type Repo struct {
+	dir string
+}
+
+// Open returns a Repo for dir. It does not check that dir is a repository;
+// operations on a non-repository fail with ErrNotRepository.
+func Open(dir string) *Repo {
+	return &Repo{dir: dir}
+}
+
+// Dir returns the directory the repository was opened at
+func (r *Repo) Dir() string {
+	return r.dir
+}
+
+// run executes git in the repository and returns stdout
+func (r *Repo) run(ctx context.Context, args ...string) (string, error) {
+	return runGit(ctx, r.dir, args...)
+}
+
+// runGit executes the git binary with a stable locale, no prompts and no
+// editor (rebase and merge keep their default messages). It is the only place
+// the package starts a process. A failed command
+// returns a *CommandError whose Kind classifies stdout and stderr.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...) //nolint:gosec // Arguments are built by this package
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "LC_ALL=C", "GIT_TERMINAL_PROMPT=0", "GIT_EDITOR=true")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err == nil {
+		return stdout.String(), nil
+	}
+
+	cmdErr := &CommandError{Args: args, ExitCode: -1, Stderr: stderr.String(), Err: err}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		cmdErr.ExitCode = exitErr.ExitCode()
+	}
+	cmdErr.Kind = classify(stderr.String() + "\n" + stdout.String())
+	return stdout.String(), cmdErr
+}
+
+// exitCode returns the exit status of a failed git command, or -1
+func exitCode(err error) int {
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.ExitCode
+	}
+	return -1
+}
+
+// IsRepository reports whether the directory is inside a work tree
+func (r *Repo) IsRepository(ctx context.Context) bool {
+	out, err := r.run(ctx, "rev-parse", "--is-inside-work-tree")
+	return err == nil && strings.TrimSpace(out) == "true"
+}
+
+// Head returns the commit hash HEAD points to
+func (r *Repo) Head(ctx context.Context) (string, error) {
+	return r.ResolveRef(ctx, "HEAD")
+}
+
+// CurrentBranch returns the checked-out branch, or "" when HEAD is detached
+func (r *Repo) CurrentBranch(ctx context.Context) (string, error) {
+	out, err := r.run(ctx, "branch", "--show-current")
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// ResolveRef returns the commit hash of ref, or ErrRefNotFound
+func (r *Repo) ResolveRef(ctx context.Context, ref string) (string, error) {
+	out, err := r.run(ctx, "rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}")
+	if err != nil {
+		// --quiet exits 1 without output for missing refs
+		if exitCode(err) == 1 {
+			return "", fmt.Errorf("%s: %w", ref, ErrRefNotFound)
+		}
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// ReadFile returns the content of path at rev
+func (r *Repo) ReadFile(ctx context.Context, rev, path string) ([]byte, error) {
+	out, err := r.run(ctx, "show", rev+":"+path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at %s: %w", path, rev, err)
+	}
+	return []byte(out), nil
+}
+
+// Add stages paths; no paths stages every change including deletions
+func (r *Repo) Add(ctx context.Context, paths ...string) error {
+	args := []string{"add", "-A"}
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	if _, err := r.run(ctx, args...); err != nil {
+		return fmt.Errorf("failed to stage files: %w", err)
+	}
+	return nil
+}
+
+// ResetHard discards staged and unstaged changes, moving HEAD to ref when set
+func (r *Repo) ResetHard(ctx context.Context, ref string) error {
+	args := []string{"reset", "--hard", "--quiet"}
+	if ref != "" {
+		args = append(args, ref)
+	}
+	if _, err := r.run(ctx, args...); err != nil {
+		return fmt.Errorf("failed to reset: %w", err)
+	}
+	return nil
+}
+
+// Clean removes untracked and ignored files, keeping paths matching exclude
+func (r *Repo) Clean(ctx context.Context, exclude ...string) error {
+	args := []string{"clean", "-fdx", "--quiet"}
+	for _, pattern := range exclude {
+		args = append(args, "-e", pattern)
+	}
+	if _, err := r.run(ctx, args...); err != nil {
+		return fmt.Errorf("failed to clean: %w", err)
+	}
+	return nil
+}
+
+// CloneOptions configures Clone
+type CloneOptions struct {
+	Branch string // Branch to check out (empty = remote default)
+	Depth  int    // Shallow clone depth (0 = full history)
+}
+
+// Clone clones url into dir
+func Clone(ctx context.Context, url, dir string, opts CloneOptions) (*Repo, error) {
+	args := []string{"clone", "--quiet"}
+	if opts.Branch != "" {
+		args = append(args, "--branch", opts.Branch)
+	}
+	if opts.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opts.Depth))
+	}
+	args = append(args, "--", url, dir)
+	if _, err := runGit(ctx, "", args...); err != nil {
+		return nil, fmt.Errorf("failed to clone %s: %w", url, err)
+	}
+	return Open(dir), nil
+}
+
+// RemoteURL returns the fetch URL of a remote
+func (r *Repo) RemoteURL(ctx context.Context, remote string) (string, error) {
+	out, err := r.run(ctx, "remote", "get-url", remote)
+	if err != nil {
+		return "", fmt.Errorf("failed to get URL of remote %s: %w", remote, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// PushOptions configures Push
+type PushOptions struct {
+	Remote      string // Remote name (default "origin")
+	Branch      string // Branch or refspec to push
+	Force       bool   // Overwrite the remote branch
+	SetUpstream bool   // Record the remote branch as upstream
+}
+
+// Push pushes a branch. A rejected push returns ErrNonFastForward.
+func (r *Repo) Push(ctx context.Context, opts PushOptions) error {
+	remote := opts.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+	args := []string{"push", "--porcelain"}
+	if opts.Force {
+		args = append(args, "--force")
+	}
+	if opts.SetUpstream {
+		args = append(args, "--set-upstream")
+	}
+	args = append(args, remote)
+	if opts.Branch != "" {
+		args = append(args, opts.Branch)
+	}
+	if _, err := r.run(ctx, args...); err != nil {
+		return fmt.Errorf("failed to push to %s: %w", remote, err)
+	}
+	return nil
+}
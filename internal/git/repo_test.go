@@ -0,0 +1,195 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newTestRepo creates a repository with one commit of README.md on main
+func newTestRepo(t *testing.T) *Repo {
+	t.Helper()
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet", "--initial-branch=main"},
+		{"config", "user.name", "Test User"},
+		{"config", "user.email", "test@example.com"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	writeFile(t, dir, "README.md", "# Test\n")
+	repo := Open(dir)
+	if _, err := repo.Commit(context.Background(), CommitOptions{Message: "Initial commit"}); err != nil {
+		t.Fatalf("initial commit: %v", err)
+	}
+	return repo
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRepo_CommitWithTrailers(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	writeFile(t, repo.Dir(), "pkg/calc.go", "package calc\n")
+
+	commit, err := repo.Commit(ctx, CommitOptions{
+		Message:  "Add calc",
+		Trailers: []Trailer{{"Task-ID", "T-7"}},
+		Author:   "Agent <agent@example.com>",
+	})
+	if err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if commit.Subject != "Add calc" || commit.Trailer("task-id") != "T-7" || commit.AuthorEmail != "agent@example.com" {
+		t.Errorf("commit = %+v", commit)
+	}
+	if !reflect.DeepEqual(commit.Files, []string{"pkg/calc.go"}) || len(commit.Parents) != 1 {
+		t.Errorf("files = %v, parents = %v", commit.Files, commit.Parents)
+	}
+
+	if _, err := repo.Commit(ctx, CommitOptions{Message: "Empty"}); !errors.Is(err, ErrNothingToCommit) {
+		t.Errorf("Commit() with nothing staged error = %v, want ErrNothingToCommit", err)
+	}
+}
+
+func TestRepo_StatusAndDiff(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	writeFile(t, repo.Dir(), "README.md", "# Test\nmore\n")
+	writeFile(t, repo.Dir(), "new.txt", "a\nb\n")
+
+	status, err := repo.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status.Branch != "main" || !reflect.DeepEqual(status.Modified(), []string{"README.md"}) || !reflect.DeepEqual(status.Untracked(), []string{"new.txt"}) {
+		t.Errorf("status = %+v", status)
+	}
+
+	diff, err := repo.Diff(ctx, DiffOptions{From: "HEAD", IncludeUntracked: true})
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if !reflect.DeepEqual(diff.Paths(), []string{"README.md", "new.txt"}) || diff.Insertions() != 3 || diff.Deletions() != 0 {
+		t.Errorf("diff paths = %v, +%d -%d", diff.Paths(), diff.Insertions(), diff.Deletions())
+	}
+	want := map[string][]LineRange{"README.md": {{2, 2}}, "new.txt": {{1, 2}}}
+	if got := diff.AddedLines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AddedLines() = %v, want %v", got, want)
+	}
+}
+
+func TestRepo_RenameDetection(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	if out, err := exec.Command("git", "-C", repo.Dir(), "mv", "README.md", "DOCS.md").CombinedOutput(); err != nil {
+		t.Fatalf("git mv: %v\n%s", err, out)
+	}
+
+	diff, err := repo.Diff(ctx, DiffOptions{Staged: true})
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if len(diff.Files) != 1 || diff.Files[0].OldPath != "README.md" || diff.Files[0].NewPath != "DOCS.md" || !diff.Files[0].IsPureRename() {
+		t.Errorf("rename diff = %+v", diff.Files)
+	}
+}
+
+func TestRepo_TypedErrors(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+
+	if _, err := repo.ResolveRef(ctx, "no-such-branch"); !errors.Is(err, ErrRefNotFound) {
+		t.Errorf("ResolveRef() error = %v, want ErrRefNotFound", err)
+	}
+	if err := repo.Checkout(ctx, CheckoutOptions{Branch: "no-such-branch"}); !errors.Is(err, ErrRefNotFound) {
+		t.Errorf("Checkout() error = %v, want ErrRefNotFound", err)
+	}
+	if err := repo.DeleteBranch(ctx, "no-such-branch", false); !errors.Is(err, ErrRefNotFound) {
+		t.Errorf("DeleteBranch() error = %v, want ErrRefNotFound", err)
+	}
+	if err := repo.CreateBranch(ctx, "main", ""); !errors.Is(err, ErrBranchExists) {
+		t.Errorf("CreateBranch() error = %v, want ErrBranchExists", err)
+	}
+	if _, err := Open(t.TempDir()).Status(ctx); !errors.Is(err, ErrNotRepository) {
+		t.Errorf("Status() outside a repository error = %v, want ErrNotRepository", err)
+	}
+
+	// A local edit to a file that differs on the target branch blocks checkout
+	if err := repo.Checkout(ctx, CheckoutOptions{Branch: "other", Create: true}); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, repo.Dir(), "README.md", "# Other\n")
+	if _, err := repo.Commit(ctx, CommitOptions{Message: "Other"}); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, repo.Dir(), "README.md", "# Local edit\n")
+	if err := repo.Checkout(ctx, CheckoutOptions{Branch: "main"}); !errors.Is(err, ErrDirtyTree) {
+		t.Errorf("Checkout() with local edits error = %v, want ErrDirtyTree", err)
+	}
+
+	// Conflicting edits on both branches stop a merge
+	if _, err := repo.Commit(ctx, CommitOptions{Message: "Local"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Checkout(ctx, CheckoutOptions{Branch: "main"}); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, repo.Dir(), "README.md", "# Main edit\n")
+	if _, err := repo.Commit(ctx, CommitOptions{Message: "Main"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.run(ctx, "merge", "other"); !errors.Is(err, ErrConflict) {
+		t.Errorf("merge error = %v, want ErrConflict", err)
+	}
+	status, err := repo.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(status.Conflicted(), []string{"README.md"}) {
+		t.Errorf("Conflicted() = %v", status.Conflicted())
+	}
+}
+
+func TestRepo_Worktrees(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+	path := filepath.Join(t.TempDir(), "cell-1")
+
+	wt, err := repo.AddWorktree(ctx, path, AddWorktreeOptions{NewBranch: "worktree-cell-1", Ref: "main"})
+	if err != nil {
+		t.Fatalf("AddWorktree() error = %v", err)
+	}
+	if branch, _ := wt.CurrentBranch(ctx); branch != "worktree-cell-1" {
+		t.Errorf("worktree branch = %q", branch)
+	}
+
+	worktrees, err := repo.Worktrees(ctx)
+	if err != nil || len(worktrees) != 2 || worktrees[1].Branch != "worktree-cell-1" {
+		t.Fatalf("Worktrees() = %+v, %v", worktrees, err)
+	}
+
+	if err := repo.RemoveWorktree(ctx, path, true); err != nil {
+		t.Fatalf("RemoveWorktree() error = %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EntryKind is the kind of a status entry
+type EntryKind string
+
+const (
+	// EntryChanged is a tracked path with staged or unstaged changes
+	EntryChanged EntryKind = "changed"
+	// EntryRenamed is a renamed or copied path
+	EntryRenamed EntryKind = "renamed"
+	// EntryUnmerged is a path with unresolved conflicts
+	EntryUnmerged EntryKind = "unmerged"
+	// EntryUntracked is a path not known to git
+	EntryUntracked EntryKind = "untracked"
+	// EntryIgnored is an ignored path (only reported when requested)
+	EntryIgnored EntryKind = "ignored"
+)
+
+// StatusEntry is one path in the status. Index and Worktree hold git's
+// status letters (M, T, A, D, R, C, U) or "." when unchanged on that side.
+type StatusEntry struct {
+	Kind       EntryKind
+	Path       string
+	OrigPath   string // Source path of a rename or copy
+	Index      string
+	Worktree   string
+	Similarity int // Rename or copy score, 0-100
+}
+
+// Status is the parsed output of git status --porcelain=v2 --branch
+type Status struct {
+	Branch   string // Empty when detached
+	Detached bool
+	Commit   string // Empty before the first commit
+	Upstream string
+	Ahead    int
+	Behind   int
+	Entries  []StatusEntry
+}
+
+// Status returns the status of the work tree, listing untracked files
+// individually
+func (r *Repo) Status(ctx context.Context) (*Status, error) {
+	out, err := r.run(ctx, "status", "--porcelain=v2", "--branch", "-z", "--untracked-files=all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status: %w", err)
+	}
+	return ParseStatus(out)
+}
+
+// ParseStatus parses git status --porcelain=v2 --branch -z output
+func ParseStatus(output string) (*Status, error) {
+	status := &Status{Entries: []StatusEntry{}}
+	records := strings.Split(output, "\x00")
+	for i := 0; i < len(records); i++ {
+		record := records[i]
+		if record == "" {
+			continue
+		}
+		switch record[0] {
+		case '#':
+			parseBranchHeader(status, record)
+		case '1':
+			// 1 XY sub mH mI mW hH hI path
+			fields := strings.SplitN(record, " ", 9)
+			if len(fields) != 9 {
+				return nil, fmt.Errorf("malformed status record %q", record)
+			}
+			status.Entries = append(status.Entries, StatusEntry{
+				Kind: EntryChanged, Path: fields[8], Index: fields[1][:1], Worktree: fields[1][1:],
+			})
+		case '2':
+			// 2 XY sub mH mI mW hH hI Xscore path NUL origPath
+			fields := strings.SplitN(record, " ", 10)
+			if len(fields) != 10 || i+1 >= len(records) {
+				return nil, fmt.Errorf("malformed status record %q", record)
+			}
+			score, _ := strconv.Atoi(fields[8][1:])
+			i++
+			status.Entries = append(status.Entries, StatusEntry{
+				Kind: EntryRenamed, Path: fields[9], OrigPath: records[i],
+				Index: fields[1][:1], Worktree: fields[1][1:], Similarity: score,
+			})
+		case 'u':
+			// u XY sub m1 m2 m3 mW h1 h2 h3 path
+			fields := strings.SplitN(record, " ", 11)
+			if len(fields) != 11 {
+				return nil, fmt.Errorf("malformed status record %q", record)
+			}
+			status.Entries = append(status.Entries, StatusEntry{
+				Kind: EntryUnmerged, Path: fields[10], Index: fields[1][:1], Worktree: fields[1][1:],
+			})
+		case '?':
+			status.Entries = append(status.Entries, StatusEntry{
+				Kind: EntryUntracked, Path: strings.TrimPrefix(record, "? "), Index: "?", Worktree: "?",
+			})
+		case '!':
+			status.Entries = append(status.Entries, StatusEntry{
+				Kind: EntryIgnored, Path: strings.TrimPrefix(record, "! "), Index: "!", Worktree: "!",
+			})
+		default:
+			return nil, fmt.Errorf("unknown status record %q", record)
+		}
+	}
+	return status, nil
+}
+
+func parseBranchHeader(status *Status, record string) {
+	key, value, _ := strings.Cut(strings.TrimPrefix(record, "# "), " ")
+	switch key {
+	case "branch.oid":
+		if value != "(initial)" {
+			status.Commit = value
+		}
+	case "branch.head":
+		if value == "(detached)" {
+			status.Detached = true
+		} else {
+			status.Branch = value
+		}
+	case "branch.upstream":
+		status.Upstream = value
+	case "branch.ab":
+		ahead, behind, _ := strings.Cut(value, " ")
+		status.Ahead, _ = strconv.Atoi(strings.TrimPrefix(ahead, "+"))
+		status.Behind, _ = strconv.Atoi(strings.TrimPrefix(behind, "-"))
+	}
+}
+
+// IsClean reports whether there are no tracked changes and no untracked files
+func (s *Status) IsClean() bool {
+	for _, entry := range s.Entries {
+		if entry.Kind != EntryIgnored {
+			return false
+		}
+	}
+	return true
+}
+
+// Staged returns paths with staged changes
+func (s *Status) Staged() []string {
+	return s.paths(func(e StatusEntry) bool {
+		return (e.Kind == EntryChanged || e.Kind == EntryRenamed) && e.Index != "."
+	})
+}
+
+// Modified returns tracked paths modified in the work tree but not staged
+func (s *Status) Modified() []string {
+	return s.paths(func(e StatusEntry) bool {
+		return (e.Kind == EntryChanged || e.Kind == EntryRenamed) && (e.Worktree == "M" || e.Worktree == "T")
+	})
+}
+
+// Deleted returns tracked paths deleted in the index or work tree
+func (s *Status) Deleted() []string {
+	return s.paths(func(e StatusEntry) bool {
+		return e.Kind == EntryChanged && (e.Index == "D" || e.Worktree == "D")
+	})
+}
+
+// Untracked returns untracked paths
+func (s *Status) Untracked() []string {
+	return s.paths(func(e StatusEntry) bool { return e.Kind == EntryUntracked })
+}
+
+// Conflicted returns paths with unresolved conflicts
+func (s *Status) Conflicted() []string {
+	return s.paths(func(e StatusEntry) bool { return e.Kind == EntryUnmerged })
+}
+
+// Changed returns every changed, renamed (new path), unmerged and untracked path
+func (s *Status) Changed() []string {
+	return s.paths(func(e StatusEntry) bool { return e.Kind != EntryIgnored })
+}
+
+func (s *Status) paths(keep func(StatusEntry) bool) []string {
+	paths := []string{}
+	for _, entry := range s.Entries {
+		if keep(entry) {
+			paths = append(paths, entry.Path)
+		}
+	}
+	return paths
+}
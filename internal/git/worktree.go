@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Worktree is one entry of git worktree list --porcelain
+type Worktree struct {
+	Path     string
+	Commit   string
+	Branch   string // Short branch name; empty when detached or bare
+	Bare     bool
+	Detached bool
+	Locked   bool
+	Prunable bool
+}
+
+// AddWorktreeOptions configures AddWorktree
+type AddWorktreeOptions struct {
+	NewBranch string // Create this branch for the worktree
+	Detach    bool   // Detach HEAD instead of checking out a branch
+	Ref       string // Commit-ish to check out
+}
+
+// AddWorktree creates a linked worktree at path
+func (r *Repo) AddWorktree(ctx context.Context, path string, opts AddWorktreeOptions) (*Repo, error) {
+	args := []string{"worktree", "add", "--quiet"}
+	if opts.NewBranch != "" {
+		args = append(args, "-b", opts.NewBranch)
+	}
+	if opts.Detach {
+		args = append(args, "--detach")
+	}
+	args = append(args, path)
+	if opts.Ref != "" {
+		args = append(args, opts.Ref)
+	}
+	if _, err := r.run(ctx, args...); err != nil {
+		return nil, fmt.Errorf("failed to add worktree %s: %w", path, err)
+	}
+	return Open(path), nil
+}
+
+// RemoveWorktree removes a linked worktree, discarding its changes when force is set
+func (r *Repo) RemoveWorktree(ctx context.Context, path string, force bool) error {
+	args := []string{"worktree", "remove"}
+	if force {
+		args = append(args, "--force")
+	}
+	if _, err := r.run(ctx, append(args, path)...); err != nil {
+		return fmt.Errorf("failed to remove worktree %s: %w", path, err)
+	}
+	return nil
+}
+
+// PruneWorktrees removes administrative data of worktrees whose directory is gone
+func (r *Repo) PruneWorktrees(ctx context.Context) error {
+	if _, err := r.run(ctx, "worktree", "prune"); err != nil {
+		return fmt.Errorf("failed to prune worktrees: %w", err)
+	}
+	return nil
+}
+
+// Worktrees lists the main worktree and all linked worktrees
+func (r *Repo) Worktrees(ctx context.Context) ([]Worktree, error) {
+	out, err := r.run(ctx, "worktree", "list", "--porcelain", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+	}
+	return ParseWorktreeList(out), nil
+}
+
+// ParseWorktreeList parses git worktree list --porcelain -z output, where
+// attributes end in NUL and worktrees are separated by an empty attribute
+func ParseWorktreeList(output string) []Worktree {
+	var worktrees []Worktree
+	var current *Worktree
+	for _, attr := range strings.Split(output, "\x00") {
+		if attr == "" {
+			if current != nil {
+				worktrees = append(worktrees, *current)
+				current = nil
+			}
+			continue
+		}
+		key, value, _ := strings.Cut(attr, " ")
+		if key == "worktree" {
+			if current != nil {
+				worktrees = append(worktrees, *current)
+			}
+			current = &Worktree{Path: value}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		switch key {
+		case "HEAD":
+			current.Commit = value
+		case "branch":
+			current.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "bare":
+			current.Bare = true
+		case "detached":
+			current.Detached = true
+		case "locked":
+			current.Locked = true
+		case "prunable":
+			current.Prunable = true
+		}
+	}
+	if current != nil {
+		worktrees = append(worktrees, *current)
+	}
+	return worktrees
+}
@@ -60,7 +60,24 @@ type WorktreeManagerInterface interface {
 	CleanupAll() error
 }
 
+// RecyclableWorktreeManagerInterface extends WorktreeManagerInterface with the
+// operations needed to reuse worktrees across tasks (warm pools)
+type RecyclableWorktreeManagerInterface interface {
+	WorktreeManagerInterface
+
+	// CreateDetachedWorktree creates a worktree with a detached HEAD at ref
+	CreateDetachedWorktree(id string, ref string) (*WorktreeInfo, error)
+
+	// CheckoutBranch creates (or resets) branch at base inside an existing worktree
+	CheckoutBranch(id string, branch string, base string) error
+
+	// ScrubWorktree discards all changes, detaches the worktree at ref and
+	// deletes the branch it had checked out unless it holds commits
+	ScrubWorktree(id string, ref string) error
+}
+
 // Ensure concrete types implement interfaces
 var _ PortManagerInterface = (*PortManager)(nil)
 var _ ServerManagerInterface = (*ServerManager)(nil)
 var _ WorktreeManagerInterface = (*WorktreeManager)(nil)
+var _ RecyclableWorktreeManagerInterface = (*WorktreeManager)(nil)
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package infra
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// DefaultReservationFileName is the file shared by all workers on a host
+const DefaultReservationFileName = "open-swarm-ports.json"
+
+// PortReservation records which worker process holds a port
+type PortReservation struct {
+	PID        int       `json:"pid"`
+	ReservedAt time.Time `json:"reserved_at"`
+}
+
+// PortReservations coordinates port usage between open-swarm workers on the
+// same host through a JSON file guarded by an advisory flock.
+// Entries whose owning process has exited are pruned on every access, so a
+// crashed worker never leaks its ports permanently.
+type PortReservations struct {
+	path string
+	pid  int
+}
+
+// NewPortReservations creates a reservation file handle for the current process
+func NewPortReservations(path string) *PortReservations {
+	return &PortReservations{
+		path: path,
+		pid:  os.Getpid(),
+	}
+}
+
+// DefaultReservationPath returns the host-wide reservation file location
+func DefaultReservationPath() string {
+	return filepath.Join(os.TempDir(), DefaultReservationFileName)
+}
+
+// Path returns the reservation file location
+func (r *PortReservations) Path() string {
+	return r.path
+}
+
+// Reserve claims the port for this process.
+// Returns false if another live process already holds it.
+func (r *PortReservations) Reserve(port int) (bool, error) {
+	reserved := false
+	err := r.update(func(entries map[string]PortReservation) {
+		key := strconv.Itoa(port)
+		if _, held := entries[key]; held {
+			return
+		}
+		entries[key] = PortReservation{PID: r.pid, ReservedAt: time.Now()}
+		reserved = true
+	})
+	return reserved, err
+}
+
+// Release removes this process's reservation of the port
+func (r *PortReservations) Release(port int) error {
+	return r.update(func(entries map[string]PortReservation) {
+		key := strconv.Itoa(port)
+		if entry, held := entries[key]; held && entry.PID == r.pid {
+			delete(entries, key)
+		}
+	})
+}
+
+// List returns all live reservations keyed by port
+func (r *PortReservations) List() (map[int]PortReservation, error) {
+	result := make(map[int]PortReservation)
+	err := r.update(func(entries map[string]PortReservation) {
+		for key, entry := range entries {
+			port, convErr := strconv.Atoi(key)
+			if convErr != nil {
+				continue
+			}
+			result[port] = entry
+		}
+	})
+	return result, err
+}
+
+// update runs fn on the current reservations while holding an exclusive lock
+// and writes the result back. Stale entries are dropped before fn runs.
+func (r *PortReservations) update(fn func(entries map[string]PortReservation)) error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0750); err != nil {
+		return fmt.Errorf("failed to create reservation directory: %w", err)
+	}
+
+	f, err := os.OpenFile(r.path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open reservation file: %w", err)
+	}
+	defer f.Close()
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock reservation file: %w", err)
+	}
+	defer func() { _ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN) }()
+
+	entries := make(map[string]PortReservation)
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to read reservation file: %w", err)
+	}
+	if len(data) > 0 {
+		// A corrupt file is treated as empty rather than blocking every worker
+		_ = json.Unmarshal(data, &entries)
+	}
+
+	for key, entry := range entries {
+		if !processAlive(entry.PID) {
+			delete(entries, key)
+		}
+	}
+
+	fn(entries)
+
+	data, err = json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode reservations: %w", err)
+	}
+	if err := f.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate reservation file: %w", err)
+	}
+	if _, err := f.WriteAt(data, 0); err != nil {
+		return fmt.Errorf("failed to write reservation file: %w", err)
+	}
+	return nil
+}
+
+// processAlive reports whether a process with the given PID exists
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -6,51 +6,156 @@
 package infra
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"sync"
 )
 
+// ErrPortRangeExhausted is returned when every port in the range is held by
+// this manager or reserved by another open-swarm worker.
+var ErrPortRangeExhausted = errors.New("port range exhausted")
+
+// ErrPortInUse is returned when free ports in the range exist on paper but are
+// bound by processes outside open-swarm.
+var ErrPortInUse = errors.New("port in use by foreign process")
+
+// PortAllocationError describes why Allocate could not hand out a port.
+// Use errors.Is with ErrPortRangeExhausted or ErrPortInUse to distinguish the causes.
+type PortAllocationError struct {
+	MinPort   int
+	MaxPort   int
+	Allocated int   // Ports held by this manager
+	Reserved  int   // Ports reserved by other workers in the reservation file
+	InUse     []int // Ports bound by foreign processes
+}
+
+// Error implements the error interface.
+func (e *PortAllocationError) Error() string {
+	total := e.MaxPort - e.MinPort + 1
+	if len(e.InUse) > 0 {
+		return fmt.Sprintf("no available ports in range %d-%d: %d allocated, %d reserved by other workers, %d in use by foreign processes",
+			e.MinPort, e.MaxPort, e.Allocated, e.Reserved, len(e.InUse))
+	}
+	return fmt.Sprintf("no available ports in range %d-%d (all %d ports allocated: %d locally, %d by other workers)",
+		e.MinPort, e.MaxPort, total, e.Allocated, e.Reserved)
+}
+
+// Is reports whether the error matches ErrPortRangeExhausted or ErrPortInUse.
+func (e *PortAllocationError) Is(target error) bool {
+	switch target {
+	case ErrPortInUse:
+		return len(e.InUse) > 0
+	case ErrPortRangeExhausted:
+		return len(e.InUse) == 0
+	}
+	return false
+}
+
+// PortProbe reports whether a port can currently be bound on the host.
+type PortProbe func(port int) bool
+
+// ProbePortFree performs a bind test on localhost to check that no other
+// process is listening on the port.
+func ProbePortFree(port int) bool {
+	ln, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port))
+	if err != nil {
+		return false
+	}
+	_ = ln.Close()
+	return true
+}
+
 // PortManager manages the allocation of ports in the range 8000-9000
 // Enforces INV-001: Each Agent runs 'opencode serve' on a unique port
 type PortManager struct {
-	mu        sync.Mutex
-	minPort   int
-	maxPort   int
-	allocated map[int]bool
-	nextPort  int
+	mu           sync.Mutex
+	minPort      int
+	maxPort      int
+	allocated    map[int]bool
+	nextPort     int
+	probe        PortProbe
+	reservations *PortReservations
 }
 
-// NewPortManager creates a new port manager with the specified range
+// NewPortManager creates a new port manager with the specified range.
+// Candidate ports are bind-tested on the host before being handed out.
 func NewPortManager(minPort, maxPort int) *PortManager {
 	return &PortManager{
 		minPort:   minPort,
 		maxPort:   maxPort,
 		allocated: make(map[int]bool),
 		nextPort:  minPort,
+		probe:     ProbePortFree,
 	}
 }
 
-// Allocate reserves the next available port
+// SetPortProbe overrides the host bind test (nil disables probing, useful for testing)
+func (pm *PortManager) SetPortProbe(probe PortProbe) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.probe = probe
+}
+
+// SetReservations enables coordination with other workers on the same host
+// through a shared reservation file (nil disables it)
+func (pm *PortManager) SetReservations(reservations *PortReservations) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.reservations = reservations
+}
+
+// Allocate reserves the next available port.
+// A port is only handed out when it is not allocated locally, not reserved by
+// another worker and passes the host bind test. On failure the returned error
+// is a *PortAllocationError.
 func (pm *PortManager) Allocate() (int, error) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
+	rangeSize := pm.maxPort - pm.minPort + 1
+	allocErr := &PortAllocationError{
+		MinPort:   pm.minPort,
+		MaxPort:   pm.maxPort,
+		Allocated: len(pm.allocated),
+	}
+
 	// Scan for available port starting from nextPort
-	for i := 0; i < (pm.maxPort - pm.minPort + 1); i++ {
-		candidatePort := pm.minPort + ((pm.nextPort - pm.minPort + i) % (pm.maxPort - pm.minPort + 1))
-
-		if !pm.allocated[candidatePort] {
-			pm.allocated[candidatePort] = true
-			pm.nextPort = candidatePort + 1
-			if pm.nextPort > pm.maxPort {
-				pm.nextPort = pm.minPort
+	for i := 0; i < rangeSize; i++ {
+		candidatePort := pm.minPort + ((pm.nextPort - pm.minPort + i) % rangeSize)
+
+		if pm.allocated[candidatePort] {
+			continue
+		}
+
+		if pm.reservations != nil {
+			reserved, err := pm.reservations.Reserve(candidatePort)
+			if err != nil {
+				return 0, fmt.Errorf("failed to reserve port %d: %w", candidatePort, err)
+			}
+			if !reserved {
+				allocErr.Reserved++
+				continue
 			}
-			return candidatePort, nil
 		}
+
+		if pm.probe != nil && !pm.probe(candidatePort) {
+			allocErr.InUse = append(allocErr.InUse, candidatePort)
+			if pm.reservations != nil {
+				_ = pm.reservations.Release(candidatePort)
+			}
+			continue
+		}
+
+		pm.allocated[candidatePort] = true
+		pm.nextPort = candidatePort + 1
+		if pm.nextPort > pm.maxPort {
+			pm.nextPort = pm.minPort
+		}
+		return candidatePort, nil
 	}
 
-	return 0, fmt.Errorf("no available ports in range %d-%d (all %d ports allocated)",
-		pm.minPort, pm.maxPort, pm.maxPort-pm.minPort+1)
+	return 0, allocErr
 }
 
 // Release frees a previously allocated port
@@ -67,6 +172,12 @@ func (pm *PortManager) Release(port int) error {
 	}
 
 	delete(pm.allocated, port)
+
+	if pm.reservations != nil {
+		if err := pm.reservations.Release(port); err != nil {
+			return fmt.Errorf("failed to release reservation for port %d: %w", port, err)
+		}
+	}
 	return nil
 }
 
@@ -6,11 +6,16 @@
 package infra
 
 import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
 func TestPortManager_Allocate(t *testing.T) {
 	pm := NewPortManager(8000, 8010)
+	pm.SetPortProbe(nil) // Same result whichever host ports are free
 
 	// Test basic allocation
 	port1, err := pm.Allocate()
@@ -38,6 +43,7 @@ func TestPortManager_Allocate(t *testing.T) {
 
 func TestPortManager_Release(t *testing.T) {
 	pm := NewPortManager(8000, 8010)
+	pm.SetPortProbe(nil) // Same result whichever host ports are free
 
 	port, err := pm.Allocate()
 	if err != nil {
@@ -68,6 +74,7 @@ func TestPortManager_Release(t *testing.T) {
 
 func TestPortManager_Exhaustion(t *testing.T) {
 	pm := NewPortManager(8000, 8002) // Only 3 ports
+	pm.SetPortProbe(nil)             // Same result whichever host ports are free
 
 	// Allocate all ports
 	ports := make([]int, 0, 3)
@@ -98,6 +105,7 @@ func TestPortManager_Exhaustion(t *testing.T) {
 
 func TestPortManager_IsAllocated(t *testing.T) {
 	pm := NewPortManager(8000, 8010)
+	pm.SetPortProbe(nil) // Same result whichever host ports are free
 
 	port, _ := pm.Allocate()
 
@@ -118,6 +126,7 @@ func TestPortManager_IsAllocated(t *testing.T) {
 
 func TestPortManager_AvailableCount(t *testing.T) {
 	pm := NewPortManager(8000, 8010) // 11 ports total
+	pm.SetPortProbe(nil)             // Same result whichever host ports are free
 
 	if available := pm.AvailableCount(); available != 11 {
 		t.Errorf("Expected 11 available ports initially, got %d", available)
@@ -135,3 +144,128 @@ func TestPortManager_AvailableCount(t *testing.T) {
 		t.Errorf("Expected 11 available ports after release, got %d", available)
 	}
 }
+
+func TestPortManager_SkipsPortsInUse(t *testing.T) {
+	pm := NewPortManager(8000, 8002)
+	pm.SetPortProbe(func(port int) bool { return port != 8000 })
+
+	port, err := pm.Allocate()
+	if err != nil {
+		t.Fatalf("Failed to allocate port: %v", err)
+	}
+	if port != 8001 {
+		t.Errorf("Expected busy port 8000 to be skipped, got %d", port)
+	}
+}
+
+func TestPortManager_BindProbe(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer ln.Close()
+	busy := ln.Addr().(*net.TCPAddr).Port
+
+	pm := NewPortManager(busy, busy)
+	_, err = pm.Allocate()
+	if !errors.Is(err, ErrPortInUse) {
+		t.Errorf("Expected ErrPortInUse for bound port %d, got %v", busy, err)
+	}
+}
+
+func TestPortManager_ExhaustionErrorTypes(t *testing.T) {
+	pm := NewPortManager(8000, 8000)
+	pm.SetPortProbe(nil)
+
+	if _, err := pm.Allocate(); err != nil {
+		t.Fatalf("Failed to allocate port: %v", err)
+	}
+
+	_, err := pm.Allocate()
+	var allocErr *PortAllocationError
+	if !errors.As(err, &allocErr) {
+		t.Fatalf("Expected *PortAllocationError, got %T", err)
+	}
+	if !errors.Is(err, ErrPortRangeExhausted) {
+		t.Errorf("Expected ErrPortRangeExhausted, got %v", err)
+	}
+	if errors.Is(err, ErrPortInUse) {
+		t.Error("Exhausted range should not report ErrPortInUse")
+	}
+
+	pm2 := NewPortManager(8000, 8001)
+	pm2.SetPortProbe(func(int) bool { return false })
+	_, err = pm2.Allocate()
+	if !errors.Is(err, ErrPortInUse) {
+		t.Errorf("Expected ErrPortInUse, got %v", err)
+	}
+	if errors.As(err, &allocErr) && len(allocErr.InUse) != 2 {
+		t.Errorf("Expected 2 ports in use, got %v", allocErr.InUse)
+	}
+}
+
+func TestPortManager_SharedReservations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultReservationFileName)
+
+	worker1 := NewPortManager(8000, 8001)
+	worker1.SetPortProbe(nil)
+	worker1.SetReservations(NewPortReservations(path))
+
+	worker2 := NewPortManager(8000, 8001)
+	worker2.SetPortProbe(nil)
+	worker2.SetReservations(NewPortReservations(path))
+
+	port1, err := worker1.Allocate()
+	if err != nil {
+		t.Fatalf("Worker 1 failed to allocate: %v", err)
+	}
+	port2, err := worker2.Allocate()
+	if err != nil {
+		t.Fatalf("Worker 2 failed to allocate: %v", err)
+	}
+	if port1 == port2 {
+		t.Errorf("Workers sharing a reservation file got the same port %d", port1)
+	}
+
+	_, err = worker2.Allocate()
+	if !errors.Is(err, ErrPortRangeExhausted) {
+		t.Errorf("Expected ErrPortRangeExhausted, got %v", err)
+	}
+
+	if err := worker1.Release(port1); err != nil {
+		t.Fatalf("Failed to release port: %v", err)
+	}
+	port3, err := worker2.Allocate()
+	if err != nil {
+		t.Fatalf("Expected port released by worker 1 to be available: %v", err)
+	}
+	if port3 != port1 {
+		t.Errorf("Expected port %d, got %d", port1, port3)
+	}
+}
+
+func TestPortReservations_PrunesDeadProcesses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultReservationFileName)
+	stale := &PortReservations{path: path, pid: 1 << 30}
+
+	if ok, err := stale.Reserve(8000); err != nil || !ok {
+		t.Fatalf("Failed to write stale reservation: ok=%v err=%v", ok, err)
+	}
+
+	live := NewPortReservations(path)
+	ok, err := live.Reserve(8000)
+	if err != nil {
+		t.Fatalf("Reserve failed: %v", err)
+	}
+	if !ok {
+		t.Error("Reservation held by a dead process should be pruned")
+	}
+
+	entries, err := live.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if entries[8000].PID != os.Getpid() {
+		t.Errorf("Expected reservation owned by pid %d, got %d", os.Getpid(), entries[8000].PID)
+	}
+}
@@ -118,6 +118,12 @@ func (sm *ServerManager) BootServer(ctx context.Context, worktreePath string, wo
 		return nil, fmt.Errorf("invalid port number: %d", port)
 	}
 
+	// Fail fast if another process grabbed the port after allocation,
+	// instead of waiting out the full health check timeout
+	if !ProbePortFree(port) {
+		return nil, fmt.Errorf("cannot boot opencode server on port %d: %w", port, ErrPortInUse)
+	}
+
 	// Acquire semaphore to limit concurrent bootstraps
 	// This prevents resource contention when starting multiple servers
 	select {
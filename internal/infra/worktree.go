@@ -6,12 +6,14 @@
 package infra
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"open-swarm/internal/git"
 )
 
 // WorktreeManager manages Git worktrees for agent isolation
@@ -70,14 +72,50 @@ func (wm *WorktreeManager) CreateWorktree(id string, branch string) (*WorktreeIn
 	}
 
 	// Create worktree with a NEW branch to avoid "already used" error
-	// Format: git worktree add -b <new-branch> <path> <base-branch>
 	newBranch := fmt.Sprintf("worktree-%s", id)
-	cmd := exec.Command("git", "worktree", "add", "-b", newBranch, worktreePath, branch)
-	cmd.Dir = wm.repoDir
+	_, err := wm.repo().AddWorktree(context.Background(), worktreePath, git.AddWorktreeOptions{
+		NewBranch: newBranch,
+		Ref:       branch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	return &WorktreeInfo{
+		ID:   id,
+		Path: worktreePath,
+	}, nil
+}
+
+// CreateDetachedWorktree creates a worktree with a detached HEAD at ref.
+// Detached worktrees hold no branch, so they can be recycled between tasks.
+func (wm *WorktreeManager) CreateDetachedWorktree(id string, ref string) (*WorktreeInfo, error) {
+	if id == "" {
+		return nil, fmt.Errorf("worktree id cannot be empty")
+	}
+	if !isValidGitIdentifier(id) {
+		return nil, fmt.Errorf("invalid worktree id: %s", id)
+	}
+	if !isValidGitIdentifier(ref) {
+		return nil, fmt.Errorf("invalid ref: %s", ref)
+	}
+
+	worktreePath := filepath.Join(wm.baseDir, id)
 
-	output, err := cmd.CombinedOutput()
+	if err := os.MkdirAll(wm.baseDir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create worktree base directory: %w", err)
+	}
+
+	if _, err := os.Stat(worktreePath); err == nil {
+		return nil, fmt.Errorf("worktree %s already exists at %s", id, worktreePath)
+	}
+
+	_, err := wm.repo().AddWorktree(context.Background(), worktreePath, git.AddWorktreeOptions{
+		Detach: true,
+		Ref:    ref,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create worktree: %w\nOutput: %s", err, string(output))
+		return nil, fmt.Errorf("failed to create detached worktree: %w", err)
 	}
 
 	return &WorktreeInfo{
@@ -86,6 +124,83 @@ func (wm *WorktreeManager) CreateWorktree(id string, branch string) (*WorktreeIn
 	}, nil
 }
 
+// CheckoutBranch creates (or resets) branch at base inside an existing worktree
+func (wm *WorktreeManager) CheckoutBranch(id string, branch string, base string) error {
+	if !isValidGitIdentifier(id) {
+		return fmt.Errorf("invalid worktree id: %s", id)
+	}
+	if !isValidGitIdentifier(branch) {
+		return fmt.Errorf("invalid branch name: %s", branch)
+	}
+	if !isValidGitIdentifier(base) {
+		return fmt.Errorf("invalid base ref: %s", base)
+	}
+
+	err := wm.worktree(id).Checkout(context.Background(), git.CheckoutOptions{
+		Branch:     branch,
+		Reset:      true,
+		StartPoint: base,
+	})
+	if err != nil {
+		return fmt.Errorf("checkout failed in worktree %s: %w", id, err)
+	}
+	return nil
+}
+
+// ScrubWorktree discards all changes and untracked files in a worktree and
+// detaches it at ref, ready to be handed to the next task. The task branch it
+// had checked out is deleted when it holds no commits beyond ref; a branch
+// with commits is kept for the merge queue, which deletes it once integrated.
+// Server logs in .opencode-logs are preserved because the server keeps them open.
+func (wm *WorktreeManager) ScrubWorktree(id string, ref string) error {
+	if !isValidGitIdentifier(id) {
+		return fmt.Errorf("invalid worktree id: %s", id)
+	}
+	if !isValidGitIdentifier(ref) {
+		return fmt.Errorf("invalid ref: %s", ref)
+	}
+
+	ctx := context.Background()
+	repo := wm.worktree(id)
+	if err := repo.ResetHard(ctx, ""); err != nil {
+		return fmt.Errorf("scrub failed in worktree %s: %w", id, err)
+	}
+	if err := repo.Clean(ctx, ".opencode-logs"); err != nil {
+		return fmt.Errorf("scrub failed in worktree %s: %w", id, err)
+	}
+	branch, err := repo.CurrentBranch(ctx)
+	if err != nil {
+		return fmt.Errorf("scrub failed in worktree %s: %w", id, err)
+	}
+	if err := repo.Checkout(ctx, git.CheckoutOptions{Branch: ref, Detach: true}); err != nil {
+		return fmt.Errorf("scrub failed in worktree %s: %w", id, err)
+	}
+	if branch == "" {
+		return nil
+	}
+	// Task branches are per lease; empty ones would pile up in the main repo
+	merged, err := repo.IsAncestor(ctx, branch, ref)
+	if err != nil {
+		return fmt.Errorf("scrub failed in worktree %s: %w", id, err)
+	}
+	if merged {
+		if err := repo.DeleteBranch(ctx, branch, true); err != nil {
+			return fmt.Errorf("scrub failed in worktree %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// repo returns the main repository
+func (wm *WorktreeManager) repo() *git.Repo {
+	return git.Open(wm.repoDir)
+}
+
+// worktree returns the linked worktree with the given id
+func (wm *WorktreeManager) worktree(id string) *git.Repo {
+	return git.Open(filepath.Join(wm.baseDir, id))
+}
+
 // RemoveWorktree removes a Git worktree
 func (wm *WorktreeManager) RemoveWorktree(id string) error {
 	// Validate id to prevent command injection
@@ -98,54 +213,29 @@ func (wm *WorktreeManager) RemoveWorktree(id string) error {
 
 	worktreePath := filepath.Join(wm.baseDir, id)
 
-	// Remove worktree: git worktree remove <path>
-	cmd := exec.Command("git", "worktree", "remove", worktreePath, "--force")
-	cmd.Dir = wm.repoDir
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to remove worktree: %w\nOutput: %s", err, string(output))
+	if err := wm.repo().RemoveWorktree(context.Background(), worktreePath, true); err != nil {
+		return fmt.Errorf("failed to remove worktree: %w", err)
 	}
 
 	return nil
 }
 
-// ListWorktrees lists all worktrees in the repository
+// ListWorktrees lists the worktrees under the base directory
 func (wm *WorktreeManager) ListWorktrees() ([]*WorktreeInfo, error) {
-	cmd := exec.Command("git", "worktree", "list", "--porcelain")
-	cmd.Dir = wm.repoDir
-
-	output, err := cmd.CombinedOutput()
+	all, err := wm.repo().Worktrees(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+		return nil, err
 	}
 
-	return wm.parseWorktreeList(string(output))
-}
-
-// parseWorktreeList parses the output of git worktree list --porcelain
-func (wm *WorktreeManager) parseWorktreeList(output string) ([]*WorktreeInfo, error) {
 	var worktrees []*WorktreeInfo
-	lines := strings.Split(output, "\n")
-
-	var currentPath string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+	for _, wt := range all {
+		if !strings.HasPrefix(wt.Path, wm.baseDir) {
 			continue
 		}
-
-		if strings.HasPrefix(line, "worktree ") {
-			currentPath = strings.TrimPrefix(line, "worktree ")
-		} else if currentPath != "" && strings.HasPrefix(currentPath, wm.baseDir) {
-			// Extract ID from path
-			id := filepath.Base(currentPath)
-			worktrees = append(worktrees, &WorktreeInfo{
-				ID:   id,
-				Path: currentPath,
-			})
-			currentPath = ""
-		}
+		worktrees = append(worktrees, &WorktreeInfo{
+			ID:   filepath.Base(wt.Path),
+			Path: wt.Path,
+		})
 	}
 
 	return worktrees, nil
@@ -153,15 +243,7 @@ func (wm *WorktreeManager) parseWorktreeList(output string) ([]*WorktreeInfo, er
 
 // PruneWorktrees removes worktree administrative information for missing worktrees
 func (wm *WorktreeManager) PruneWorktrees() error {
-	cmd := exec.Command("git", "worktree", "prune")
-	cmd.Dir = wm.repoDir
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to prune worktrees: %w\nOutput: %s", err, string(output))
-	}
-
-	return nil
+	return wm.repo().PruneWorktrees(context.Background())
 }
 
 // CleanupAll removes all worktrees in the base directory
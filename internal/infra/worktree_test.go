@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package infra
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"open-swarm/internal/git"
+)
+
+// newScrubRepo creates a repository with one commit and a worktree manager
+// whose worktree "pool-1" has task branch worktree-cell-task-1 checked out
+func newScrubRepo(t *testing.T) (*WorktreeManager, *WorktreeInfo, *git.Repo) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repoDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# repo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	runGitIn(t, repoDir, "init", "-q")
+	runGitIn(t, repoDir, "add", "-A")
+	runGitIn(t, repoDir, "commit", "-q", "-m", "base")
+
+	wm := NewWorktreeManager(repoDir, filepath.Join(t.TempDir(), "worktrees"))
+	worktree, err := wm.CreateDetachedWorktree("pool-1", "HEAD")
+	if err != nil {
+		t.Fatalf("CreateDetachedWorktree failed: %v", err)
+	}
+	if err := wm.CheckoutBranch("pool-1", "worktree-cell-task-1", "HEAD"); err != nil {
+		t.Fatalf("CheckoutBranch failed: %v", err)
+	}
+	return wm, worktree, git.Open(repoDir)
+}
+
+func runGitIn(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.email=t@example.com", "-c", "user.name=t"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v: %s", args, err, out)
+	}
+}
+
+func TestWorktreeManager_ScrubDeletesTaskBranch(t *testing.T) {
+	wm, _, repo := newScrubRepo(t)
+	ctx := context.Background()
+	if exists, err := repo.BranchExists(ctx, "worktree-cell-task-1"); err != nil || !exists {
+		t.Fatalf("Expected task branch to exist, got %v, %v", exists, err)
+	}
+
+	if err := wm.ScrubWorktree("pool-1", "HEAD"); err != nil {
+		t.Fatalf("ScrubWorktree failed: %v", err)
+	}
+	if exists, err := repo.BranchExists(ctx, "worktree-cell-task-1"); err != nil || exists {
+		t.Errorf("Expected task branch without commits deleted after scrub, got %v, %v", exists, err)
+	}
+
+	// A detached worktree has no branch to delete
+	if err := wm.ScrubWorktree("pool-1", "HEAD"); err != nil {
+		t.Errorf("Scrubbing a detached worktree failed: %v", err)
+	}
+}
+
+func TestWorktreeManager_ScrubKeepsCommittedTaskBranch(t *testing.T) {
+	wm, worktree, repo := newScrubRepo(t)
+	ctx := context.Background()
+	base, err := repo.Head(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(worktree.Path, "task.go"), []byte("package task\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	runGitIn(t, worktree.Path, "add", "-A")
+	runGitIn(t, worktree.Path, "commit", "-q", "-m", "task")
+
+	if err := wm.ScrubWorktree("pool-1", base); err != nil {
+		t.Fatalf("ScrubWorktree failed: %v", err)
+	}
+	// The merge queue integrates the branch after the cell is back in the pool
+	if exists, err := repo.BranchExists(ctx, "worktree-cell-task-1"); err != nil || !exists {
+		t.Errorf("Expected committed task branch kept after scrub, got %v, %v", exists, err)
+	}
+}
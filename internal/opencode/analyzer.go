@@ -7,15 +7,18 @@ package opencode
 
 import (
 	"context"
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
 // CodeAnalyzer provides code analysis capabilities for agents.
-// Uses treesitter for parsing and structural analysis.
+// Each file is analyzed by the LanguageAnalyzer registered for its extension.
 type CodeAnalyzer interface {
 	// AnalyzeFile analyzes a single source file and returns structure
 	AnalyzeFile(ctx context.Context, filePath string) (*CodeAnalysis, error)
@@ -63,6 +66,9 @@ type Symbol struct {
 	EndLine    int           // End line of definition
 	Parameters []Parameter   // For functions: parameters
 	ReturnType string        // For functions: return type
+	Receiver   string        // For methods: receiver type
+	Signature  string        // Declared signature or type expression
+	Exported   bool          // Visible outside its package or module
 }
 
 // SymbolKind represents the type of a symbol
@@ -117,24 +123,115 @@ type ComplexityMetrics struct {
 	Issues               string // Any complexity issues detected
 }
 
-// DefaultCodeAnalyzer implements CodeAnalyzer using Go's ast package
+// ImportResolver resolves the imports of a file to the files they refer to
+type ImportResolver interface {
+	// ResolveImport returns the files importPath may refer to, as paths in
+	// the same form as fromFile. Imports of external packages resolve to
+	// nothing.
+	ResolveImport(fromFile, importPath string) []string
+}
+
+// LanguageAnalyzer analyzes the source files of one language
+type LanguageAnalyzer interface {
+	ImportResolver
+
+	// Language names the language, e.g. "go" or "python"
+	Language() string
+
+	// Extensions lists the file extensions of the language, e.g. ".py"
+	Extensions() []string
+
+	// Analyze extracts the symbols, imports and complexity of a file
+	Analyze(filePath string, content []byte) *CodeAnalysis
+
+	// IsTestFile reports whether a file name follows the language's test
+	// file convention
+	IsTestFile(filePath string) bool
+}
+
+// BuiltinLanguages returns the analyzers for Go, TypeScript/JavaScript and
+// Python
+func BuiltinLanguages() []LanguageAnalyzer {
+	return []LanguageAnalyzer{goAnalyzer{}, typeScriptAnalyzer, javaScriptAnalyzer, pythonAnalyzer{}}
+}
+
+var builtinByExtension = languagesByExtension(BuiltinLanguages())
+
+func languagesByExtension(languages []LanguageAnalyzer) map[string]LanguageAnalyzer {
+	byExtension := make(map[string]LanguageAnalyzer)
+	for _, language := range languages {
+		for _, ext := range language.Extensions() {
+			byExtension[ext] = language
+		}
+	}
+	return byExtension
+}
+
+// LanguageForFile returns the language of a file by its extension, or ""
+// for files no built-in analyzer understands
+func LanguageForFile(filePath string) string {
+	if language, ok := builtinByExtension[strings.ToLower(filepath.Ext(filePath))]; ok {
+		return language.Language()
+	}
+	return ""
+}
+
+// IsTestFile reports whether a file is a test file of a built-in language
+func IsTestFile(filePath string) bool {
+	language, ok := builtinByExtension[strings.ToLower(filepath.Ext(filePath))]
+	return ok && language.IsTestFile(filePath)
+}
+
+// DefaultCodeAnalyzer implements CodeAnalyzer by dispatching each file to
+// the analyzer of its language. The zero value uses the built-in languages.
 type DefaultCodeAnalyzer struct {
-	// Uses Go's built-in ast parser for code analysis
+	languages map[string]LanguageAnalyzer // By extension
 }
 
-// NewCodeAnalyzer creates a new CodeAnalyzer
+// NewCodeAnalyzer creates a new CodeAnalyzer for the built-in languages
 func NewCodeAnalyzer() CodeAnalyzer {
 	return &DefaultCodeAnalyzer{}
 }
 
-// AnalyzeFile analyzes a single Go file
+// RegisterLanguage adds or replaces the analyzer for a language's extensions
+func (a *DefaultCodeAnalyzer) RegisterLanguage(language LanguageAnalyzer) {
+	if a.languages == nil {
+		a.languages = languagesByExtension(BuiltinLanguages())
+	}
+	for _, ext := range language.Extensions() {
+		a.languages[ext] = language
+	}
+}
+
+// languageFor returns the analyzer for a file's extension
+func (a *DefaultCodeAnalyzer) languageFor(filePath string) (LanguageAnalyzer, bool) {
+	languages := a.languages
+	if languages == nil {
+		languages = builtinByExtension
+	}
+	language, ok := languages[strings.ToLower(filepath.Ext(filePath))]
+	return language, ok
+}
+
+// AnalyzeFile analyzes a single file with the analyzer of its language
 func (a *DefaultCodeAnalyzer) AnalyzeFile(ctx context.Context, filePath string) (*CodeAnalysis, error) {
+	language, ok := a.languageFor(filePath)
+	if !ok {
+		return &CodeAnalysis{
+			FilePath: filePath,
+			IsValid:  false,
+			Issues: []SyntaxError{{
+				Message: fmt.Sprintf("No analyzer for %q files", filepath.Ext(filePath)),
+			}},
+		}, nil
+	}
+
 	// Read the file
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return &CodeAnalysis{
 			FilePath: filePath,
-			Language: "go",
+			Language: language.Language(),
 			IsValid:  false,
 			Issues: []SyntaxError{{
 				LineNumber: 0,
@@ -143,7 +240,32 @@ func (a *DefaultCodeAnalyzer) AnalyzeFile(ctx context.Context, filePath string)
 			}},
 		}, nil
 	}
+	return language.Analyze(filePath, content), nil
+}
+
+// ResolveImport resolves an import with the analyzer of the importing
+// file's language
+func (a *DefaultCodeAnalyzer) ResolveImport(fromFile, importPath string) []string {
+	language, ok := a.languageFor(fromFile)
+	if !ok {
+		return nil
+	}
+	return language.ResolveImport(fromFile, importPath)
+}
 
+// goAnalyzer analyzes Go files with the go/ast package
+type goAnalyzer struct{}
+
+func (goAnalyzer) Language() string { return "go" }
+
+func (goAnalyzer) Extensions() []string { return []string{".go"} }
+
+func (goAnalyzer) IsTestFile(filePath string) bool {
+	return strings.HasSuffix(filePath, "_test.go")
+}
+
+// Analyze parses a Go file
+func (goAnalyzer) Analyze(filePath string, content []byte) *CodeAnalysis {
 	// Parse the file
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, filePath, content, parser.AllErrors)
@@ -165,7 +287,7 @@ func (a *DefaultCodeAnalyzer) AnalyzeFile(ctx context.Context, filePath string)
 			Column:     0,
 			Message:    err.Error(),
 		})
-		return analysis, nil
+		return analysis
 	}
 
 	analysis.IsValid = true
@@ -193,9 +315,13 @@ func (a *DefaultCodeAnalyzer) AnalyzeFile(ctx context.Context, filePath string)
 				LineNumber: fset.Position(decl.Pos()).Line,
 				EndLine:    fset.Position(decl.End()).Line,
 				ReturnType: typeToString(decl.Type.Results),
+				Parameters: fieldsToParameters(decl.Type.Params),
+				Signature:  types.ExprString(decl.Type),
+				Exported:   ast.IsExported(decl.Name.Name),
 			}
 			if decl.Recv != nil {
 				symbol.Kind = SymbolMethod
+				symbol.Receiver = types.ExprString(decl.Recv.List[0].Type)
 			}
 			analysis.Symbols = append(analysis.Symbols, symbol)
 
@@ -210,6 +336,8 @@ func (a *DefaultCodeAnalyzer) AnalyzeFile(ctx context.Context, filePath string)
 							FilePath:   filePath,
 							LineNumber: fset.Position(tspec.Pos()).Line,
 							EndLine:    fset.Position(tspec.End()).Line,
+							Signature:  types.ExprString(tspec.Type),
+							Exported:   ast.IsExported(tspec.Name.Name),
 						}
 						analysis.Symbols = append(analysis.Symbols, symbol)
 					}
@@ -223,6 +351,10 @@ func (a *DefaultCodeAnalyzer) AnalyzeFile(ctx context.Context, filePath string)
 						if decl.Tok == token.CONST {
 							kind = SymbolConstant
 						}
+						signature := ""
+						if vspec.Type != nil {
+							signature = types.ExprString(vspec.Type)
+						}
 						for _, name := range vspec.Names {
 							analysis.Symbols = append(analysis.Symbols, &Symbol{
 								Name:       name.Name,
@@ -230,6 +362,8 @@ func (a *DefaultCodeAnalyzer) AnalyzeFile(ctx context.Context, filePath string)
 								FilePath:   filePath,
 								LineNumber: fset.Position(vspec.Pos()).Line,
 								EndLine:    fset.Position(vspec.End()).Line,
+								Signature:  signature,
+								Exported:   ast.IsExported(name.Name),
 							})
 						}
 					}
@@ -241,10 +375,53 @@ func (a *DefaultCodeAnalyzer) AnalyzeFile(ctx context.Context, filePath string)
 	// Calculate complexity metrics
 	analysis.Complexity = calculateComplexity(file, content)
 
-	return analysis, nil
+	return analysis
+}
+
+// ResolveImport resolves a module-local import path to the non-test Go
+// files of its package, using the go.mod above the importing file
+func (goAnalyzer) ResolveImport(fromFile, importPath string) []string {
+	root, module := findGoModule(filepath.Dir(fromFile))
+	if module == "" || (importPath != module && !strings.HasPrefix(importPath, module+"/")) {
+		return nil
+	}
+	dir := filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(importPath, module)))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && filepath.Ext(name) == ".go" && !strings.HasSuffix(name, "_test.go") {
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+	return files
 }
 
-// AnalyzeFiles analyzes multiple files
+// findGoModule walks up from dir to the nearest go.mod and returns its
+// directory and module path
+func findGoModule(dir string) (string, string) {
+	for {
+		if content, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil { //nolint:gosec // go.mod of the analyzed project
+			for _, line := range strings.Split(string(content), "\n") {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					return dir, strings.Trim(strings.TrimSpace(module), `"`)
+				}
+			}
+			return dir, ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
+
+// AnalyzeFiles analyzes multiple files, in any mix of languages, and links
+// each file to the analyzed files its imports resolve to
 func (a *DefaultCodeAnalyzer) AnalyzeFiles(ctx context.Context, filePaths []string) (*ProjectAnalysis, error) {
 	analysis := &ProjectAnalysis{
 		Files:         []*CodeAnalysis{},
@@ -252,17 +429,50 @@ func (a *DefaultCodeAnalyzer) AnalyzeFiles(ctx context.Context, filePaths []stri
 		MainPackages:  []string{},
 	}
 
+	analyzed := make(map[string]string) // Cleaned path to the path as given
 	for _, filePath := range filePaths {
 		fileAnalysis, err := a.AnalyzeFile(ctx, filePath)
 		if err != nil {
 			continue
 		}
 		analysis.Files = append(analysis.Files, fileAnalysis)
+		analyzed[filepath.Clean(filePath)] = filePath
+	}
+
+	for _, file := range analysis.Files {
+		seen := make(map[string]bool)
+		for _, importPath := range file.Imports {
+			for _, target := range a.ResolveImport(file.FilePath, importPath) {
+				to, ok := analyzed[filepath.Clean(target)]
+				if !ok || to == file.FilePath || seen[to] {
+					continue
+				}
+				seen[to] = true
+				analysis.Relationships = append(analysis.Relationships, Dependency{
+					From: file.FilePath,
+					To:   to,
+					Type: "import",
+				})
+			}
+		}
+		if file.Language == "go" && hasMainFunc(file.Symbols) {
+			analysis.MainPackages = append(analysis.MainPackages, file.FilePath)
+		}
 	}
 
 	return analysis, nil
 }
 
+// hasMainFunc reports whether symbols declare a Go program's main function
+func hasMainFunc(symbols []*Symbol) bool {
+	for _, symbol := range symbols {
+		if symbol.Name == "main" && symbol.Kind == SymbolFunction {
+			return true
+		}
+	}
+	return false
+}
+
 // FindSymbols finds symbols in a file
 func (a *DefaultCodeAnalyzer) FindSymbols(ctx context.Context, filePath string) ([]*Symbol, error) {
 	analysis, err := a.AnalyzeFile(ctx, filePath)
@@ -330,6 +540,25 @@ func typeToString(fl *ast.FieldList) string {
 	return strings.Join(types, ", ")
 }
 
+// fieldsToParameters converts a parameter list to named parameters
+func fieldsToParameters(fl *ast.FieldList) []Parameter {
+	if fl == nil {
+		return nil
+	}
+	var params []Parameter
+	for _, field := range fl.List {
+		paramType := types.ExprString(field.Type)
+		if len(field.Names) == 0 {
+			params = append(params, Parameter{Type: paramType})
+			continue
+		}
+		for _, name := range field.Names {
+			params = append(params, Parameter{Name: name.Name, Type: paramType})
+		}
+	}
+	return params
+}
+
 // fieldTypeToString converts a type expression to string
 func fieldTypeToString(expr ast.Expr) string {
 	switch t := expr.(type) {
@@ -0,0 +1,320 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package opencode
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// pythonAnalyzer analyzes Python files from their indentation structure
+type pythonAnalyzer struct{}
+
+var pythonSyntax = sourceSyntax{lineComment: "#", quotes: `'"`, tripleQuotes: true}
+
+var (
+	pythonDefPattern      = regexp.MustCompile(`^(async\s+)?def\s+([A-Za-z_]\w*)\s*\(`)
+	pythonClassPattern    = regexp.MustCompile(`^class\s+([A-Za-z_]\w*)\s*(\((.*)\))?\s*:`)
+	pythonAssignPattern   = regexp.MustCompile(`^([A-Za-z_]\w*)\s*(?::\s*([^=]+?))?\s*=([^=]|$)`)
+	pythonImportPattern   = regexp.MustCompile(`^import\s+(.+)$`)
+	pythonFromPattern     = regexp.MustCompile(`^from\s+(\S+)\s+import\b`)
+	pythonBlockPattern    = regexp.MustCompile(`^(async\s+)?(def|class|if|elif|else|for|while|try|except|finally|with|match|case)\b.*:$`)
+	pythonDecisionPattern = regexp.MustCompile(`\b(if|elif|for|while|except|and|or|case)\b`)
+)
+
+func (pythonAnalyzer) Language() string { return "python" }
+
+func (pythonAnalyzer) Extensions() []string { return []string{".py", ".pyi"} }
+
+// IsTestFile matches pytest's test_*.py and *_test.py files
+func (pythonAnalyzer) IsTestFile(filePath string) bool {
+	base := filepath.Base(filePath)
+	return strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test.py")
+}
+
+// pythonLine is a logical line: physical lines joined while brackets are
+// open or a line ends with a backslash
+type pythonLine struct {
+	text      string // Masked code, joined with spaces
+	indent    int
+	startLine int
+	endLine   int
+}
+
+// pythonBlock is an open block while scanning
+type pythonBlock struct {
+	indent int
+	symbol *Symbol // Nil for blocks other than top-level classes
+}
+
+// Analyze scans a Python file
+func (pythonAnalyzer) Analyze(filePath string, content []byte) *CodeAnalysis {
+	text := string(content)
+	masked := maskSource(text, pythonSyntax)
+	issues := append(masked.issues, checkBrackets(masked.code)...)
+	lines := pythonLogicalLines(masked.code)
+	issues = append(issues, pythonIndentIssues(lines)...)
+
+	analysis := &CodeAnalysis{
+		FilePath: filePath,
+		Language: "python",
+		Symbols:  []*Symbol{},
+		Imports:  pythonImports(lines),
+		IsValid:  len(issues) == 0,
+		Issues:   append([]SyntaxError{}, issues...),
+	}
+
+	var stack []pythonBlock
+	nesting := 0
+	for i, line := range lines {
+		for len(stack) > 0 && stack[len(stack)-1].indent >= line.indent {
+			stack = stack[:len(stack)-1]
+		}
+		var symbol *Symbol
+		switch {
+		case pythonDefPattern.MatchString(line.text):
+			symbol = pythonFunction(line.text, stack)
+		case pythonClassPattern.MatchString(line.text) && len(stack) == 0:
+			m := pythonClassPattern.FindStringSubmatch(line.text)
+			symbol = &Symbol{Name: m[1], Kind: SymbolClass, Signature: strings.TrimSpace(m[3])}
+		case len(stack) == 0 && pythonAssignPattern.MatchString(line.text):
+			m := pythonAssignPattern.FindStringSubmatch(line.text)
+			symbol = &Symbol{Name: m[1], Kind: SymbolVariable, Signature: strings.TrimSpace(m[2])}
+			if strings.ToUpper(m[1]) == m[1] && strings.ContainsAny(m[1], "ABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+				symbol.Kind = SymbolConstant
+			}
+		}
+		if symbol != nil {
+			symbol.FilePath = filePath
+			symbol.LineNumber = line.startLine
+			symbol.EndLine = pythonBlockEnd(lines, i)
+			symbol.Exported = !strings.HasPrefix(symbol.Name, "_")
+			if symbol.Kind == SymbolMethod {
+				symbol.Exported = symbol.Exported && stack[len(stack)-1].symbol.Exported
+			}
+			analysis.Symbols = append(analysis.Symbols, symbol)
+		}
+
+		if strings.HasSuffix(line.text, ":") && pythonBlockPattern.MatchString(line.text) {
+			block := pythonBlock{indent: line.indent}
+			if symbol != nil && symbol.Kind == SymbolClass {
+				block.symbol = symbol
+			}
+			stack = append(stack, block)
+			nesting = max(nesting, len(stack))
+		}
+	}
+
+	analysis.Complexity.AverageFunctionSize, analysis.Complexity.Functions = averageFunctionSize(analysis.Symbols)
+	analysis.Complexity.LinesOfCode = len(strings.Split(text, "\n"))
+	analysis.Complexity.CyclomaticComplexity = 1 + len(pythonDecisionPattern.FindAllStringIndex(masked.code, -1))
+	analysis.Complexity.NestedDepth = nesting
+	return analysis
+}
+
+// pythonFunction builds the symbol of a def line. Functions in other
+// functions or blocks are skipped; defs directly in a top-level class are
+// methods.
+func pythonFunction(text string, stack []pythonBlock) *Symbol {
+	name := pythonDefPattern.FindStringSubmatch(text)[2]
+	symbol := &Symbol{Name: name, Kind: SymbolFunction}
+	switch {
+	case len(stack) == 1 && stack[0].symbol != nil:
+		symbol.Kind = SymbolMethod
+		symbol.Receiver = stack[0].symbol.Name
+	case len(stack) > 0:
+		return nil
+	}
+
+	open := strings.IndexByte(text, '(')
+	closing := matchingClose(text, open)
+	if closing < 0 {
+		return symbol
+	}
+	for i, param := range splitTopLevel(text[open+1 : closing]) {
+		param, _, _ = cutTopLevel(param, '=')
+		paramName, paramType, _ := cutTopLevel(param, ':')
+		paramName = strings.TrimLeft(strings.TrimSpace(paramName), "*")
+		if paramName == "" || paramName == "/" {
+			continue // Keyword-only and positional-only markers
+		}
+		if i == 0 && symbol.Kind == SymbolMethod && (paramName == "self" || paramName == "cls") {
+			continue
+		}
+		symbol.Parameters = append(symbol.Parameters, Parameter{Name: paramName, Type: strings.TrimSpace(paramType)})
+	}
+	if _, returnType, ok := strings.Cut(text[closing+1:], "->"); ok {
+		symbol.ReturnType = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(returnType), ":"))
+	}
+	symbol.Signature = strings.TrimSpace(text[open : closing+1])
+	if symbol.ReturnType != "" {
+		symbol.Signature += " -> " + symbol.ReturnType
+	}
+	return symbol
+}
+
+// pythonLogicalLines joins physical lines into logical lines and drops
+// blank ones. Lines of a triple-quoted string belong to the line it starts
+// on.
+func pythonLogicalLines(code string) []pythonLine {
+	var lines []pythonLine
+	var current *pythonLine
+	depth := 0
+	triple := "" // Quote of the open triple-quoted string
+	for i, physical := range strings.Split(code, "\n") {
+		trimmed := strings.TrimSpace(physical)
+		if current == nil {
+			if trimmed == "" {
+				continue
+			}
+			current = &pythonLine{indent: pythonIndent(physical), startLine: i + 1}
+		} else {
+			current.text += " "
+		}
+		current.text += strings.TrimSuffix(trimmed, "\\")
+		current.endLine = i + 1
+		for k := 0; k < len(physical); k++ {
+			switch {
+			case triple != "":
+				if strings.HasPrefix(physical[k:], triple) {
+					triple, k = "", k+2
+				}
+			case strings.HasPrefix(physical[k:], `"""`) || strings.HasPrefix(physical[k:], "'''"):
+				triple, k = physical[k:k+3], k+2
+			case strings.IndexByte("([{", physical[k]) >= 0:
+				depth++
+			case strings.IndexByte(")]}", physical[k]) >= 0:
+				depth--
+			}
+		}
+		if depth <= 0 && triple == "" && !strings.HasSuffix(trimmed, "\\") {
+			current.text = strings.TrimSpace(current.text)
+			lines = append(lines, *current)
+			current, depth = nil, 0
+		}
+	}
+	if current != nil {
+		lines = append(lines, *current)
+	}
+	return lines
+}
+
+// pythonIndent measures leading whitespace, with tabs to the next multiple
+// of eight as Python does
+func pythonIndent(line string) int {
+	indent := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			indent++
+		case '\t':
+			indent += 8 - indent%8
+		default:
+			return indent
+		}
+	}
+	return indent
+}
+
+// pythonBlockEnd returns the last physical line of the statement at
+// lines[i], including its indented body
+func pythonBlockEnd(lines []pythonLine, i int) int {
+	end := lines[i].endLine
+	for j := i + 1; j < len(lines) && lines[j].indent > lines[i].indent; j++ {
+		end = lines[j].endLine
+	}
+	return end
+}
+
+// pythonIndentIssues reports blocks without an indented body and
+// unexpected indentation
+func pythonIndentIssues(lines []pythonLine) []SyntaxError {
+	var issues []SyntaxError
+	indents := []int{0}
+	for i, line := range lines {
+		opener := i > 0 && strings.HasSuffix(lines[i-1].text, ":") && pythonBlockPattern.MatchString(lines[i-1].text)
+		switch {
+		case opener && line.indent <= lines[i-1].indent:
+			issues = append(issues, SyntaxError{LineNumber: line.startLine, Message: "expected an indented block"})
+		case opener:
+			indents = append(indents, line.indent)
+			continue
+		case line.indent > indents[len(indents)-1]:
+			issues = append(issues, SyntaxError{LineNumber: line.startLine, Message: "unexpected indent"})
+		}
+		for len(indents) > 1 && indents[len(indents)-1] > line.indent {
+			indents = indents[:len(indents)-1]
+		}
+		if line.indent != indents[len(indents)-1] {
+			issues = append(issues, SyntaxError{LineNumber: line.startLine, Message: "unindent does not match any outer indentation level"})
+		}
+	}
+	if n := len(lines); n > 0 && strings.HasSuffix(lines[n-1].text, ":") && pythonBlockPattern.MatchString(lines[n-1].text) {
+		issues = append(issues, SyntaxError{LineNumber: lines[n-1].endLine, Message: "expected an indented block"})
+	}
+	return issues
+}
+
+// pythonImports lists imported modules in order; relative imports keep
+// their leading dots
+func pythonImports(lines []pythonLine) []string {
+	imports := []string{}
+	seen := make(map[string]bool)
+	add := func(module string) {
+		if module != "" && !seen[module] {
+			seen[module] = true
+			imports = append(imports, module)
+		}
+	}
+	for _, line := range lines {
+		if m := pythonFromPattern.FindStringSubmatch(line.text); m != nil {
+			add(m[1])
+			continue
+		}
+		if m := pythonImportPattern.FindStringSubmatch(line.text); m != nil {
+			for _, item := range strings.Split(m[1], ",") {
+				module, _, _ := strings.Cut(strings.TrimSpace(item), " ")
+				add(module)
+			}
+		}
+	}
+	return imports
+}
+
+// ResolveImport resolves a module to its file or package __init__.py.
+// Relative modules resolve from the importing file's package; absolute ones
+// from the nearest enclosing directory that contains them.
+func (pythonAnalyzer) ResolveImport(fromFile, importPath string) []string {
+	module := strings.TrimLeft(importPath, ".")
+	dots := len(importPath) - len(module)
+	rel := filepath.FromSlash(strings.ReplaceAll(module, ".", "/"))
+	candidates := func(dir string) []string {
+		if rel == "" {
+			return []string{filepath.Join(dir, "__init__.py")}
+		}
+		base := filepath.Join(dir, rel)
+		return []string{base + ".py", base + ".pyi", filepath.Join(base, "__init__.py")}
+	}
+
+	dir := filepath.Dir(fromFile)
+	if dots > 0 {
+		for i := 1; i < dots; i++ {
+			dir = filepath.Dir(dir)
+		}
+		return firstExisting(candidates(dir)...)
+	}
+	for {
+		if found := firstExisting(candidates(dir)...); found != nil {
+			return found
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
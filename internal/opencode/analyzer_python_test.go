@@ -0,0 +1,178 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package opencode
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const shapesPy = `"""Shapes and their areas."""
+import math, os.path as osp
+from .units import Meters
+from typing import (
+    Optional,
+    List,
+)
+
+MAX_SIDES = 12
+_cache: dict = {}
+
+
+class Rect(Shape):
+    """A rectangle.
+
+Unindented docstring lines do not end the class.
+"""
+
+    def __init__(self, w: Meters, h: Meters = 1):
+        self.w = w
+        self.h = h
+
+    def area(self) -> Meters:
+        if self.w > 0 and self.h > 0:
+            return self.w * self.h
+        return 0
+
+    def _log(self, msg):  # "def fake(): in a comment"
+        print("def fake():", msg)
+
+
+class _Hidden:
+    def visible(self):
+        pass
+
+
+async def describe(shape, *args, verbose: bool = False, **kwargs) -> str:
+    def inner():
+        return 1
+    for x in [1, 2]:
+        try:
+            pass
+        except ValueError:
+            pass
+    return str(shape.area())
+`
+
+func TestPythonAnalyzer_Symbols(t *testing.T) {
+	path := writeSource(t, t.TempDir(), "shapes.py", shapesPy)
+	analysis, err := NewCodeAnalyzer().AnalyzeFile(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.Language != "python" || !analysis.IsValid {
+		t.Fatalf("language %q valid %v, issues %+v", analysis.Language, analysis.IsValid, analysis.Issues)
+	}
+
+	want := strings.Join([]string{
+		"constant MAX_SIDES exported",
+		"variable _cache",
+		"class Rect exported",
+		"method Rect.__init__",
+		"method Rect.area exported",
+		"method Rect._log",
+		"class _Hidden",
+		"method _Hidden.visible",
+		"function describe exported",
+	}, "\n")
+	if got := symbolSummary(analysis.Symbols); got != want {
+		t.Errorf("symbols:\n%s\nwant:\n%s", got, want)
+	}
+
+	rect := symbolByName(analysis.Symbols, "Rect")
+	if rect.LineNumber != 13 || rect.EndLine != 29 || rect.Signature != "Shape" {
+		t.Errorf("Rect spans %d-%d with bases %q", rect.LineNumber, rect.EndLine, rect.Signature)
+	}
+	init := symbolByName(analysis.Symbols, "__init__")
+	if len(init.Parameters) != 2 || init.Parameters[1] != (Parameter{Name: "h", Type: "Meters"}) {
+		t.Errorf("__init__ parameters = %+v", init.Parameters)
+	}
+	describe := symbolByName(analysis.Symbols, "describe")
+	if describe.ReturnType != "str" || len(describe.Parameters) != 4 || describe.Parameters[2].Type != "bool" {
+		t.Errorf("describe signature: %+v returns %q", describe.Parameters, describe.ReturnType)
+	}
+	if describe.EndLine != 45 {
+		t.Errorf("describe ends at %d, want 45", describe.EndLine)
+	}
+
+	wantImports := []string{"math", "os.path", ".units", "typing"}
+	if strings.Join(analysis.Imports, " ") != strings.Join(wantImports, " ") {
+		t.Errorf("imports = %v, want %v", analysis.Imports, wantImports)
+	}
+
+	c := analysis.Complexity
+	// if, and, for, except and the base path
+	if c.CyclomaticComplexity != 5 || c.Functions != 5 || c.NestedDepth != 3 {
+		t.Errorf("complexity = %+v", c)
+	}
+}
+
+func TestPythonAnalyzer_SyntaxErrors(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]string{
+		"no_body.py":  "def f():\nreturn 1\n",
+		"indent.py":   "x = 1\n    y = 2\n",
+		"dedent.py":   "if x:\n        a = 1\n    b = 2\n",
+		"bracket.py":  "x = [1, 2\n",
+		"string.py":   "x = 'open\n",
+		"trailing.py": "class A:\n",
+	}
+	for name, content := range tests {
+		valid, issues, err := NewCodeAnalyzer().ValidateSyntax(context.Background(), writeSource(t, dir, name, content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if valid || len(issues) == 0 {
+			t.Errorf("%s should be invalid", name)
+		}
+	}
+}
+
+func TestPythonAnalyzer_ResolveImport(t *testing.T) {
+	dir := t.TempDir()
+	from := writeSource(t, dir, "app/shapes/rect.py", "")
+	units := writeSource(t, dir, "app/shapes/units.py", "")
+	pkg := writeSource(t, dir, "app/__init__.py", "")
+	util := writeSource(t, dir, "app/util/strings.py", "")
+
+	analyzer := &DefaultCodeAnalyzer{}
+	tests := map[string]string{
+		".units":           units,
+		"..":               pkg,
+		"..util.strings":   util,
+		"app.util.strings": util,
+		"app":              pkg,
+		"requests":         "",
+	}
+	for importPath, want := range tests {
+		got := analyzer.ResolveImport(from, importPath)
+		if strings.Join(got, "") != want {
+			t.Errorf("ResolveImport(%q) = %v, want %q", importPath, got, want)
+		}
+	}
+}
+
+func TestIsTestFile(t *testing.T) {
+	tests := map[string]bool{
+		"pkg/shapes/shapes_test.go":   true,
+		"pkg/shapes/shapes.go":        false,
+		"src/rect.test.ts":            true,
+		"src/rect.spec.jsx":           true,
+		"src/__tests__/rect.js":       true,
+		"src/rect.ts":                 false,
+		"tests/test_rect.py":          true,
+		"app/rect_test.py":            true,
+		"app/rect.py":                 false,
+		filepath.Join("docs", "x.md"): false,
+	}
+	for path, want := range tests {
+		if got := IsTestFile(path); got != want {
+			t.Errorf("IsTestFile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package opencode
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeFiles_MixedLanguages(t *testing.T) {
+	dir := t.TempDir()
+	writeSource(t, dir, "go.mod", "module example.com/shop\n\ngo 1.22\n")
+	mainGo := writeSource(t, dir, "cmd/shop/main.go", "package main\n\nimport \"example.com/shop/cart\"\n\nfunc main() { cart.New() }\n")
+	cartGo := writeSource(t, dir, "cart/cart.go", "package cart\n\nimport \"fmt\"\n\nfunc New() { fmt.Println() }\n")
+	cartTest := writeSource(t, dir, "cart/cart_test.go", "package cart\n")
+	appTS := writeSource(t, dir, "web/app.ts", "import { get } from \"./api\";\nimport React from \"react\";\n")
+	apiTS := writeSource(t, dir, "web/api.ts", "export function get() {}\n")
+	svcPy := writeSource(t, dir, "svc/main.py", "from .models import Item\nimport json\n")
+	modelsPy := writeSource(t, dir, "svc/models.py", "class Item:\n    pass\n")
+	readme := writeSource(t, dir, "README.md", "# shop\n")
+
+	files := []string{mainGo, cartGo, cartTest, appTS, apiTS, svcPy, modelsPy, readme}
+	project, err := NewCodeAnalyzer().AnalyzeFiles(context.Background(), files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(project.Files) != len(files) {
+		t.Fatalf("analyzed %d files, want %d", len(project.Files), len(files))
+	}
+
+	rel := func(path string) string {
+		r, _ := filepath.Rel(dir, path)
+		return filepath.ToSlash(r)
+	}
+	var edges []string
+	for _, dep := range project.Relationships {
+		edges = append(edges, rel(dep.From)+" -> "+rel(dep.To))
+	}
+	sort.Strings(edges)
+	want := strings.Join([]string{
+		"cmd/shop/main.go -> cart/cart.go",
+		"svc/main.py -> svc/models.py",
+		"web/app.ts -> web/api.ts",
+	}, "\n")
+	if got := strings.Join(edges, "\n"); got != want {
+		t.Errorf("relationships:\n%s\nwant:\n%s", got, want)
+	}
+
+	if len(project.MainPackages) != 1 || project.MainPackages[0] != mainGo {
+		t.Errorf("main packages = %v", project.MainPackages)
+	}
+	for _, file := range project.Files {
+		if file.FilePath == readme && file.IsValid {
+			t.Error("files without an analyzer should be reported invalid")
+		}
+	}
+}
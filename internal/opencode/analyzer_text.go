@@ -0,0 +1,241 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package opencode
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// sourceSyntax describes the comments and strings of a language for
+// maskSource
+type sourceSyntax struct {
+	lineComment   string // e.g. "//" or "#"
+	blockComments bool   // /* ... */
+	quotes        string // String delimiters, e.g. `'"` + "`"
+	tripleQuotes  bool   // Python's ''' and """ strings
+}
+
+// maskedSource is source text with comments replaced by spaces. Offsets and
+// lines match the original text.
+type maskedSource struct {
+	withStrings string // Comments masked, strings kept (for imports)
+	code        string // Comments and string contents masked
+	issues      []SyntaxError
+}
+
+// maskSource masks the comments and string contents of content, so that
+// declarations and brackets can be found with simple scans
+func maskSource(content string, syntax sourceSyntax) maskedSource {
+	withStrings := []byte(content)
+	code := []byte(content)
+	var issues []SyntaxError
+	blank := func(from, to int, keepInStrings bool) {
+		for k := from; k < to && k < len(content); k++ {
+			if content[k] == '\n' {
+				continue
+			}
+			code[k] = ' '
+			if !keepInStrings {
+				withStrings[k] = ' '
+			}
+		}
+	}
+
+	for i := 0; i < len(content); {
+		switch {
+		case syntax.lineComment != "" && strings.HasPrefix(content[i:], syntax.lineComment):
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				end = len(content) - i
+			}
+			blank(i, i+end, false)
+			i += end
+
+		case syntax.blockComments && strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				issues = append(issues, syntaxErrorAt(content, i, "unterminated comment"))
+				blank(i, len(content), false)
+				i = len(content)
+				continue
+			}
+			blank(i, i+end+4, false)
+			i += end + 4
+
+		case strings.IndexByte(syntax.quotes, content[i]) >= 0:
+			quote := content[i : i+1]
+			if syntax.tripleQuotes && strings.HasPrefix(content[i:], strings.Repeat(quote, 3)) {
+				quote = strings.Repeat(quote, 3)
+			}
+			end := stringEnd(content, i+len(quote), quote)
+			if end < 0 {
+				issues = append(issues, syntaxErrorAt(content, i, "unterminated string"))
+				blank(i+len(quote), len(content), true)
+				i = len(content)
+				continue
+			}
+			blank(i+len(quote), end, true)
+			i = end + len(quote)
+
+		default:
+			i++
+		}
+	}
+	return maskedSource{withStrings: string(withStrings), code: string(code), issues: issues}
+}
+
+// stringEnd returns the offset of the quote closing a string that starts at
+// from, or -1. Single-character quotes other than the backtick end at a
+// newline.
+func stringEnd(content string, from int, quote string) int {
+	for k := from; k < len(content); k++ {
+		switch {
+		case content[k] == '\\':
+			k++
+		case strings.HasPrefix(content[k:], quote):
+			return k
+		case content[k] == '\n' && len(quote) == 1 && quote != "`":
+			return -1
+		}
+	}
+	return -1
+}
+
+// syntaxErrorAt reports an error at a byte offset
+func syntaxErrorAt(content string, offset int, message string) SyntaxError {
+	line := strings.Count(content[:offset], "\n") + 1
+	column := offset - strings.LastIndexByte(content[:offset], '\n')
+	return SyntaxError{LineNumber: line, Column: column, Message: message}
+}
+
+// lineAt returns the 1-based line of a byte offset
+func lineAt(content string, offset int) int {
+	return strings.Count(content[:offset], "\n") + 1
+}
+
+// checkBrackets reports unbalanced brackets in masked code
+func checkBrackets(code string) []SyntaxError {
+	pairs := map[byte]byte{')': '(', ']': '[', '}': '{'}
+	var stack []int
+	var issues []SyntaxError
+	for i := 0; i < len(code); i++ {
+		switch c := code[i]; c {
+		case '(', '[', '{':
+			stack = append(stack, i)
+		case ')', ']', '}':
+			if len(stack) == 0 || code[stack[len(stack)-1]] != pairs[c] {
+				issues = append(issues, syntaxErrorAt(code, i, fmt.Sprintf("unexpected %q", c)))
+				return issues
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	for _, open := range stack {
+		issues = append(issues, syntaxErrorAt(code, open, fmt.Sprintf("unclosed %q", code[open])))
+	}
+	return issues
+}
+
+// matchingClose returns the offset of the bracket closing the one at open,
+// or -1
+func matchingClose(code string, open int) int {
+	depth := 0
+	for k := open; k < len(code); k++ {
+		switch code[k] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				return k
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits s at commas outside brackets. Angle brackets count,
+// except in "=>".
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[', '{', '<':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case '>':
+			if i == 0 || s[i-1] != '=' {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, s[start:])
+
+	var trimmed []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			trimmed = append(trimmed, part)
+		}
+	}
+	return trimmed
+}
+
+// cutTopLevel splits s at the first sep outside brackets
+func cutTopLevel(s string, sep byte) (string, string, bool) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[', '{', '<':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case '>':
+			if i == 0 || s[i-1] != '=' {
+				depth--
+			}
+		case sep:
+			if depth == 0 && !(sep == '=' && i+1 < len(s) && s[i+1] == '>') {
+				return s[:i], s[i+1:], true
+			}
+		}
+	}
+	return s, "", false
+}
+
+// averageFunctionSize returns the mean line span of the function and
+// method symbols, and how many there are
+func averageFunctionSize(symbols []*Symbol) (int, int) {
+	functions, lines := 0, 0
+	for _, symbol := range symbols {
+		if symbol.Kind == SymbolFunction || symbol.Kind == SymbolMethod {
+			functions++
+			lines += symbol.EndLine - symbol.LineNumber + 1
+		}
+	}
+	if functions == 0 {
+		return 0, 0
+	}
+	return lines / functions, functions
+}
+
+// firstExisting returns the first candidate path that is a regular file
+func firstExisting(candidates ...string) []string {
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return []string{candidate}
+		}
+	}
+	return nil
+}
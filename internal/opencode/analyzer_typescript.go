@@ -0,0 +1,468 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package opencode
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// scriptAnalyzer analyzes TypeScript or JavaScript files. It scans
+// declarations line by line rather than parsing, which is enough for
+// symbols, imports and complexity.
+type scriptAnalyzer struct {
+	language   string
+	extensions []string
+}
+
+var (
+	typeScriptAnalyzer = scriptAnalyzer{language: "typescript", extensions: []string{".ts", ".tsx", ".mts", ".cts"}}
+	javaScriptAnalyzer = scriptAnalyzer{language: "javascript", extensions: []string{".js", ".jsx", ".mjs", ".cjs"}}
+)
+
+var scriptSyntax = sourceSyntax{lineComment: "//", blockComments: true, quotes: "'\"`"}
+
+// scriptResolveExtensions is the order module paths without an extension
+// are tried in
+var scriptResolveExtensions = []string{".ts", ".tsx", ".mts", ".cts", ".js", ".jsx", ".mjs", ".cjs"}
+
+const scriptIdent = `[A-Za-z_$][\w$]*`
+
+var (
+	scriptFuncPattern      = regexp.MustCompile(`^(export\s+)?(default\s+)?(declare\s+)?(async\s+)?function\s*\*?\s*(` + scriptIdent + `)`)
+	scriptClassPattern     = regexp.MustCompile(`^(export\s+)?(default\s+)?(declare\s+)?(abstract\s+)?class\s+(` + scriptIdent + `)`)
+	scriptInterfacePattern = regexp.MustCompile(`^(export\s+)?(declare\s+)?interface\s+(` + scriptIdent + `)`)
+	scriptTypePattern      = regexp.MustCompile(`^(export\s+)?(declare\s+)?type\s+(` + scriptIdent + `)\s*(<.*>)?\s*=`)
+	scriptEnumPattern      = regexp.MustCompile(`^(export\s+)?(declare\s+)?(const\s+)?enum\s+(` + scriptIdent + `)`)
+	scriptVarPattern       = regexp.MustCompile(`^(export\s+)?(declare\s+)?(const|let|var)\s+(` + scriptIdent + `)\s*(:[^=]+)?=\s*(.*)`)
+	scriptArrowPattern     = regexp.MustCompile(`^(async\s+)?(function\b|(<[^>]*>\s*)?\(|` + scriptIdent + `\s*=>)`)
+	scriptMemberPattern    = regexp.MustCompile(`^((?:(?:public|private|protected|static|readonly|abstract|async|override|declare|get|set)\s+)*)\*?\s*(#?` + scriptIdent + `)\s*(<[^(]*>)?\s*(\(|[?!]?\s*(:[^=]+)?=\s*(async\s+)?(\(|function\b|` + scriptIdent + `\s*=>))`)
+
+	scriptExportListPattern   = regexp.MustCompile(`(?m)^\s*export\s*\{([^}]*)\}`)
+	scriptExportDefaultIdent  = regexp.MustCompile(`(?m)^\s*export\s+default\s+(` + scriptIdent + `)\s*;?\s*$`)
+	scriptCommonJSExport      = regexp.MustCompile(`(?:module\.)?exports\.(` + scriptIdent + `)\s*=`)
+	scriptCommonJSExportsList = regexp.MustCompile(`module\.exports\s*=\s*\{([^}]*)\}`)
+
+	scriptImportPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?m)^\s*import\s+(?:type\s+)?(?:[\w$*{}\s,]+?\s+from\s+)?['"]([^'"]+)['"]`),
+		regexp.MustCompile(`(?m)^\s*export\s+(?:type\s+)?(?:\*(?:\s+as\s+[\w$]+)?|\{[^}]*\})\s*from\s+['"]([^'"]+)['"]`),
+		regexp.MustCompile(`\brequire\(\s*['"]([^'"]+)['"]\s*\)`),
+		regexp.MustCompile(`\bimport\(\s*['"]([^'"]+)['"]\s*\)`),
+	}
+
+	scriptDecisionPattern = regexp.MustCompile(`\b(if|for|while|case|catch)\b|&&|\|\||\?\?`)
+)
+
+// scriptKeywords are words that look like method names in a class body
+var scriptKeywords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true,
+	"return": true, "function": true, "new": true, "typeof": true, "super": true,
+}
+
+func (a scriptAnalyzer) Language() string { return a.language }
+
+func (a scriptAnalyzer) Extensions() []string { return a.extensions }
+
+// IsTestFile matches *.test.* and *.spec.* files and files under __tests__
+func (scriptAnalyzer) IsTestFile(filePath string) bool {
+	base := filepath.Base(filePath)
+	return strings.Contains(base, ".test.") || strings.Contains(base, ".spec.") ||
+		strings.Contains(filepath.ToSlash(filePath), "/__tests__/")
+}
+
+// Analyze scans a TypeScript or JavaScript file
+func (a scriptAnalyzer) Analyze(filePath string, content []byte) *CodeAnalysis {
+	text := string(content)
+	masked := maskSource(text, scriptSyntax)
+	issues := append(masked.issues, checkBrackets(masked.code)...)
+
+	analysis := &CodeAnalysis{
+		FilePath: filePath,
+		Language: a.language,
+		Symbols:  scriptSymbols(filePath, masked.code),
+		Imports:  scriptImports(masked.withStrings),
+		IsValid:  len(issues) == 0,
+		Issues:   append([]SyntaxError{}, issues...),
+	}
+	markScriptExports(analysis.Symbols, masked.code)
+	analysis.Complexity = scriptComplexity(text, masked.code, analysis.Symbols)
+	return analysis
+}
+
+// scriptSymbols finds the top-level declarations and class members
+func scriptSymbols(filePath, code string) []*Symbol {
+	lines := strings.SplitAfter(code, "\n")
+	depths := make([]int, len(lines)) // Bracket depth at the start of each line
+	offsets := make([]int, len(lines))
+	depth, offset := 0, 0
+	for i, line := range lines {
+		depths[i], offsets[i] = depth, offset
+		for k := 0; k < len(line); k++ {
+			switch line[k] {
+			case '(', '[', '{':
+				depth++
+			case ')', ']', '}':
+				depth--
+			}
+		}
+		offset += len(line)
+	}
+
+	symbols := []*Symbol{}
+	for i := 0; i < len(lines); i++ {
+		if depths[i] != 0 {
+			continue
+		}
+		line := strings.TrimSpace(lines[i])
+		start := offsets[i] + strings.Index(lines[i], line)
+		symbol := scriptDeclaration(line)
+		if symbol == nil {
+			continue
+		}
+		symbol.FilePath = filePath
+		end := scriptStatementEnd(code, start)
+		symbol.LineNumber = i + 1
+		symbol.EndLine = lineAt(code, end)
+		if symbol.Kind == SymbolFunction {
+			scriptSignature(symbol, code[:end+1], start)
+		}
+		symbols = append(symbols, symbol)
+
+		if symbol.Kind == SymbolClass {
+			for j := i + 1; j < symbol.EndLine-1 && j < len(lines); j++ {
+				if depths[j] != 1 {
+					continue
+				}
+				member := strings.TrimSpace(lines[j])
+				method := scriptMethod(member, symbol)
+				if method == nil {
+					continue
+				}
+				memberStart := offsets[j] + strings.Index(lines[j], member)
+				method.FilePath = filePath
+				memberEnd := scriptStatementEnd(code, memberStart)
+				method.LineNumber = j + 1
+				method.EndLine = lineAt(code, memberEnd)
+				scriptSignature(method, code[:memberEnd+1], memberStart)
+				symbols = append(symbols, method)
+			}
+		}
+		i = symbol.EndLine - 1
+	}
+	return symbols
+}
+
+// scriptDeclaration matches a top-level declaration
+func scriptDeclaration(line string) *Symbol {
+	exported := strings.HasPrefix(line, "export ")
+	if m := scriptFuncPattern.FindStringSubmatch(line); m != nil {
+		return &Symbol{Name: m[5], Kind: SymbolFunction, Exported: exported}
+	}
+	if m := scriptClassPattern.FindStringSubmatch(line); m != nil {
+		return &Symbol{Name: m[5], Kind: SymbolClass, Exported: exported, Signature: scriptHeader(line)}
+	}
+	if m := scriptInterfacePattern.FindStringSubmatch(line); m != nil {
+		return &Symbol{Name: m[3], Kind: SymbolInterface, Exported: exported, Signature: scriptHeader(line)}
+	}
+	if m := scriptTypePattern.FindStringSubmatch(line); m != nil {
+		_, value, _ := strings.Cut(line, "=")
+		return &Symbol{Name: m[3], Kind: SymbolType, Exported: exported, Signature: strings.TrimSuffix(strings.TrimSpace(value), ";")}
+	}
+	if m := scriptEnumPattern.FindStringSubmatch(line); m != nil {
+		return &Symbol{Name: m[4], Kind: SymbolType, Exported: exported, Signature: scriptHeader(line)}
+	}
+	if m := scriptVarPattern.FindStringSubmatch(line); m != nil {
+		symbol := &Symbol{Name: m[4], Kind: SymbolVariable, Exported: exported}
+		if m[3] == "const" {
+			symbol.Kind = SymbolConstant
+		}
+		if m[5] != "" {
+			symbol.Signature = strings.TrimSpace(strings.TrimPrefix(m[5], ":"))
+		}
+		value := strings.TrimPrefix(m[6], "async ")
+		if scriptArrowPattern.MatchString(m[6]) && (strings.HasPrefix(value, "function") || strings.Contains(value, "=>")) {
+			symbol.Kind = SymbolFunction
+		}
+		return symbol
+	}
+	return nil
+}
+
+// scriptMethod matches a method or function-valued property in a class
+// body. Public members are marked exported; markScriptExports unmarks those
+// of classes that are not exported.
+func scriptMethod(line string, class *Symbol) *Symbol {
+	m := scriptMemberPattern.FindStringSubmatch(line)
+	if m == nil || scriptKeywords[m[2]] {
+		return nil
+	}
+	private := strings.Contains(m[1], "private") || strings.HasPrefix(m[2], "#")
+	return &Symbol{
+		Name:     m[2],
+		Kind:     SymbolMethod,
+		Receiver: class.Name,
+		Exported: !private,
+	}
+}
+
+// scriptSignature fills a function's parameters, return type and
+// signature from the declaration starting at start; code ends with the
+// declaration
+func scriptSignature(symbol *Symbol, code string, start int) {
+	open := strings.IndexByte(code[start:], '(')
+	if open < 0 {
+		return
+	}
+	open += start
+	if nameAt := strings.Index(code[start:], symbol.Name); nameAt < 0 || start+nameAt > open {
+		return
+	}
+	closing := matchingClose(code, open)
+	if closing < 0 {
+		return
+	}
+	symbol.Parameters = nil
+	for _, param := range splitTopLevel(code[open+1 : closing]) {
+		param, _, _ = cutTopLevel(param, '=')
+		name, paramType, _ := cutTopLevel(param, ':')
+		name = strings.TrimSpace(name)
+		for _, modifier := range []string{"public ", "private ", "protected ", "readonly ", "..."} {
+			name = strings.TrimSpace(strings.TrimPrefix(name, modifier))
+		}
+		symbol.Parameters = append(symbol.Parameters, Parameter{
+			Name: strings.TrimSuffix(name, "?"),
+			Type: strings.TrimSpace(paramType),
+		})
+	}
+
+	rest := strings.TrimSpace(code[closing+1:])
+	if strings.HasPrefix(rest, ":") {
+		returnType := rest[1:]
+		if end := scriptReturnTypeEnd(returnType); end >= 0 {
+			returnType = returnType[:end]
+		}
+		symbol.ReturnType = strings.TrimSpace(returnType)
+	}
+	params := make([]string, 0, len(symbol.Parameters))
+	for _, param := range symbol.Parameters {
+		if param.Type != "" {
+			params = append(params, param.Name+": "+param.Type)
+		} else {
+			params = append(params, param.Name)
+		}
+	}
+	symbol.Signature = "(" + strings.Join(params, ", ") + ")"
+	if symbol.ReturnType != "" {
+		symbol.Signature += ": " + symbol.ReturnType
+	}
+}
+
+// scriptReturnTypeEnd returns where a return type annotation ends: at the
+// body's "{", an arrow or a statement end outside brackets
+func scriptReturnTypeEnd(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[', '<':
+			depth++
+		case ')', ']':
+			depth--
+		case '>':
+			if i == 0 || s[i-1] != '=' {
+				depth--
+			}
+		case '{':
+			if depth == 0 && i > 0 && strings.TrimSpace(s[:i]) != "" {
+				return i
+			}
+			depth++
+		case '}':
+			depth--
+		case '=':
+			if depth == 0 && i+1 < len(s) && s[i+1] == '>' {
+				return i
+			}
+		case ';', '\n':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// scriptStatementEnd returns the offset where the statement starting at
+// start ends: after its top-level block closes, at a top-level ";", or at a
+// line end that does not continue the statement
+func scriptStatementEnd(code string, start int) int {
+	depth := 0
+	sawBlock := false
+	for k := start; k < len(code); k++ {
+		switch c := code[k]; c {
+		case '(', '[', '{':
+			if c == '{' && depth == 0 {
+				sawBlock = true
+			}
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth <= 0 && sawBlock && c == '}' {
+				return k
+			}
+			if depth < 0 {
+				return k
+			}
+		case ';':
+			if depth == 0 {
+				return k
+			}
+		case '\n':
+			if depth == 0 && !sawBlock && !scriptContinues(code[start:k], code[k+1:]) {
+				return k
+			}
+		}
+	}
+	return len(code) - 1
+}
+
+// scriptContinues reports whether a statement continues on the next line
+func scriptContinues(before, after string) bool {
+	last := strings.TrimSpace(before)
+	for _, suffix := range []string{"=", ",", "=>", "(", "|", "&", "+", "-", "?", ":", "extends", "implements"} {
+		if strings.HasSuffix(last, suffix) {
+			return true
+		}
+	}
+	next := strings.TrimSpace(after)
+	for _, prefix := range []string{"{", ".", "|", "&", "?", ":", "=>", "extends", "implements"} {
+		if strings.HasPrefix(next, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// scriptHeader is a declaration line without its opening brace
+func scriptHeader(line string) string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), "{"))
+}
+
+// markScriptExports marks symbols exported by export lists, export default
+// and CommonJS exports
+func markScriptExports(symbols []*Symbol, code string) {
+	names := make(map[string]bool)
+	for _, m := range scriptExportListPattern.FindAllStringSubmatch(code, -1) {
+		for _, item := range strings.Split(m[1], ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(item), " ")
+			names[strings.TrimPrefix(name, "type ")] = true
+		}
+	}
+	for _, m := range scriptExportDefaultIdent.FindAllStringSubmatch(code, -1) {
+		names[m[1]] = true
+	}
+	for _, m := range scriptCommonJSExport.FindAllStringSubmatch(code, -1) {
+		names[m[1]] = true
+	}
+	for _, m := range scriptCommonJSExportsList.FindAllStringSubmatch(code, -1) {
+		for _, item := range strings.Split(m[1], ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(item), ":")
+			names[strings.TrimSpace(name)] = true
+		}
+	}
+
+	exportedClasses := make(map[string]bool)
+	for _, symbol := range symbols {
+		if symbol.Receiver == "" && names[symbol.Name] {
+			symbol.Exported = true
+		}
+		if symbol.Kind == SymbolClass && symbol.Exported {
+			exportedClasses[symbol.Name] = true
+		}
+	}
+	for _, symbol := range symbols {
+		if symbol.Kind == SymbolMethod {
+			symbol.Exported = symbol.Exported && exportedClasses[symbol.Receiver]
+		}
+	}
+}
+
+// scriptImports lists the module specifiers a file imports, in order
+func scriptImports(withStrings string) []string {
+	type found struct {
+		offset int
+		path   string
+	}
+	var all []found
+	for _, pattern := range scriptImportPatterns {
+		for _, m := range pattern.FindAllStringSubmatchIndex(withStrings, -1) {
+			all = append(all, found{offset: m[2], path: withStrings[m[2]:m[3]]})
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].offset < all[j].offset })
+
+	imports := []string{}
+	seen := make(map[string]bool)
+	for _, f := range all {
+		if !seen[f.path] {
+			seen[f.path] = true
+			imports = append(imports, f.path)
+		}
+	}
+	return imports
+}
+
+// scriptComplexity counts decision points, functions and brace nesting
+func scriptComplexity(text, code string, symbols []*Symbol) ComplexityMetrics {
+	metrics := ComplexityMetrics{
+		LinesOfCode:          len(strings.Split(text, "\n")),
+		CyclomaticComplexity: 1 + len(scriptDecisionPattern.FindAllStringIndex(code, -1)),
+	}
+	metrics.AverageFunctionSize, metrics.Functions = averageFunctionSize(symbols)
+
+	depth := 0
+	for i := 0; i < len(code); i++ {
+		switch code[i] {
+		case '{':
+			depth++
+			metrics.NestedDepth = max(metrics.NestedDepth, depth)
+		case '}':
+			depth--
+		}
+	}
+	return metrics
+}
+
+// ResolveImport resolves a relative module specifier the way Node and
+// TypeScript do: the exact file, the file with an extension, then an index
+// file. Package imports resolve to nothing.
+func (scriptAnalyzer) ResolveImport(fromFile, importPath string) []string {
+	if importPath != "." && importPath != ".." && !strings.HasPrefix(importPath, "./") && !strings.HasPrefix(importPath, "../") {
+		return nil
+	}
+	base := filepath.Join(filepath.Dir(fromFile), filepath.FromSlash(importPath))
+
+	var candidates []string
+	switch ext := filepath.Ext(base); ext {
+	case ".js", ".jsx", ".mjs", ".cjs":
+		// TypeScript sources import their compiled .js names
+		stem := strings.TrimSuffix(base, ext)
+		candidates = append(candidates, base, stem+strings.Replace(ext, "j", "t", 1))
+		if ext == ".js" {
+			candidates = append(candidates, stem+".tsx")
+		}
+	default:
+		candidates = append(candidates, base)
+	}
+	for _, ext := range scriptResolveExtensions {
+		candidates = append(candidates, base+ext)
+	}
+	for _, ext := range scriptResolveExtensions {
+		candidates = append(candidates, filepath.Join(base, "index"+ext))
+	}
+	return firstExisting(candidates...)
+}
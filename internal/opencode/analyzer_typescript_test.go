@@ -0,0 +1,225 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package opencode
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const shapesTS = `import { Meters } from "./units";
+import type { Logger } from '../log/logger';
+import * as path from "path";
+const fs = require("fs");
+
+// Shape is anything with an area
+export interface Shape {
+  area(): Meters;
+}
+
+export type Color = "red" | "green";
+
+export enum Kind { Square, Circle }
+
+export const MAX_SIDES = 12;
+let counter = 0;
+
+/* A rectangle; "{" in comments and strings does not count */
+export class Rect implements Shape {
+  private cache = new Map<string, number>();
+
+  constructor(public w: Meters, public h: Meters) {}
+
+  area(): Meters {
+    if (this.w > 0 && this.h > 0) {
+      return this.w * this.h;
+    }
+    return 0;
+  }
+
+  private log(msg: string): void {
+    console.log("rect: {" + msg);
+  }
+
+  scale = (factor: number): Rect => {
+    return new Rect(this.w * factor, this.h * factor);
+  };
+}
+
+export async function describe(shape: Shape, opts?: { verbose: boolean }): Promise<string> {
+  for (const x of [1, 2]) {
+    counter += x;
+  }
+  return String(shape.area() ?? 0);
+}
+
+const helper = (a: number, b = 2) => a + b;
+
+function internal() {
+  return helper(1);
+}
+`
+
+func writeSource(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func symbolSummary(symbols []*Symbol) string {
+	var lines []string
+	for _, s := range symbols {
+		name := s.Name
+		if s.Receiver != "" {
+			name = s.Receiver + "." + name
+		}
+		exported := ""
+		if s.Exported {
+			exported = " exported"
+		}
+		lines = append(lines, string(s.Kind)+" "+name+exported)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func symbolByName(symbols []*Symbol, name string) *Symbol {
+	for _, s := range symbols {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func TestTypeScriptAnalyzer_Symbols(t *testing.T) {
+	path := writeSource(t, t.TempDir(), "shapes.ts", shapesTS)
+	analysis, err := NewCodeAnalyzer().AnalyzeFile(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.Language != "typescript" || !analysis.IsValid {
+		t.Fatalf("language %q valid %v, issues %+v", analysis.Language, analysis.IsValid, analysis.Issues)
+	}
+
+	want := strings.Join([]string{
+		"constant fs",
+		"interface Shape exported",
+		"type Color exported",
+		"type Kind exported",
+		"constant MAX_SIDES exported",
+		"variable counter",
+		"class Rect exported",
+		"method Rect.constructor exported",
+		"method Rect.area exported",
+		"method Rect.log",
+		"method Rect.scale exported",
+		"function describe exported",
+		"function helper",
+		"function internal",
+	}, "\n")
+	if got := symbolSummary(analysis.Symbols); got != want {
+		t.Errorf("symbols:\n%s\nwant:\n%s", got, want)
+	}
+
+	describe := symbolByName(analysis.Symbols, "describe")
+	if describe.LineNumber != 40 || describe.EndLine != 45 {
+		t.Errorf("describe spans %d-%d, want 40-45", describe.LineNumber, describe.EndLine)
+	}
+	if describe.ReturnType != "Promise<string>" || len(describe.Parameters) != 2 ||
+		describe.Parameters[1] != (Parameter{Name: "opts", Type: "{ verbose: boolean }"}) {
+		t.Errorf("describe signature: %+v returns %q", describe.Parameters, describe.ReturnType)
+	}
+	if rect := symbolByName(analysis.Symbols, "Rect"); rect.EndLine != 38 {
+		t.Errorf("Rect ends at %d, want 38", rect.EndLine)
+	}
+	if helper := symbolByName(analysis.Symbols, "helper"); helper.Signature != "(a: number, b)" {
+		t.Errorf("helper signature = %q", helper.Signature)
+	}
+
+	wantImports := []string{"./units", "../log/logger", "path", "fs"}
+	if strings.Join(analysis.Imports, " ") != strings.Join(wantImports, " ") {
+		t.Errorf("imports = %v, want %v", analysis.Imports, wantImports)
+	}
+
+	c := analysis.Complexity
+	// if, &&, for, ?? and the base path
+	if c.CyclomaticComplexity != 5 || c.Functions != 7 || c.NestedDepth != 3 {
+		t.Errorf("complexity = %+v", c)
+	}
+}
+
+func TestTypeScriptAnalyzer_ExportListsAndCommonJS(t *testing.T) {
+	dir := t.TempDir()
+	esm := writeSource(t, dir, "a.js", "function one() {}\nfunction two() {}\nclass Three {\n  run() {}\n}\nexport { one, Three as Four };\nexport default two;\n")
+	cjs := writeSource(t, dir, "b.cjs", "function one() {}\nfunction two() {}\nexports.three = 3;\nmodule.exports = { one };\n")
+
+	analyzer := NewCodeAnalyzer()
+	for path, want := range map[string]string{
+		esm: "function one exported\nfunction two exported\nclass Three exported\nmethod Three.run exported",
+		cjs: "function one exported\nfunction two",
+	} {
+		analysis, err := analyzer.AnalyzeFile(context.Background(), path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if analysis.Language != "javascript" {
+			t.Errorf("%s language = %q", filepath.Base(path), analysis.Language)
+		}
+		if got := symbolSummary(analysis.Symbols); got != want {
+			t.Errorf("%s symbols:\n%s\nwant:\n%s", filepath.Base(path), got, want)
+		}
+	}
+}
+
+func TestTypeScriptAnalyzer_SyntaxErrors(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]string{
+		"unclosed.ts":   "function f() {\n  return 1;\n",
+		"mismatched.ts": "const x = [1, 2);\n",
+		"string.ts":     "const s = \"open\nconst t = 1;\n",
+	}
+	for name, content := range tests {
+		valid, issues, err := NewCodeAnalyzer().ValidateSyntax(context.Background(), writeSource(t, dir, name, content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if valid || len(issues) == 0 {
+			t.Errorf("%s should be invalid", name)
+		}
+	}
+}
+
+func TestTypeScriptAnalyzer_ResolveImport(t *testing.T) {
+	dir := t.TempDir()
+	from := writeSource(t, dir, "src/app.ts", "")
+	units := writeSource(t, dir, "src/units.ts", "")
+	index := writeSource(t, dir, "src/lib/index.tsx", "")
+	compiled := writeSource(t, dir, "src/compiled.ts", "")
+
+	analyzer := &DefaultCodeAnalyzer{}
+	tests := map[string]string{
+		"./units":       units,
+		"./lib":         index,
+		"./compiled.js": compiled,
+		"react":         "",
+		"./missing":     "",
+	}
+	for importPath, want := range tests {
+		got := analyzer.ResolveImport(from, importPath)
+		if strings.Join(got, "") != want {
+			t.Errorf("ResolveImport(%q) = %v, want %q", importPath, got, want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package opencode
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+// APIChangeKind classifies a change to an exported symbol
+type APIChangeKind string
+
+const (
+	// APIAdded is a new exported symbol (compatible)
+	APIAdded APIChangeKind = "added"
+	// APIRemoved is an exported symbol that no longer exists (breaking)
+	APIRemoved APIChangeKind = "removed"
+	// APIChanged is an exported symbol whose signature changed (potentially breaking)
+	APIChanged APIChangeKind = "changed"
+)
+
+// APIChange describes one change to the exported surface of a file
+type APIChange struct {
+	Name   string        // Symbol name, "Recv.Method" for methods
+	Kind   SymbolKind    // Kind of symbol
+	Change APIChangeKind // What happened to it
+	Before string        // Signature before the change
+	After  string        // Signature after the change
+}
+
+// APIDiff is the difference between two versions of a file's exported symbols
+type APIDiff struct {
+	FilePath string
+	Changes  []APIChange
+}
+
+// Breaking reports whether any change can break existing callers
+func (d *APIDiff) Breaking() bool {
+	if d == nil {
+		return false
+	}
+	for _, change := range d.Changes {
+		if change.Change != APIAdded {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary renders the diff as a short report, one change per line
+func (d *APIDiff) Summary() string {
+	if d == nil || len(d.Changes) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s:\n", d.FilePath))
+	for _, change := range d.Changes {
+		switch change.Change {
+		case APIAdded:
+			sb.WriteString(fmt.Sprintf("  + %s %s %s\n", change.Kind, change.Name, change.After))
+		case APIRemoved:
+			sb.WriteString(fmt.Sprintf("  - %s %s %s\n", change.Kind, change.Name, change.Before))
+		default:
+			sb.WriteString(fmt.Sprintf("  ~ %s %s: %s -> %s\n", change.Kind, change.Name, change.Before, change.After))
+		}
+	}
+	return sb.String()
+}
+
+// DiffExportedAPI compares the exported symbols of two versions of a Go file.
+// An empty path stands for a file that does not exist on that side.
+func (a *DefaultCodeAnalyzer) DiffExportedAPI(ctx context.Context, beforePath, afterPath string) (*APIDiff, error) {
+	load := func(path string) ([]*Symbol, error) {
+		if path == "" {
+			return nil, nil
+		}
+		analysis, err := a.AnalyzeFile(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if !analysis.IsValid {
+			return nil, fmt.Errorf("cannot parse %s", path)
+		}
+		return analysis.Symbols, nil
+	}
+
+	before, err := load(beforePath)
+	if err != nil {
+		return nil, err
+	}
+	after, err := load(afterPath)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := afterPath
+	if filePath == "" {
+		filePath = beforePath
+	}
+	return &APIDiff{FilePath: filePath, Changes: DiffExportedSymbols(before, after)}, nil
+}
+
+// DiffExportedSymbols compares two symbol sets, ignoring unexported symbols
+// and methods on unexported receivers. Changes are sorted by name.
+func DiffExportedSymbols(before, after []*Symbol) []APIChange {
+	oldSymbols := exportedSymbols(before)
+	newSymbols := exportedSymbols(after)
+
+	var changes []APIChange
+	for name, old := range oldSymbols {
+		cur, ok := newSymbols[name]
+		switch {
+		case !ok:
+			changes = append(changes, APIChange{Name: name, Kind: old.Kind, Change: APIRemoved, Before: old.Signature})
+		case old.Kind != cur.Kind || old.Signature != cur.Signature:
+			changes = append(changes, APIChange{Name: name, Kind: cur.Kind, Change: APIChanged, Before: old.Signature, After: cur.Signature})
+		}
+	}
+	for name, cur := range newSymbols {
+		if _, ok := oldSymbols[name]; !ok {
+			changes = append(changes, APIChange{Name: name, Kind: cur.Kind, Change: APIAdded, After: cur.Signature})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// exportedSymbols indexes exported symbols by their qualified name
+func exportedSymbols(symbols []*Symbol) map[string]*Symbol {
+	result := make(map[string]*Symbol)
+	for _, symbol := range symbols {
+		if !ast.IsExported(symbol.Name) {
+			continue
+		}
+		name := symbol.Name
+		if symbol.Kind == SymbolMethod {
+			recv := strings.TrimPrefix(symbol.Receiver, "*")
+			if i := strings.IndexByte(recv, '['); i >= 0 {
+				recv = recv[:i]
+			}
+			if !ast.IsExported(recv) {
+				continue
+			}
+			name = recv + "." + name
+		}
+		result[name] = symbol
+	}
+	return result
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package opencode
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeGoFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestDiffExportedAPI(t *testing.T) {
+	dir := t.TempDir()
+	before := writeGoFile(t, dir, "before.go", `package lib
+
+type Client struct{ addr string }
+
+func New(addr string) *Client { return &Client{addr: addr} }
+
+func (c *Client) Get(key string) string { return key }
+
+func (c *Client) Close() {}
+
+func helper() {}
+
+const Version = "1"
+`)
+	after := writeGoFile(t, dir, "after.go", `package lib
+
+type Client struct{ addr string }
+
+func New(addr string, timeout int) *Client { return &Client{addr: addr} }
+
+func (c *Client) Get(key string) string { return key }
+
+func (c *Client) Ping() error { return nil }
+
+func helper(x int) {}
+
+const Version = "1"
+`)
+
+	analyzer := &DefaultCodeAnalyzer{}
+	diff, err := analyzer.DiffExportedAPI(context.Background(), before, after)
+	if err != nil {
+		t.Fatalf("DiffExportedAPI: %v", err)
+	}
+
+	got := map[string]APIChangeKind{}
+	for _, change := range diff.Changes {
+		got[change.Name] = change.Change
+	}
+	want := map[string]APIChangeKind{
+		"New":          APIChanged,
+		"Client.Close": APIRemoved,
+		"Client.Ping":  APIAdded,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d changes, got %v", len(want), diff.Changes)
+	}
+	for name, kind := range want {
+		if got[name] != kind {
+			t.Errorf("%s: expected %s, got %s", name, kind, got[name])
+		}
+	}
+	if !diff.Breaking() {
+		t.Error("expected diff to be breaking")
+	}
+	if diff.Summary() == "" {
+		t.Error("expected non-empty summary")
+	}
+}
+
+func TestDiffExportedAPI_NewFile(t *testing.T) {
+	dir := t.TempDir()
+	after := writeGoFile(t, dir, "new.go", "package lib\n\nfunc Added() {}\n")
+
+	diff, err := (&DefaultCodeAnalyzer{}).DiffExportedAPI(context.Background(), "", after)
+	if err != nil {
+		t.Fatalf("DiffExportedAPI: %v", err)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0].Change != APIAdded {
+		t.Fatalf("expected one added symbol, got %v", diff.Changes)
+	}
+	if diff.Breaking() {
+		t.Error("additions are not breaking")
+	}
+}
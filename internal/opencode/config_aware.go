@@ -8,8 +8,11 @@ package opencode
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
+
+	"open-swarm/internal/config"
 )
 
 // ModelInfo represents information about an available AI model.
@@ -33,8 +36,9 @@ type ProviderInfo struct {
 }
 
 // ConfigAwareness provides intelligent provider and model selection based on cached configuration.
-// It discovers available models and providers from the OpenCode SDK and caches the results
-// to improve performance and enable cost-aware task execution.
+// It discovers available models and providers from a running OpenCode server and caches the results
+// to improve performance and enable cost-aware task execution. Without a ConfigSource it falls
+// back to a built-in model list.
 type ConfigAwareness struct {
 	mu sync.RWMutex
 
@@ -46,8 +50,12 @@ type ConfigAwareness struct {
 	cachedAt       time.Time
 	cacheExpiry    time.Duration
 
-	// Integration points (placeholder for OpenCode SDK client)
-	// In a real implementation, this would hold: client *opencode.Client
+	// Discovery and routing
+	source    ConfigSource
+	overrides config.ModelConfig
+	policy    RoutingPolicy
+	health    map[string]*modelHealth
+	now       func() time.Time
 }
 
 // NewConfigAwareness creates a new ConfigAwareness instance with default cache expiry.
@@ -62,40 +70,57 @@ func NewConfigAwareness(cacheExpiry time.Duration) *ConfigAwareness {
 		providers:   make(map[string]*ProviderInfo),
 		cacheExpiry: cacheExpiry,
 		cachedAt:    time.Time{}, // Not cached yet
+		policy:      DefaultRoutingPolicy(),
+		health:      make(map[string]*modelHealth),
+		now:         time.Now,
 	}
 }
 
-// RefreshConfig queries the OpenCode Config service to discover available models and providers.
-// This should be called on startup and periodically to stay up-to-date with provider changes.
+// NewConfigAwarenessWithSource creates a ConfigAwareness that discovers models from source
+// and applies the model preferences of the Open Swarm config on top.
+func NewConfigAwarenessWithSource(source ConfigSource, overrides config.ModelConfig, cacheExpiry time.Duration) *ConfigAwareness {
+	ca := NewConfigAwareness(cacheExpiry)
+	ca.source = source
+	ca.overrides = overrides
+	return ca
+}
+
+// RefreshConfig queries the OpenCode config and providers endpoints to discover available models
+// and providers. This should be called on startup and periodically to stay up-to-date with
+// provider changes. On failure the previous configuration stays cached.
 func (ca *ConfigAwareness) RefreshConfig(ctx context.Context) error {
-	ca.mu.Lock()
-	defer ca.mu.Unlock()
+	ca.mu.RLock()
+	source := ca.source
+	ca.mu.RUnlock()
 
-	// TODO: Integrate with OpenCode SDK Config service
-	// Example integration (when SDK available):
-	/*
-	config, err := ca.client.Config.Get(ctx, opencode.ConfigGetParams{})
-	if err != nil {
-		return fmt.Errorf("failed to fetch config: %w", err)
+	if source == nil {
+		ca.mu.Lock()
+		defer ca.mu.Unlock()
+		ca.initializeDefaults()
+		applyModelOverrides(ca.overrides, ca.models, &ca.defaultModel, &ca.defaultProvider)
+		ca.cachedAt = time.Now()
+		return nil
 	}
 
-	// Parse models from config
-	ca.models = parseModels(config.AvailableModels)
-	ca.providers = parseProviders(config.AvailableProviders)
-	ca.defaultModel = config.DefaultModel
-	ca.defaultProvider = config.DefaultProvider
-	ca.cachedAt = time.Now()
-
-	return nil
-	*/
+	// Query outside the lock so readers are not blocked by the server
+	discovered, err := discoverConfig(ctx, source)
+	if err != nil {
+		return err
+	}
+	applyModelOverrides(ca.overrides, discovered.models, &discovered.defaultModel, &discovered.defaultProvider)
 
-	// Placeholder: Initialize with sensible defaults
-	ca.initializeDefaults()
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.models = discovered.models
+	ca.providers = discovered.providers
+	ca.defaultModel = discovered.defaultModel
+	ca.defaultProvider = discovered.defaultProvider
 	ca.cachedAt = time.Now()
 	return nil
 }
 
-// GetAvailableModels returns the list of all available models.
+// GetAvailableModels returns the list of all available models, sorted by ID.
+// Models cooling down after repeated rate limit or server errors are left out.
 // Returns cached results if available; returns error if cache is stale and refresh fails.
 func (ca *ConfigAwareness) GetAvailableModels(ctx context.Context) ([]*ModelInfo, error) {
 	if err := ca.ensureFreshConfig(ctx); err != nil {
@@ -107,10 +132,11 @@ func (ca *ConfigAwareness) GetAvailableModels(ctx context.Context) ([]*ModelInfo
 
 	models := make([]*ModelInfo, 0, len(ca.models))
 	for _, m := range ca.models {
-		if m.IsAvailable {
+		if ca.isUsable(m) {
 			models = append(models, m)
 		}
 	}
+	sort.Slice(models, func(i, j int) bool { return models[i].ID < models[j].ID })
 	return models, nil
 }
 
@@ -192,7 +218,7 @@ func (ca *ConfigAwareness) GetModelInfo(modelID string) (*ModelInfo, error) {
 		return nil, fmt.Errorf("model %s not found in configuration", modelID)
 	}
 
-	if !model.IsAvailable {
+	if !ca.isUsable(model) {
 		return nil, fmt.Errorf("model %s is not currently available", modelID)
 	}
 
@@ -248,7 +274,7 @@ func (ca *ConfigAwareness) ensureFreshConfig(ctx context.Context) error {
 }
 
 // initializeDefaults sets up sensible default models and providers.
-// This is used as placeholder until SDK integration is available.
+// This is used when no ConfigSource is configured.
 func (ca *ConfigAwareness) initializeDefaults() {
 	// Initialize providers
 	ca.providers = map[string]*ProviderInfo{
@@ -373,10 +399,16 @@ func selectByCost(models []*ModelInfo, cheap bool) string {
 // Usage examples for agents:
 //
 // Initializing config awareness on startup:
-//   ca := NewConfigAwareness(1 * time.Hour)
+//   source := NewSDKConfigSource(client.GetSDK())
+//   ca := NewConfigAwarenessWithSource(source, cfg.Model, 1 * time.Hour)
 //   err := ca.RefreshConfig(ctx)
 //   // Agents now have current provider/model info
 //
+// Routing per gate:
+//   fixModel, _ := ca.SelectModelForGate(ctx, GateLintFix)         // cheapest
+//   implModel, _ := ca.SelectModelForGate(ctx, GateImplementation) // strongest
+//   reviewers, _ := ca.SelectModelsForGate(ctx, GateReview, 3)     // one per provider first
+//
 // Selecting optimal model for task:
 //   modelID, err := ca.SelectModelForTask(ctx, "coding")
 //   // Agent uses selected model for code generation
@@ -391,8 +423,10 @@ func selectByCost(models []*ModelInfo, cheap bool) string {
 //   }
 //
 // Provider fallback handling:
-//   model1, _ := ca.GetModelInfo("claude-3-opus")
-//   if !model1.IsAvailable {
-//     // Try next available provider
-//     model2, _ := ca.SelectModelForTask(ctx, "default")
+//   result, err := executeTask(modelID)
+//   if err != nil {
+//     ca.ReportModelError(modelID, err) // 429/5xx: out of rotation after 3 in a row
+//     modelID, _ = ca.SelectModelForGate(ctx, gate)
+//   } else {
+//     ca.ReportModelSuccess(modelID)
 //   }
@@ -0,0 +1,162 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package opencode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sst/opencode-sdk-go"
+
+	"open-swarm/internal/config"
+)
+
+// ConfigSource reads the configuration and provider catalogue of a running
+// OpenCode server
+type ConfigSource interface {
+	GetConfig(ctx context.Context) (*opencode.Config, error)
+	GetProviders(ctx context.Context) (*opencode.AppProvidersResponse, error)
+}
+
+// sdkConfigSource is the ConfigSource backed by the OpenCode SDK client
+type sdkConfigSource struct {
+	client *opencode.Client
+}
+
+// NewSDKConfigSource queries the /config and /config/providers endpoints
+// through an SDK client, for example agent.Client.GetSDK()
+func NewSDKConfigSource(client *opencode.Client) ConfigSource {
+	return &sdkConfigSource{client: client}
+}
+
+func (s *sdkConfigSource) GetConfig(ctx context.Context) (*opencode.Config, error) {
+	return s.client.Config.Get(ctx, opencode.ConfigGetParams{})
+}
+
+func (s *sdkConfigSource) GetProviders(ctx context.Context) (*opencode.AppProvidersResponse, error) {
+	return s.client.App.Providers(ctx, opencode.AppProvidersParams{})
+}
+
+// discoveredConfig is one snapshot of the server's models and providers
+type discoveredConfig struct {
+	models          map[string]*ModelInfo
+	providers       map[string]*ProviderInfo
+	defaultModel    string
+	defaultProvider string
+}
+
+// discoverConfig fetches the provider catalogue and config from source.
+// Discovered model IDs are "provider/model", the form OpenCode prompts take.
+func discoverConfig(ctx context.Context, source ConfigSource) (*discoveredConfig, error) {
+	providers, err := source.GetProviders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch providers: %w", err)
+	}
+	cfg, err := source.GetConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch config: %w", err)
+	}
+
+	disabled := make(map[string]bool, len(cfg.DisabledProviders))
+	for _, id := range cfg.DisabledProviders {
+		disabled[id] = true
+	}
+
+	discovered := &discoveredConfig{
+		models:    make(map[string]*ModelInfo),
+		providers: make(map[string]*ProviderInfo, len(providers.Providers)),
+	}
+	for _, p := range providers.Providers {
+		provider := &ProviderInfo{
+			ID:          p.ID,
+			Name:        p.Name,
+			IsAvailable: !disabled[p.ID],
+			Config:      map[string]interface{}{"env": p.Env, "api": p.API},
+		}
+		for _, m := range p.Models {
+			model := modelFromSDK(p.ID, m)
+			model.IsAvailable = provider.IsAvailable
+			discovered.models[model.ID] = model
+			provider.Models = append(provider.Models, model.ID)
+		}
+		sort.Strings(provider.Models)
+		discovered.providers[p.ID] = provider
+	}
+
+	// The configured model wins over the per-provider defaults
+	discovered.defaultModel = cfg.Model
+	if discovered.defaultModel == "" {
+		for _, providerID := range sortedProviderIDs(providers.Default) {
+			if provider, ok := discovered.providers[providerID]; ok && provider.IsAvailable {
+				discovered.defaultModel = providerID + "/" + providers.Default[providerID]
+				break
+			}
+		}
+	}
+	discovered.defaultProvider, _, _ = strings.Cut(discovered.defaultModel, "/")
+	return discovered, nil
+}
+
+// modelFromSDK maps an SDK model to ModelInfo. Costs from OpenCode are per
+// million input tokens.
+func modelFromSDK(providerID string, m opencode.Model) *ModelInfo {
+	var capabilities []string
+	if m.ToolCall {
+		capabilities = append(capabilities, "function_calling", "code_generation")
+	}
+	if m.Reasoning {
+		capabilities = append(capabilities, "reasoning")
+	}
+	if m.Attachment || containsModality(m.Modalities.Input, opencode.ModelModalitiesInputImage) {
+		capabilities = append(capabilities, "vision")
+	}
+
+	name := m.Name
+	if name == "" {
+		name = m.ID
+	}
+	return &ModelInfo{
+		ID:           providerID + "/" + m.ID,
+		Provider:     providerID,
+		DisplayName:  name,
+		ContextSize:  int(m.Limit.Context),
+		CostPer1K:    m.Cost.Input / 1000,
+		IsAvailable:  true,
+		Capabilities: capabilities,
+	}
+}
+
+// applyModelOverrides applies the default model of config.ModelConfig when
+// the server knows it. Per-agent entries are applied at routing time.
+func applyModelOverrides(overrides config.ModelConfig, models map[string]*ModelInfo, defaultModel, defaultProvider *string) {
+	if overrides.Backend != "" && overrides.Backend != "opencode" {
+		return
+	}
+	if model, ok := models[overrides.Default]; ok {
+		*defaultModel = model.ID
+		*defaultProvider = model.Provider
+	}
+}
+
+func containsModality(modalities []opencode.ModelModalitiesInput, want opencode.ModelModalitiesInput) bool {
+	for _, modality := range modalities {
+		if modality == want {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedProviderIDs(defaults map[string]string) []string {
+	ids := make([]string, 0, len(defaults))
+	for id := range defaults {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package opencode
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sst/opencode-sdk-go"
+	"github.com/sst/opencode-sdk-go/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"open-swarm/internal/config"
+)
+
+const providersJSON = `{
+  "default": {"anthropic": "claude-sonnet-4-5", "openai": "gpt-5"},
+  "providers": [
+    {"id": "anthropic", "name": "Anthropic", "env": ["ANTHROPIC_API_KEY"], "models": {
+      "claude-sonnet-4-5": {"id": "claude-sonnet-4-5", "name": "Claude Sonnet 4.5", "attachment": true, "reasoning": true, "tool_call": true,
+        "temperature": true, "release_date": "2025-09-29", "options": {},
+        "cost": {"input": 3, "output": 15}, "limit": {"context": 200000, "output": 64000}},
+      "claude-haiku-4-5": {"id": "claude-haiku-4-5", "name": "Claude Haiku 4.5", "attachment": false, "reasoning": false, "tool_call": true,
+        "temperature": true, "release_date": "2025-10-01", "options": {},
+        "cost": {"input": 1, "output": 5}, "limit": {"context": 200000, "output": 64000}}
+    }},
+    {"id": "openai", "name": "OpenAI", "env": ["OPENAI_API_KEY"], "models": {
+      "gpt-5": {"id": "gpt-5", "name": "GPT-5", "attachment": true, "reasoning": true, "tool_call": true,
+        "temperature": false, "release_date": "2025-08-07", "options": {},
+        "cost": {"input": 1.25, "output": 10}, "limit": {"context": 400000, "output": 128000}}
+    }}
+  ]
+}`
+
+// newOpenCodeServer serves the config and providers endpoints of OpenCode
+func newOpenCodeServer(t *testing.T, configJSON string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/config":
+			_, _ = w.Write([]byte(configJSON))
+		case "/config/providers":
+			_, _ = w.Write([]byte(providersJSON))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newSDKAwareness(t *testing.T, configJSON string, overrides config.ModelConfig) *ConfigAwareness {
+	t.Helper()
+	server := newOpenCodeServer(t, configJSON)
+	client := opencode.NewClient(option.WithBaseURL(server.URL), option.WithMaxRetries(0))
+	return NewConfigAwarenessWithSource(NewSDKConfigSource(client), overrides, time.Hour)
+}
+
+func TestRefreshConfig_DiscoversFromServer(t *testing.T) {
+	ctx := context.Background()
+	ca := newSDKAwareness(t, `{"model": "openai/gpt-5"}`, config.ModelConfig{})
+	require.NoError(t, ca.RefreshConfig(ctx))
+
+	models, err := ca.GetAvailableModels(ctx)
+	require.NoError(t, err)
+	ids := make([]string, len(models))
+	for i, m := range models {
+		ids[i] = m.ID
+	}
+	assert.Equal(t, []string{"anthropic/claude-haiku-4-5", "anthropic/claude-sonnet-4-5", "openai/gpt-5"}, ids)
+
+	sonnet, err := ca.GetModelInfo("anthropic/claude-sonnet-4-5")
+	require.NoError(t, err)
+	assert.Equal(t, "anthropic", sonnet.Provider)
+	assert.Equal(t, "Claude Sonnet 4.5", sonnet.DisplayName)
+	assert.Equal(t, 200000, sonnet.ContextSize)
+	assert.InDelta(t, 0.003, sonnet.CostPer1K, 1e-9)
+	assert.ElementsMatch(t, []string{"function_calling", "code_generation", "reasoning", "vision"}, sonnet.Capabilities)
+
+	provider, err := ca.GetProviderInfo("anthropic")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"anthropic/claude-haiku-4-5", "anthropic/claude-sonnet-4-5"}, provider.Models)
+
+	modelID, err := ca.SelectModelForTask(ctx, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "openai/gpt-5", modelID, "the configured model is the default")
+}
+
+func TestRefreshConfig_DisabledProvidersAndOverrides(t *testing.T) {
+	ctx := context.Background()
+	ca := newSDKAwareness(t, `{"disabled_providers": ["openai"]}`,
+		config.ModelConfig{Default: "anthropic/claude-haiku-4-5"})
+	require.NoError(t, ca.RefreshConfig(ctx))
+
+	_, err := ca.GetModelInfo("openai/gpt-5")
+	assert.Error(t, err, "models of disabled providers are unavailable")
+	_, err = ca.GetProviderInfo("openai")
+	assert.Error(t, err)
+
+	modelID, err := ca.SelectModelForTask(ctx, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "anthropic/claude-haiku-4-5", modelID, "the Open Swarm default overrides the server's")
+}
+
+func TestRefreshConfig_ServerErrorKeepsCache(t *testing.T) {
+	ctx := context.Background()
+	ca := newSDKAwareness(t, `{}`, config.ModelConfig{})
+	require.NoError(t, ca.RefreshConfig(ctx))
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	ca.source = NewSDKConfigSource(opencode.NewClient(option.WithBaseURL(failing.URL), option.WithMaxRetries(0)))
+
+	assert.Error(t, ca.RefreshConfig(ctx))
+	_, err := ca.GetModelInfo("openai/gpt-5")
+	assert.NoError(t, err, "a failed refresh keeps the previous configuration")
+}
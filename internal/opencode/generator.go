@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"open-swarm/internal/agent"
+	"open-swarm/internal/prompts/templates"
 )
 
 // CodeGenerator provides a high-level interface for agents to generate code.
@@ -47,6 +48,9 @@ type CodeGenerationTask struct {
 	VerificationFn  func(string) bool // Optional function to verify generated code
 }
 
+// CodeGenerationTemplate is the template name of code generation prompts
+const CodeGenerationTemplate = "code_generation"
+
 // GenerationResult contains the result of code generation
 type GenerationResult struct {
 	Success         bool          // Whether generation succeeded
@@ -57,6 +61,7 @@ type GenerationResult struct {
 	ErrorMessage    string        // Error if it failed
 	Attempts        int           // Number of attempts made
 	FullOutput      string        // Full output from the generator
+	PromptVersion   string        // Template that rendered the prompt
 }
 
 // DefaultCodeGenerator implements CodeGenerator with OpenCode SDK for Claude/Copilot
@@ -98,8 +103,16 @@ func (g *DefaultCodeGenerator) GenerateCode(ctx context.Context, task *CodeGener
 		FilesModified: []string{},
 	}
 
-	// Build prompt
-	prompt := buildCodeGenerationPrompt(task)
+	// Build prompt; templates named code_generation render with the task
+	rendered, err := templates.Default().Render(CodeGenerationTemplate, task.TaskID, task, func() string {
+		return buildCodeGenerationPrompt(task)
+	})
+	if err != nil {
+		result.ErrorMessage = err.Error()
+		return result, err
+	}
+	prompt := rendered.Text
+	result.PromptVersion = rendered.Stamp()
 
 	// Call Claude API
 	var attempts int
@@ -0,0 +1,326 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package opencode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/sst/opencode-sdk-go"
+)
+
+// Gates with a routing policy. The names match the agent names of
+// config.ModelConfig, so "agents: {review: anthropic/claude-sonnet-4-5}" pins
+// the reviewer model.
+const (
+	GateLintFix        = "lint_fix"
+	GateTestGen        = "test_gen"
+	GateImplementation = "implementation"
+	GateReview         = "review"
+)
+
+// RoutingStrategy chooses a model among the available ones
+type RoutingStrategy string
+
+const (
+	// RouteCheapest picks the lowest cost model that can edit code
+	RouteCheapest RoutingStrategy = "cheapest"
+	// RouteStrongest picks the most capable model that can edit code
+	RouteStrongest RoutingStrategy = "strongest"
+	// RouteDiverse spreads picks over providers, strongest first
+	RouteDiverse RoutingStrategy = "diverse"
+)
+
+// RoutingPolicy maps gates to routing strategies
+type RoutingPolicy map[string]RoutingStrategy
+
+// DefaultRoutingPolicy uses a cheap model for lint fixes, the strongest for
+// tests and implementation, and diverse models for reviewers.
+func DefaultRoutingPolicy() RoutingPolicy {
+	return RoutingPolicy{
+		GateLintFix:        RouteCheapest,
+		GateTestGen:        RouteStrongest,
+		GateImplementation: RouteStrongest,
+		GateReview:         RouteDiverse,
+	}
+}
+
+// Availability tracking defaults
+const (
+	// UnavailableAfterFailures is the number of consecutive rate limit or
+	// server errors after which a model is taken out of rotation
+	UnavailableAfterFailures = 3
+	// UnavailableCooldown is how long a failing model stays out of rotation
+	UnavailableCooldown = 5 * time.Minute
+)
+
+// modelHealth counts consecutive transient failures of a model
+type modelHealth struct {
+	failures         int
+	unavailableUntil time.Time
+}
+
+// SetRoutingPolicy replaces the routing policy. Gates missing from policy
+// use SelectModelForTask(ctx, "default").
+func (ca *ConfigAwareness) SetRoutingPolicy(policy RoutingPolicy) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.policy = policy
+}
+
+// SetSource points discovery at another OpenCode server, e.g. the server of
+// the cell about to run a gate. Cached models and availability are kept.
+func (ca *ConfigAwareness) SetSource(source ConfigSource) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.source = source
+}
+
+// ModelUnavailable reports whether a known model is disabled or cooling down
+// after repeated errors. Models missing from the configuration are not
+// reported, since routing knows nothing about them.
+func (ca *ConfigAwareness) ModelUnavailable(modelID string) bool {
+	ca.mu.RLock()
+	defer ca.mu.RUnlock()
+	model, ok := ca.models[modelID]
+	return ok && !ca.isUsable(model)
+}
+
+// SelectModelForGate selects the model for one gate: the model pinned for
+// the gate in config.ModelConfig if available, otherwise the policy's pick.
+func (ca *ConfigAwareness) SelectModelForGate(ctx context.Context, gate string) (string, error) {
+	models, err := ca.SelectModelsForGate(ctx, gate, 1)
+	if err != nil {
+		return "", err
+	}
+	return models[0], nil
+}
+
+// SelectModelsForGate selects n models for a gate, such as one per reviewer.
+// With RouteDiverse, every provider is used once before any is used twice;
+// other strategies return the same model n times.
+func (ca *ConfigAwareness) SelectModelsForGate(ctx context.Context, gate string, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("model count must be positive, got %d", n)
+	}
+	models, err := ca.GetAvailableModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, fmt.Errorf("no available models")
+	}
+
+	ca.mu.RLock()
+	strategy, ok := ca.policy[gate]
+	pinned := ca.pinnedModel(gate)
+	ca.mu.RUnlock()
+
+	var ranked []*ModelInfo
+	switch {
+	case !ok:
+		id, err := ca.SelectModelForTask(ctx, "default")
+		if err != nil {
+			return nil, err
+		}
+		return repeatModel(id, n), nil
+	case strategy == RouteCheapest:
+		ranked = rankByCost(codeModels(models))
+	case strategy == RouteStrongest:
+		ranked = rankByStrength(codeModels(models))
+	case strategy == RouteDiverse:
+		ranked = spreadProviders(rankByStrength(codeModels(models)))
+	default:
+		return nil, fmt.Errorf("unknown routing strategy %q for gate %s", strategy, gate)
+	}
+
+	// A pinned model goes first; the rest of a diverse pick avoids its provider
+	if pinned != nil {
+		rest := make([]*ModelInfo, 0, len(ranked))
+		for _, m := range ranked {
+			if m.ID != pinned.ID {
+				rest = append(rest, m)
+			}
+		}
+		if strategy == RouteDiverse {
+			rest = spreadProviders(append([]*ModelInfo{pinned}, rest...))[1:]
+		}
+		ranked = append([]*ModelInfo{pinned}, rest...)
+	}
+
+	if strategy != RouteDiverse {
+		return repeatModel(ranked[0].ID, n), nil
+	}
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = ranked[i%len(ranked)].ID
+	}
+	return ids, nil
+}
+
+// ReportModelError records a failed request to a model. Rate limit (429)
+// and server (5xx) errors count towards taking the model out of rotation;
+// other errors are ignored. It returns whether the error counted.
+func (ca *ConfigAwareness) ReportModelError(modelID string, err error) bool {
+	var apiErr *opencode.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return ca.ReportModelStatus(modelID, apiErr.StatusCode)
+}
+
+// ReportModelStatus records the HTTP status of a request to a model. After
+// UnavailableAfterFailures consecutive 429 or 5xx responses the model is
+// unavailable for UnavailableCooldown. When the cooldown ends, the model is
+// tried again, and one more failure takes it out again.
+func (ca *ConfigAwareness) ReportModelStatus(modelID string, statusCode int) bool {
+	if statusCode != http.StatusTooManyRequests && statusCode < http.StatusInternalServerError {
+		if statusCode < http.StatusBadRequest {
+			ca.ReportModelSuccess(modelID)
+		}
+		return false
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	h, ok := ca.health[modelID]
+	if !ok {
+		h = &modelHealth{}
+		ca.health[modelID] = h
+	}
+	h.failures++
+	if h.failures >= UnavailableAfterFailures {
+		h.unavailableUntil = ca.now().Add(UnavailableCooldown)
+	}
+	return true
+}
+
+// ReportModelSuccess records a successful request, resetting the model's
+// failure count.
+func (ca *ConfigAwareness) ReportModelSuccess(modelID string) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	delete(ca.health, modelID)
+}
+
+// isUsable reports whether a model is available and not cooling down.
+// Callers hold ca.mu.
+func (ca *ConfigAwareness) isUsable(m *ModelInfo) bool {
+	if !m.IsAvailable {
+		return false
+	}
+	h, ok := ca.health[m.ID]
+	return !ok || !ca.now().Before(h.unavailableUntil)
+}
+
+// pinnedModel returns the usable OpenCode model configured for the gate's
+// agent, or nil. Callers hold ca.mu.
+func (ca *ConfigAwareness) pinnedModel(gate string) *ModelInfo {
+	entry, ok := ca.overrides.Agents[gate]
+	if !ok || entry == "" {
+		return nil
+	}
+	backend, modelID := ca.overrides.AgentBackend(gate)
+	if backend != "" && backend != "opencode" {
+		return nil
+	}
+	model, ok := ca.models[modelID]
+	if !ok || !ca.isUsable(model) {
+		return nil
+	}
+	return model
+}
+
+// codeModels keeps the models that can generate code, or all of them if
+// none can
+func codeModels(models []*ModelInfo) []*ModelInfo {
+	var capable []*ModelInfo
+	for _, m := range models {
+		if hasCapability(m, "code_generation") {
+			capable = append(capable, m)
+		}
+	}
+	if len(capable) == 0 {
+		return models
+	}
+	return capable
+}
+
+// rankByCost orders models cheapest first, then by ID
+func rankByCost(models []*ModelInfo) []*ModelInfo {
+	ranked := append([]*ModelInfo(nil), models...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].CostPer1K != ranked[j].CostPer1K {
+			return ranked[i].CostPer1K < ranked[j].CostPer1K
+		}
+		return ranked[i].ID < ranked[j].ID
+	})
+	return ranked
+}
+
+// rankByStrength orders models strongest first: reasoning models, then by
+// cost as a proxy for capability, then by context size
+func rankByStrength(models []*ModelInfo) []*ModelInfo {
+	ranked := append([]*ModelInfo(nil), models...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if ra, rb := hasCapability(a, "reasoning"), hasCapability(b, "reasoning"); ra != rb {
+			return ra
+		}
+		if a.CostPer1K != b.CostPer1K {
+			return a.CostPer1K > b.CostPer1K
+		}
+		if a.ContextSize != b.ContextSize {
+			return a.ContextSize > b.ContextSize
+		}
+		return a.ID < b.ID
+	})
+	return ranked
+}
+
+// spreadProviders reorders ranked models round-robin over providers, keeping
+// each provider's own order and the order in which providers first appear
+func spreadProviders(ranked []*ModelInfo) []*ModelInfo {
+	var order []string
+	byProvider := make(map[string][]*ModelInfo)
+	for _, m := range ranked {
+		if _, ok := byProvider[m.Provider]; !ok {
+			order = append(order, m.Provider)
+		}
+		byProvider[m.Provider] = append(byProvider[m.Provider], m)
+	}
+
+	spread := make([]*ModelInfo, 0, len(ranked))
+	for round := 0; len(spread) < len(ranked); round++ {
+		for _, provider := range order {
+			if round < len(byProvider[provider]) {
+				spread = append(spread, byProvider[provider][round])
+			}
+		}
+	}
+	return spread
+}
+
+func hasCapability(m *ModelInfo, capability string) bool {
+	for _, c := range m.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+func repeatModel(id string, n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = id
+	}
+	return ids
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package opencode
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sst/opencode-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"open-swarm/internal/config"
+)
+
+// routingAwareness has three providers with a cheap and a strong model each
+// plus a model that cannot call tools
+func routingAwareness(t *testing.T, overrides config.ModelConfig) *ConfigAwareness {
+	t.Helper()
+	ca := NewConfigAwarenessWithSource(nil, overrides, time.Hour)
+	code := []string{"code_generation", "function_calling"}
+	reasoning := []string{"code_generation", "function_calling", "reasoning"}
+	models := []*ModelInfo{
+		{ID: "anthropic/opus", Provider: "anthropic", CostPer1K: 0.015, Capabilities: reasoning},
+		{ID: "anthropic/haiku", Provider: "anthropic", CostPer1K: 0.001, Capabilities: code},
+		{ID: "openai/gpt-5", Provider: "openai", CostPer1K: 0.00125, Capabilities: reasoning},
+		{ID: "openai/mini", Provider: "openai", CostPer1K: 0.0002, Capabilities: code},
+		{ID: "google/gemini-pro", Provider: "google", CostPer1K: 0.00125, Capabilities: reasoning},
+		{ID: "local/embed", Provider: "local", CostPer1K: 0},
+	}
+	ca.mu.Lock()
+	for _, m := range models {
+		m.IsAvailable = true
+		ca.models[m.ID] = m
+	}
+	ca.cachedAt = time.Now()
+	ca.mu.Unlock()
+	return ca
+}
+
+func TestSelectModelForGate_DefaultPolicy(t *testing.T) {
+	ctx := context.Background()
+	ca := routingAwareness(t, config.ModelConfig{})
+
+	lint, err := ca.SelectModelForGate(ctx, GateLintFix)
+	require.NoError(t, err)
+	assert.Equal(t, "openai/mini", lint, "lint fixes use the cheapest model that can edit code")
+
+	impl, err := ca.SelectModelForGate(ctx, GateImplementation)
+	require.NoError(t, err)
+	assert.Equal(t, "anthropic/opus", impl)
+
+	reviewers, err := ca.SelectModelsForGate(ctx, GateReview, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"anthropic/opus", "google/gemini-pro", "openai/gpt-5", "anthropic/haiku"}, reviewers,
+		"every provider reviews once before any reviews twice")
+}
+
+func TestSelectModelForGate_PinnedModel(t *testing.T) {
+	ctx := context.Background()
+	ca := routingAwareness(t, config.ModelConfig{Agents: map[string]string{
+		GateImplementation: "openai/gpt-5",
+		GateReview:         "openai/gpt-5",
+		GateLintFix:        "claude-code:anthropic/haiku",
+	}})
+
+	impl, err := ca.SelectModelForGate(ctx, GateImplementation)
+	require.NoError(t, err)
+	assert.Equal(t, "openai/gpt-5", impl)
+
+	reviewers, err := ca.SelectModelsForGate(ctx, GateReview, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"openai/gpt-5", "anthropic/opus", "google/gemini-pro"}, reviewers)
+
+	lint, err := ca.SelectModelForGate(ctx, GateLintFix)
+	require.NoError(t, err)
+	assert.Equal(t, "openai/mini", lint, "pins to other backends do not apply to OpenCode routing")
+}
+
+func TestSelectModelForGate_CustomPolicy(t *testing.T) {
+	ctx := context.Background()
+	ca := routingAwareness(t, config.ModelConfig{})
+	ca.SetRoutingPolicy(RoutingPolicy{GateReview: RouteCheapest})
+
+	reviewers, err := ca.SelectModelsForGate(ctx, GateReview, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"openai/mini", "openai/mini"}, reviewers)
+
+	_, err = ca.SelectModelForGate(ctx, GateImplementation)
+	assert.NoError(t, err, "gates without a policy use the default model selection")
+
+	_, err = ca.SelectModelsForGate(ctx, GateReview, 0)
+	assert.Error(t, err)
+}
+
+func TestReportModelStatus_MarksUnavailableAfterRepeatedFailures(t *testing.T) {
+	ctx := context.Background()
+	ca := routingAwareness(t, config.ModelConfig{})
+	now := time.Now()
+	ca.now = func() time.Time { return now }
+
+	assert.False(t, ca.ReportModelStatus("anthropic/opus", http.StatusBadRequest), "client errors do not count")
+	for i := 0; i < UnavailableAfterFailures-1; i++ {
+		assert.True(t, ca.ReportModelStatus("anthropic/opus", http.StatusTooManyRequests))
+	}
+	_, err := ca.GetModelInfo("anthropic/opus")
+	require.NoError(t, err, "still available below the threshold")
+
+	ca.ReportModelError("anthropic/opus", &opencode.Error{StatusCode: http.StatusBadGateway})
+	_, err = ca.GetModelInfo("anthropic/opus")
+	assert.Error(t, err)
+	assert.True(t, ca.ModelUnavailable("anthropic/opus"))
+	assert.False(t, ca.ModelUnavailable("unknown/model"), "unknown models are left to the caller")
+	impl, err := ca.SelectModelForGate(ctx, GateImplementation)
+	require.NoError(t, err)
+	assert.Equal(t, "google/gemini-pro", impl, "routing skips the unavailable model")
+
+	now = now.Add(UnavailableCooldown)
+	_, err = ca.GetModelInfo("anthropic/opus")
+	assert.NoError(t, err, "available again after the cooldown")
+
+	ca.ReportModelStatus("anthropic/opus", http.StatusInternalServerError)
+	_, err = ca.GetModelInfo("anthropic/opus")
+	assert.Error(t, err, "one more failure after the cooldown takes it out again")
+
+	ca.ReportModelSuccess("anthropic/opus")
+	_, err = ca.GetModelInfo("anthropic/opus")
+	assert.NoError(t, err)
+}
+
+func TestReportModelError_IgnoresNonHTTPErrors(t *testing.T) {
+	ca := routingAwareness(t, config.ModelConfig{})
+	assert.False(t, ca.ReportModelError("anthropic/opus", errors.New("context deadline exceeded")))
+}
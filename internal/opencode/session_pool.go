@@ -12,6 +12,14 @@ import (
 	"time"
 )
 
+const (
+	// DefaultSessionTTL is how long an idle pooled session is kept
+	DefaultSessionTTL = 30 * time.Minute
+	// DefaultSessionMaxTurns is how many turns a session takes before it is
+	// replaced by a new one
+	DefaultSessionMaxTurns = 50
+)
+
 // SessionContext represents a reusable session tied to an agent and task.
 // It tracks session metadata and lifecycle information.
 type SessionContext struct {
@@ -40,10 +48,10 @@ type SessionPool struct {
 // maxTurns: maximum turns per session before creating new one (default: 50)
 func NewSessionPool(sessionTTL time.Duration, maxTurns int) *SessionPool {
 	if sessionTTL <= 0 {
-		sessionTTL = 30 * time.Minute
+		sessionTTL = DefaultSessionTTL
 	}
 	if maxTurns <= 0 {
-		maxTurns = 50
+		maxTurns = DefaultSessionMaxTurns
 	}
 
 	return &SessionPool{
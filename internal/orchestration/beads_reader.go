@@ -50,6 +50,7 @@ func (r *BeadsTaskReader) ReadFromIssue(issue BeadsIssue) (*AgentConfig, error)
 		Acceptance:  issue.Acceptance,
 		Scenarios:   scenarios,
 		EdgeCases:   edgeCases,
+		Scope:       r.parseScope(issue.Labels),
 	}
 
 	// Create agent config
@@ -65,6 +66,9 @@ func (r *BeadsTaskReader) ReadFromIssue(issue BeadsIssue) (*AgentConfig, error)
 		MaxRetries:         3, // Default retries
 		TimeoutSeconds:     300, // 5 min default
 		ReviewersCount:     1, // Single reviewer default
+		TaskType:           issue.Type,
+		Labels:             issue.Labels,
+		Packages:           req.Scope,
 	}
 
 	// Adjust based on issue priority
@@ -86,6 +90,17 @@ func (r *BeadsTaskReader) ReadFromIssue(issue BeadsIssue) (*AgentConfig, error)
 	return config, nil
 }
 
+// parseScope extracts the declared change scope from "scope:<path>" labels.
+func (r *BeadsTaskReader) parseScope(labels []string) []string {
+	var scope []string
+	for _, label := range labels {
+		if path, ok := strings.CutPrefix(label, "scope:"); ok && path != "" {
+			scope = append(scope, path)
+		}
+	}
+	return scope
+}
+
 // parseScenarios extracts test scenarios and edge cases from description.
 func (r *BeadsTaskReader) parseScenarios(description string) ([]string, []string) {
 	var scenarios []string
@@ -428,3 +428,26 @@ func TestBeadsReaderDefaultValues(t *testing.T) {
 		t.Fatalf("Expected ReviewersCount=1 for priority 3, got %d", config.ReviewersCount)
 	}
 }
+
+// TestBeadsReaderScopeLabels tests declared scope is read from scope: labels
+func TestBeadsReaderScopeLabels(t *testing.T) {
+	reader := NewBeadsTaskReader(&MockLogger{})
+
+	config, err := reader.ReadFromIssue(BeadsIssue{
+		ID:     "open-swarm-002",
+		Title:  "Add phone validation",
+		Type:   "feature",
+		Labels: []string{"backend", "scope:internal/validate", "scope:"},
+	})
+	if err != nil {
+		t.Fatalf("ReadFromIssue failed: %v", err)
+	}
+
+	scope := config.RequirementsForGate.Scope
+	if len(scope) != 1 || scope[0] != "internal/validate" {
+		t.Fatalf("Expected scope [internal/validate], got %v", scope)
+	}
+	if config.TaskType != "feature" || len(config.Packages) != 1 {
+		t.Fatalf("Expected task type and packages to be set, got %q %v", config.TaskType, config.Packages)
+	}
+}
@@ -24,6 +24,13 @@ type AgentConfig struct {
 	TimeoutSeconds      int               // Execution timeout
 	ReviewersCount      int               // Parallel reviewers (default 1)
 	RequirementsForGate *gates.Requirement // For gate verification
+	TaskType            string            // Beads issue type (bug, feature, ...)
+	Labels              []string          // Beads issue labels
+	Packages            []string          // Packages the task is expected to touch
+	Learnings           []string          // Relevant lessons from previous runs, for prompts
+	WorkDir             string            // Git worktree the agent works in, for diff-based gates
+	TestPrompt          string            // Test generation prompt, rendered by the spawner
+	Prompt              string            // Implementation prompt, rendered by the spawner
 }
 
 // AgentResult represents the outcome of agent execution.
@@ -294,6 +301,7 @@ func (c *Coordinator) executeAgentWave(
 			// Execute agent
 			config := c.agents[id]
 			c.logger.Infof("Starting agent: %s (%s)", id, config.Title)
+			c.loadLearnings(ctx, config)
 
 			result, err := c.spawnerFunc(ctx, config)
 			if err != nil {
@@ -309,6 +317,7 @@ func (c *Coordinator) executeAgentWave(
 			c.mu.Lock()
 			c.results[id] = result
 			c.mu.Unlock()
+			c.storeLearnings(ctx, config, result)
 
 			// Mark completed
 			completionLock.Lock()
@@ -357,6 +366,55 @@ func (c *Coordinator) executeAgentWave(
 	return nil
 }
 
+// loadLearnings fills config.Learnings with relevant lessons from previous runs.
+func (c *Coordinator) loadLearnings(ctx context.Context, config *AgentConfig) {
+	if c.mem0Integration == nil || len(config.Learnings) > 0 {
+		return
+	}
+
+	scoped := WithLearningScope(ctx, ScopeForAgent(config))
+	patterns, err := c.mem0Integration.GetPatterns(scoped, config.TaskType)
+	if err != nil {
+		c.logger.Warnf("failed to load learnings for %s: %v", config.TaskID, err)
+		return
+	}
+	config.Learnings = patterns
+}
+
+// storeLearnings persists the patterns an agent reported, weighted by their learning value.
+func (c *Coordinator) storeLearnings(ctx context.Context, config *AgentConfig, result *AgentResult) {
+	if c.mem0Integration == nil || len(result.Mem0Patterns) == 0 {
+		return
+	}
+
+	scope := ScopeForAgent(config)
+	if len(result.FilesModified) > 0 {
+		scope.Packages = PackagesFromFiles(result.FilesModified)
+	}
+	scope.Weight = result.LearningValue
+	scoped := WithLearningScope(ctx, scope)
+
+	stored := 0
+	for _, pattern := range result.Mem0Patterns {
+		var err error
+		if result.Success {
+			err = c.mem0Integration.StorePattern(scoped, pattern)
+		} else {
+			err = c.mem0Integration.RecordFailure(scoped, result.TaskID, pattern)
+		}
+		if err != nil {
+			c.logger.Warnf("failed to store learning for %s: %v", result.TaskID, err)
+			continue
+		}
+		stored++
+	}
+
+	c.mu.Lock()
+	c.metrics.LearningCount += len(result.Mem0Patterns)
+	c.metrics.MemoriesStored += stored
+	c.mu.Unlock()
+}
+
 // getReadyAgents returns all agents whose dependencies are satisfied.
 func (c *Coordinator) getReadyAgents(order []string, completed map[string]bool) []string {
 	c.mu.RLock()
@@ -0,0 +1,386 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package orchestration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// DefaultLearningStorePath is where swarm runs persist learnings by default
+const DefaultLearningStorePath = ".open-swarm/learnings.jsonl"
+
+// BM25 tuning parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// LearningKind distinguishes what worked from what went wrong
+type LearningKind string
+
+const (
+	// LearningSuccess is a pattern that led to a passing task
+	LearningSuccess LearningKind = "success"
+	// LearningFailure is the root cause of a failed task
+	LearningFailure LearningKind = "failure"
+)
+
+// LearningEntry is one record in the learning log
+type LearningEntry struct {
+	Kind      LearningKind `json:"kind"`
+	TaskID    string       `json:"task_id,omitempty"`
+	TaskType  string       `json:"task_type,omitempty"`
+	Labels    []string     `json:"labels,omitempty"`
+	Packages  []string     `json:"packages,omitempty"`
+	Text      string       `json:"text"`
+	Weight    float64      `json:"weight,omitempty"` // LearningValue of the originating result (0-1)
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// String renders the entry for inclusion in a prompt
+func (e LearningEntry) String() string {
+	prefix := "Worked before"
+	if e.Kind == LearningFailure {
+		prefix = "Failed before"
+	}
+	if e.TaskID != "" {
+		prefix = fmt.Sprintf("%s (%s)", prefix, e.TaskID)
+	}
+	return prefix + ": " + e.Text
+}
+
+// LearningScope describes the task a learning belongs to or is wanted for.
+// Mem0Client methods only carry strings, so callers attach the scope to the context.
+type LearningScope struct {
+	TaskID   string
+	TaskType string
+	Labels   []string
+	Packages []string
+	Query    string  // Free text such as the task title and description
+	Weight   float64 // LearningValue of stored entries (0-1)
+}
+
+type learningScopeKey struct{}
+
+// WithLearningScope attaches a learning scope to the context
+func WithLearningScope(ctx context.Context, scope LearningScope) context.Context {
+	return context.WithValue(ctx, learningScopeKey{}, scope)
+}
+
+// LearningScopeFromContext returns the scope attached to the context, if any
+func LearningScopeFromContext(ctx context.Context) (LearningScope, bool) {
+	scope, ok := ctx.Value(learningScopeKey{}).(LearningScope)
+	return scope, ok
+}
+
+// ScopeForAgent builds the learning scope of an agent configuration
+func ScopeForAgent(config *AgentConfig) LearningScope {
+	return LearningScope{
+		TaskID:   config.TaskID,
+		TaskType: config.TaskType,
+		Labels:   config.Labels,
+		Packages: config.Packages,
+		Query:    config.Title + " " + config.Description,
+	}
+}
+
+// PackagesFromFiles returns the sorted, de-duplicated directories of the given files
+func PackagesFromFiles(files []string) []string {
+	seen := make(map[string]bool)
+	var pkgs []string
+	for _, f := range files {
+		dir := path.Dir(filepath.ToSlash(f))
+		if dir == "." || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		pkgs = append(pkgs, dir)
+	}
+	sort.Strings(pkgs)
+	return pkgs
+}
+
+// Ensure LearningStore implements Mem0Client
+var _ Mem0Client = (*LearningStore)(nil)
+
+// LearningStore is an embedded Mem0Client backed by an append-only JSONL log.
+// Entries are ranked with BM25 over their text, task type, labels and packages.
+type LearningStore struct {
+	mu      sync.Mutex
+	path    string
+	limit   int
+	entries []LearningEntry
+	docs    []map[string]int // term frequencies per entry
+	lengths []int
+	df      map[string]int
+	total   int
+}
+
+// NewLearningStore opens (or creates on first write) the log at path and indexes existing entries
+func NewLearningStore(logPath string) (*LearningStore, error) {
+	s := &LearningStore{
+		path:  logPath,
+		limit: 5,
+		df:    make(map[string]int),
+	}
+
+	f, err := os.Open(logPath) //nolint:gosec // Path comes from configuration
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open learning store: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var entry LearningEntry
+		// A torn final line from a crashed writer is skipped rather than fatal
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.Text == "" {
+			continue
+		}
+		s.index(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read learning store: %w", err)
+	}
+	return s, nil
+}
+
+// SetLimit sets how many entries GetPatterns returns
+func (s *LearningStore) SetLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if limit > 0 {
+		s.limit = limit
+	}
+}
+
+// Len returns the number of stored entries
+func (s *LearningStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// Record appends an entry to the log and the index
+func (s *LearningStore) Record(_ context.Context, entry LearningEntry) error {
+	if strings.TrimSpace(entry.Text) == "" {
+		return nil
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode learning: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create learning store directory: %w", err)
+		}
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // Path comes from configuration
+	if err != nil {
+		return fmt.Errorf("failed to open learning store: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write learning: %w", err)
+	}
+
+	s.index(entry)
+	return nil
+}
+
+// StorePattern records a success pattern, keyed by the scope attached to ctx
+func (s *LearningStore) StorePattern(ctx context.Context, pattern string) error {
+	scope, _ := LearningScopeFromContext(ctx)
+	return s.Record(ctx, scopedEntry(LearningSuccess, scope, pattern))
+}
+
+// RecordFailure records a failure root cause, keyed by the scope attached to ctx
+func (s *LearningStore) RecordFailure(ctx context.Context, taskID string, rootCause string) error {
+	scope, _ := LearningScopeFromContext(ctx)
+	entry := scopedEntry(LearningFailure, scope, rootCause)
+	entry.TaskID = taskID
+	return s.Record(ctx, entry)
+}
+
+// GetPatterns returns the most relevant learnings for a task type, refined by
+// the scope attached to ctx
+func (s *LearningStore) GetPatterns(ctx context.Context, taskType string) ([]string, error) {
+	scope, _ := LearningScopeFromContext(ctx)
+	if taskType != "" {
+		scope.TaskType = taskType
+	}
+
+	s.mu.Lock()
+	limit := s.limit
+	s.mu.Unlock()
+
+	entries := s.Search(scope, limit)
+	patterns := make([]string, len(entries))
+	for i, entry := range entries {
+		patterns[i] = entry.String()
+	}
+	return patterns, nil
+}
+
+// Search returns up to limit entries ranked by relevance to the scope.
+// Identical lessons are returned once.
+func (s *LearningStore) Search(scope LearningScope, limit int) []LearningEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := queryTerms(scope)
+	if len(query) == 0 || len(s.entries) == 0 {
+		return nil
+	}
+
+	type hit struct {
+		idx   int
+		score float64
+	}
+	n := float64(len(s.entries))
+	avgLen := float64(s.total) / n
+	var hits []hit
+	for i, doc := range s.docs {
+		score := 0.0
+		for _, term := range query {
+			tf := float64(doc[term])
+			if tf == 0 {
+				continue
+			}
+			df := float64(s.df[term])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			norm := 1 - bm25B + bm25B*float64(s.lengths[i])/avgLen
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+		if score > 0 {
+			hits = append(hits, hit{idx: i, score: score * (1 + s.entries[i].Weight)})
+		}
+	}
+
+	sort.SliceStable(hits, func(a, b int) bool {
+		if hits[a].score != hits[b].score {
+			return hits[a].score > hits[b].score
+		}
+		// Prefer the more recent lesson on ties
+		return hits[a].idx > hits[b].idx
+	})
+
+	seen := make(map[string]bool)
+	var results []LearningEntry
+	for _, h := range hits {
+		entry := s.entries[h.idx]
+		key := string(entry.Kind) + "\x00" + entry.Text
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		results = append(results, entry)
+		if limit > 0 && len(results) >= limit {
+			break
+		}
+	}
+	return results
+}
+
+// index adds an entry to the in-memory BM25 index; callers hold s.mu or own s
+func (s *LearningStore) index(entry LearningEntry) {
+	terms := tokenize(entry.Text)
+	terms = append(terms, keyTerms(entry.TaskType, entry.Labels, entry.Packages)...)
+
+	tf := make(map[string]int, len(terms))
+	for _, term := range terms {
+		tf[term]++
+	}
+	for term := range tf {
+		s.df[term]++
+	}
+
+	s.entries = append(s.entries, entry)
+	s.docs = append(s.docs, tf)
+	s.lengths = append(s.lengths, len(terms))
+	s.total += len(terms)
+}
+
+func scopedEntry(kind LearningKind, scope LearningScope, text string) LearningEntry {
+	return LearningEntry{
+		Kind:     kind,
+		TaskID:   scope.TaskID,
+		TaskType: scope.TaskType,
+		Labels:   scope.Labels,
+		Packages: scope.Packages,
+		Text:     text,
+		Weight:   scope.Weight,
+	}
+}
+
+// queryTerms returns the unique search terms of a scope
+func queryTerms(scope LearningScope) []string {
+	terms := tokenize(scope.Query)
+	terms = append(terms, keyTerms(scope.TaskType, scope.Labels, scope.Packages)...)
+
+	seen := make(map[string]bool, len(terms))
+	unique := terms[:0]
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			unique = append(unique, term)
+		}
+	}
+	return unique
+}
+
+// keyTerms turns structured keys into prefixed terms so they only match each other
+func keyTerms(taskType string, labels, packages []string) []string {
+	var terms []string
+	if taskType != "" {
+		terms = append(terms, "type:"+strings.ToLower(taskType))
+	}
+	for _, label := range labels {
+		terms = append(terms, "label:"+strings.ToLower(label))
+	}
+	for _, pkg := range packages {
+		terms = append(terms, "pkg:"+strings.ToLower(pkg))
+	}
+	return terms
+}
+
+// tokenize lower-cases text and splits it into words, dropping very short ones
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := fields[:0]
+	for _, f := range fields {
+		if len(f) > 2 {
+			terms = append(terms, f)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package orchestration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"open-swarm/internal/gates"
+	"open-swarm/internal/prompts"
+)
+
+func TestLearningStoreRanksByScope(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "learnings.jsonl")
+	store, err := NewLearningStore(storePath)
+	if err != nil {
+		t.Fatalf("NewLearningStore failed: %v", err)
+	}
+
+	ctx := context.Background()
+	apiCtx := WithLearningScope(ctx, LearningScope{TaskID: "bd-1", TaskType: "bug", Packages: []string{"internal/api"}})
+	dbCtx := WithLearningScope(ctx, LearningScope{TaskID: "bd-2", TaskType: "feature", Labels: []string{"database"}, Packages: []string{"internal/db"}})
+
+	if err := store.RecordFailure(apiCtx, "bd-1", "Handler tests edited to match broken output"); err != nil {
+		t.Fatalf("RecordFailure failed: %v", err)
+	}
+	if err := store.StorePattern(dbCtx, "Wrap migrations in a transaction"); err != nil {
+		t.Fatalf("StorePattern failed: %v", err)
+	}
+	if err := store.RecordFailure(apiCtx, "bd-1", "Handler tests edited to match broken output"); err != nil {
+		t.Fatalf("RecordFailure failed: %v", err)
+	}
+
+	// Reload from disk to prove persistence across runs
+	reloaded, err := NewLearningStore(storePath)
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if reloaded.Len() != 3 {
+		t.Fatalf("Expected 3 entries after reload, got %d", reloaded.Len())
+	}
+
+	query := WithLearningScope(ctx, LearningScope{Packages: []string{"internal/api"}, Query: "fix handler"})
+	patterns, err := reloaded.GetPatterns(query, "bug")
+	if err != nil {
+		t.Fatalf("GetPatterns failed: %v", err)
+	}
+	if len(patterns) != 1 {
+		t.Fatalf("Expected duplicate lessons to collapse to 1 relevant entry, got %v", patterns)
+	}
+	if patterns[0] != "Failed before (bd-1): Handler tests edited to match broken output" {
+		t.Errorf("Unexpected pattern: %q", patterns[0])
+	}
+
+	patterns, _ = reloaded.GetPatterns(ctx, "feature")
+	if len(patterns) != 1 || !strings.Contains(patterns[0], "transaction") {
+		t.Errorf("Expected the feature pattern, got %v", patterns)
+	}
+
+	if patterns, _ := reloaded.GetPatterns(ctx, ""); len(patterns) != 0 {
+		t.Errorf("Expected no patterns for an empty query, got %v", patterns)
+	}
+}
+
+func TestLearningStoreWeightsByLearningValue(t *testing.T) {
+	store, _ := NewLearningStore(filepath.Join(t.TempDir(), "learnings.jsonl"))
+	ctx := context.Background()
+
+	low := WithLearningScope(ctx, LearningScope{TaskType: "task", Weight: 0})
+	high := WithLearningScope(ctx, LearningScope{TaskType: "task", Weight: 1})
+	_ = store.StorePattern(high, "Run go vet before committing")
+	_ = store.StorePattern(low, "Keep diffs small")
+
+	entries := store.Search(LearningScope{TaskType: "task"}, 0)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Text != "Run go vet before committing" {
+		t.Errorf("Expected higher learning value to rank first, got %q", entries[0].Text)
+	}
+}
+
+func TestLearningStoreSkipsTornLines(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "learnings.jsonl")
+	content := `{"kind":"success","task_type":"bug","text":"Reproduce with a failing test first"}` + "\n" + `{"kind":"fail`
+	if err := os.WriteFile(storePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewLearningStore(storePath)
+	if err != nil {
+		t.Fatalf("NewLearningStore failed: %v", err)
+	}
+	if store.Len() != 1 {
+		t.Errorf("Expected 1 entry, got %d", store.Len())
+	}
+}
+
+func TestPackagesFromFiles(t *testing.T) {
+	got := PackagesFromFiles([]string{"internal/api/handler.go", "main.go", "internal/api/routes.go", "pkg/db/db.go"})
+	want := []string{"internal/api", "pkg/db"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("PackagesFromFiles = %v, want %v", got, want)
+	}
+}
+
+func TestCoordinatorFeedsAndStoresLearnings(t *testing.T) {
+	store, _ := NewLearningStore(filepath.Join(t.TempDir(), "learnings.jsonl"))
+	ctx := context.Background()
+	seed := WithLearningScope(ctx, LearningScope{TaskID: "bd-old", TaskType: "bug", Labels: []string{"parser"}})
+	_ = store.RecordFailure(seed, "bd-old", "Forgot to handle empty input")
+
+	var seen []string
+	spawner := func(ctx context.Context, config *AgentConfig) (*AgentResult, error) {
+		seen = config.Learnings
+		return &AgentResult{
+			TaskID:        config.TaskID,
+			Success:       true,
+			FilesModified: []string{"internal/parser/lexer.go"},
+			Mem0Patterns:  []string{"Check empty input in lexer"},
+			LearningValue: 0.8,
+		}, nil
+	}
+
+	coord := NewCoordinator(spawner, store, &MockLogger{})
+	_ = coord.AddAgent(&AgentConfig{TaskID: "bd-new", Title: "Fix parser crash", TaskType: "bug", Labels: []string{"parser"}})
+	if err := coord.Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if len(seen) != 1 || !strings.Contains(seen[0], "Forgot to handle empty input") {
+		t.Errorf("Expected spawner to receive the earlier failure, got %v", seen)
+	}
+
+	entries := store.Search(LearningScope{Packages: []string{"internal/parser"}}, 0)
+	if len(entries) != 1 || entries[0].Weight != 0.8 || entries[0].TaskID != "bd-new" {
+		t.Errorf("Expected reported pattern to be stored with its scope, got %+v", entries)
+	}
+
+	metrics := coord.GetMetrics()
+	if metrics.LearningCount != 1 || metrics.MemoriesStored != 1 {
+		t.Errorf("Expected learning metrics to count stored patterns, got %+v", metrics)
+	}
+}
+
+func TestSpawnerPromptsCarryLearnings(t *testing.T) {
+	store, _ := NewLearningStore(filepath.Join(t.TempDir(), "learnings.jsonl"))
+	ctx := context.Background()
+	seed := WithLearningScope(ctx, LearningScope{TaskID: "bd-old", TaskType: "bug", Labels: []string{"parser"}})
+	_ = store.RecordFailure(seed, "bd-old", "Forgot to handle empty input")
+
+	executor := func(ctx context.Context, config *AgentConfig) (string, []string, error) {
+		return "Implementation code", []string{"internal/parser/lexer.go"}, nil
+	}
+	testRunner := func(ctx context.Context, config *AgentConfig) (*gates.TestResult, error) {
+		return &gates.TestResult{Total: 1, Passed: 1}, nil
+	}
+	spawner := NewSpawner(gates.NewGateChain(), store, &MockLogger{}, executor, testRunner)
+
+	coord := NewCoordinator(spawner.Spawn, store, &MockLogger{})
+	config := &AgentConfig{
+		TaskID:             "bd-new",
+		Title:              "Fix parser crash",
+		AcceptanceCriteria: "- Empty input returns an error",
+		TaskType:           "bug",
+		Labels:             []string{"parser"},
+		RequirementsForGate: &gates.Requirement{
+			TaskID:     "bd-new",
+			Title:      "Fix parser crash",
+			Acceptance: "Empty input returns an error",
+		},
+	}
+	_ = coord.AddAgent(config)
+	if err := coord.Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	for name, p := range map[string]string{"test generation": config.TestPrompt, "implementation": config.Prompt} {
+		if !strings.Contains(p, prompts.LearningsHeading) || !strings.Contains(p, "Forgot to handle empty input") {
+			t.Errorf("Expected %s prompt to carry the earlier failure, got:\n%s", name, p)
+		}
+		if !strings.Contains(p, "Empty input returns an error") {
+			t.Errorf("Expected %s prompt to carry the acceptance criteria, got:\n%s", name, p)
+		}
+	}
+}
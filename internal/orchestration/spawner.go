@@ -3,9 +3,12 @@ package orchestration
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"open-swarm/internal/gates"
+	"open-swarm/internal/infra"
+	"open-swarm/internal/prompts"
 )
 
 // Spawner handles agent spawning and execution with proper isolation and gate execution.
@@ -16,6 +19,8 @@ type Spawner struct {
 	executorFunc   ExecutorFunc // Function to execute agent
 	testRunnerFunc TestRunnerFunc // Function to run tests
 	gateTimeout    time.Duration // Gate execution timeout
+	worktrees      infra.WorktreeManagerInterface // Optional; gives each agent its own worktree
+	baseBranch     string        // Ref agent worktrees branch from
 }
 
 // ExecutorFunc defines the agent execution signature.
@@ -49,6 +54,16 @@ func (s *Spawner) Spawn(ctx context.Context, config *AgentConfig) (*AgentResult,
 		Timestamp: time.Now(),
 	}
 
+	ctx = WithLearningScope(ctx, ScopeForAgent(config))
+	s.buildPrompts(config)
+	if err := s.prepareWorktree(config); err != nil {
+		result.Success = false
+		result.FailureReason = "Worktree creation failed"
+		result.Error = fmt.Sprintf("Worktree error: %v", err)
+		_ = s.recordFailure(ctx, result)
+		return result, nil
+	}
+
 	startTime := time.Now()
 	defer func() {
 		result.ExecutionTime = time.Since(startTime)
@@ -322,6 +337,10 @@ func (s *Spawner) executeGate5(ctx context.Context, config *AgentConfig, impleme
 	// Create gate instance
 	gate := gates.NewRequirementDriftDetectionGate(config.TaskID, config.RequirementsForGate)
 	gate.SetCurrentImplementation(implementationCode)
+	if config.WorkDir != "" {
+		// Judge drift from the worktree diff and mapped tests rather than keywords
+		gate.SetDriftAnalyzer(gates.NewDriftAnalyzer(config.WorkDir))
+	}
 
 	// Execute gate
 	err := gate.Check(ctx)
@@ -342,13 +361,82 @@ func (s *Spawner) executeGate5(ctx context.Context, config *AgentConfig, impleme
 	return result, nil
 }
 
+// prepareWorktree creates the agent's worktree and records its path as the
+// agent's WorkDir, so diff-based gates judge the agent's changes. Configs that
+// already name a WorkDir, such as retries, keep it.
+func (s *Spawner) prepareWorktree(config *AgentConfig) error {
+	if s.worktrees == nil || config.WorkDir != "" {
+		return nil
+	}
+	worktree, err := s.worktrees.CreateWorktree(config.TaskID, s.baseBranch)
+	if err != nil {
+		return err
+	}
+	config.WorkDir = worktree.Path
+	return nil
+}
+
+// buildPrompts renders the prompts the executor sends to the agent, with the
+// learnings the coordinator loaded. Prompts the caller already set are kept.
+func (s *Spawner) buildPrompts(config *AgentConfig) {
+	if config.TestPrompt == "" {
+		config.TestPrompt = TestGenerationPrompt(config)
+	}
+	if config.Prompt == "" {
+		config.Prompt = ImplementationPrompt(config)
+	}
+}
+
+// TestGenerationPrompt renders the prompt asking an agent to write the tests for a task.
+func TestGenerationPrompt(config *AgentConfig) string {
+	builder := prompts.NewTestGenerationBuilder().
+		WithTaskDescription(taskDescription(config)).
+		WithLearnings(config.Learnings)
+	for _, criterion := range acceptanceCriteria(config) {
+		builder.AddRequiredCriteria(criterion)
+	}
+	return builder.BuildPrompt()
+}
+
+// ImplementationPrompt renders the prompt asking an agent to implement a task.
+func ImplementationPrompt(config *AgentConfig) string {
+	builder := prompts.NewImplementationBuilder(taskDescription(config))
+	if criteria := acceptanceCriteria(config); len(criteria) > 0 {
+		builder.WithContext("Acceptance Criteria", "- "+strings.Join(criteria, "\n- "))
+	}
+	return builder.WithLearnings(config.Learnings).Build()
+}
+
+func taskDescription(config *AgentConfig) string {
+	if config.Description == "" {
+		return config.Title
+	}
+	return config.Title + "\n\n" + config.Description
+}
+
+// acceptanceCriteria lists the acceptance criteria lines, scenarios and edge cases.
+func acceptanceCriteria(config *AgentConfig) []string {
+	var criteria []string
+	for _, line := range strings.Split(config.AcceptanceCriteria, "\n") {
+		if line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*")); line != "" {
+			criteria = append(criteria, line)
+		}
+	}
+	criteria = append(criteria, config.Scenarios...)
+	return append(criteria, config.EdgeCases...)
+}
+
 // recordFailure captures failure context for learning.
 func (s *Spawner) recordFailure(ctx context.Context, result *AgentResult) error {
 	if s.mem0Client == nil {
 		return nil
 	}
 
-	return s.mem0Client.RecordFailure(ctx, result.TaskID, result.FailureReason)
+	rootCause := result.FailureReason
+	if result.Error != "" {
+		rootCause = fmt.Sprintf("%s (%s)", result.FailureReason, result.Error)
+	}
+	return s.mem0Client.RecordFailure(s.learningContext(ctx, result), result.TaskID, rootCause)
 }
 
 // recordSuccess stores successful patterns for learning.
@@ -362,7 +450,27 @@ func (s *Spawner) recordSuccess(ctx context.Context, result *AgentResult) error
 		result.TaskID, result.ExecutionTime, result.TestResult.Passed, result.TestResult.Total, result.TokensUsed,
 	)
 
-	return s.mem0Client.StorePattern(ctx, pattern)
+	return s.mem0Client.StorePattern(s.learningContext(ctx, result), pattern)
+}
+
+// learningContext keys recorded learnings by the packages the agent touched.
+func (s *Spawner) learningContext(ctx context.Context, result *AgentResult) context.Context {
+	scope, ok := LearningScopeFromContext(ctx)
+	if !ok {
+		scope = LearningScope{TaskID: result.TaskID}
+	}
+	if len(result.FilesModified) > 0 {
+		scope.Packages = PackagesFromFiles(result.FilesModified)
+	}
+	return WithLearningScope(ctx, scope)
+}
+
+// SetWorktreeManager runs each agent in its own worktree branched from
+// baseBranch. Worktrees are left in place for merging; the manager's
+// CleanupAll removes them.
+func (s *Spawner) SetWorktreeManager(manager infra.WorktreeManagerInterface, baseBranch string) {
+	s.worktrees = manager
+	s.baseBranch = baseBranch
 }
 
 // SetGateTimeout sets the timeout for gate execution.
@@ -2,10 +2,15 @@ package orchestration
 
 import (
 	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"open-swarm/internal/gates"
+	"open-swarm/internal/infra"
 )
 
 // TestSpawnerBasicExecution tests basic spawner - just verifies result structure
@@ -318,3 +323,72 @@ func TestSpawnerExecutionTime(t *testing.T) {
 		t.Fatalf("ExecutionTime should be positive, got %v", result.ExecutionTime)
 	}
 }
+
+// TestSpawnerWorktreeFeedsSemanticDrift tests that agents get their own worktree
+// and that drift detection judges its diff
+func TestSpawnerWorktreeFeedsSemanticDrift(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repoDir := t.TempDir()
+	writeFile := func(dir, name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(repoDir, "go.mod", "module example.com/drift\n\ngo 1.21\n")
+	writeFile(repoDir, "validate/validate.go", "package validate\n\n// Email reports whether s looks like an email address.\nfunc Email(s string) bool { return len(s) > 0 }\n")
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.email=t@example.com", "-c", "user.name=t", "commit", "-q", "-m", "base"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+
+	executor := func(ctx context.Context, config *AgentConfig) (string, []string, error) {
+		return "code", nil, nil
+	}
+	testRunner := func(ctx context.Context, config *AgentConfig) (*gates.TestResult, error) {
+		return &gates.TestResult{Total: 1, Passed: 1}, nil
+	}
+	spawner := NewSpawner(gates.NewGateChain(), &MockMem0Client{}, &MockLogger{}, executor, testRunner)
+	worktreeDir := filepath.Join(t.TempDir(), "worktrees")
+	spawner.SetWorktreeManager(infra.NewWorktreeManager(repoDir, worktreeDir), "HEAD")
+
+	config := &AgentConfig{
+		TaskID: "task-1",
+		Title:  "Validate email",
+		RequirementsForGate: &gates.Requirement{
+			TaskID: "task-1",
+			Title:  "Validate email",
+			Scope:  []string{"validate"},
+		},
+	}
+	ctx := context.Background()
+	if _, err := spawner.Spawn(ctx, config); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	if config.WorkDir != filepath.Join(worktreeDir, "task-1") {
+		t.Fatalf("Expected WorkDir to be the agent's worktree, got %q", config.WorkDir)
+	}
+
+	// The agent adds an exported symbol outside the declared scope
+	writeFile(config.WorkDir, "metrics/metrics.go", "package metrics\n\n// Counter is unrelated to the task.\ntype Counter struct{}\n")
+	result, err := spawner.executeGate5(ctx, config, "")
+	if err != nil {
+		t.Fatalf("Gate 5 failed to run: %v", err)
+	}
+	if result.Passed || !strings.Contains(result.Message, "out of scope") {
+		t.Errorf("Expected semantic drift to flag the out of scope symbol, got %+v", result)
+	}
+}
@@ -2,8 +2,11 @@ package prompts
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
+
+	"open-swarm/internal/prompts/templates"
 )
 
 // GetBuilder returns the appropriate prompt builder for the given review type
@@ -15,6 +18,12 @@ func GetBuilder(reviewType ReviewType) (PromptBuilder, error) {
 		return NewFunctionalReviewBuilder(), nil
 	case ReviewTypeTesting:
 		return NewTestingReviewBuilder(), nil
+	case ReviewTypeSecurity:
+		return NewSecurityReviewBuilder(), nil
+	case ReviewTypePerformance:
+		return NewPerformanceReviewBuilder(), nil
+	case ReviewTypeAPICompatibility:
+		return NewAPICompatibilityReviewBuilder(), nil
 	default:
 		return nil, fmt.Errorf("unknown review type: %s", reviewType)
 	}
@@ -22,11 +31,33 @@ func GetBuilder(reviewType ReviewType) (PromptBuilder, error) {
 
 // BuildPrompt is a convenience function that creates a prompt for the given request
 func BuildPrompt(request ReviewRequest) (string, error) {
-	builder, err := GetBuilder(request.Type)
+	prompt, err := RenderReviewPrompt(request)
 	if err != nil {
 		return "", err
 	}
-	return builder.Build(request)
+	return prompt.Text, nil
+}
+
+// ReviewTemplateName is the template name of a review type's prompt, e.g.
+// "review_security"
+func ReviewTemplateName(reviewType ReviewType) string {
+	return "review_" + string(reviewType)
+}
+
+// RenderReviewPrompt creates a review prompt from the process-wide template
+// registry, stamped with the template version. Templates render with the
+// ReviewRequest; without a template the type's builder is used.
+func RenderReviewPrompt(request ReviewRequest) (templates.Prompt, error) {
+	builder, err := GetBuilder(request.Type)
+	if err != nil {
+		return templates.Prompt{}, err
+	}
+	builtin, err := builder.Build(request)
+	if err != nil {
+		return templates.Prompt{}, err
+	}
+	return templates.Default().Render(ReviewTemplateName(request.Type), request.TaskID, request,
+		func() string { return builtin })
 }
 
 // BuildArchitecturePrompt creates an architecture review prompt
@@ -47,6 +78,24 @@ func BuildTestingPrompt(request ReviewRequest) (string, error) {
 	return NewTestingReviewBuilder().Build(request)
 }
 
+// BuildSecurityPrompt creates a security review prompt
+func BuildSecurityPrompt(request ReviewRequest) (string, error) {
+	request.Type = ReviewTypeSecurity
+	return NewSecurityReviewBuilder().Build(request)
+}
+
+// BuildPerformancePrompt creates a performance review prompt
+func BuildPerformancePrompt(request ReviewRequest) (string, error) {
+	request.Type = ReviewTypePerformance
+	return NewPerformanceReviewBuilder().Build(request)
+}
+
+// BuildAPICompatibilityPrompt creates a public API compatibility review prompt
+func BuildAPICompatibilityPrompt(request ReviewRequest) (string, error) {
+	request.Type = ReviewTypeAPICompatibility
+	return NewAPICompatibilityReviewBuilder().Build(request)
+}
+
 // BuildAllReviewPrompts creates prompts for all three review types
 func BuildAllReviewPrompts(baseRequest ReviewRequest) (map[ReviewType]string, error) {
 	results := make(map[ReviewType]string)
@@ -89,6 +138,7 @@ type ImplementationBuilder struct {
 	testContents    string
 	reviewFeedback  string
 	testFailures    string
+	relatedCode     string
 	context         map[string]string
 	metadata        map[string]interface{}
 }
@@ -138,12 +188,27 @@ func (b *ImplementationBuilder) WithTestFailures(failures string) *Implementatio
 	return b
 }
 
+// WithRelatedCode includes existing code packed for the task, so the agent
+// does not have to search the repository for it
+func (b *ImplementationBuilder) WithRelatedCode(pack *ContextPack) *ImplementationBuilder {
+	b.relatedCode = pack.Format()
+	return b
+}
+
 // WithContext adds relevant context information (e.g., related files, architecture notes)
 func (b *ImplementationBuilder) WithContext(key, value string) *ImplementationBuilder {
 	b.context[key] = value
 	return b
 }
 
+// WithLearnings adds lessons from previous runs (what worked, what failed) as context
+func (b *ImplementationBuilder) WithLearnings(learnings []string) *ImplementationBuilder {
+	if len(learnings) > 0 {
+		b.WithContext(LearningsHeading, FormatLearnings(learnings))
+	}
+	return b
+}
+
 // WithMetadata adds metadata about the request (e.g., agent info, timestamps)
 func (b *ImplementationBuilder) WithMetadata(key string, value interface{}) *ImplementationBuilder {
 	b.metadata[key] = value
@@ -197,11 +262,23 @@ func (b *ImplementationBuilder) Build() string {
 		sb.WriteString("Please fix the implementation to make these tests pass.\n\n")
 	}
 
-	// Write additional context
+	// Write existing code related to the task
+	if b.relatedCode != "" {
+		sb.WriteString(fmt.Sprintf("## %s\n\n", RelatedCodeHeading))
+		sb.WriteString(b.relatedCode)
+		sb.WriteString("\n\n")
+	}
+
+	// Write additional context in a stable order
 	if len(b.context) > 0 {
 		sb.WriteString("## Context & Architecture\n\n")
-		for key, value := range b.context {
-			sb.WriteString(fmt.Sprintf("### %s\n\n%s\n\n", key, value))
+		keys := make([]string, 0, len(b.context))
+		for key := range b.context {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			sb.WriteString(fmt.Sprintf("### %s\n\n%s\n\n", key, b.context[key]))
 		}
 	}
 
@@ -287,6 +364,21 @@ func BuildImplementationPrompt(request *PromptRequest) string {
 	for key, value := range request.Context {
 		builder.WithContext(key, value)
 	}
+	builder.WithLearnings(request.Learnings)
+	builder.WithRelatedCode(request.RelatedCode)
 
 	return builder.Build()
 }
+
+// LearningsHeading titles the section that carries lessons from previous runs
+const LearningsHeading = "Lessons From Previous Runs"
+
+// FormatLearnings renders lessons from previous runs as a markdown list
+func FormatLearnings(learnings []string) string {
+	var sb strings.Builder
+	sb.WriteString("Apply what worked and avoid repeating these failures:\n\n")
+	for _, learning := range learnings {
+		sb.WriteString(fmt.Sprintf("- %s\n", learning))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,77 @@
+package prompts
+
+import (
+	"strings"
+)
+
+// APICompatibilityReviewBuilder builds prompts for public API compatibility reviews
+type APICompatibilityReviewBuilder struct{}
+
+// NewAPICompatibilityReviewBuilder creates a new API compatibility review prompt builder
+func NewAPICompatibilityReviewBuilder() *APICompatibilityReviewBuilder {
+	return &APICompatibilityReviewBuilder{}
+}
+
+// Build creates an API compatibility review prompt from the request
+func (b *APICompatibilityReviewBuilder) Build(request ReviewRequest) (string, error) {
+	if err := validateRequest(request); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	// Role and context
+	sb.WriteString("You are a library maintainer reviewing changes to a public Go API.\n\n")
+	sb.WriteString("# Review Focus: Public API Compatibility\n\n")
+
+	writeReviewContext(&sb, request)
+
+	// Exported symbol diff
+	sb.WriteString("## Exported Symbol Changes\n\n")
+	if request.APIChanges != "" {
+		sb.WriteString("Changes detected in exported symbols (`+` added, `-` removed, `~` changed):\n\n")
+		sb.WriteString("```\n")
+		sb.WriteString(request.APIChanges)
+		sb.WriteString("\n```\n\n")
+	} else {
+		sb.WriteString("No changes to exported symbols were detected.\n\n")
+	}
+
+	// Review criteria
+	sb.WriteString("## Compatibility Review Criteria\n\n")
+
+	sb.WriteString("### Breaking Changes\n")
+	sb.WriteString("- Were exported functions, types, methods, fields or constants removed or renamed?\n")
+	sb.WriteString("- Did function signatures change (parameters, results, variadics)?\n")
+	sb.WriteString("- Were methods added to exported interfaces that callers implement?\n")
+	sb.WriteString("- Did struct changes break positional composite literals or comparability?\n\n")
+
+	sb.WriteString("### Behavioral Compatibility\n")
+	sb.WriteString("- Do existing callers observe different results, errors or side effects?\n")
+	sb.WriteString("- Did zero values, defaults or error sentinel values change meaning?\n\n")
+
+	sb.WriteString("### Evolution Strategy\n")
+	sb.WriteString("- Could the change be made additively (new function, option, or field)?\n")
+	sb.WriteString("- Are deprecated symbols kept with a `Deprecated:` doc comment?\n")
+	sb.WriteString("- Are new exported symbols documented and deliberately public?\n\n")
+
+	// Instructions
+	sb.WriteString("## Review Instructions\n\n")
+	sb.WriteString("For each compatibility issue, report:\n\n")
+	sb.WriteString("1. **Severity** (critical/major/minor/suggestion)\n")
+	sb.WriteString("2. **Symbol** affected\n")
+	sb.WriteString("3. **Impact** on existing callers\n")
+	sb.WriteString("4. **Compatible alternative** if one exists\n\n")
+
+	writeVoteSection(&sb, request,
+		"No breaking changes, or breaks are intended and documented",
+		"Compatibility issues with a compatible alternative",
+		"Unjustified breaking change to the public API")
+
+	return sb.String(), nil
+}
+
+// GetReviewType returns the review type this builder handles
+func (b *APICompatibilityReviewBuilder) GetReviewType() ReviewType {
+	return ReviewTypeAPICompatibility
+}
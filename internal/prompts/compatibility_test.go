@@ -0,0 +1,57 @@
+package prompts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAPICompatibilityReviewBuilder_GetReviewType(t *testing.T) {
+	if got := NewAPICompatibilityReviewBuilder().GetReviewType(); got != ReviewTypeAPICompatibility {
+		t.Errorf("Expected ReviewTypeAPICompatibility, got %v", got)
+	}
+}
+
+func TestAPICompatibilityReviewBuilder_Build_WithAPIChanges(t *testing.T) {
+	request := ReviewRequest{
+		TaskID:          "API-001",
+		TaskDescription: "Add timeout to client constructor",
+		CodeContext: CodeContext{
+			Diff: "-func New(addr string) *Client\n+func New(addr string, timeout time.Duration) *Client",
+		},
+		APIChanges:  "pkg/client/client.go:\n  ~ function New: func(addr string) *Client -> func(addr string, timeout time.Duration) *Client\n",
+		RequireVote: true,
+	}
+
+	prompt, err := BuildAPICompatibilityPrompt(request)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"API-001",
+		"## Exported Symbol Changes",
+		"~ function New",
+		"### Breaking Changes",
+		"VOTE: APPROVE",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Prompt should contain %q", want)
+		}
+	}
+}
+
+func TestAPICompatibilityReviewBuilder_Build_NoAPIChanges(t *testing.T) {
+	request := ReviewRequest{
+		TaskID:          "API-002",
+		TaskDescription: "Refactor internals",
+		CodeContext:     CodeContext{FileContent: "package client\n"},
+	}
+
+	prompt, err := NewAPICompatibilityReviewBuilder().Build(request)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if !strings.Contains(prompt, "No changes to exported symbols were detected") {
+		t.Error("Prompt should note that no exported symbols changed")
+	}
+}
@@ -0,0 +1,579 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"open-swarm/internal/opencode"
+)
+
+// DefaultContextBudget is the token budget for packed code when a request
+// sets none
+const DefaultContextBudget = 4000
+
+// RelatedCodeHeading titles the prompt section that carries packed code
+const RelatedCodeHeading = "Related Code"
+
+// Scores of the evidence that ties a symbol to the task
+const (
+	scoreFailingTest = 10 // Used by a failing test
+	scoreTest        = 4  // Used by a test
+	scoreSignature   = 3  // Type in the signature of a used function
+	scoreDescription = 2  // Named in the task description
+	scoreCaller      = 1  // Calls a used symbol
+)
+
+// ContextRequest describes the task an implementation prompt is packed for
+type ContextRequest struct {
+	Root            string   // Repository root; other paths are relative to it
+	TaskDescription string   // Task text; symbols it names rank higher
+	TestFiles       []string // Test files the implementation must pass
+	FailingTests    []string // Failing test names, e.g. "TestAdd/negative"
+	Budget          int      // Token budget (default: DefaultContextBudget)
+}
+
+// ContextSnippet is a symbol's source packed into a prompt
+type ContextSnippet struct {
+	File      string // Path relative to the root
+	Symbol    string
+	StartLine int
+	EndLine   int
+	Score     int
+	Reason    string // Strongest evidence, e.g. "used by failing test"
+	Code      string
+}
+
+// ContextPack is the related code that fit the token budget
+type ContextPack struct {
+	Snippets []ContextSnippet // Ordered by file, then line
+	Tokens   int              // Estimated tokens of the formatted pack
+	Dropped  int              // Related symbols that did not fit
+}
+
+// ContextPacker finds code related to a task and its tests, ranks it and
+// packs it under a token budget, so agents do not spend turns rediscovering
+// the codebase
+type ContextPacker struct {
+	analyzer opencode.CodeAnalyzer
+	finder   opencode.CodeFinder // Optional; locates symbols outside the candidate packages
+}
+
+// NewContextPacker creates a packer. A nil analyzer uses the default
+// analyzer for the built-in languages; finder may be nil.
+func NewContextPacker(analyzer opencode.CodeAnalyzer, finder opencode.CodeFinder) *ContextPacker {
+	if analyzer == nil {
+		analyzer = opencode.NewCodeAnalyzer()
+	}
+	return &ContextPacker{analyzer: analyzer, finder: finder}
+}
+
+// EstimateTokens approximates the tokens of a text at four bytes per token
+func EstimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+// symbolRef is a name used by the tests. Dir is the package directory it
+// must be defined in; anyDir matches methods in any candidate package.
+type symbolRef struct {
+	dir  string
+	name string
+}
+
+const anyDir = "*"
+
+// testUse is how much the tests use a name
+type testUse struct {
+	score   int
+	failing bool // Used by a failing test
+}
+
+func (u *testUse) reason() string {
+	if u.failing {
+		return "used by failing test"
+	}
+	return "used by test"
+}
+
+// candidate is a symbol scored for packing
+type candidate struct {
+	symbol      *opencode.Symbol
+	file        string // Relative to the root
+	score       int
+	reason      string
+	tested      bool // Used by a test, directly or as a method
+	reasonScore int
+}
+
+// addUse scores a symbol the tests use
+func (c *candidate) addUse(use *testUse) {
+	if use == nil {
+		return
+	}
+	c.add(use.score, use.reason())
+	c.tested = true
+}
+
+func (c *candidate) add(score int, reason string) {
+	c.score += score
+	if score > c.reasonScore {
+		c.reason, c.reasonScore = reason, score
+	}
+}
+
+// Pack ranks the symbols related to the request and packs the highest
+// ranked ones that fit the budget. Snippets come out ordered by file and
+// line, so the same code yields the same prompt.
+func (p *ContextPacker) Pack(ctx context.Context, req ContextRequest) (*ContextPack, error) {
+	budget := req.Budget
+	if budget <= 0 {
+		budget = DefaultContextBudget
+	}
+
+	refs, dirs, err := p.testReferences(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := p.candidates(ctx, req.Root, dirs)
+	if err != nil {
+		return nil, err
+	}
+	candidates = p.locateElsewhere(ctx, req.Root, refs, candidates)
+
+	scoreCandidates(candidates, refs, descriptionWords(req.TaskDescription))
+	p.scoreCallers(ctx, req.Root, candidates)
+
+	ranked := make([]*candidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.score > 0 {
+			ranked = append(ranked, c)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if a.file != b.file {
+			return a.file < b.file
+		}
+		return a.symbol.LineNumber < b.symbol.LineNumber
+	})
+
+	pack := &ContextPack{}
+	remaining := budget
+	for _, c := range ranked {
+		code, err := ExtractSurroundingContext(filepath.Join(req.Root, c.file), c.symbol.LineNumber, c.symbol.EndLine, 0)
+		if err != nil {
+			continue
+		}
+		snippet := ContextSnippet{
+			File:      c.file,
+			Symbol:    symbolName(c.symbol),
+			StartLine: c.symbol.LineNumber,
+			EndLine:   c.symbol.EndLine,
+			Score:     c.score,
+			Reason:    c.reason,
+			Code:      code,
+		}
+		tokens := EstimateTokens(formatSnippet(snippet))
+		if tokens > remaining {
+			pack.Dropped++
+			continue
+		}
+		remaining -= tokens
+		pack.Tokens += tokens
+		pack.Snippets = append(pack.Snippets, snippet)
+	}
+	sort.Slice(pack.Snippets, func(i, j int) bool {
+		a, b := pack.Snippets[i], pack.Snippets[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.StartLine < b.StartLine
+	})
+	return pack, nil
+}
+
+// Format renders the pack as prompt text, one fenced block per snippet
+func (pack *ContextPack) Format() string {
+	if pack == nil || len(pack.Snippets) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("Existing code related to this task. Use it instead of searching the repository:\n\n")
+	for _, snippet := range pack.Snippets {
+		sb.WriteString(formatSnippet(snippet))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func formatSnippet(snippet ContextSnippet) string {
+	return fmt.Sprintf("%s:%d-%d (%s, %s)\n```%s\n%s\n```\n\n",
+		snippet.File, snippet.StartLine, snippet.EndLine, snippet.Symbol, snippet.Reason,
+		getLanguageFromExtension(filepath.Ext(snippet.File)), snippet.Code)
+}
+
+// testReferences collects the names the test files use, weighted by whether
+// the using test is failing, and the package directories to search: the
+// tests' own and the module packages they import
+func (p *ContextPacker) testReferences(ctx context.Context, req ContextRequest) (map[symbolRef]*testUse, []string, error) {
+	failing := make(map[string]bool)
+	for _, name := range req.FailingTests {
+		// pytest node IDs, e.g. "tests/test_calc.py::TestAdd::test_negative"
+		if i := strings.LastIndex(name, "::"); i >= 0 {
+			name = name[i+2:]
+		}
+		name, _, _ = strings.Cut(name, "/")
+		failing[name] = true
+	}
+	module := modulePath(req.Root)
+
+	refs := make(map[symbolRef]*testUse)
+	dirSet := make(map[string]bool)
+	for _, testFile := range req.TestFiles {
+		if filepath.Ext(testFile) != ".go" {
+			if err := p.sourceReferences(ctx, req.Root, testFile, failing, refs, dirSet); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, filepath.Join(req.Root, testFile), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse test file %s: %w", testFile, err)
+		}
+		dir := filepath.ToSlash(filepath.Dir(testFile))
+		dirSet[dir] = true
+
+		imports := make(map[string]string) // Local name to package directory
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			name := path.Base(importPath)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			// Packages outside the module map to no directory
+			importDir := ""
+			if module != "" && strings.HasPrefix(importPath, module+"/") {
+				importDir = strings.TrimPrefix(importPath, module+"/")
+				dirSet[importDir] = true
+			}
+			imports[name] = importDir
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			isFailing := ok && failing[fn.Name.Name]
+			score := scoreTest
+			if isFailing {
+				score = scoreFailingTest
+			}
+			for ref := range declReferences(decl, dir, imports) {
+				if refs[ref] == nil {
+					refs[ref] = &testUse{}
+				}
+				refs[ref].score += score
+				refs[ref].failing = refs[ref].failing || isFailing
+			}
+		}
+	}
+
+	dirs := make([]string, 0, len(dirSet))
+	for dir := range dirSet {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return refs, dirs, nil
+}
+
+// sourceReferences collects the names a test file in a language other than
+// Go uses. Without a parser the names are the identifiers in the text; each
+// counts once per test function, or once for code outside any. The
+// directories searched are the test's own and those of the files its
+// imports resolve to.
+func (p *ContextPacker) sourceReferences(ctx context.Context, root, testFile string, failing map[string]bool, refs map[symbolRef]*testUse, dirSet map[string]bool) error {
+	absPath := filepath.Join(root, testFile)
+	analysis, err := p.analyzer.AnalyzeFile(ctx, absPath)
+	if err != nil {
+		return fmt.Errorf("failed to analyze test file %s: %w", testFile, err)
+	}
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		return fmt.Errorf("failed to read test file %s: %w", testFile, err)
+	}
+
+	dirs := []string{filepath.ToSlash(filepath.Dir(testFile))}
+	addDir := func(dir string) {
+		if !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	if resolver, ok := p.analyzer.(opencode.ImportResolver); ok {
+		for _, importPath := range analysis.Imports {
+			for _, target := range resolver.ResolveImport(absPath, importPath) {
+				rel, err := filepath.Rel(root, target)
+				if err != nil || strings.HasPrefix(rel, "..") {
+					continue
+				}
+				addDir(filepath.ToSlash(filepath.Dir(rel)))
+			}
+		}
+	}
+	for _, dir := range dirs {
+		dirSet[dir] = true
+	}
+
+	// Each line belongs to the innermost test function around it, or to none
+	lines := strings.Split(string(content), "\n")
+	owners := make([]*opencode.Symbol, len(lines)+1)
+	for _, symbol := range analysis.Symbols {
+		if symbol.Kind != opencode.SymbolFunction && symbol.Kind != opencode.SymbolMethod {
+			continue
+		}
+		for line := symbol.LineNumber; line <= symbol.EndLine && line <= len(lines); line++ {
+			owners[line] = symbol
+		}
+	}
+
+	seen := make(map[*opencode.Symbol]map[string]bool)
+	for i, line := range lines {
+		owner := owners[i+1]
+		isFailing := owner != nil && failing[owner.Name]
+		score := scoreTest
+		if isFailing {
+			score = scoreFailingTest
+		}
+		if seen[owner] == nil {
+			seen[owner] = make(map[string]bool)
+		}
+		for _, name := range identPattern.FindAllString(line, -1) {
+			if seen[owner][name] {
+				continue
+			}
+			seen[owner][name] = true
+			for _, dir := range dirs {
+				ref := symbolRef{dir: dir, name: name}
+				if refs[ref] == nil {
+					refs[ref] = &testUse{}
+				}
+				refs[ref].score += score
+				refs[ref].failing = refs[ref].failing || isFailing
+			}
+		}
+	}
+	return nil
+}
+
+// declReferences returns the names a declaration uses, once each
+func declReferences(decl ast.Decl, dir string, imports map[string]string) map[symbolRef]bool {
+	refs := make(map[symbolRef]bool)
+	var inspect func(ast.Node) bool
+	inspect = func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok {
+				if importDir, ok := imports[x.Name]; ok {
+					if importDir != "" {
+						refs[symbolRef{dir: importDir, name: n.Sel.Name}] = true
+					}
+					return false
+				}
+			}
+			// A field or method; only the receiver expression names more
+			refs[symbolRef{dir: anyDir, name: n.Sel.Name}] = true
+			ast.Inspect(n.X, inspect)
+			return false
+		case *ast.Ident:
+			refs[symbolRef{dir: dir, name: n.Name}] = true
+		}
+		return true
+	}
+	ast.Inspect(decl, inspect)
+	return refs
+}
+
+// candidates returns the symbols defined in the non-test source files of
+// dirs, in any language the analyzer supports
+func (p *ContextPacker) candidates(ctx context.Context, root string, dirs []string) ([]*candidate, error) {
+	var candidates []*candidate
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(filepath.Join(root, dir))
+		if err != nil {
+			continue // The package may not exist yet
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			file := path.Join(dir, name)
+			if entry.IsDir() || opencode.LanguageForFile(name) == "" || opencode.IsTestFile(file) {
+				continue
+			}
+			symbols, err := p.analyzer.FindSymbols(ctx, filepath.Join(root, file))
+			if err != nil {
+				return nil, fmt.Errorf("failed to analyze %s: %w", file, err)
+			}
+			for _, symbol := range symbols {
+				candidates = append(candidates, &candidate{symbol: symbol, file: file})
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// locateElsewhere asks the finder for exported Go names the candidate
+// packages do not define and adds the definitions it finds, scored by the
+// tests that use them
+func (p *ContextPacker) locateElsewhere(ctx context.Context, root string, refs map[symbolRef]*testUse, candidates []*candidate) []*candidate {
+	if p.finder == nil {
+		return candidates
+	}
+	defined := make(map[string]bool)
+	for _, c := range candidates {
+		defined[c.symbol.Name] = true
+	}
+	for ref := range refs {
+		if ref.dir == anyDir || defined[ref.name] || !ast.IsExported(ref.name) {
+			continue
+		}
+		matches, err := p.finder.FindSymbols(ctx, ref.name)
+		if err != nil {
+			continue
+		}
+		for _, match := range matches {
+			if match.Name != ref.name {
+				continue
+			}
+			symbols, err := p.analyzer.FindSymbols(ctx, filepath.Join(root, match.File))
+			if err != nil {
+				continue
+			}
+			for _, symbol := range symbols {
+				if symbol.Name != match.Name || symbol.LineNumber != match.Line {
+					continue
+				}
+				c := &candidate{symbol: symbol, file: filepath.ToSlash(match.File)}
+				c.addUse(refs[ref])
+				candidates = append(candidates, c)
+			}
+		}
+	}
+	return candidates
+}
+
+// scoreCandidates scores symbols the tests use, the types in their
+// signatures and the symbols the task description names
+func scoreCandidates(candidates []*candidate, refs map[symbolRef]*testUse, words map[string]bool) {
+	byDirName := make(map[symbolRef]*candidate)
+	for _, c := range candidates {
+		ref := symbolRef{dir: path.Dir(c.file), name: c.symbol.Name}
+		if c.symbol.Receiver == "" {
+			byDirName[ref] = c
+		}
+		c.addUse(refs[ref])
+		if c.symbol.Receiver != "" {
+			c.addUse(refs[symbolRef{dir: anyDir, name: c.symbol.Name}])
+		}
+		if words[strings.ToLower(c.symbol.Name)] {
+			c.add(scoreDescription, "named in task")
+		}
+	}
+
+	for _, c := range candidates {
+		if !c.tested || (c.symbol.Kind != opencode.SymbolFunction && c.symbol.Kind != opencode.SymbolMethod) {
+			continue
+		}
+		for _, typeName := range signatureTypes(c.symbol) {
+			if t, ok := byDirName[symbolRef{dir: path.Dir(c.file), name: typeName}]; ok && t != c {
+				t.add(scoreSignature, "type in signature of "+c.symbol.Name)
+			}
+		}
+	}
+}
+
+// scoreCallers raises functions that call the symbols the tests use
+func (p *ContextPacker) scoreCallers(ctx context.Context, root string, candidates []*candidate) {
+	var used []*candidate
+	for _, c := range candidates {
+		if c.tested && (c.symbol.Kind == opencode.SymbolFunction || c.symbol.Kind == opencode.SymbolMethod) {
+			used = append(used, c)
+		}
+	}
+	for _, callee := range used {
+		callers := make(map[*candidate]bool)
+		for _, c := range candidates {
+			if c == callee || callers[c] || (c.symbol.Kind != opencode.SymbolFunction && c.symbol.Kind != opencode.SymbolMethod) {
+				continue
+			}
+			refs, err := p.analyzer.FindReferences(ctx, filepath.Join(root, c.file), callee.symbol.Name+"(")
+			if err != nil {
+				continue
+			}
+			for _, ref := range refs {
+				if ref.LineNumber > c.symbol.LineNumber && ref.LineNumber <= c.symbol.EndLine {
+					callers[c] = true
+					c.add(scoreCaller, "calls "+callee.symbol.Name)
+					break
+				}
+			}
+		}
+	}
+}
+
+// signatureTypes returns the named types of a function's parameters and
+// results
+func signatureTypes(symbol *opencode.Symbol) []string {
+	exprs := []string{symbol.ReturnType}
+	for _, param := range symbol.Parameters {
+		exprs = append(exprs, param.Type)
+	}
+	var names []string
+	for _, expr := range exprs {
+		names = append(names, identPattern.FindAllString(expr, -1)...)
+	}
+	return names
+}
+
+var identPattern = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// descriptionWords returns the lowercased identifiers of a task description
+func descriptionWords(description string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range identPattern.FindAllString(description, -1) {
+		if len(word) >= 3 {
+			words[strings.ToLower(word)] = true
+		}
+	}
+	return words
+}
+
+// symbolName names a symbol as Go code refers to it
+func symbolName(symbol *opencode.Symbol) string {
+	if symbol.Receiver != "" {
+		return fmt.Sprintf("(%s).%s", symbol.Receiver, symbol.Name)
+	}
+	return symbol.Name
+}
+
+// modulePath reads the module path from the root's go.mod
+func modulePath(root string) string {
+	content, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
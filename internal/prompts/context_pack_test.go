@@ -0,0 +1,348 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package prompts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"open-swarm/internal/opencode"
+)
+
+var packFiles = map[string]string{
+	"go.mod": "module example.com/app\n\ngo 1.25\n",
+	"lib/units/units.go": `package units
+
+// Meters is a length in meters
+type Meters float64
+
+// Feet is a length in feet
+type Feet float64
+`,
+	"pkg/shapes/shapes.go": `package shapes
+
+import "example.com/app/lib/units"
+
+// Rect is a rectangle
+type Rect struct {
+	W, H units.Meters
+}
+
+// Area returns the rectangle's area
+func (r *Rect) Area() units.Meters {
+	return r.W * r.H
+}
+
+// Describe prints a rectangle
+func Describe(r *Rect) string {
+	return fmt.Sprint(r.Area())
+}
+
+// Unrelated is not used by the tests
+func Unrelated() int {
+	return 42
+}
+`,
+	"pkg/shapes/shapes_test.go": `package shapes
+
+import (
+	"testing"
+
+	"example.com/app/lib/units"
+)
+
+func TestArea(t *testing.T) {
+	r := &Rect{W: units.Meters(2), H: 3}
+	if r.Area() != 6 {
+		t.Fatal("wrong area")
+	}
+}
+
+func TestZero(t *testing.T) {
+	var r Rect
+	_ = r
+}
+`,
+}
+
+func writePackRepo(t *testing.T) string {
+	t.Helper()
+	return writePackFiles(t, packFiles)
+}
+
+func writePackFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func packRequest(root string) ContextRequest {
+	return ContextRequest{
+		Root:            root,
+		TaskDescription: "Rectangles report their area",
+		TestFiles:       []string{"pkg/shapes/shapes_test.go"},
+		FailingTests:    []string{"TestArea/square"},
+	}
+}
+
+func TestContextPacker_RanksCodeTheTestsUse(t *testing.T) {
+	root := writePackRepo(t)
+	pack, err := NewContextPacker(nil, nil).Pack(context.Background(), packRequest(root))
+	if err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+
+	var got []string
+	reasons := make(map[string]string)
+	for _, snippet := range pack.Snippets {
+		got = append(got, snippet.File+" "+snippet.Symbol)
+		reasons[snippet.Symbol] = snippet.Reason
+	}
+	want := []string{
+		"lib/units/units.go Meters",
+		"pkg/shapes/shapes.go Rect",
+		"pkg/shapes/shapes.go (*Rect).Area",
+		"pkg/shapes/shapes.go Describe",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("snippets = %q, want %q", got, want)
+	}
+	if reasons["(*Rect).Area"] != "used by failing test" {
+		t.Errorf("Area reason = %q", reasons["(*Rect).Area"])
+	}
+	if reasons["Describe"] != "calls Area" {
+		t.Errorf("Describe reason = %q", reasons["Describe"])
+	}
+	if !strings.Contains(pack.Snippets[2].Code, "return r.W * r.H") {
+		t.Errorf("snippet should hold the symbol's source, got %q", pack.Snippets[2].Code)
+	}
+}
+
+func TestContextPacker_RespectsBudget(t *testing.T) {
+	root := writePackRepo(t)
+	req := packRequest(root)
+	req.Budget = 40
+
+	pack, err := NewContextPacker(nil, nil).Pack(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	if pack.Tokens > req.Budget {
+		t.Errorf("pack uses %d tokens, budget is %d", pack.Tokens, req.Budget)
+	}
+	if pack.Dropped == 0 {
+		t.Error("a small budget should drop snippets")
+	}
+	if len(pack.Snippets) == 0 || pack.Snippets[0].Symbol != "Rect" {
+		t.Errorf("the highest ranked snippet should fit first, got %+v", pack.Snippets)
+	}
+}
+
+func TestContextPacker_StableOutput(t *testing.T) {
+	root := writePackRepo(t)
+	packer := NewContextPacker(nil, nil)
+	first, err := packer.Pack(context.Background(), packRequest(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		again, err := packer.Pack(context.Background(), packRequest(root))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again.Format() != first.Format() {
+			t.Fatal("packing the same code should give the same prompt text")
+		}
+	}
+}
+
+// finderStub finds symbols by name in a fixed table
+type finderStub struct {
+	opencode.CodeFinder
+	symbols map[string]opencode.SymbolMatch
+}
+
+func (f finderStub) FindSymbols(_ context.Context, query string) ([]opencode.SymbolMatch, error) {
+	if match, ok := f.symbols[query]; ok {
+		return []opencode.SymbolMatch{match}, nil
+	}
+	return nil, nil
+}
+
+func TestContextPacker_FinderLocatesOtherPackages(t *testing.T) {
+	root := writePackRepo(t)
+	testFile := filepath.Join(root, "pkg/shapes/convert_test.go")
+	content := "package shapes\n\nimport \"testing\"\n\nfunc TestConvert(t *testing.T) {\n\t_ = ToFeet\n}\n"
+	if err := os.WriteFile(testFile, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "lib/units/convert.go"),
+		[]byte("package units\n\n// ToFeet converts meters\nfunc ToFeet(m Meters) Feet {\n\treturn Feet(m * 3.28)\n}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	finder := finderStub{symbols: map[string]opencode.SymbolMatch{
+		"ToFeet": {Name: "ToFeet", Type: "function", File: "lib/units/convert.go", Line: 4},
+	}}
+	pack, err := NewContextPacker(nil, finder).Pack(context.Background(), ContextRequest{
+		Root:      root,
+		TestFiles: []string{"pkg/shapes/convert_test.go"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pack.Snippets) != 1 || pack.Snippets[0].Symbol != "ToFeet" {
+		t.Errorf("snippets = %+v, want ToFeet from the finder", pack.Snippets)
+	}
+}
+
+func TestContextPacker_OtherLanguages(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		req     ContextRequest
+		want    []string
+		reasons map[string]string
+	}{
+		{
+			name: "typescript",
+			files: map[string]string{
+				"web/src/units.ts": "export type Meters = number;\nexport type Feet = number;\n",
+				"web/src/shapes.ts": `import { Meters } from "./units";
+
+export class Rect {
+  constructor(public w: Meters, public h: Meters) {}
+
+  area(): Meters {
+    return this.w * this.h;
+  }
+}
+
+export function summarize(r: Rect): string {
+  return String(r.area());
+}
+
+export function unrelated(): number {
+  return 42;
+}
+`,
+				"web/test/shapes.test.ts": `import { Rect } from "../src/shapes";
+
+test("area", () => {
+  expect(new Rect(2, 3).area()).toBe(6);
+});
+`,
+			},
+			req: ContextRequest{TestFiles: []string{"web/test/shapes.test.ts"}},
+			want: []string{
+				"web/src/shapes.ts Rect",
+				"web/src/shapes.ts (Rect).area",
+				"web/src/shapes.ts summarize",
+				"web/src/units.ts Meters",
+			},
+			reasons: map[string]string{"(Rect).area": "used by test", "summarize": "calls area", "Meters": "type in signature of area"},
+		},
+		{
+			name: "python",
+			files: map[string]string{
+				"app/__init__.py": "",
+				"app/units.py":    "Meters = float\n",
+				"app/shapes.py": `from .units import Meters
+
+
+class Rect:
+    def __init__(self, w: Meters, h: Meters):
+        self.w = w
+        self.h = h
+
+    def area(self) -> Meters:
+        return self.w * self.h
+
+
+def unrelated():
+    return 42
+`,
+				"tests/test_shapes.py": `from app.shapes import Rect
+
+
+class TestRect:
+    def test_area(self):
+        assert Rect(2, 3).area() == 6
+
+    def test_zero(self):
+        assert Rect(0, 0).w == 0
+`,
+			},
+			req: ContextRequest{
+				TestFiles:    []string{"tests/test_shapes.py"},
+				FailingTests: []string{"tests/test_shapes.py::TestRect::test_area"},
+			},
+			want: []string{
+				"app/shapes.py Rect",
+				"app/shapes.py (Rect).area",
+				"app/units.py Meters",
+			},
+			reasons: map[string]string{"(Rect).area": "used by failing test", "Meters": "type in signature of area"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			req.Root = writePackFiles(t, tt.files)
+			pack, err := NewContextPacker(nil, nil).Pack(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Pack failed: %v", err)
+			}
+
+			var got []string
+			for _, snippet := range pack.Snippets {
+				got = append(got, snippet.File+" "+snippet.Symbol)
+				if want, ok := tt.reasons[snippet.Symbol]; ok && snippet.Reason != want {
+					t.Errorf("%s reason = %q, want %q", snippet.Symbol, snippet.Reason, want)
+				}
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Fatalf("snippets = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImplementationBuilder_WithRelatedCode(t *testing.T) {
+	pack := &ContextPack{Snippets: []ContextSnippet{{
+		File: "pkg/shapes/shapes.go", Symbol: "Rect", StartLine: 5, EndLine: 7,
+		Reason: "used by test", Code: "type Rect struct{}",
+	}}}
+	prompt := NewImplementationBuilder("Add Area").
+		WithRelatedCode(pack).
+		WithContext("B", "second").
+		WithContext("A", "first").
+		Build()
+
+	if !strings.Contains(prompt, "## Related Code") || !strings.Contains(prompt, "pkg/shapes/shapes.go:5-7 (Rect, used by test)\n```go\ntype Rect struct{}\n```") {
+		t.Errorf("prompt should carry the packed code, got:\n%s", prompt)
+	}
+	if strings.Index(prompt, "### A") > strings.Index(prompt, "### B") {
+		t.Error("context sections should be in a stable order")
+	}
+
+	if strings.Contains(NewImplementationBuilder("Add Area").WithRelatedCode(nil).Build(), "## Related Code") {
+		t.Error("an empty pack adds no section")
+	}
+}
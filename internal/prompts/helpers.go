@@ -168,3 +168,67 @@ func max(a, b int) int {
 	}
 	return b
 }
+
+// writeReviewContext writes the task, acceptance criteria and code sections
+// shared by the specialized review prompts
+func writeReviewContext(sb *strings.Builder, request ReviewRequest) {
+	sb.WriteString("## Task Information\n")
+	sb.WriteString(fmt.Sprintf("- **Task ID**: %s\n", request.TaskID))
+	sb.WriteString(fmt.Sprintf("- **Description**: %s\n\n", request.TaskDescription))
+
+	if len(request.AcceptanceCriteria) > 0 {
+		sb.WriteString("## Acceptance Criteria\n")
+		for _, criterion := range request.AcceptanceCriteria {
+			sb.WriteString(fmt.Sprintf("- %s\n", criterion))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Code Under Review\n\n")
+	if request.CodeContext.FilePath != "" {
+		sb.WriteString(fmt.Sprintf("**File**: `%s`\n", request.CodeContext.FilePath))
+		if request.CodeContext.PackageName != "" {
+			sb.WriteString(fmt.Sprintf("**Package**: `%s`\n", request.CodeContext.PackageName))
+		}
+		sb.WriteString("\n")
+	}
+
+	if request.CodeContext.Diff != "" {
+		sb.WriteString("### Changes (Git Diff)\n")
+		sb.WriteString("```diff\n")
+		sb.WriteString(request.CodeContext.Diff)
+		sb.WriteString("\n```\n\n")
+	} else if request.CodeContext.FileContent != "" {
+		sb.WriteString("### Code\n")
+		sb.WriteString(fmt.Sprintf("```%s\n", request.CodeContext.Language))
+		sb.WriteString(request.CodeContext.FileContent)
+		sb.WriteString("\n```\n\n")
+	}
+
+	if request.CodeContext.SurroundingCode != "" {
+		sb.WriteString("### Related Code Context\n")
+		sb.WriteString("```go\n")
+		sb.WriteString(request.CodeContext.SurroundingCode)
+		sb.WriteString("\n```\n\n")
+	}
+
+	if request.AdditionalContext != "" {
+		sb.WriteString("## Additional Context\n")
+		sb.WriteString(request.AdditionalContext)
+		sb.WriteString("\n\n")
+	}
+}
+
+// writeVoteSection writes the vote instructions with review-specific meanings
+func writeVoteSection(sb *strings.Builder, request ReviewRequest, approve, requestChange, reject string) {
+	if !request.RequireVote {
+		sb.WriteString("Provide your assessment without a formal vote.\n")
+		return
+	}
+	sb.WriteString("## Vote Required\n\n")
+	sb.WriteString("Your response MUST end with one of these votes:\n\n")
+	sb.WriteString(fmt.Sprintf("- **VOTE: APPROVE** - %s\n", approve))
+	sb.WriteString(fmt.Sprintf("- **VOTE: REQUEST_CHANGE** - %s\n", requestChange))
+	sb.WriteString(fmt.Sprintf("- **VOTE: REJECT** - %s\n\n", reject))
+	sb.WriteString("Format your vote on the last line as: `VOTE: [APPROVE|REQUEST_CHANGE|REJECT]`\n")
+}
@@ -316,3 +316,21 @@ func TestImplementationBuilderWithAllFields(t *testing.T) {
 		t.Error("failures missing")
 	}
 }
+
+func TestImplementationBuilderWithLearnings(t *testing.T) {
+	prompt := NewImplementationBuilder("Add retry to client").
+		WithLearnings([]string{"Failed before (bd-7): Test immutability check failed"}).
+		Build()
+
+	if !strings.Contains(prompt, "### "+LearningsHeading) {
+		t.Error("Prompt should contain the learnings section")
+	}
+	if !strings.Contains(prompt, "- Failed before (bd-7): Test immutability check failed") {
+		t.Error("Prompt should list each learning")
+	}
+
+	empty := NewImplementationBuilder("Add retry to client").WithLearnings(nil).Build()
+	if strings.Contains(empty, LearningsHeading) {
+		t.Error("Prompt should omit the learnings section when there are none")
+	}
+}
@@ -0,0 +1,74 @@
+package prompts
+
+import (
+	"strings"
+)
+
+// PerformanceReviewBuilder builds prompts for performance-focused code reviews
+type PerformanceReviewBuilder struct{}
+
+// NewPerformanceReviewBuilder creates a new performance review prompt builder
+func NewPerformanceReviewBuilder() *PerformanceReviewBuilder {
+	return &PerformanceReviewBuilder{}
+}
+
+// Build creates a performance review prompt from the request
+func (b *PerformanceReviewBuilder) Build(request ReviewRequest) (string, error) {
+	if err := validateRequest(request); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	// Role and context
+	sb.WriteString("You are a performance engineer reviewing code for efficiency and scalability.\n\n")
+	sb.WriteString("# Review Focus: Performance and Resource Usage\n\n")
+
+	writeReviewContext(&sb, request)
+
+	// Review criteria
+	sb.WriteString("## Performance Review Criteria\n\n")
+	sb.WriteString("Focus on hot paths and code that runs per request, per item or in loops:\n\n")
+
+	sb.WriteString("### Allocations\n")
+	sb.WriteString("- Are slices and maps preallocated when the size is known?\n")
+	sb.WriteString("- Are strings built with `strings.Builder` instead of repeated concatenation?\n")
+	sb.WriteString("- Are values needlessly copied, boxed into interfaces or escaped to the heap?\n\n")
+
+	sb.WriteString("### N+1 and Redundant Work\n")
+	sb.WriteString("- Are queries, RPCs or file reads issued once per item instead of in batch?\n")
+	sb.WriteString("- Is the same result recomputed inside a loop?\n")
+	sb.WriteString("- Are regular expressions or templates compiled on every call?\n\n")
+
+	sb.WriteString("### Concurrency and Lock Contention\n")
+	sb.WriteString("- Are locks held across I/O, network calls or long computations?\n")
+	sb.WriteString("- Could a `sync.RWMutex`, sharding or atomics reduce contention?\n")
+	sb.WriteString("- Are goroutines bounded, and can they leak?\n\n")
+
+	sb.WriteString("### Algorithmic Complexity and I/O\n")
+	sb.WriteString("- Is there quadratic behavior on inputs that can grow?\n")
+	sb.WriteString("- Are readers and writers buffered, and are resources closed promptly?\n")
+	sb.WriteString("- Are timeouts and context cancellation respected?\n\n")
+
+	// Instructions
+	sb.WriteString("## Review Instructions\n\n")
+	sb.WriteString("For each issue found, report:\n\n")
+	sb.WriteString("1. **Severity** (critical/major/minor/suggestion)\n")
+	sb.WriteString("2. **Category** (allocation/n+1/contention/complexity/io/etc.)\n")
+	sb.WriteString("3. **Location** (file:line or function name)\n")
+	sb.WriteString("4. **Impact** estimated in terms of input size or call frequency\n")
+	sb.WriteString("5. **Fix** with a concrete improvement\n\n")
+	sb.WriteString("Do not flag micro-optimizations on cold paths.\n\n")
+
+	writeVoteSection(&sb, request,
+		"No meaningful performance problems",
+		"Performance issues that can be fixed in place",
+		"Design that cannot meet reasonable performance without a different approach")
+
+	return sb.String(), nil
+}
+
+// GetReviewType returns the review type this builder handles
+func (b *PerformanceReviewBuilder) GetReviewType() ReviewType {
+	return ReviewTypePerformance
+}
@@ -0,0 +1,40 @@
+package prompts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPerformanceReviewBuilder_GetReviewType(t *testing.T) {
+	if got := NewPerformanceReviewBuilder().GetReviewType(); got != ReviewTypePerformance {
+		t.Errorf("Expected ReviewTypePerformance, got %v", got)
+	}
+}
+
+func TestPerformanceReviewBuilder_Build(t *testing.T) {
+	request := ReviewRequest{
+		TaskID:          "PERF-001",
+		TaskDescription: "Load orders for users",
+		CodeContext: CodeContext{
+			Diff: "+for _, u := range users {\n+\torders = append(orders, db.Orders(u.ID)...)\n+}",
+		},
+	}
+
+	prompt, err := NewPerformanceReviewBuilder().Build(request)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"PERF-001",
+		"```diff",
+		"### Allocations",
+		"### N+1 and Redundant Work",
+		"### Concurrency and Lock Contention",
+		"without a formal vote",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Prompt should contain %q", want)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package prompts
 import (
 	"strings"
 	"testing"
+
+	"open-swarm/internal/prompts/templates"
 )
 
 func TestArchitectureReviewBuilder(t *testing.T) {
@@ -222,6 +224,9 @@ func TestGetBuilder(t *testing.T) {
 		{ReviewTypeArchitecture, false},
 		{ReviewTypeFunctional, false},
 		{ReviewTypeTesting, false},
+		{ReviewTypeSecurity, false},
+		{ReviewTypePerformance, false},
+		{ReviewTypeAPICompatibility, false},
 		{"invalid", true},
 	}
 
@@ -267,6 +272,40 @@ func TestBuildPrompt(t *testing.T) {
 	assertContains(t, prompt, "TASK-005")
 }
 
+func TestRenderReviewPrompt_Template(t *testing.T) {
+	previous := templates.Default()
+	t.Cleanup(func() { templates.SetDefault(previous) })
+
+	request := ReviewRequest{
+		Type:            ReviewTypeSecurity,
+		TaskID:          "TASK-006",
+		TaskDescription: "Test templates",
+		CodeContext:     CodeContext{FileContent: "package main"},
+	}
+
+	prompt, err := RenderReviewPrompt(request)
+	if err != nil {
+		t.Fatalf("RenderReviewPrompt failed: %v", err)
+	}
+	if prompt.Stamp() != "review_security@builtin" {
+		t.Errorf("without templates the builder is used, got %s", prompt.Stamp())
+	}
+
+	registry := templates.NewRegistry()
+	if err := registry.Register(ReviewTemplateName(ReviewTypeSecurity), "v2", "Audit {{.TaskID}}: {{.CodeContext.FileContent}}"); err != nil {
+		t.Fatal(err)
+	}
+	templates.SetDefault(registry)
+
+	text, err := BuildPrompt(request)
+	if err != nil {
+		t.Fatalf("BuildPrompt failed: %v", err)
+	}
+	if text != "Audit TASK-006: package main" {
+		t.Errorf("prompt = %q, want the template", text)
+	}
+}
+
 func TestBuildAllReviewPrompts(t *testing.T) {
 	request := ReviewRequest{
 		TaskID:          "TASK-006",
@@ -0,0 +1,78 @@
+package prompts
+
+import (
+	"strings"
+)
+
+// SecurityReviewBuilder builds prompts for security-focused code reviews
+type SecurityReviewBuilder struct{}
+
+// NewSecurityReviewBuilder creates a new security review prompt builder
+func NewSecurityReviewBuilder() *SecurityReviewBuilder {
+	return &SecurityReviewBuilder{}
+}
+
+// Build creates a security review prompt from the request
+func (b *SecurityReviewBuilder) Build(request ReviewRequest) (string, error) {
+	if err := validateRequest(request); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	// Role and context
+	sb.WriteString("You are an application security engineer performing a security code review.\n\n")
+	sb.WriteString("# Review Focus: Security Vulnerabilities\n\n")
+
+	writeReviewContext(&sb, request)
+
+	// Review criteria
+	sb.WriteString("## Security Review Criteria\n\n")
+	sb.WriteString("Assume all external input is attacker-controlled and check for:\n\n")
+
+	sb.WriteString("### Injection\n")
+	sb.WriteString("- Is user input concatenated into SQL, shell commands, templates or queries?\n")
+	sb.WriteString("- Are parameterized queries and proper escaping used?\n")
+	sb.WriteString("- Can input reach format strings, regular expressions or log lines unsanitized?\n\n")
+
+	sb.WriteString("### Secrets and Credentials\n")
+	sb.WriteString("- Are API keys, tokens, passwords or private keys hard-coded or committed?\n")
+	sb.WriteString("- Are secrets logged, returned in errors or included in telemetry?\n")
+	sb.WriteString("- Is sensitive data compared in constant time where it matters?\n\n")
+
+	sb.WriteString("### Unsafe Execution\n")
+	sb.WriteString("- Does `os/exec` receive user-controlled program names or arguments?\n")
+	sb.WriteString("- Are commands run through a shell (`sh -c`) when they need not be?\n")
+	sb.WriteString("- Is `unsafe`, reflection or dynamic loading used on untrusted data?\n\n")
+
+	sb.WriteString("### Path Traversal and File Access\n")
+	sb.WriteString("- Can user input produce paths containing `..` or absolute paths?\n")
+	sb.WriteString("- Are paths cleaned and checked to stay inside the intended root?\n")
+	sb.WriteString("- Are file permissions and temporary files created safely?\n\n")
+
+	sb.WriteString("### Authentication, Authorization and Transport\n")
+	sb.WriteString("- Are access checks performed before sensitive operations?\n")
+	sb.WriteString("- Is TLS verification disabled or weak cryptography used?\n")
+	sb.WriteString("- Are resource limits applied to untrusted input (size, time, count)?\n\n")
+
+	// Instructions
+	sb.WriteString("## Review Instructions\n\n")
+	sb.WriteString("For each vulnerability found, report:\n\n")
+	sb.WriteString("1. **Severity** (critical/major/minor/suggestion)\n")
+	sb.WriteString("2. **Category** (injection/secrets/exec/path-traversal/authz/crypto/etc.)\n")
+	sb.WriteString("3. **Location** (file:line or function name)\n")
+	sb.WriteString("4. **Exploit scenario** describing how an attacker would abuse it\n")
+	sb.WriteString("5. **Fix** with a concrete remediation\n\n")
+
+	writeVoteSection(&sb, request,
+		"No exploitable issues found",
+		"Issues that can be fixed in place",
+		"Exploitable vulnerability or unsafe design that needs a different approach")
+
+	return sb.String(), nil
+}
+
+// GetReviewType returns the review type this builder handles
+func (b *SecurityReviewBuilder) GetReviewType() ReviewType {
+	return ReviewTypeSecurity
+}
@@ -0,0 +1,51 @@
+package prompts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecurityReviewBuilder_GetReviewType(t *testing.T) {
+	if got := NewSecurityReviewBuilder().GetReviewType(); got != ReviewTypeSecurity {
+		t.Errorf("Expected ReviewTypeSecurity, got %v", got)
+	}
+}
+
+func TestSecurityReviewBuilder_Build(t *testing.T) {
+	request := ReviewRequest{
+		TaskID:          "SEC-001",
+		TaskDescription: "Run user-provided scripts",
+		CodeContext: CodeContext{
+			FilePath:    "runner/exec.go",
+			FileContent: "package runner\n\nfunc Run(name string) error {\n\treturn exec.Command(\"sh\", \"-c\", name).Run()\n}",
+			Language:    "go",
+		},
+		RequireVote: true,
+	}
+
+	prompt, err := NewSecurityReviewBuilder().Build(request)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"SEC-001",
+		"runner/exec.go",
+		"exec.Command",
+		"### Injection",
+		"### Secrets and Credentials",
+		"### Unsafe Execution",
+		"### Path Traversal",
+		"VOTE: REJECT",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Prompt should contain %q", want)
+		}
+	}
+}
+
+func TestSecurityReviewBuilder_Build_ValidationError(t *testing.T) {
+	if _, err := NewSecurityReviewBuilder().Build(ReviewRequest{TaskID: "SEC-002"}); err == nil {
+		t.Error("Expected error for missing description and code")
+	}
+}
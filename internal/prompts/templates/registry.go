@@ -0,0 +1,265 @@
+// Package templates loads agent prompt templates from a directory, so prompts
+// can be tuned without a rebuild, and routes a share of tasks to a variant
+// template for A/B evaluation.
+//
+// A template directory holds one subdirectory per prompt, one text/template
+// file per version, and an optional variants.yaml:
+//
+//	prompts/
+//	  gen_impl/
+//	    v1.tmpl
+//	    v2.tmpl
+//	  variants.yaml
+//
+// variants.yaml picks the version of prompts with several, and splits tasks
+// between two versions:
+//
+//	gen_impl:
+//	  a: v1
+//	  b: v2
+//	  b_percent: 20
+//
+// Prompts without a template file, and the version "builtin", use the prompt
+// built in Go code.
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BuiltinVersion is the version of the prompts built in Go code
+const BuiltinVersion = "builtin"
+
+// DirEnv names the environment variable that points at a template directory
+const DirEnv = "OPEN_SWARM_PROMPT_DIR"
+
+// VariantsFile is the file in a template directory that picks versions
+const VariantsFile = "variants.yaml"
+
+// Variant picks the version of one prompt. BPercent of tasks get B, the
+// rest get A.
+type Variant struct {
+	A        string `yaml:"a"`
+	B        string `yaml:"b"`
+	BPercent int    `yaml:"b_percent"`
+}
+
+// Prompt is a rendered prompt stamped with the template that made it
+type Prompt struct {
+	Name    string
+	Version string
+	Text    string
+}
+
+// Stamp identifies the template version, e.g. "gen_impl@v2"
+func (p Prompt) Stamp() string {
+	return Stamp(p.Name, p.Version)
+}
+
+// Stamp joins a prompt name and version as "name@version"
+func Stamp(name, version string) string {
+	return name + "@" + version
+}
+
+// Registry holds prompt templates by name and version
+type Registry struct {
+	mu        sync.RWMutex
+	templates map[string]map[string]*template.Template
+	variants  map[string]Variant
+}
+
+// NewRegistry creates a registry without templates; every prompt uses its
+// built-in version
+func NewRegistry() *Registry {
+	return &Registry{
+		templates: make(map[string]map[string]*template.Template),
+		variants:  make(map[string]Variant),
+	}
+}
+
+// Load reads the templates and variants.yaml of a directory. A prompt with
+// several versions needs a variants.yaml entry saying which to use.
+func Load(dir string) (*Registry, error) {
+	r := NewRegistry()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template directory: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		files, err := filepath.Glob(filepath.Join(dir, name, "*.tmpl"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			text, err := os.ReadFile(file) //nolint:gosec // Template files come from the configured directory
+			if err != nil {
+				return nil, fmt.Errorf("failed to read template: %w", err)
+			}
+			version := strings.TrimSuffix(filepath.Base(file), ".tmpl")
+			if err := r.Register(name, version, string(text)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, VariantsFile)) //nolint:gosec // Fixed file in the configured directory
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", VariantsFile, err)
+	}
+	if err == nil {
+		var variants map[string]Variant
+		if err := yaml.Unmarshal(content, &variants); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", VariantsFile, err)
+		}
+		for name, variant := range variants {
+			if err := r.SetVariant(name, variant); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for name, versions := range r.templates {
+		if _, ok := r.variants[name]; !ok && len(versions) > 1 {
+			return nil, fmt.Errorf("template %s has versions %v; pick one in %s", name, sortedKeys(versions), VariantsFile)
+		}
+	}
+	return r, nil
+}
+
+// Register parses a template version
+func (r *Registry) Register(name, version, text string) error {
+	if name == "" || version == "" || version == BuiltinVersion || strings.Contains(name+version, "@") {
+		return fmt.Errorf("invalid template name %q or version %q", name, version)
+	}
+	tmpl, err := template.New(Stamp(name, version)).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", Stamp(name, version), err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.templates[name] == nil {
+		r.templates[name] = make(map[string]*template.Template)
+	}
+	r.templates[name][version] = tmpl
+	return nil
+}
+
+// SetVariant sets which versions of a prompt tasks get
+func (r *Registry) SetVariant(name string, variant Variant) error {
+	if variant.A == "" {
+		variant.A = BuiltinVersion
+	}
+	if variant.BPercent < 0 || variant.BPercent > 100 {
+		return fmt.Errorf("template %s: b_percent %d is not between 0 and 100", name, variant.BPercent)
+	}
+	if variant.BPercent > 0 && variant.B == "" {
+		return fmt.Errorf("template %s: b_percent needs a version b", name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, version := range []string{variant.A, variant.B} {
+		if version != "" && version != BuiltinVersion && r.templates[name][version] == nil {
+			return fmt.Errorf("template %s has no version %s", name, version)
+		}
+	}
+	r.variants[name] = variant
+	return nil
+}
+
+// Select returns the version of a prompt for a task. The same key always
+// gets the same version, so retries of a task render the same prompt.
+func (r *Registry) Select(name, key string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if variant, ok := r.variants[name]; ok {
+		if variant.BPercent > 0 && bucket(name, key) < variant.BPercent {
+			return variant.B
+		}
+		return variant.A
+	}
+	for version := range r.templates[name] {
+		return version // Load ensures there is only one
+	}
+	return BuiltinVersion
+}
+
+// Render renders the version of a prompt selected for key with data. The
+// built-in version calls builtin instead of a template.
+func (r *Registry) Render(name, key string, data any, builtin func() string) (Prompt, error) {
+	version := r.Select(name, key)
+	prompt := Prompt{Name: name, Version: version}
+	if version == BuiltinVersion {
+		prompt.Text = builtin()
+		return prompt, nil
+	}
+
+	r.mu.RLock()
+	tmpl := r.templates[name][version]
+	r.mu.RUnlock()
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return prompt, fmt.Errorf("failed to render template %s: %w", prompt.Stamp(), err)
+	}
+	prompt.Text = buf.String()
+	return prompt, nil
+}
+
+// bucket maps a prompt and key to 0-99
+func bucket(name, key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name + "/" + key))
+	return int(h.Sum32() % 100)
+}
+
+func sortedKeys(m map[string]*template.Template) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var (
+	defaultMu       sync.RWMutex
+	defaultRegistry = NewRegistry()
+)
+
+// Default returns the process-wide registry. Until LoadDefault or
+// SetDefault is called, every prompt uses its built-in version.
+func Default() *Registry {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultRegistry
+}
+
+// SetDefault replaces the process-wide registry
+func SetDefault(r *Registry) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultRegistry = r
+}
+
+// LoadDefault loads dir into the process-wide registry
+func LoadDefault(dir string) error {
+	r, err := Load(dir)
+	if err != nil {
+		return err
+	}
+	SetDefault(r)
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTemplateDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func builtin() string { return "built-in" }
+
+func TestLoad(t *testing.T) {
+	dir := writeTemplateDir(t, map[string]string{
+		"gen_test/v1.tmpl": "Write tests for {{.TaskID}}",
+		"gen_impl/v1.tmpl": "Implement {{.TaskID}}",
+		"gen_impl/v2.tmpl": "Implement {{.TaskID}} carefully",
+		VariantsFile:       "gen_impl:\n  a: v1\n  b: v2\n  b_percent: 0\n",
+	})
+	r, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	data := map[string]string{"TaskID": "task-1"}
+	tests := []struct {
+		name  string
+		stamp string
+		text  string
+	}{
+		{"gen_test", "gen_test@v1", "Write tests for task-1"},
+		{"gen_impl", "gen_impl@v1", "Implement task-1"},
+		{"review", "review@builtin", "built-in"},
+	}
+	for _, tt := range tests {
+		prompt, err := r.Render(tt.name, "task-1", data, builtin)
+		if err != nil {
+			t.Fatalf("Render(%s) failed: %v", tt.name, err)
+		}
+		if prompt.Stamp() != tt.stamp || prompt.Text != tt.text {
+			t.Errorf("Render(%s) = %s %q, want %s %q", tt.name, prompt.Stamp(), prompt.Text, tt.stamp, tt.text)
+		}
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name: "versions without variant",
+			files: map[string]string{
+				"gen_impl/v1.tmpl": "a",
+				"gen_impl/v2.tmpl": "b",
+			},
+			want: "pick one in variants.yaml",
+		},
+		{
+			name:  "unknown version",
+			files: map[string]string{"gen_impl/v1.tmpl": "a", VariantsFile: "gen_impl:\n  a: v1\n  b: v3\n  b_percent: 10\n"},
+			want:  "no version v3",
+		},
+		{
+			name:  "percent without b",
+			files: map[string]string{"gen_impl/v1.tmpl": "a", VariantsFile: "gen_impl:\n  a: v1\n  b_percent: 10\n"},
+			want:  "needs a version b",
+		},
+		{
+			name:  "bad template",
+			files: map[string]string{"gen_impl/v1.tmpl": "{{.TaskID"},
+			want:  "gen_impl@v1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeTemplateDir(t, tt.files))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelect_SplitsTasksBetweenVariants(t *testing.T) {
+	r := NewRegistry()
+	for _, version := range []string{"v1", "v2"} {
+		if err := r.Register("gen_impl", version, version); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.SetVariant("gen_impl", Variant{A: "v1", B: "v2", BPercent: 20}); err != nil {
+		t.Fatal(err)
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("task-%d", i)
+		version := r.Select("gen_impl", key)
+		if again := r.Select("gen_impl", key); again != version {
+			t.Fatalf("task %s got %s then %s; a task must keep its version", key, version, again)
+		}
+		counts[version]++
+	}
+	if counts["v2"] < 150 || counts["v2"] > 250 {
+		t.Errorf("v2 got %d of 1000 tasks, want about 200", counts["v2"])
+	}
+}
+
+func TestSetVariant_BuiltinA(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Register("review", "v2", "Review {{.TaskID}}"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SetVariant("review", Variant{B: "v2", BPercent: 100}); err != nil {
+		t.Fatal(err)
+	}
+	prompt, err := r.Render("review", "task-1", map[string]string{"TaskID": "task-1"}, builtin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt.Stamp() != "review@v2" {
+		t.Errorf("b_percent 100 should always pick b, got %s", prompt.Stamp())
+	}
+
+	if err := r.SetVariant("review", Variant{B: "v2"}); err != nil {
+		t.Fatal(err)
+	}
+	if version := r.Select("review", "task-1"); version != BuiltinVersion {
+		t.Errorf("a defaults to the built-in prompt, got %s", version)
+	}
+}
+
+func TestRender_MissingField(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Register("lint_fix", "v1", "Fix {{.Issues}}"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Render("lint_fix", "task-1", map[string]string{}, builtin); err == nil {
+		t.Error("a template using a missing field should fail to render")
+	}
+}
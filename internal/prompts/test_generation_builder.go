@@ -85,6 +85,8 @@ type TestGenerationRequest struct {
 	CodeContext *TestCodeContext
 	// AdditionalNotes are any extra instructions
 	AdditionalNotes string
+	// Learnings are relevant lessons from previous runs (optional)
+	Learnings []string
 	// Language is the programming language (default: "Go")
 	Language string
 	// TestFramework is the testing framework to use (default: "testing")
@@ -150,6 +152,13 @@ func (b *TestGenerationPromptBuilder) Build() string {
 		b.buildCoverageReport(&sb)
 	}
 
+	// Add lessons from previous runs if present
+	if len(b.request.Learnings) > 0 {
+		sb.WriteString("## " + LearningsHeading + "\n\n")
+		sb.WriteString(FormatLearnings(b.request.Learnings))
+		sb.WriteString("\n\n")
+	}
+
 	// Add output path instructions
 	b.buildOutputInstructions(&sb)
 
@@ -460,6 +469,12 @@ func (b *TestGenerationBuilder) WithAdditionalNotes(notes string) *TestGeneratio
 	return b
 }
 
+// WithLearnings sets lessons from previous runs
+func (b *TestGenerationBuilder) WithLearnings(learnings []string) *TestGenerationBuilder {
+	b.request.Learnings = learnings
+	return b
+}
+
 // Build returns the constructed TestGenerationRequest
 func (b *TestGenerationBuilder) Build() *TestGenerationRequest {
 	return b.request
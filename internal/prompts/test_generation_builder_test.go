@@ -469,3 +469,20 @@ func TestTestGenerationPromptBuilder_ConditionalLintFeedback(t *testing.T) {
 		}
 	})
 }
+
+func TestTestGenerationBuilder_WithLearnings(t *testing.T) {
+	prompt := NewTestGenerationBuilder().
+		WithTaskDescription("Test the parser").
+		WithLearnings([]string{"Worked before (bd-3): table-driven tests for each token"}).
+		BuildPrompt()
+
+	if !strings.Contains(prompt, "## "+LearningsHeading) {
+		t.Error("Prompt should contain the learnings section")
+	}
+	if !strings.Contains(prompt, "- Worked before (bd-3): table-driven tests for each token") {
+		t.Error("Prompt should list each learning")
+	}
+	if strings.Index(prompt, LearningsHeading) > strings.Index(prompt, "## Output Instructions") {
+		t.Error("Learnings should come before the output instructions")
+	}
+}
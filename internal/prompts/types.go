@@ -34,6 +34,10 @@ type PromptRequest struct {
 	TestFailures string
 	// Context contains additional contextual information
 	Context map[string]string
+	// Learnings are relevant lessons from previous runs
+	Learnings []string
+	// RelatedCode is existing code packed for the task by a ContextPacker
+	RelatedCode *ContextPack
 	// CreatedAt is when the request was created
 	CreatedAt time.Time
 }
@@ -48,6 +52,12 @@ const (
 	ReviewTypeFunctional ReviewType = "functional"
 	// ReviewTypeTesting focuses on test coverage and quality
 	ReviewTypeTesting ReviewType = "testing"
+	// ReviewTypeSecurity focuses on injection, secrets, unsafe exec and path traversal
+	ReviewTypeSecurity ReviewType = "security"
+	// ReviewTypePerformance focuses on allocations, N+1 patterns and lock contention
+	ReviewTypePerformance ReviewType = "performance"
+	// ReviewTypeAPICompatibility focuses on changes to the exported API
+	ReviewTypeAPICompatibility ReviewType = "api_compatibility"
 )
 
 // CodeContext contains contextual information about the code being reviewed
@@ -82,6 +92,8 @@ type ReviewRequest struct {
 	AdditionalContext string
 	// RequireVote indicates if the reviewer must provide a vote
 	RequireVote bool
+	// APIChanges summarizes changes to exported symbols (API compatibility reviews)
+	APIChanges string
 }
 
 // ReviewResponse contains the structured review from the LLM
@@ -8,6 +8,7 @@ package temporal
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"go.temporal.io/sdk/activity"
@@ -301,10 +302,25 @@ const (
 	// ErrorCauseAgentError indicates the agent returned an error
 	ErrorCauseAgentError ErrorCause = "agent_error"
 
+	// ErrorCauseRateLimit indicates the model provider throttled the request
+	ErrorCauseRateLimit ErrorCause = "rate_limit"
+
+	// ErrorCauseOverloaded indicates the model provider is overloaded or down
+	ErrorCauseOverloaded ErrorCause = "overloaded"
+
+	// ErrorCauseAuth indicates the model provider rejected the credentials
+	ErrorCauseAuth ErrorCause = "auth"
+
 	// ErrorCauseUnknown indicates an unknown error
 	ErrorCauseUnknown ErrorCause = "unknown"
 )
 
+// IsProviderError reports whether the cause lies with the model provider
+// rather than the task, so another provider may succeed
+func (c ErrorCause) IsProviderError() bool {
+	return c == ErrorCauseRateLimit || c == ErrorCauseOverloaded || c == ErrorCauseAuth
+}
+
 // ClassifyError analyzes an error and returns its cause
 // Used by workflows to determine retry strategy
 func ClassifyError(err error, duration time.Duration, timeoutSeconds int) ErrorCause {
@@ -313,6 +329,7 @@ func ClassifyError(err error, duration time.Duration, timeoutSeconds int) ErrorC
 	}
 
 	errStr := err.Error()
+	lower := strings.ToLower(errStr)
 
 	// Check for timeout
 	if duration.Seconds() > float64(timeoutSeconds) {
@@ -321,6 +338,22 @@ func ClassifyError(err error, duration time.Duration, timeoutSeconds int) ErrorC
 
 	// Check for specific error patterns
 	switch {
+	case contains(lower, "too many requests"),
+		contains(lower, "rate limit"),
+		contains(lower, "rate_limit"):
+		return ErrorCauseRateLimit
+
+	case contains(lower, "overloaded"),
+		contains(lower, "service unavailable"),
+		contains(lower, "bad gateway"):
+		return ErrorCauseOverloaded
+
+	case contains(lower, "unauthorized"),
+		contains(lower, "invalid api key"),
+		contains(lower, "invalid x-api-key"),
+		contains(lower, "authentication"):
+		return ErrorCauseAuth
+
 	case contains(errStr, "connection refused"),
 		contains(errStr, "connection reset"),
 		contains(errStr, "network"),
@@ -444,32 +477,60 @@ func (p *AgentResultParser) ExtractStructuredData(output string) map[string]inte
 	}
 
 	// Try to find JSON sections
-	jsonStartIdx := -1
+	if objects := p.ExtractJSONObjects(output); len(objects) > 0 {
+		data["has_json"] = true
+		data["json_objects"] = objects
+	}
+
+	return data
+}
+
+// ExtractJSONObjects returns the balanced top-level {...} sections of the
+// output, in order. Braces inside JSON strings are ignored; the sections are
+// not validated as JSON.
+func (p *AgentResultParser) ExtractJSONObjects(output string) []string {
+	var objects []string
+	depth := 0
+	start := -1
+	inString := false
+	escaped := false
+
 	for i := 0; i < len(output); i++ {
-		if output[i] == '{' {
-			jsonStartIdx = i
-			break
+		c := output[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
 		}
-	}
 
-	if jsonStartIdx >= 0 {
-		// Find matching closing brace
-		braceCount := 0
-		for i := jsonStartIdx; i < len(output); i++ {
-			if output[i] == '{' {
-				braceCount++
-			} else if output[i] == '}' {
-				braceCount--
-				if braceCount == 0 {
-					// Potential JSON block found
-					data["has_json"] = true
-					break
-				}
+		switch c {
+		case '"':
+			if depth > 0 {
+				inString = true
+			}
+		case '{':
+			if depth == 0 {
+				start = i
+			}
+			depth++
+		case '}':
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth == 0 {
+				objects = append(objects, output[start:i+1])
 			}
 		}
 	}
 
-	return data
+	return objects
 }
 
 // ValidateResult checks if an agent result is valid and complete
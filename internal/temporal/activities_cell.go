@@ -12,6 +12,7 @@ import (
 	"go.temporal.io/sdk/activity"
 
 	"open-swarm/internal/agent"
+	"open-swarm/internal/git"
 	"open-swarm/internal/infra"
 	"open-swarm/internal/workflow"
 )
@@ -24,8 +25,15 @@ type CellActivities struct {
 // NewCellActivities creates a new CellActivities instance using global managers
 func NewCellActivities() *CellActivities {
 	pm, sm, wm := GetManagers()
+	activities := workflow.NewActivities(pm, sm, wm)
+	if pool := GetWarmPool(); pool != nil {
+		activities.SetWarmPool(pool)
+	}
+	if cassette, mode := GetCassette(); cassette != nil {
+		activities.SetCassette(cassette, mode)
+	}
 	return &CellActivities{
-		activities: workflow.NewActivities(pm, sm, wm),
+		activities: activities,
 	}
 }
 
@@ -35,6 +43,9 @@ func NewCellActivities() *CellActivities {
 type BootstrapInput struct {
 	CellID string
 	Branch string
+	// Backend selects the coding agent (opencode, claude-code, aider, anthropic).
+	// Empty means OpenCode.
+	Backend string
 }
 
 // BootstrapOutput contains the serializable cell information
@@ -45,6 +56,13 @@ type BootstrapOutput struct {
 	WorktreePath string
 	BaseURL      string
 	ServerPID    int
+	Backend      string
+	// Agent session shared by the task's gates. The workflow records these
+	// after each agent gate, so they survive activity retries and worker
+	// restarts. SessionMaxTurns 0 means every gate starts a new session.
+	SessionID       string
+	SessionTurns    int
+	SessionMaxTurns int
 }
 
 // TaskInput contains parameters for executing a task
@@ -70,21 +88,30 @@ func (ca *CellActivities) BootstrapCell(ctx context.Context, input BootstrapInpu
 
 	activity.RecordHeartbeat(ctx, "allocating resources")
 
+	backend, err := agent.ParseBackend(input.Backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bootstrap cell %q: %w", input.CellID, err)
+	}
+
 	// Call existing infrastructure
-	cell, err := ca.activities.BootstrapCell(ctx, input.CellID, input.Branch)
+	cell, err := ca.activities.BootstrapCellWithBackend(ctx, input.CellID, input.Branch, backend)
 	if err != nil {
 		return nil, fmt.Errorf("failed to bootstrap cell %q: %w", input.CellID, err)
 	}
 
 	// Convert to serializable output
-	return &BootstrapOutput{
+	output := &BootstrapOutput{
 		CellID:       cell.CellID,
 		Port:         cell.Port,
 		WorktreeID:   cell.WorktreeID,
 		WorktreePath: cell.WorktreePath,
-		BaseURL:      cell.ServerHandle.BaseURL,
-		ServerPID:    cell.ServerHandle.PID,
-	}, nil
+		Backend:      string(backend),
+	}
+	if cell.ServerHandle != nil {
+		output.BaseURL = cell.ServerHandle.BaseURL
+		output.ServerPID = cell.ServerHandle.PID
+	}
+	return output, nil
 }
 
 // ExecuteTask runs a prompt in the cell
@@ -141,6 +168,40 @@ func (ca *CellActivities) CommitChanges(ctx context.Context, bootstrap *Bootstra
 	return nil
 }
 
+// CellHeadOutput identifies the commit a cell finished on
+type CellHeadOutput struct {
+	Branch string // Worktree branch; empty when HEAD is detached
+	Commit string
+}
+
+// CellHead returns the branch and commit checked out in the cell's worktree.
+// The branch outlives the cell, so the merge queue can integrate it later.
+func (ca *CellActivities) CellHead(ctx context.Context, bootstrap *BootstrapOutput) (*CellHeadOutput, error) {
+	repo := git.Open(bootstrap.WorktreePath)
+	commit, err := repo.Head(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read head of cell %q: %w", bootstrap.CellID, err)
+	}
+	branch, err := repo.CurrentBranch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read branch of cell %q: %w", bootstrap.CellID, err)
+	}
+	return &CellHeadOutput{Branch: branch, Commit: commit}, nil
+}
+
+// MergeCommits merges commits into the cell's branch, e.g. the work of the
+// DAG nodes a task depends on. Conflicting commits leave the cell unchanged.
+func (ca *CellActivities) MergeCommits(ctx context.Context, bootstrap *BootstrapOutput, commits []string) error {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Merging commits into cell", "cellID", bootstrap.CellID, "commits", commits)
+
+	message := fmt.Sprintf("Merge dependencies of %s", bootstrap.CellID)
+	if err := git.Open(bootstrap.WorktreePath).Merge(ctx, message, commits...); err != nil {
+		return fmt.Errorf("failed to merge commits into cell %q: %w", bootstrap.CellID, err)
+	}
+	return nil
+}
+
 // RevertChanges reverts work in the cell
 func (ca *CellActivities) RevertChanges(ctx context.Context, bootstrap *BootstrapOutput) error {
 	cell := ca.reconstructCell(bootstrap)
@@ -165,21 +226,34 @@ func (ca *CellActivities) TeardownCell(ctx context.Context, bootstrap *Bootstrap
 // reconstructCell rebuilds runtime cell from serialized bootstrap
 // Note: Cmd and process cannot be reconstructed - TeardownCell will use PID directly
 func (ca *CellActivities) reconstructCell(bootstrap *BootstrapOutput) *workflow.CellBootstrap {
-	// Reconstruct server handle
-	serverHandle := &infra.ServerHandle{
-		Port:    bootstrap.Port,
-		BaseURL: bootstrap.BaseURL,
-		PID:     bootstrap.ServerPID,
-		// Note: Cmd and process cannot be reconstructed -
-		// TeardownCell will use PID directly
+	backend, err := agent.ParseBackend(bootstrap.Backend)
+	if err != nil {
+		backend = agent.BackendOpenCode
 	}
 
-	return &workflow.CellBootstrap{
+	client, err := ca.activities.CellClient(backend, bootstrap.BaseURL, bootstrap.Port, bootstrap.WorktreePath)
+	if err != nil {
+		client = agent.NewClient(bootstrap.BaseURL, bootstrap.Port)
+	}
+
+	cell := &workflow.CellBootstrap{
 		CellID:       bootstrap.CellID,
 		Port:         bootstrap.Port,
 		WorktreeID:   bootstrap.WorktreeID,
 		WorktreePath: bootstrap.WorktreePath,
-		ServerHandle: serverHandle,
-		Client:       agent.NewClient(bootstrap.BaseURL, bootstrap.Port),
+		Client:       client,
+		Backend:      backend,
+	}
+
+	// Serverless and replayed cells have no server to reconstruct
+	if bootstrap.BaseURL != "" || bootstrap.ServerPID != 0 {
+		cell.ServerHandle = &infra.ServerHandle{
+			Port:    bootstrap.Port,
+			BaseURL: bootstrap.BaseURL,
+			PID:     bootstrap.ServerPID,
+			// Note: Cmd and process cannot be reconstructed -
+			// TeardownCell will use PID directly
+		}
 	}
+	return cell
 }
@@ -22,6 +22,8 @@ import (
 
 	"open-swarm/internal/agent"
 	"open-swarm/internal/filelock"
+	"open-swarm/internal/git"
+	"open-swarm/internal/opencode"
 	"open-swarm/internal/telemetry"
 	"open-swarm/internal/workflow"
 )
@@ -49,9 +51,16 @@ func newFailedGateResult(gateName string, err error, startTime time.Time) *GateR
 	}
 }
 
-// getChangedFiles extracts file paths from agent file status.
-// Returns a slice of non-empty file paths that were modified.
+// getChangedFiles returns the paths changed in the cell's worktree, read from
+// git status (porcelain v2). Falls back to the agent's file status when the
+// worktree is not a git repository.
 func getChangedFiles(ctx context.Context, cell *workflow.CellBootstrap) []string {
+	if cell.WorktreePath != "" {
+		if status, err := git.Open(cell.WorktreePath).Status(ctx); err == nil {
+			return status.Changed()
+		}
+	}
+
 	fileStatus, _ := cell.Client.GetFileStatus(ctx)
 	filesChanged := make([]string, 0, len(fileStatus))
 	for _, file := range fileStatus {
@@ -199,11 +208,27 @@ IMPORTANT: The file MUST be created at pkg/%s/%s_test.go`, taskID,
 		packageName, packageName, packageName, packageName, packageName,
 		packageName, packageName)
 
-	result, err := cell.Client.ExecutePrompt(ctx, prompt, &agent.PromptOptions{
+	rendered, err := renderGatePrompt(ctx, PromptGenTest, GatePromptData{
+		TaskID:             taskID,
+		Package:            packageName,
+		AcceptanceCriteria: acceptanceCriteria,
+		TestFile:           fmt.Sprintf("pkg/%s/%s_test.go", packageName, packageName),
+	}, prompt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to render prompt")
+		telemetry.AddEvent(ctx, "gate.failed", telemetry.AttrGateName.String("gen_test"))
+		return newFailedGateResult("gen_test", err, startTime), err
+	}
+	prompt = rendered.Text
+
+	model := routeModel(ctx, bootstrap, opencode.GateTestGen, "", DefaultImplModel)
+	result, err := promptInTaskSession(ctx, cell.Client, bootstrap, prompt, &agent.PromptOptions{
 		Title: fmt.Sprintf("GenTest: %s", taskID),
 		Agent: "test-generator",
-		Model: "anthropic/claude-haiku-4-5",
+		Model: model,
 	})
+	reportModelResult(model, err)
 
 	if err != nil {
 		span.RecordError(err)
@@ -230,20 +255,25 @@ IMPORTANT: The file MUST be created at pkg/%s/%s_test.go`, taskID,
 		Passed:   true,
 		AgentResults: []AgentResult{
 			{
-				AgentName:    "test-generator",
-				Model:        "anthropic/claude-haiku-4-5",
-				Prompt:       prompt,
-				Response:     result.GetText(),
-				Success:      true,
-				Duration:     time.Since(startTime),
-				FilesChanged: filesChanged,
+				AgentName:     "test-generator",
+				Model:         model,
+				Prompt:        prompt,
+				Response:      result.GetText(),
+				Success:       true,
+				Duration:      time.Since(startTime),
+				FilesChanged:  filesChanged,
+				SessionID:     result.SessionID,
+				PromptVersion: rendered.Stamp(),
 			},
 		},
 		Duration: time.Since(startTime),
 	}, nil
 }
 
-// ExecuteLintTest - Gate 2: Lint test files
+// ExecuteLintTest - Gate 2: Lint the cell's changes
+//
+// Runs golangci-lint with JSON output and keeps only issues on lines changed
+// in the worktree, so existing issues elsewhere in the repo don't fail the gate.
 func (ea *EnhancedActivities) ExecuteLintTest(ctx context.Context, bootstrap *BootstrapOutput) (*GateResult, error) {
 	ctx, span := telemetry.StartSpan(ctx, "activity.enhanced", "ExecuteLintTest")
 	defer span.End()
@@ -253,28 +283,25 @@ func (ea *EnhancedActivities) ExecuteLintTest(ctx context.Context, bootstrap *Bo
 
 	startTime := time.Now()
 	telemetry.AddEvent(ctx, "gate.start", telemetry.AttrGateName.String("lint_test"))
-	cellActivities := NewCellActivities()
-	cell := cellActivities.reconstructCell(bootstrap)
-
-	// Run golangci-lint on test files
-	result, _ := cell.Client.ExecuteCommand(ctx, "", "shell", []string{"golangci-lint", "run", "--disable-all", "--enable=errcheck,staticcheck,unused", "*_test.go"})
 
-	output := ""
-	if result != nil {
-		output = result.GetText()
+	issues, output, err := runLinter(ctx, bootstrap.WorktreePath, "golangci-lint", false)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "linter execution failed")
+		err = fmt.Errorf("linter execution failed: %w", err)
+		return newFailedGateResult("lint_test", err, startTime), err
 	}
-
-	// Parse lint output using LintParser
-	parser := NewLintParser()
-	parseResult := parser.ParseGolangciLint(output)
+	issues = filterIssuesToChangedLines(issues, bootstrap.WorktreePath, worktreeChangedLines(ctx, bootstrap.WorktreePath))
+	sortIssues(issues)
+	passed := countErrorIssues(issues) == 0
 
 	span.SetAttributes(
 		telemetry.AttrGateName.String("lint_test"),
-		telemetry.AttrGatePassed.Bool(!parseResult.HasErrors),
-		attribute.Int("lint.issues", len(parseResult.Issues)),
+		telemetry.AttrGatePassed.Bool(passed),
+		attribute.Int("lint.issues", len(issues)),
 	)
 
-	if parseResult.HasErrors {
+	if !passed {
 		span.SetStatus(codes.Error, "lint check failed")
 		telemetry.AddEvent(ctx, "gate.failed", telemetry.AttrGateName.String("lint_test"))
 	} else {
@@ -284,15 +311,15 @@ func (ea *EnhancedActivities) ExecuteLintTest(ctx context.Context, bootstrap *Bo
 
 	return &GateResult{
 		GateName: "lint_test",
-		Passed:   !parseResult.HasErrors,
+		Passed:   passed,
 		LintResult: &LintResult{
-			Passed:   !parseResult.HasErrors,
+			Passed:   passed,
 			Output:   output,
 			Duration: time.Since(startTime),
-			Issues:   parseResult.Issues,
+			Issues:   issues,
 		},
 		Duration: time.Since(startTime),
-		Message:  parseResult.Summary,
+		Message:  NewLintParser().FormatLintSummary(issues),
 	}, nil
 }
 
@@ -432,6 +459,8 @@ Remember: In TDD RED phase, failing tests are GOOD - they prove the test was wri
 
 // ExecuteGenImpl - Gate 4: Generate implementation
 // testFailureOutput: Optional. If provided (non-empty), includes test failure feedback for retry attempts.
+// model: The "provider/model" to generate with, picked by the workflow's model ladder. Empty, or out of
+// rotation after repeated provider errors, routes the implementation gate (default: DefaultImplModel).
 //
 // When retrying after VerifyGREEN failures, the workflow should:
 //  1. Extract test output from verifyGreenResult.TestResult.Output
@@ -442,16 +471,19 @@ Remember: In TDD RED phase, failing tests are GOOD - they prove the test was wri
 //
 //	if !verifyGreenResult.Passed {
 //	  testOutput := verifyGreenResult.TestResult.Output
-//	  genImplResult, _ := ExecuteGenImpl(ctx, bootstrap, taskID, desc, criteria, testOutput)
+//	  genImplResult, _ := ExecuteGenImpl(ctx, bootstrap, taskID, desc, criteria, testOutput, model)
 //	}
-func (ea *EnhancedActivities) ExecuteGenImpl(ctx context.Context, bootstrap *BootstrapOutput, taskID string, description string, acceptanceCriteria string, testFailureOutput string) (*GateResult, error) {
+func (ea *EnhancedActivities) ExecuteGenImpl(ctx context.Context, bootstrap *BootstrapOutput, taskID string, description string, acceptanceCriteria string, testFailureOutput string, model string) (*GateResult, error) {
 	ctx, span := telemetry.StartSpan(ctx, "activity.enhanced", "ExecuteGenImpl",
 		trace.WithAttributes(telemetry.TCRAttrs("", taskID)...),
 	)
 	defer span.End()
 
+	model = routeModel(ctx, bootstrap, opencode.GateImplementation, model, DefaultImplModel)
+	span.SetAttributes(telemetry.AttrModel.String(model))
+
 	logger := activity.GetLogger(ctx)
-	logger.Info("Gate: GenImpl", "taskID", taskID)
+	logger.Info("Gate: GenImpl", "taskID", taskID, "model", model)
 
 	startTime := time.Now()
 	telemetry.AddEvent(ctx, "gate.start", telemetry.AttrGateName.String("gen_impl"))
@@ -479,14 +511,19 @@ func (ea *EnhancedActivities) ExecuteGenImpl(ctx context.Context, bootstrap *Boo
 
 	// Build base prompt
 	var promptBuilder strings.Builder
+	var failingTests []string
+	var failureSummary string
 
 	// If retry feedback is provided, include parsed test failures
 	if testFailureOutput != "" {
 		parser := NewTestParser()
 		parseResult := parser.ParseTestOutput(testFailureOutput)
+		for _, failure := range parseResult.Failures {
+			failingTests = append(failingTests, failure.TestName)
+		}
 
 		if parseResult.HasFailures {
-			failureSummary := parser.GetFailureSummary(parseResult)
+			failureSummary = parser.GetFailureSummary(parseResult)
 			promptBuilder.WriteString("Previous implementation attempt failed with test failures:\n\n")
 			promptBuilder.WriteString(failureSummary)
 			promptBuilder.WriteString("\n\nPlease fix the implementation to address these failures.\n\n")
@@ -513,13 +550,33 @@ Requirements:
 - Add documentation comments`, taskID, packageName, packageName, packageName,
 		description, acceptanceCriteria, packageName, packageName, packageName))
 
-	prompt := promptBuilder.String()
+	// Pack the code the tests use, so the agent does not search for it
+	relatedCode := relatedCodeSection(ctx, bootstrap.WorktreePath, packageName, description, failingTests)
+	promptBuilder.WriteString(relatedCode)
+
+	rendered, err := renderGatePrompt(ctx, PromptGenImpl, GatePromptData{
+		TaskID:             taskID,
+		Package:            packageName,
+		Description:        description,
+		AcceptanceCriteria: acceptanceCriteria,
+		Feedback:           failureSummary,
+		ImplFile:           fmt.Sprintf("pkg/%s/%s.go", packageName, packageName),
+		RelatedCode:        relatedCode,
+	}, promptBuilder.String())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to render prompt")
+		telemetry.AddEvent(ctx, "gate.failed", telemetry.AttrGateName.String("gen_impl"))
+		return newFailedGateResult("gen_impl", err, startTime), err
+	}
+	prompt := rendered.Text
 
-	result, err := cell.Client.ExecutePrompt(ctx, prompt, &agent.PromptOptions{
+	result, err := promptInTaskSession(ctx, cell.Client, bootstrap, prompt, &agent.PromptOptions{
 		Title: fmt.Sprintf("GenImpl: %s", taskID),
 		Agent: "implementation",
-		Model: "anthropic/claude-haiku-4-5",
+		Model: model,
 	})
+	reportModelResult(model, err)
 
 	if err != nil {
 		span.RecordError(err)
@@ -546,13 +603,15 @@ Requirements:
 		Passed:   true,
 		AgentResults: []AgentResult{
 			{
-				AgentName:    "implementation",
-				Model:        "anthropic/claude-haiku-4-5",
-				Prompt:       prompt,
-				Response:     result.GetText(),
-				Success:      true,
-				Duration:     time.Since(startTime),
-				FilesChanged: filesChanged,
+				AgentName:     "implementation",
+				Model:         model,
+				Prompt:        prompt,
+				Response:      result.GetText(),
+				Success:       true,
+				Duration:      time.Since(startTime),
+				FilesChanged:  filesChanged,
+				SessionID:     result.SessionID,
+				PromptVersion: rendered.Stamp(),
 			},
 		},
 		Duration: time.Since(startTime),
@@ -608,6 +667,7 @@ FEEDBACK TO ADDRESS:
 
 INSTRUCTIONS:
 - Make MINIMAL changes to fix the specific issues in the feedback
+- When findings name a file and line range, change only those lines unless the fix requires more
 - Preserve all working functionality
 - Only modify code directly related to the feedback
 - Do NOT rewrite the entire file from scratch
@@ -628,11 +688,28 @@ Package name: %s
 Address the feedback in your implementation.`, taskID, feedback, implFilePath, packageName)
 	}
 
-	result, err := cell.Client.ExecutePrompt(ctx, prompt, &agent.PromptOptions{
+	rendered, err := renderGatePrompt(ctx, PromptFixFromFeedback, GatePromptData{
+		TaskID:      taskID,
+		Package:     packageName,
+		Feedback:    feedback,
+		CurrentCode: currentCode,
+		ImplFile:    implFilePath,
+	}, prompt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to render prompt")
+		telemetry.AddEvent(ctx, "gate.failed", telemetry.AttrGateName.String("fix_from_feedback"))
+		return newFailedGateResult("fix_from_feedback", err, startTime), err
+	}
+	prompt = rendered.Text
+
+	model := routeModel(ctx, bootstrap, opencode.GateImplementation, "", DefaultImplModel)
+	result, err := promptInTaskSession(ctx, cell.Client, bootstrap, prompt, &agent.PromptOptions{
 		Title: fmt.Sprintf("FixFromFeedback: %s", taskID),
 		Agent: "implementation",
-		Model: "anthropic/claude-haiku-4-5",
+		Model: model,
 	})
+	reportModelResult(model, err)
 
 	if err != nil {
 		span.RecordError(err)
@@ -659,13 +736,15 @@ Address the feedback in your implementation.`, taskID, feedback, implFilePath, p
 		Passed:   true,
 		AgentResults: []AgentResult{
 			{
-				AgentName:    "implementation",
-				Model:        "anthropic/claude-haiku-4-5",
-				Prompt:       prompt,
-				Response:     result.GetText(),
-				Success:      true,
-				Duration:     time.Since(startTime),
-				FilesChanged: filesChanged,
+				AgentName:     "implementation",
+				Model:         model,
+				Prompt:        prompt,
+				Response:      result.GetText(),
+				Success:       true,
+				Duration:      time.Since(startTime),
+				FilesChanged:  filesChanged,
+				SessionID:     result.SessionID,
+				PromptVersion: rendered.Stamp(),
 			},
 		},
 		Duration: time.Since(startTime),
@@ -814,15 +893,17 @@ Remember: In TDD GREEN phase, passing tests confirm the implementation is correc
 	}, nil
 }
 
-// ExecuteMultiReview - Gate 6: Multi-reviewer approval (3 reviewers, unanimous)
-func (ea *EnhancedActivities) ExecuteMultiReview(ctx context.Context, bootstrap *BootstrapOutput, taskID string, description string, reviewersCount int) (*GateResult, error) {
+// ExecuteMultiReview - Gate 6: Multi-reviewer approval, decided by the review policy (default unanimous).
+// It runs reviewer slots firstReviewer..firstReviewer+reviewersCount-1 of the round,
+// so a round can be split across parallel activities.
+func (ea *EnhancedActivities) ExecuteMultiReview(ctx context.Context, bootstrap *BootstrapOutput, taskID string, description string, firstReviewer int, reviewersCount int, policy ReviewPolicy) (*GateResult, error) {
 	ctx, span := telemetry.StartSpan(ctx, "activity.enhanced", "ExecuteMultiReview",
 		trace.WithAttributes(telemetry.TCRAttrs("", taskID)...),
 	)
 	defer span.End()
 
 	logger := activity.GetLogger(ctx)
-	logger.Info("Gate: MultiReview", "reviewers", reviewersCount)
+	logger.Info("Gate: MultiReview", "reviewers", reviewersCount, "policy", policy.Kind)
 
 	startTime := time.Now()
 	telemetry.AddEvent(ctx, "gate.start",
@@ -832,12 +913,20 @@ func (ea *EnhancedActivities) ExecuteMultiReview(ctx context.Context, bootstrap
 	cellActivities := NewCellActivities()
 	cell := cellActivities.reconstructCell(bootstrap)
 
-	reviewTypes := []ReviewType{ReviewTypeTesting, ReviewTypeFunctional, ReviewTypeArchitecture}
+	// Specialized reviewers join based on the files the change touches
+	filesChanged := getChangedFiles(ctx, cell)
+	reviewTypes := reviewTypesForChange(firstReviewer, reviewersCount, filesChanged)
+	if len(reviewTypes) > reviewersCount {
+		logger.Info("Adding specialized reviewers", "types", reviewTypes[reviewersCount:])
+	}
 	votes := []ReviewVote{}
 	voteParser := NewVoteParser()
+	diff := worktreeDiff(ctx, bootstrap.WorktreePath)
+	changedLines := diff.AddedLines()
+	hunks := formatDiffHunks(diff)
+	models := routeReviewModels(ctx, bootstrap, policy, reviewTypes)
 
-	for i := 0; i < reviewersCount; i++ {
-		reviewType := reviewTypes[i%len(reviewTypes)]
+	for i, reviewType := range reviewTypes {
 		reviewStart := time.Now()
 
 		prompt := fmt.Sprintf(`Review the code changes for task: %s
@@ -846,99 +935,139 @@ Description: %s
 
 Review Focus: %s
 
-Provide:
-1. Vote: APPROVE, REQUEST_CHANGE, or REJECT
-2. Detailed feedback on the implementation
-3. Specific issues or improvements needed
+Your review should focus on: %s
 
-Your review should focus on: %s`, taskID, description, reviewType, getReviewFocus(reviewType))
+%s`, taskID, description, reviewType, getReviewFocus(reviewType), structuredReviewSchema)
 
-		result, err := cell.Client.ExecutePrompt(ctx, prompt, &agent.PromptOptions{
-			Title: fmt.Sprintf("Review %d (%s): %s", i+1, reviewType, taskID),
-			Agent: getReviewerAgent(reviewType),
-			Model: "anthropic/claude-haiku-4-5",
-		})
+		if hunks != "" {
+			prompt += "\n\nChanged files and hunks (anchor findings to new-side line numbers):\n" + hunks
+		}
 
-		var vote VoteResult
-		var feedback string
+		var apiChanges string
+		if reviewType == ReviewTypeAPICompatibility {
+			apiChanges = apiChangesForFiles(ctx, bootstrap.WorktreePath, filesChanged)
+			if apiChanges == "" {
+				apiChanges = "No changes to exported symbols were detected.\n"
+			}
+			prompt += "\n\nExported symbol changes (+ added, - removed, ~ changed):\n" + apiChanges
+		}
 
-		if err != nil {
-			vote = VoteReject
+		model := models[i]
+		rendered, err := renderGatePrompt(ctx, PromptReview, GatePromptData{
+			TaskID:       taskID,
+			Description:  description,
+			ReviewType:   string(reviewType),
+			ReviewFocus:  getReviewFocus(reviewType),
+			ReviewSchema: structuredReviewSchema,
+			Hunks:        hunks,
+			APIChanges:   apiChanges,
+		}, prompt)
+		var result *agent.PromptResult
+		if err == nil {
+			result, err = cell.Client.ExecutePrompt(ctx, rendered.Text, &agent.PromptOptions{
+				Title: fmt.Sprintf("Review %d (%s): %s", firstReviewer+i+1, reviewType, taskID),
+				Agent: getReviewerAgent(reviewType),
+				Model: model,
+			})
+			reportModelResult(model, err)
+		}
+
+		reviewerName := fmt.Sprintf("reviewer-%d", firstReviewer+i+1)
+		var vote VoteResult
+		var feedback, summary string
+		var findings []ReviewFinding
+		confidence := 1.0
+		failed := err != nil
+
+		if failed {
+			// A failed reviewer asks for another round rather than discarding the work
+			vote = VoteRequestChange
+			confidence = 0
 			feedback = fmt.Sprintf("Review failed: %v", err)
 		} else {
 			feedback = result.GetText()
-			// Use VoteParser to extract vote
-			parsed := voteParser.ParseVote(feedback)
-			vote = parsed.Vote
+			if review, ok := voteParser.ParseStructuredReview(feedback); ok {
+				vote = review.Vote
+				confidence = review.Confidence
+				summary = review.Summary
+				for _, finding := range review.Findings {
+					finding.Reviewer = reviewerName
+					findings = append(findings, finding)
+				}
+				findings = markFindingsOnDiff(findings, changedLines)
+			} else {
+				// Fall back to free-text vote markers
+				parsed := voteParser.ParseVote(feedback)
+				vote = parsed.Vote
+				confidence = parsed.Confidence
+			}
 		}
 
 		votes = append(votes, ReviewVote{
-			ReviewerName: fmt.Sprintf("reviewer-%d", i+1),
-			ReviewType:   reviewType,
-			Vote:         vote,
-			Feedback:     feedback,
-			Duration:     time.Since(reviewStart),
+			ReviewerName:  reviewerName,
+			ReviewType:    reviewType,
+			Vote:          vote,
+			Feedback:      feedback,
+			Duration:      time.Since(reviewStart),
+			Model:         model,
+			Confidence:    confidence,
+			Failed:        failed,
+			Summary:       summary,
+			Findings:      findings,
+			PromptVersion: rendered.Stamp(),
 		})
 
 		telemetry.AddEvent(ctx, "review.completed",
-			attribute.Int("reviewer.number", i+1),
+			attribute.Int("reviewer.number", firstReviewer+i+1),
 			attribute.String("review.type", string(reviewType)),
 			attribute.String("vote", string(vote)),
+			attribute.Int("findings", len(findings)),
 		)
 	}
 
-	// Check for unanimous approval
-	allApproved := voteParser.CheckUnanimousApproval(votes)
+	// Apply the review policy
+	decision := policy.Decide(votes)
+	approved := decision.Approved()
 
 	// Generate aggregated feedback if not approved
 	aggregator := NewReviewAggregator()
 	errorMsg := ""
-	if !allApproved {
+	if !approved {
 		errorMsg = aggregator.GetRejectionSummary(votes)
 	}
 
-	// Count votes by type
-	approvals := 0
-	rejections := 0
-	requestChanges := 0
-	for _, vote := range votes {
-		switch vote.Vote {
-		case VoteApprove:
-			approvals++
-		case VoteReject:
-			rejections++
-		case VoteRequestChange:
-			requestChanges++
-		}
-	}
-
 	span.SetAttributes(
 		telemetry.AttrGateName.String("multi_review"),
-		telemetry.AttrGatePassed.Bool(allApproved),
+		telemetry.AttrGatePassed.Bool(approved),
+		attribute.String("review.policy", string(decision.Policy)),
+		attribute.String("review.outcome", string(decision.Outcome)),
+		attribute.Float64("review.approval_score", decision.ApprovalScore),
 		attribute.Int("reviews.total", len(votes)),
-		attribute.Int("reviews.approved", approvals),
-		attribute.Int("reviews.rejected", rejections),
-		attribute.Int("reviews.request_change", requestChanges),
+		attribute.Int("reviews.approved", decision.Approvals),
+		attribute.Int("reviews.rejected", decision.Rejections),
+		attribute.Int("reviews.request_change", decision.RequestChanges),
 	)
 
-	if allApproved {
-		span.SetStatus(codes.Ok, "all reviewers approved")
+	if approved {
+		span.SetStatus(codes.Ok, decision.Reason)
 		telemetry.AddEvent(ctx, "gate.passed", telemetry.AttrGateName.String("multi_review"))
 	} else {
-		span.SetStatus(codes.Error, "review not unanimously approved")
+		span.SetStatus(codes.Error, decision.Reason)
 		telemetry.AddEvent(ctx, "gate.failed",
 			telemetry.AttrGateName.String("multi_review"),
-			attribute.Int("rejections", rejections),
+			attribute.String("review.outcome", string(decision.Outcome)),
+			attribute.Int("rejections", decision.Rejections),
 		)
 	}
 
 	return &GateResult{
-		GateName:    "multi_review",
-		Passed:      allApproved,
-		ReviewVotes: votes,
-		Duration:    time.Since(startTime),
-		Error:       errorMsg,
-		Message:     aggregator.AggregateReviewFeedback(votes),
+		GateName:       "multi_review",
+		Passed:         approved,
+		ReviewVotes:    votes,
+		ReviewDecision: &decision,
+		Duration:       time.Since(startTime),
+		Error:          errorMsg,
+		Message:        aggregator.AggregateReviewFeedback(votes),
 	}, nil
 }
 
@@ -973,6 +1102,12 @@ func getReviewFocus(reviewType ReviewType) string {
 		return "correctness, requirements satisfaction, and behavior"
 	case ReviewTypeArchitecture:
 		return "design patterns, code structure, and long-term maintainability"
+	case ReviewTypeSecurity:
+		return "injection, hard-coded secrets, unsafe command execution, and path traversal"
+	case ReviewTypePerformance:
+		return "allocations, N+1 calls, lock contention, and algorithmic complexity"
+	case ReviewTypeAPICompatibility:
+		return "breaking changes to exported Go symbols and compatible alternatives"
 	default:
 		return "overall code quality"
 	}
@@ -987,6 +1122,12 @@ func getReviewerAgent(reviewType ReviewType) string {
 		return "reviewer-functional"
 	case ReviewTypeArchitecture:
 		return "reviewer-architecture"
+	case ReviewTypeSecurity:
+		return "reviewer-security"
+	case ReviewTypePerformance:
+		return "reviewer-performance"
+	case ReviewTypeAPICompatibility:
+		return "reviewer-api-compatibility"
 	default:
 		return "reviewer"
 	}
@@ -1027,6 +1168,20 @@ func matchesPattern(file string, patterns []string) bool {
 	return false
 }
 
+// DetectBypassEligibilityFromDiff analyzes a structured diff for bypass lane
+// eligibility. Pure renames carry no content change and are ignored, so moving
+// code around does not block a documentation-only bypass.
+func DetectBypassEligibilityFromDiff(diff *git.Diff) *BypassEligibility {
+	files := make([]string, 0, len(diff.Files))
+	for _, file := range diff.Files {
+		if file.IsPureRename() {
+			continue
+		}
+		files = append(files, file.Path())
+	}
+	return DetectBypassEligibility(files)
+}
+
 // DetectBypassEligibility analyzes file changes to determine bypass lane eligibility
 func DetectBypassEligibility(files []string) *BypassEligibility {
 	if len(files) == 0 {
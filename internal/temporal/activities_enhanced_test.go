@@ -8,6 +8,8 @@ package temporal
 import (
 	"strings"
 	"testing"
+
+	"open-swarm/internal/git"
 )
 
 // TestExecuteGenImpl_WithRetryFeedback verifies that ExecuteGenImpl includes
@@ -207,6 +209,27 @@ func TestDetectBypassEligibility_CodeOnly(t *testing.T) {
 	}
 }
 
+// TestDetectBypassEligibilityFromDiff_IgnoresPureRenames verifies that a moved
+// Go file does not block a documentation bypass
+func TestDetectBypassEligibilityFromDiff_IgnoresPureRenames(t *testing.T) {
+	diff := &git.Diff{Files: []git.FileDiff{
+		{OldPath: "README.md", NewPath: "README.md", Status: git.FileModified, Insertions: 2},
+		{OldPath: "pkg/old.go", NewPath: "pkg/new.go", Status: git.FileRenamed, Similarity: 100},
+	}}
+
+	result := DetectBypassEligibilityFromDiff(diff)
+	if result.BypassType != BypassTypeDocumentation || !result.Eligible {
+		t.Errorf("Expected documentation bypass, got %s (%s)", result.BypassType, result.Reason)
+	}
+
+	// A rename with edits is a code change
+	diff.Files[1].Similarity = 80
+	diff.Files[1].Hunks = []git.Hunk{{OldStart: 1, OldLines: 1, NewStart: 1, NewLines: 2}}
+	if result := DetectBypassEligibilityFromDiff(diff); result.Eligible {
+		t.Error("Edited rename should not be eligible for bypass")
+	}
+}
+
 // TestDetectBypassEligibility_Empty verifies empty file list
 func TestDetectBypassEligibility_Empty(t *testing.T) {
 	result := DetectBypassEligibility([]string{})
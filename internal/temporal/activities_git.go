@@ -7,12 +7,13 @@ package temporal
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os/exec"
-	"strings"
 
 	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/log"
+
+	"open-swarm/internal/git"
 )
 
 // gitSafeGetLogger returns the activity logger if in activity context, otherwise a noop logger
@@ -37,7 +38,8 @@ func gitSafeRecordHeartbeat(ctx context.Context, details ...interface{}) {
 	activity.RecordHeartbeat(ctx, details...)
 }
 
-// GitActivities provides thin wrappers around Git operations
+// GitActivities provides thin wrappers around Git operations on top of
+// internal/git, which reads only machine-readable git output
 // These activities are designed to be:
 // - Idempotent where possible
 // - Minimal business logic
@@ -68,29 +70,20 @@ func (ga *GitActivities) GitClone(ctx context.Context, input GitCloneInput) (*Gi
 
 	gitSafeRecordHeartbeat(ctx, "executing")
 
-	// Build git clone command
-	args := []string{"clone", input.URL, input.TargetDir}
-	if input.Branch != "" {
-		args = append(args, "-b", input.Branch)
-	}
-	if input.Depth > 0 {
-		args = append(args, "--depth", fmt.Sprintf("%d", input.Depth))
+	// Check if already cloned with the same remote (idempotent)
+	existing := git.Open(input.TargetDir)
+	if existing.IsRepository(ctx) {
+		if remoteURL, err := existing.RemoteURL(ctx, "origin"); err == nil && remoteURL == input.URL {
+			logger.Info("Repository already cloned with matching remote")
+			return ga.getRepoInfo(ctx, input.TargetDir)
+		}
 	}
 
-	cmd := exec.CommandContext(ctx, "git", args...)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		// Check if already exists with same remote (idempotent)
-		if strings.Contains(string(output), "already exists") {
-			// Verify it's the same repository
-			remoteCmd := exec.CommandContext(ctx, "git", "-C", input.TargetDir, "remote", "get-url", "origin")
-			remoteURL, remoteErr := remoteCmd.Output()
-			if remoteErr == nil && strings.TrimSpace(string(remoteURL)) == input.URL {
-				logger.Info("Repository already cloned with matching remote")
-				return ga.getRepoInfo(ctx, input.TargetDir)
-			}
-		}
-		return nil, fmt.Errorf("git clone failed: %w\nOutput: %s", err, string(output))
+	if _, err := git.Clone(ctx, input.URL, input.TargetDir, git.CloneOptions{
+		Branch: input.Branch,
+		Depth:  input.Depth,
+	}); err != nil {
+		return nil, fmt.Errorf("git clone failed: %w", err)
 	}
 
 	logger.Info("Repository cloned successfully", "path", input.TargetDir)
@@ -107,33 +100,28 @@ type GitCheckoutInput struct {
 
 // GitCheckout checks out a branch (or creates and checks out a new branch)
 // Idempotent: If already on the specified branch, returns success
+// Errors wrap git.ErrDirtyTree or git.ErrRefNotFound when applicable
 func (ga *GitActivities) GitCheckout(ctx context.Context, input GitCheckoutInput) error {
 	logger := gitSafeGetLogger(ctx)
 	logger.Info("Checking out branch", "repo", input.RepoPath, "branch", input.Branch, "create", input.CreateNew)
 
 	gitSafeRecordHeartbeat(ctx, "executing")
 
+	repo := git.Open(input.RepoPath)
+
 	// Check current branch (idempotent check)
-	currentBranchCmd := exec.CommandContext(ctx, "git", "-C", input.RepoPath, "branch", "--show-current")
-	currentBranch, _ := currentBranchCmd.Output()
-	if strings.TrimSpace(string(currentBranch)) == input.Branch {
+	if current, err := repo.CurrentBranch(ctx); err == nil && current == input.Branch {
 		logger.Info("Already on target branch", "branch", input.Branch)
 		return nil
 	}
 
-	args := []string{"-C", input.RepoPath, "checkout"}
-	if input.CreateNew {
-		args = append(args, "-b")
-	}
-	args = append(args, input.Branch)
-	if input.StartPoint != "" {
-		args = append(args, input.StartPoint)
-	}
-
-	cmd := exec.CommandContext(ctx, "git", args...)
-	output, err := cmd.CombinedOutput()
+	err := repo.Checkout(ctx, git.CheckoutOptions{
+		Branch:     input.Branch,
+		Create:     input.CreateNew,
+		StartPoint: input.StartPoint,
+	})
 	if err != nil {
-		return fmt.Errorf("git checkout failed: %w\nOutput: %s", err, string(output))
+		return fmt.Errorf("git checkout failed: %w", err)
 	}
 
 	logger.Info("Branch checked out successfully", "branch", input.Branch)
@@ -142,16 +130,18 @@ func (ga *GitActivities) GitCheckout(ctx context.Context, input GitCheckoutInput
 
 // GitCommitInput specifies parameters for creating a commit
 type GitCommitInput struct {
-	RepoPath string   // Path to the repository
-	Message  string   // Commit message
-	Files    []string // Files to stage (empty = all changes)
-	Author   string   // Optional: author in "Name <email>" format
+	RepoPath string        // Path to the repository
+	Message  string        // Commit message
+	Files    []string      // Files to stage (empty = all changes)
+	Author   string        // Optional: author in "Name <email>" format
+	Trailers []git.Trailer // Optional: trailers appended to the message (e.g. Task-ID)
 }
 
 // GitCommitOutput contains the result of a commit operation
 type GitCommitOutput struct {
-	CommitHash string // Hash of the created commit
-	Files      int    // Number of files changed
+	CommitHash string        // Hash of the created commit
+	Files      int           // Number of files changed
+	Trailers   []git.Trailer // Trailers parsed back from the created commit
 }
 
 // GitCommit creates a Git commit with the specified files and message
@@ -162,57 +152,24 @@ func (ga *GitActivities) GitCommit(ctx context.Context, input GitCommitInput) (*
 
 	gitSafeRecordHeartbeat(ctx, "executing")
 
-	// Stage files
-	if len(input.Files) == 0 {
-		// Add all changes
-		addCmd := exec.CommandContext(ctx, "git", "-C", input.RepoPath, "add", ".")
-		if output, err := addCmd.CombinedOutput(); err != nil {
-			return nil, fmt.Errorf("git add failed: %w\nOutput: %s", err, string(output))
-		}
-	} else {
-		// Add specific files
-		args := append([]string{"-C", input.RepoPath, "add"}, input.Files...)
-		addCmd := exec.CommandContext(ctx, "git", args...)
-		if output, err := addCmd.CombinedOutput(); err != nil {
-			return nil, fmt.Errorf("git add failed: %w\nOutput: %s", err, string(output))
-		}
-	}
-
-	// Check if there are changes to commit
-	statusCmd := exec.CommandContext(ctx, "git", "-C", input.RepoPath, "status", "--porcelain")
-	statusOutput, _ := statusCmd.Output()
-	if len(strings.TrimSpace(string(statusOutput))) == 0 {
+	commit, err := git.Open(input.RepoPath).Commit(ctx, git.CommitOptions{
+		Message:  input.Message,
+		Trailers: input.Trailers,
+		Author:   input.Author,
+		Paths:    input.Files,
+	})
+	if errors.Is(err, git.ErrNothingToCommit) {
 		logger.Info("No changes to commit")
 		return &GitCommitOutput{CommitHash: "", Files: 0}, nil
 	}
-
-	// Create commit
-	commitArgs := []string{"-C", input.RepoPath, "commit", "-m", input.Message}
-	if input.Author != "" {
-		commitArgs = append(commitArgs, "--author", input.Author)
-	}
-
-	commitCmd := exec.CommandContext(ctx, "git", commitArgs...)
-	output, err := commitCmd.CombinedOutput()
 	if err != nil {
-		return nil, fmt.Errorf("git commit failed: %w\nOutput: %s", err, string(output))
+		return nil, fmt.Errorf("git commit failed: %w", err)
 	}
 
-	// Get commit hash
-	hashCmd := exec.CommandContext(ctx, "git", "-C", input.RepoPath, "rev-parse", "HEAD")
-	hashOutput, err := hashCmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get commit hash: %w", err)
-	}
-
-	// Count files changed
-	filesCmd := exec.CommandContext(ctx, "git", "-C", input.RepoPath, "diff", "--name-only", "HEAD~1", "HEAD")
-	filesOutput, _ := filesCmd.Output()
-	fileCount := len(strings.Split(strings.TrimSpace(string(filesOutput)), "\n"))
-
 	result := &GitCommitOutput{
-		CommitHash: strings.TrimSpace(string(hashOutput)),
-		Files:      fileCount,
+		CommitHash: commit.Hash,
+		Files:      len(commit.Files),
+		Trailers:   commit.Trailers,
 	}
 
 	logger.Info("Commit created successfully", "hash", result.CommitHash, "files", result.Files)
@@ -229,31 +186,22 @@ type GitPushInput struct {
 }
 
 // GitPush pushes commits to a remote repository
-// Idempotent: If remote is already up-to-date, returns success
+// Idempotent: If remote is already up-to-date, git reports success
+// A rejected push wraps git.ErrNonFastForward
 func (ga *GitActivities) GitPush(ctx context.Context, input GitPushInput) error {
 	logger := gitSafeGetLogger(ctx)
 	logger.Info("Pushing to remote", "repo", input.RepoPath, "remote", input.Remote, "branch", input.Branch)
 
 	gitSafeRecordHeartbeat(ctx, "executing")
 
-	args := []string{"-C", input.RepoPath, "push"}
-	if input.Force {
-		args = append(args, "--force")
-	}
-	if input.SetUpstream {
-		args = append(args, "--set-upstream")
-	}
-	args = append(args, input.Remote, input.Branch)
-
-	cmd := exec.CommandContext(ctx, "git", args...)
-	output, err := cmd.CombinedOutput()
+	err := git.Open(input.RepoPath).Push(ctx, git.PushOptions{
+		Remote:      input.Remote,
+		Branch:      input.Branch,
+		Force:       input.Force,
+		SetUpstream: input.SetUpstream,
+	})
 	if err != nil {
-		// Check if already up-to-date (idempotent)
-		if strings.Contains(string(output), "up-to-date") || strings.Contains(string(output), "up to date") {
-			logger.Info("Remote already up-to-date")
-			return nil
-		}
-		return fmt.Errorf("git push failed: %w\nOutput: %s", err, string(output))
+		return fmt.Errorf("git push failed: %w", err)
 	}
 
 	logger.Info("Pushed successfully to remote")
@@ -262,74 +210,35 @@ func (ga *GitActivities) GitPush(ctx context.Context, input GitPushInput) error
 
 // GitStatusOutput contains the status of the repository
 type GitStatusOutput struct {
-	Branch    string   // Current branch
-	Modified  []string // Modified files
-	Untracked []string // Untracked files
-	Staged    []string // Staged files
-	IsDirty   bool     // Whether there are uncommitted changes
-	AheadBy   int      // Commits ahead of remote
-	BehindBy  int      // Commits behind remote
+	Branch     string   // Current branch
+	Modified   []string // Modified files
+	Untracked  []string // Untracked files
+	Staged     []string // Staged files
+	Conflicted []string // Files with unresolved merge conflicts
+	IsDirty    bool     // Whether there are uncommitted changes
+	AheadBy    int      // Commits ahead of remote
+	BehindBy   int      // Commits behind remote
 }
 
 // GitStatus returns the current status of the repository
-//
-//nolint:cyclop // complexity 13 is acceptable for status parsing
 func (ga *GitActivities) GitStatus(ctx context.Context, repoPath string) (*GitStatusOutput, error) {
 	logger := gitSafeGetLogger(ctx)
 	logger.Info("Getting Git status", "repo", repoPath)
 
-	result := &GitStatusOutput{
-		Modified:  []string{},
-		Untracked: []string{},
-		Staged:    []string{},
-	}
-
-	// Get current branch
-	branchCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "branch", "--show-current")
-	branchOutput, err := branchCmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get current branch: %w", err)
-	}
-	result.Branch = strings.TrimSpace(string(branchOutput))
-
-	// Get status in porcelain format
-	statusCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "status", "--porcelain")
-	statusOutput, err := statusCmd.Output()
+	status, err := git.Open(repoPath).Status(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get status: %w", err)
 	}
 
-	// Parse status output
-	lines := strings.Split(string(statusOutput), "\n")
-	for _, line := range lines {
-		if len(line) < 3 {
-			continue
-		}
-		status := line[:2]
-		file := strings.TrimSpace(line[3:])
-
-		if status[0] != ' ' && status[0] != '?' {
-			result.Staged = append(result.Staged, file)
-		}
-		if status[1] == 'M' {
-			result.Modified = append(result.Modified, file)
-		}
-		if status == "??" {
-			result.Untracked = append(result.Untracked, file)
-		}
-	}
-
-	result.IsDirty = len(result.Modified) > 0 || len(result.Untracked) > 0 || len(result.Staged) > 0
-
-	// Get ahead/behind count
-	revListCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
-	revListOutput, err := revListCmd.Output()
-	if err == nil {
-		parts := strings.Fields(string(revListOutput))
-		if len(parts) == 2 {
-			fmt.Sscanf(parts[0], "%d", &result.AheadBy)
-			fmt.Sscanf(parts[1], "%d", &result.BehindBy)
-		}
+	result := &GitStatusOutput{
+		Branch:     status.Branch,
+		Modified:   status.Modified(),
+		Untracked:  status.Untracked(),
+		Staged:     status.Staged(),
+		Conflicted: status.Conflicted(),
+		IsDirty:    !status.IsClean(),
+		AheadBy:    status.Ahead,
+		BehindBy:   status.Behind,
 	}
 
 	logger.Info("Status retrieved", "branch", result.Branch, "dirty", result.IsDirty)
@@ -338,10 +247,11 @@ func (ga *GitActivities) GitStatus(ctx context.Context, repoPath string) (*GitSt
 
 // GitDiffOutput contains diff information
 type GitDiffOutput struct {
-	Diff         string   // Full diff text
-	FilesChanged []string // List of changed files
-	Insertions   int      // Number of insertions
-	Deletions    int      // Number of deletions
+	Diff         string         // Full diff text
+	FilesChanged []string       // List of changed files
+	Insertions   int            // Number of insertions
+	Deletions    int            // Number of deletions
+	Files        []git.FileDiff // Per-file hunks, rename and binary information
 }
 
 // GitDiff returns the diff between two commits/branches
@@ -349,47 +259,20 @@ func (ga *GitActivities) GitDiff(ctx context.Context, repoPath, from, to string)
 	logger := gitSafeGetLogger(ctx)
 	logger.Info("Getting Git diff", "repo", repoPath, "from", from, "to", to)
 
-	// Get diff
-	diffCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "diff", from, to)
-	diffOutput, err := diffCmd.Output()
+	diff, err := git.Open(repoPath).Diff(ctx, git.DiffOptions{From: from, To: to})
 	if err != nil {
 		return nil, fmt.Errorf("git diff failed: %w", err)
 	}
 
-	// Get file list
-	filesCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "diff", "--name-only", from, to)
-	filesOutput, err := filesCmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get changed files: %w", err)
-	}
-
-	files := []string{}
-	for _, line := range strings.Split(string(filesOutput), "\n") {
-		if trimmed := strings.TrimSpace(line); trimmed != "" {
-			files = append(files, trimmed)
-		}
-	}
-
-	// Get stats
-	statsCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "diff", "--stat", from, to)
-	statsOutput, _ := statsCmd.Output()
-
 	result := &GitDiffOutput{
-		Diff:         string(diffOutput),
-		FilesChanged: files,
-		Insertions:   0,
-		Deletions:    0,
+		Diff:         diff.Patch,
+		FilesChanged: diff.Paths(),
+		Insertions:   diff.Insertions(),
+		Deletions:    diff.Deletions(),
+		Files:        diff.Files,
 	}
 
-	// Parse stats for insertions/deletions
-	statsLines := strings.Split(string(statsOutput), "\n")
-	if len(statsLines) > 0 {
-		lastLine := statsLines[len(statsLines)-1]
-		fmt.Sscanf(lastLine, " %d files changed, %d insertions(+), %d deletions(-)",
-			&result.Insertions, &result.Deletions, &result.Deletions)
-	}
-
-	logger.Info("Diff retrieved", "files", len(files))
+	logger.Info("Diff retrieved", "files", len(result.FilesChanged))
 	return result, nil
 }
 
@@ -406,41 +289,30 @@ type GitBranchInput struct {
 // For deletion: Idempotent - if branch doesn't exist, returns success
 func (ga *GitActivities) GitBranch(ctx context.Context, input GitBranchInput) error {
 	logger := gitSafeGetLogger(ctx)
+	repo := git.Open(input.RepoPath)
 
 	if input.Delete {
 		logger.Info("Deleting branch", "repo", input.RepoPath, "branch", input.Name)
 
-		args := []string{"-C", input.RepoPath, "branch"}
-		if input.Force {
-			args = append(args, "-D")
-		} else {
-			args = append(args, "-d")
+		err := repo.DeleteBranch(ctx, input.Name, input.Force)
+		if errors.Is(err, git.ErrRefNotFound) {
+			logger.Info("Branch already deleted")
+			return nil
 		}
-		args = append(args, input.Name)
-
-		cmd := exec.CommandContext(ctx, "git", args...)
-		output, err := cmd.CombinedOutput()
 		if err != nil {
-			// Branch not found is OK (idempotent)
-			if strings.Contains(string(output), "not found") {
-				logger.Info("Branch already deleted")
-				return nil
-			}
-			return fmt.Errorf("git branch delete failed: %w\nOutput: %s", err, string(output))
+			return fmt.Errorf("git branch delete failed: %w", err)
 		}
 		logger.Info("Branch deleted successfully")
 	} else {
 		logger.Info("Creating branch", "repo", input.RepoPath, "branch", input.Name)
 
-		cmd := exec.CommandContext(ctx, "git", "-C", input.RepoPath, "branch", input.Name)
-		output, err := cmd.CombinedOutput()
+		err := repo.CreateBranch(ctx, input.Name, "")
+		if errors.Is(err, git.ErrBranchExists) {
+			logger.Info("Branch already exists")
+			return nil
+		}
 		if err != nil {
-			// Branch exists is OK (idempotent)
-			if strings.Contains(string(output), "already exists") {
-				logger.Info("Branch already exists")
-				return nil
-			}
-			return fmt.Errorf("git branch create failed: %w\nOutput: %s", err, string(output))
+			return fmt.Errorf("git branch create failed: %w", err)
 		}
 		logger.Info("Branch created successfully")
 	}
@@ -450,23 +322,21 @@ func (ga *GitActivities) GitBranch(ctx context.Context, input GitBranchInput) er
 
 // getRepoInfo is a helper to extract repository information
 func (ga *GitActivities) getRepoInfo(ctx context.Context, repoPath string) (*GitCloneOutput, error) {
-	// Get current branch
-	branchCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "branch", "--show-current")
-	branchOutput, err := branchCmd.Output()
+	repo := git.Open(repoPath)
+
+	branch, err := repo.CurrentBranch(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get branch: %w", err)
 	}
 
-	// Get current commit
-	commitCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "rev-parse", "HEAD")
-	commitOutput, err := commitCmd.Output()
+	commit, err := repo.Head(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get commit: %w", err)
 	}
 
 	return &GitCloneOutput{
 		Path:   repoPath,
-		Branch: strings.TrimSpace(string(branchOutput)),
-		Commit: strings.TrimSpace(string(commitOutput)),
+		Branch: branch,
+		Commit: commit,
 	}, nil
 }
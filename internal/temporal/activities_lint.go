@@ -6,8 +6,14 @@
 package temporal
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.temporal.io/sdk/activity"
@@ -36,8 +42,14 @@ func NewLintActivities() *LintActivities {
 
 // LintConfig contains linting configuration
 type LintConfig struct {
-	// Linter specifies which linter to use: "golangci-lint", "make lint", etc.
+	// Linter specifies which linter to use: a registered linter such as
+	// "golangci-lint", "eslint", "ruff" or "go-vet", or a shell command such
+	// as "make lint" whose text output is parsed
 	Linter string
+	// Linters lists additional registered linters to run alongside Linter
+	Linters []string
+	// IncludeUnchanged reports issues on lines the cell did not change
+	IncludeUnchanged bool
 	// Timeout specifies the maximum time to wait for lint execution
 	Timeout time.Duration
 	// EnableAutoFix enables linter auto-fix if supported
@@ -65,16 +77,16 @@ type BootstrapForLint struct {
 // RunLint executes a linter in the cell and returns structured results
 //
 // This activity:
-// 1. Executes the specified linter (golangci-lint, make lint, etc.)
+// 1. Executes the specified linters (golangci-lint, make lint, etc.)
 // 2. Captures and parses the output
 // 3. Extracts individual issues with location and severity
-// 4. Returns a structured LintResult
+// 4. Keeps only issues on lines changed in the cell
+// 5. Returns a structured LintResult
 //
 // Supports:
-// - golangci-lint: Standard Go linter aggregator
-// - make lint: Project-specific linting via Makefile
+// - Registered linters (golangci-lint, eslint, ruff, go vet): JSON output
+// - make lint and custom commands: file:line:col text output
 // - Timeout handling with activity heartbeats
-// - Output parsing for common linter formats
 func (la *LintActivities) RunLint(ctx context.Context, input LintInput, bootstrap *BootstrapForLint) (*LintResult, error) {
 	logger := activity.GetLogger(ctx)
 	logger.Info("Running lint check", "cellID", input.CellID, "linter", input.Config.Linter)
@@ -94,26 +106,34 @@ func (la *LintActivities) RunLint(ctx context.Context, input LintInput, bootstra
 	// Reconstruct cell from bootstrap output
 	cell := reconstructCellForLint(bootstrap)
 
-	// Build linter command
-	command := buildLintCommand(input.Config.Linter, input.Config.EnableAutoFix)
-
-	// Execute linter
-	logger.Info("Executing linter", "command", command)
-	activity.RecordHeartbeat(ctx, "executing linter")
-
-	output, err := runLintInCell(ctx, la.activities, cell, command)
-	if err != nil {
-		logger.Error("Linter execution failed", "error", err)
-		return &LintResult{
-			Passed:   false,
-			Output:   output,
-			Duration: time.Since(startTime),
-		}, fmt.Errorf("linter execution failed: %w", err)
+	names := append([]string{input.Config.Linter}, input.Config.Linters...)
+	var issues []LintIssue
+	var outputs []string
+	for _, name := range names {
+		logger.Info("Executing linter", "linter", name)
+		activity.RecordHeartbeat(ctx, "executing "+name)
+
+		linterIssues, output, err := runLinter(ctx, cell.WorktreePath, name, input.Config.EnableAutoFix)
+		outputs = append(outputs, output)
+		if err != nil {
+			logger.Error("Linter execution failed", "linter", name, "error", err)
+			return &LintResult{
+				Passed:   false,
+				Issues:   issues,
+				Output:   strings.Join(outputs, "\n"),
+				Duration: time.Since(startTime),
+			}, fmt.Errorf("linter execution failed: %w", err)
+		}
+		issues = append(issues, linterIssues...)
 	}
+	output := strings.Join(outputs, "\n")
 
-	// Parse linter output
-	activity.RecordHeartbeat(ctx, "parsing output")
-	issues := parseLintOutput(output, input.Config.Linter)
+	// Restrict to lines changed in the cell
+	activity.RecordHeartbeat(ctx, "filtering issues")
+	if !input.Config.IncludeUnchanged {
+		issues = filterIssuesToChangedLines(issues, cell.WorktreePath, worktreeChangedLines(ctx, cell.WorktreePath))
+	}
+	sortIssues(issues)
 
 	// Determine success: no error-severity issues
 	passed := countErrorIssues(issues) == 0
@@ -304,75 +324,102 @@ func reconstructCellForLint(bootstrap *BootstrapForLint) *workflow.CellBootstrap
 	}
 }
 
-// buildLintCommand constructs the linter command based on configuration
-func buildLintCommand(linter string, enableAutoFix bool) string {
+// buildLintCommand constructs the shell command for linters that are not in
+// the registry
+func buildLintCommand(linter string) string {
 	switch linter {
-	case "golangci-lint":
-		cmd := "golangci-lint run"
-		if enableAutoFix {
-			cmd += " --fix"
-		}
-		return cmd
-	case "make lint":
-		return "make lint"
-	case "":
+	case "make lint", "":
 		return "make lint" // Default
 	default:
 		return linter // Custom linter command
 	}
 }
 
-// runLintInCell executes a linter command in the cell and returns output
-// This is a placeholder that would delegate to the workflow.Activities
-func runLintInCell(ctx context.Context, activities *workflow.Activities, cell *workflow.CellBootstrap, command string) (string, error) {
-	// For now, return empty output - real implementation would execute via OpenCode SDK
-	// This is a thin wrapper, so actual execution would use the SDK client
-	return "", nil
-}
-
-// parseLintOutput parses linter output into structured issues
-// Supports common formats from golangci-lint and other linters
-func parseLintOutput(output string, linterType string) []LintIssue {
-	// Simple parser for golangci-lint format: file:line:column: message (rule)
-	// Full implementation would handle multiple linter formats
+// runLinter runs one linter in the worktree and parses its issues. Registered
+// linters are run with JSON output; anything else is run through the shell
+// and its text output is parsed.
+func runLinter(ctx context.Context, worktreePath, name string, enableAutoFix bool) ([]LintIssue, string, error) {
+	if linter, ok := LookupLinter(name); ok {
+		output, err := runLintCommand(ctx, worktreePath, linter.Command(enableAutoFix), linter.ReadsStderr)
+		if err != nil {
+			return nil, output, err
+		}
+		issues, err := linter.Parse(output)
+		if err != nil {
+			return nil, output, err
+		}
+		for i := range issues {
+			issues[i].Linter = linter.Name
+		}
+		return issues, output, nil
+	}
 
-	var issues []LintIssue
+	command := buildLintCommand(name)
+	output, runErr := runLintCommand(ctx, worktreePath, []string{"sh", "-c", command}, true)
+	issues := parseLintOutput(output)
+	if runErr != nil && len(issues) == 0 {
+		return nil, output, runErr
+	}
+	return issues, output, nil
+}
 
-	if output == "" {
-		return issues
+// runLintCommand executes argv in dir and returns its stdout, or stdout and
+// stderr combined when withStderr is set. Linters exit non-zero when they
+// report issues, so a failed exit that produced output is not an error.
+func runLintCommand(ctx context.Context, dir string, argv []string, withStderr bool) (string, error) {
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...) //nolint:gosec // Linter commands come from the registry or workflow config
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	if withStderr {
+		cmd.Stderr = &stdout
+	} else {
+		cmd.Stderr = &stderr
+	}
+
+	err := cmd.Run()
+	output := stdout.String()
+	if err == nil {
+		return output, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && strings.TrimSpace(output) != "" && ctx.Err() == nil {
+		return output, nil
 	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return output, fmt.Errorf("%s: %w: %s", argv[0], err, firstLine(msg))
+	}
+	return output, fmt.Errorf("%s: %w", argv[0], err)
+}
 
-	// Parse line by line
-	// Format: path/to/file.go:10:5: error message (ruleName)
-	lines := splitLintLines(output)
+// lintLinePattern matches "path/to/file.go:10:5: message" (column optional)
+var lintLinePattern = regexp.MustCompile(`^\s*([^\s:][^:]*):(\d+):(?:(\d+):)?\s*(.+)$`)
 
-	for _, line := range lines {
+// parseLintOutput parses file:line:col text output into structured issues
+func parseLintOutput(output string) []LintIssue {
+	var issues []LintIssue
+	for _, line := range strings.Split(output, "\n") {
 		if issue := parseLintLine(line); issue != nil {
 			issues = append(issues, *issue)
 		}
 	}
-
 	return issues
 }
 
 // parseLintLine parses a single lint output line
 func parseLintLine(line string) *LintIssue {
-	if len(line) == 0 {
-		return nil
-	}
-
-	// Simple parsing - look for "file:line:column: message"
-	// This is a thin implementation - production would be more robust
-	parts := splitLintString(line, ":")
-	if len(parts) < 4 {
+	matches := lintLinePattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+	if matches == nil {
 		return nil
 	}
 
+	lineNum, _ := strconv.Atoi(matches[2])
+	column, _ := strconv.Atoi(matches[3])
 	issue := &LintIssue{
-		File:     parts[0],
-		Line:     parseIntSafe(parts[1]),
-		Column:   parseIntSafe(parts[2]),
-		Message:  joinLintStrings(parts[3:], ":"),
+		File:     matches[1],
+		Line:     lineNum,
+		Column:   column,
+		Message:  strings.TrimSpace(matches[4]),
 		Severity: "error", // Default to error
 	}
 
@@ -380,7 +427,7 @@ func parseLintLine(line string) *LintIssue {
 	if rule := extractLintRule(issue.Message); rule != "" {
 		issue.Rule = rule
 		// If message contains "warning" text, downgrade to warning
-		if containsLintString(issue.Message, "warning") {
+		if strings.Contains(issue.Message, "warning") {
 			issue.Severity = "warning"
 		}
 	}
@@ -391,31 +438,15 @@ func parseLintLine(line string) *LintIssue {
 // extractLintRule extracts the rule name from a linter message
 func extractLintRule(message string) string {
 	// Find last occurrence of '(' and extract text until ')'
-	startIdx := -1
-	for i := len(message) - 1; i >= 0; i-- {
-		if message[i] == '(' {
-			startIdx = i
-			break
-		}
-	}
-
+	startIdx := strings.LastIndexByte(message, '(')
 	if startIdx == -1 {
 		return ""
 	}
-
-	endIdx := -1
-	for i := startIdx + 1; i < len(message); i++ {
-		if message[i] == ')' {
-			endIdx = i
-			break
-		}
-	}
-
+	endIdx := strings.IndexByte(message[startIdx:], ')')
 	if endIdx == -1 {
 		return ""
 	}
-
-	return message[startIdx+1 : endIdx]
+	return message[startIdx+1 : startIdx+endIdx]
 }
 
 // countErrorIssues returns the number of error-severity issues
@@ -447,123 +478,5 @@ func isLintParseError(err error) bool {
 	}
 	// Check if error message indicates parsing rather than execution
 	errMsg := err.Error()
-	return containsLintString(errMsg, "parse") || containsLintString(errMsg, "unmarshal")
-}
-
-// ============================================================================
-// STRING UTILITIES
-// ============================================================================
-
-// splitLintLines splits output into individual lines
-func splitLintLines(output string) []string {
-	if output == "" {
-		return []string{}
-	}
-
-	var lines []string
-	start := 0
-
-	for i := 0; i < len(output); i++ {
-		if output[i] == '\n' {
-			lines = append(lines, output[start:i])
-			start = i + 1
-		}
-	}
-
-	if start < len(output) {
-		lines = append(lines, output[start:])
-	}
-
-	return lines
-}
-
-// splitLintString splits a string by delimiter
-func splitLintString(s, delim string) []string {
-	if s == "" {
-		return []string{}
-	}
-	if delim == "" {
-		return []string{s}
-	}
-
-	var parts []string
-	start := 0
-	delimLen := len(delim)
-
-	for i := 0; i <= len(s)-delimLen; i++ {
-		if s[i:i+delimLen] == delim {
-			parts = append(parts, s[start:i])
-			start = i + delimLen
-			i += delimLen - 1
-		}
-	}
-
-	parts = append(parts, s[start:])
-	return parts
-}
-
-// joinLintStrings joins string slice with delimiter
-func joinLintStrings(parts []string, delim string) string {
-	if len(parts) == 0 {
-		return ""
-	}
-
-	result := parts[0]
-	for i := 1; i < len(parts); i++ {
-		result += delim + parts[i]
-	}
-	return result
-}
-
-// parseIntSafe parses an integer from string, returns 0 on error
-func parseIntSafe(s string) int {
-	s = trimLintWhitespace(s)
-	if s == "" {
-		return 0
-	}
-
-	num := 0
-	for i := 0; i < len(s); i++ {
-		if s[i] >= '0' && s[i] <= '9' {
-			num = num*10 + int(s[i]-'0')
-		} else {
-			break
-		}
-	}
-	return num
-}
-
-// trimLintWhitespace removes leading/trailing whitespace
-func trimLintWhitespace(s string) string {
-	start := 0
-	end := len(s)
-
-	for start < end && isLintWhitespace(s[start]) {
-		start++
-	}
-
-	for end > start && isLintWhitespace(s[end-1]) {
-		end--
-	}
-
-	return s[start:end]
-}
-
-// isLintWhitespace checks if a rune is whitespace
-func isLintWhitespace(ch byte) bool {
-	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
-}
-
-// containsLintString checks if a string contains a substring
-func containsLintString(s, substr string) bool {
-	if substr == "" || s == "" {
-		return substr == ""
-	}
-
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
+	return strings.Contains(errMsg, "parse") || strings.Contains(errMsg, "unmarshal")
 }
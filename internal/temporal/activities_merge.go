@@ -0,0 +1,215 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.temporal.io/sdk/activity"
+
+	"open-swarm/internal/agent"
+	"open-swarm/internal/git"
+)
+
+// MergeActivities integrates finished cell branches into a target branch.
+// Each activity works in an integration cell: a worktree bootstrapped on the
+// target branch that the branch is rebased in, tested in and fast-forwarded from.
+type MergeActivities struct{}
+
+// NewMergeActivities creates a new MergeActivities instance
+func NewMergeActivities() *MergeActivities {
+	return &MergeActivities{}
+}
+
+// MergeAttempt is the state of a cell branch being integrated
+type MergeAttempt struct {
+	TaskID     string
+	Branch     string   // Cell branch being integrated; deleted once merged
+	TargetHead string   // Target branch commit the branch is rebased onto
+	Rebased    string   // Rebased commit; empty while the rebase is stopped on conflicts
+	Conflicts  []string // Files with unresolved conflicts
+	Merged     string   // Target branch commit after the fast-forward
+	Stale      bool     // The target branch moved before it could be fast-forwarded
+}
+
+// RebaseCell rebases the request's commits onto the target branch in the
+// integration cell. Conflicts leave the rebase stopped and are returned in
+// Conflicts. On success HEAD is moved back to the target head with the
+// rebased tree kept, so gates see the task's changes as the diff to HEAD.
+func (ma *MergeActivities) RebaseCell(ctx context.Context, bootstrap *BootstrapOutput, request MergeRequest, targetBranch string) (*MergeAttempt, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Rebasing cell branch", "taskID", request.TaskID, "branch", request.Branch, "target", targetBranch)
+
+	repo := git.Open(bootstrap.WorktreePath)
+	targetHead, err := repo.ResolveRef(ctx, targetBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve target branch: %w", err)
+	}
+	commit := request.Commit
+	if commit == "" {
+		commit = request.Branch
+	}
+	if err := repo.Checkout(ctx, git.CheckoutOptions{Branch: commit, Detach: true}); err != nil {
+		return nil, fmt.Errorf("failed to check out %s: %w", commit, err)
+	}
+
+	attempt := &MergeAttempt{TaskID: request.TaskID, Branch: request.Branch, TargetHead: targetHead}
+	if err := repo.Rebase(ctx, targetHead); err != nil {
+		if !errors.Is(err, git.ErrConflict) {
+			return nil, err
+		}
+		return attempt, ma.collectConflicts(ctx, repo, attempt)
+	}
+	return attempt, ma.finishRebase(ctx, repo, attempt)
+}
+
+// ResolveConflicts asks the agent to resolve the conflicted files of a
+// stopped rebase, then continues it. Files that still hold conflict markers
+// are returned as conflicts again; so is the next commit if it conflicts too.
+func (ma *MergeActivities) ResolveConflicts(ctx context.Context, bootstrap *BootstrapOutput, attempt *MergeAttempt) (*MergeAttempt, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Resolving rebase conflicts", "taskID", attempt.TaskID, "files", attempt.Conflicts)
+
+	activity.RecordHeartbeat(ctx, "resolving conflicts")
+
+	cell := NewCellActivities().reconstructCell(bootstrap)
+	prompt := fmt.Sprintf(`The changes for task %s conflict with commits that landed on the target branch.
+Resolve the merge conflicts in these files:
+
+%s
+
+INSTRUCTIONS:
+- Edit each file so it keeps the intent of both sides, then remove every <<<<<<<, ======= and >>>>>>> marker
+- Do NOT run git commands; the rebase is continued for you
+- Do NOT change files other than the ones listed`, attempt.TaskID, "- "+strings.Join(attempt.Conflicts, "\n- "))
+
+	if _, err := cell.Client.ExecutePrompt(ctx, prompt, &agent.PromptOptions{
+		Title: fmt.Sprintf("Resolve conflicts: %s", attempt.TaskID),
+		Agent: "build",
+	}); err != nil {
+		return nil, fmt.Errorf("conflict resolution failed: %w", err)
+	}
+
+	next := *attempt
+	next.Conflicts = filesWithConflictMarkers(bootstrap.WorktreePath, attempt.Conflicts)
+	if len(next.Conflicts) > 0 {
+		logger.Warn("Conflict markers remain", "files", next.Conflicts)
+		return &next, nil
+	}
+
+	repo := git.Open(bootstrap.WorktreePath)
+	if err := repo.ContinueRebase(ctx); err != nil {
+		if !errors.Is(err, git.ErrConflict) {
+			return nil, err
+		}
+		return &next, ma.collectConflicts(ctx, repo, &next)
+	}
+	return &next, ma.finishRebase(ctx, repo, &next)
+}
+
+// FinalizeMerge commits fixes made in the integration cell on top of the
+// rebased commits and fast-forwards the target branch to the result, then
+// deletes the cell branch, whose commits now live on the target branch. When
+// the target branch moved in the meantime, Stale is set instead.
+func (ma *MergeActivities) FinalizeMerge(ctx context.Context, bootstrap *BootstrapOutput, attempt *MergeAttempt, targetBranch string, message string) (*MergeAttempt, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Fast-forwarding target branch", "taskID", attempt.TaskID, "target", targetBranch)
+
+	repo := git.Open(bootstrap.WorktreePath)
+	if err := repo.ResetSoft(ctx, attempt.Rebased); err != nil {
+		return nil, err
+	}
+	_, err := repo.Commit(ctx, git.CommitOptions{
+		Message:  message,
+		Trailers: []git.Trailer{{Key: "Task-ID", Value: attempt.TaskID}},
+	})
+	if err != nil && !errors.Is(err, git.ErrNothingToCommit) {
+		return nil, err
+	}
+	head, err := repo.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *attempt
+	if err := repo.FastForward(ctx, targetBranch, head); err != nil {
+		if !errors.Is(err, git.ErrNonFastForward) {
+			return nil, err
+		}
+		logger.Warn("Target branch moved during integration", "target", targetBranch)
+		result.Stale = true
+		return &result, nil
+	}
+	result.Merged = head
+
+	// Cells keep their branch for the queue; once merged it is only clutter
+	if attempt.Branch != "" {
+		if err := repo.DeleteBranch(ctx, attempt.Branch, true); err != nil {
+			logger.Warn("Failed to delete merged cell branch", "branch", attempt.Branch, "error", err)
+		}
+	}
+	return &result, nil
+}
+
+// AbortMerge stops a rebase left in progress and discards the integration
+// cell's changes so the worktree can be torn down or reused
+func (ma *MergeActivities) AbortMerge(ctx context.Context, bootstrap *BootstrapOutput) error {
+	repo := git.Open(bootstrap.WorktreePath)
+	if err := repo.AbortRebase(ctx); err != nil {
+		return err
+	}
+	return repo.ResetHard(ctx, "")
+}
+
+// collectConflicts records the unmerged files of a stopped rebase
+func (ma *MergeActivities) collectConflicts(ctx context.Context, repo *git.Repo, attempt *MergeAttempt) error {
+	status, err := repo.Status(ctx)
+	if err != nil {
+		return err
+	}
+	attempt.Conflicts = status.Conflicted()
+	return nil
+}
+
+// finishRebase records the rebased commit and moves HEAD back to the target
+// head, keeping the rebased tree
+func (ma *MergeActivities) finishRebase(ctx context.Context, repo *git.Repo, attempt *MergeAttempt) error {
+	rebased, err := repo.Head(ctx)
+	if err != nil {
+		return err
+	}
+	attempt.Rebased = rebased
+	attempt.Conflicts = nil
+	return repo.ResetSoft(ctx, attempt.TargetHead)
+}
+
+// filesWithConflictMarkers returns the files that still contain a conflict
+// marker line. "=======" alone is not checked; it is a valid Markdown heading.
+func filesWithConflictMarkers(worktreePath string, files []string) []string {
+	var remaining []string
+	for _, file := range files {
+		f, err := os.Open(filepath.Join(worktreePath, file))
+		if err != nil {
+			continue // Deleted while resolving
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(line, "<<<<<<< ") || strings.HasPrefix(line, ">>>>>>> ") {
+				remaining = append(remaining, file)
+				break
+			}
+		}
+		f.Close()
+	}
+	return remaining
+}
@@ -45,16 +45,18 @@ func TestEnhancedTCR_HappyPath(t *testing.T) {
 		&GateResult{GateName: "VerifyRED", Passed: true}, nil)
 
 	// Implementation & Review Phase - All pass on first try
-	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "GenImpl", Passed: true}, nil)
 
 	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "VerifyGREEN", Passed: true}, nil)
 
-	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "MultiReview", Passed: true}, nil)
 
 	env.OnActivity(cellActivities.CommitChanges, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CellHead, mock.Anything, mock.Anything).Return(
+		&CellHeadOutput{Branch: "task/e2e-1", Commit: "11111111"}, nil)
 
 	env.OnActivity(enhancedActivities.ReleaseFileLocks, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -77,6 +79,8 @@ func TestEnhancedTCR_HappyPath(t *testing.T) {
 	var result *EnhancedTCRResult
 	require.NoError(t, env.GetWorkflowResult(&result))
 	require.True(t, result.Success)
+	require.Equal(t, "task/e2e-1", result.Branch, "branch should come from CellHead")
+	require.Equal(t, "11111111", result.Commit, "commit should come from CellHead")
 	require.Empty(t, result.Error)
 	require.Equal(t, 6, len(result.GateResults), "should have 6 gate results")
 }
@@ -158,7 +162,7 @@ func TestEnhancedTCR_Gate5TestRetry(t *testing.T) {
 		&GateResult{GateName: "VerifyRED", Passed: true}, nil)
 
 	// GenImpl passes
-	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "GenImpl", Passed: true}, nil)
 
 	// VerifyGREEN fails, then passes after targeted fix
@@ -171,10 +175,12 @@ func TestEnhancedTCR_Gate5TestRetry(t *testing.T) {
 	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "VerifyGREEN", Passed: true}, nil)
 
-	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "MultiReview", Passed: true}, nil)
 
 	env.OnActivity(cellActivities.CommitChanges, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CellHead, mock.Anything, mock.Anything).Return(
+		&CellHeadOutput{Branch: "task/e2e-2", Commit: "22222222"}, nil)
 
 	env.OnActivity(enhancedActivities.ReleaseFileLocks, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -194,6 +200,8 @@ func TestEnhancedTCR_Gate5TestRetry(t *testing.T) {
 	var result *EnhancedTCRResult
 	require.NoError(t, env.GetWorkflowResult(&result))
 	require.True(t, result.Success)
+	require.Equal(t, "task/e2e-2", result.Branch, "branch should come from CellHead")
+	require.Equal(t, "22222222", result.Commit, "commit should come from CellHead")
 }
 
 // TestEnhancedTCR_MaxRetriesExceeded tests workflow exhaustion when max retries exceeded
@@ -222,7 +230,7 @@ func TestEnhancedTCR_MaxRetriesExceeded(t *testing.T) {
 		&GateResult{GateName: "VerifyRED", Passed: true}, nil)
 
 	// VerifyGREEN always fails - exhausts all retries
-	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "GenImpl", Passed: true}, nil)
 
 	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
@@ -281,17 +289,19 @@ func TestEnhancedTCR_CompleteScenario(t *testing.T) {
 		&GateResult{GateName: "VerifyRED", Passed: true}, nil)
 
 	// Implementation phase with some retries
-	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "GenImpl", Passed: true}, nil)
 
 	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "VerifyGREEN", Passed: true}, nil)
 
 	// Review phase with feedback-fix cycle
-	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "MultiReview", Passed: true}, nil).Once()
 
 	env.OnActivity(cellActivities.CommitChanges, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CellHead, mock.Anything, mock.Anything).Return(
+		&CellHeadOutput{Branch: "task/e2e-3", Commit: "33333333"}, nil)
 
 	env.OnActivity(enhancedActivities.ReleaseFileLocks, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -312,5 +322,7 @@ func TestEnhancedTCR_CompleteScenario(t *testing.T) {
 	var result *EnhancedTCRResult
 	require.NoError(t, env.GetWorkflowResult(&result))
 	require.True(t, result.Success)
+	require.Equal(t, "task/e2e-3", result.Branch, "branch should come from CellHead")
+	require.Equal(t, "33333333", result.Commit, "commit should come from CellHead")
 	assert.Equal(t, 6, len(result.GateResults), "should have all 6 gates")
 }
@@ -12,6 +12,7 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.temporal.io/sdk/activity"
 
 	"open-swarm/internal/gates"
@@ -45,8 +46,10 @@ func (ge *GateEnforcement) EnforcePreExecutionGates(
 	testFiles []string,
 ) error {
 	ctx, span := telemetry.StartSpan(ctx, "activity.gates", "EnforcePreExecutionGates",
-		attribute.String("taskID", taskID),
-		attribute.Int("testFiles", len(testFiles)),
+		trace.WithAttributes(
+			attribute.String("taskID", taskID),
+			attribute.Int("testFiles", len(testFiles)),
+		),
 	)
 	defer span.End()
 
@@ -126,8 +129,10 @@ func (ge *GateEnforcement) EnforcePostExecutionGates(
 	result *ExecutionResult,
 ) error {
 	ctx, span := telemetry.StartSpan(ctx, "activity.gates", "EnforcePostExecutionGates",
-		attribute.String("taskID", taskID),
-		attribute.Bool("claimed_success", result.Success),
+		trace.WithAttributes(
+			attribute.String("taskID", taskID),
+			attribute.Bool("claimed_success", result.Success),
+		),
 	)
 	defer span.End()
 
@@ -232,8 +237,10 @@ func (ge *GateEnforcement) CleanupGates(
 	testFiles []string,
 ) error {
 	ctx, span := telemetry.StartSpan(ctx, "activity.gates", "CleanupGates",
-		attribute.String("taskID", taskID),
-		attribute.Int("testFiles", len(testFiles)),
+		trace.WithAttributes(
+			attribute.String("taskID", taskID),
+			attribute.Int("testFiles", len(testFiles)),
+		),
 	)
 	defer span.End()
 
@@ -7,9 +7,14 @@ package temporal
 
 import (
 	"sync"
+	"time"
 
+	"open-swarm/internal/agent"
+	"open-swarm/internal/config"
 	"open-swarm/internal/filelock"
 	"open-swarm/internal/infra"
+	"open-swarm/internal/opencode"
+	"open-swarm/internal/workflow"
 )
 
 var (
@@ -17,7 +22,12 @@ var (
 	globalServerManager    *infra.ServerManager
 	globalWorktreeManager  *infra.WorktreeManager
 	globalFileLockRegistry *filelock.MemoryRegistry
+	globalWarmPool         *workflow.WarmPool
+	globalCassette         *agent.Cassette
+	globalCassetteMode     agent.CassetteMode
+	globalModelRouting     *opencode.ConfigAwareness
 	initOnce               sync.Once
+	warmPoolOnce           sync.Once
 )
 
 // InitializeGlobals sets up shared infrastructure managers
@@ -29,12 +39,14 @@ var (
 //
 // This works because:
 // - All workers run in same process (single binary deployment)
-// - PortManager state stays in memory
+// - PortManager state stays in memory (mirrored to a host-wide reservation file)
 // - No serialization needed
 // - Scales to 50 agents per host
 func InitializeGlobals(portMin, portMax int, repoDir, worktreeBase string) {
 	initOnce.Do(func() {
 		globalPortManager = infra.NewPortManager(portMin, portMax)
+		// Share port reservations with other workers on this host
+		globalPortManager.SetReservations(infra.NewPortReservations(infra.DefaultReservationPath()))
 		globalServerManager = infra.NewServerManager()
 		globalWorktreeManager = infra.NewWorktreeManager(repoDir, worktreeBase)
 		globalFileLockRegistry = filelock.NewMemoryRegistry()
@@ -52,3 +64,49 @@ func GetManagers() (*infra.PortManager, *infra.ServerManager, *infra.WorktreeMan
 func GetFileLockRegistry() *filelock.MemoryRegistry {
 	return globalFileLockRegistry
 }
+
+// InitializeWarmPool creates the worker's pool of pre-booted cells.
+// Must be called after InitializeGlobals and before NewCellActivities.
+// The pool is sized by Autoscale (or Fill) on the returned instance.
+func InitializeWarmPool(config workflow.WarmPoolConfig) *workflow.WarmPool {
+	warmPoolOnce.Do(func() {
+		globalWarmPool = workflow.NewWarmPool(globalPortManager, globalServerManager, globalWorktreeManager, config)
+	})
+	return globalWarmPool
+}
+
+// GetWarmPool returns the global warm pool, or nil if pooling is disabled
+func GetWarmPool() *workflow.WarmPool {
+	return globalWarmPool
+}
+
+// InitializeCassette loads the cassette used to record or replay agent
+// interactions. Must be called before NewCellActivities.
+func InitializeCassette(path string, mode agent.CassetteMode) (*agent.Cassette, error) {
+	cassette, err := agent.LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	globalCassette = cassette
+	globalCassetteMode = mode
+	return cassette, nil
+}
+
+// GetCassette returns the global cassette and its mode, or nil if disabled
+func GetCassette() (*agent.Cassette, agent.CassetteMode) {
+	return globalCassette, globalCassetteMode
+}
+
+// InitializeModelRouting enables per-gate model routing. Gates without a
+// model of their own pick one from the providers of the cell's OpenCode
+// server, with the preferences of overrides applied, and models that keep
+// failing are taken out of rotation for every cell of the worker.
+func InitializeModelRouting(overrides config.ModelConfig) *opencode.ConfigAwareness {
+	globalModelRouting = opencode.NewConfigAwarenessWithSource(nil, overrides, time.Hour)
+	return globalModelRouting
+}
+
+// GetModelRouting returns the global model routing, or nil if disabled
+func GetModelRouting() *opencode.ConfigAwareness {
+	return globalModelRouting
+}
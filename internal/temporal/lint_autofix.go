@@ -0,0 +1,259 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.temporal.io/sdk/activity"
+
+	"open-swarm/internal/agent"
+	"open-swarm/internal/opencode"
+	"open-swarm/internal/telemetry"
+)
+
+// ExecuteLintAutoFix runs deterministic formatters on the files changed in
+// the cell and re-runs lint. No model is involved, so formatting failures are
+// resolved without spending an agent fix attempt.
+//
+// Formatters run in order: fmtCommand when set (BuildCommands.Fmt), otherwise
+// gofmt -w, goimports -w (when installed) and golangci-lint run --fix on the
+// changed packages. Issues present before but not after are reported as
+// LintResult.MachineFixed.
+func (ea *EnhancedActivities) ExecuteLintAutoFix(ctx context.Context, bootstrap *BootstrapOutput, issues []LintIssue, fmtCommand string) (*GateResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "activity.enhanced", "ExecuteLintAutoFix",
+		trace.WithAttributes(attribute.Int("lint.issues", len(issues))),
+	)
+	defer span.End()
+
+	logger := activity.GetLogger(ctx)
+	logger.Info("Gate: LintAutoFix", "issues", len(issues))
+
+	startTime := time.Now()
+	telemetry.AddEvent(ctx, "gate.start", telemetry.AttrGateName.String("lint_autofix"))
+
+	var goFiles []string
+	for file := range worktreeChangedLines(ctx, bootstrap.WorktreePath) {
+		if strings.HasSuffix(file, ".go") {
+			goFiles = append(goFiles, file)
+		}
+	}
+	sort.Strings(goFiles)
+
+	var outputs []string
+	for _, argv := range formatterCommands(fmtCommand, goFiles) {
+		activity.RecordHeartbeat(ctx, "running "+argv[0])
+		output, err := runLintCommand(ctx, bootstrap.WorktreePath, argv, true)
+		if err != nil {
+			logger.Warn("Formatter failed", "command", argv[0], "error", err)
+		}
+		outputs = append(outputs, output)
+	}
+
+	activity.RecordHeartbeat(ctx, "re-running lint")
+	relint, err := ea.ExecuteLintTest(ctx, bootstrap)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "lint after auto-fix failed")
+		return newFailedGateResult("lint_autofix", err, startTime), err
+	}
+
+	remaining := []LintIssue{}
+	if relint.LintResult != nil {
+		remaining = relint.LintResult.Issues
+	}
+	machineFixed := resolvedLintIssues(issues, remaining)
+
+	span.SetAttributes(
+		telemetry.AttrGateName.String("lint_autofix"),
+		telemetry.AttrGatePassed.Bool(relint.Passed),
+		attribute.Int("lint.machine_fixed", len(machineFixed)),
+		attribute.Int("lint.remaining", len(remaining)),
+	)
+	if relint.Passed {
+		span.SetStatus(codes.Ok, "auto-fix resolved all lint issues")
+		telemetry.AddEvent(ctx, "gate.passed", telemetry.AttrGateName.String("lint_autofix"))
+	} else {
+		telemetry.AddEvent(ctx, "gate.failed", telemetry.AttrGateName.String("lint_autofix"))
+	}
+
+	return &GateResult{
+		GateName: "lint_autofix",
+		Passed:   relint.Passed,
+		LintResult: &LintResult{
+			Passed:       relint.Passed,
+			Issues:       remaining,
+			Output:       strings.Join(outputs, "\n"),
+			Duration:     time.Since(startTime),
+			MachineFixed: machineFixed,
+		},
+		Duration: time.Since(startTime),
+		Message:  fmt.Sprintf("Auto-fix resolved %d of %d lint issues", len(machineFixed), len(issues)),
+	}, nil
+}
+
+// ExecuteLintFix asks the agent to fix the lint issues that auto-fix could
+// not resolve. Only the remaining issues are sent, anchored to file and line.
+// The agent runs on the cell's backend with model; empty routes the lint fix
+// gate, which prefers a cheap model (default: DefaultImplModel).
+func (ea *EnhancedActivities) ExecuteLintFix(ctx context.Context, bootstrap *BootstrapOutput, taskID string, issues []LintIssue, model string) (*GateResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "activity.enhanced", "ExecuteLintFix",
+		trace.WithAttributes(telemetry.TCRAttrs("", taskID)...),
+	)
+	defer span.End()
+
+	model = routeModel(ctx, bootstrap, opencode.GateLintFix, model, DefaultImplModel)
+	span.SetAttributes(telemetry.AttrModel.String(model))
+
+	logger := activity.GetLogger(ctx)
+	logger.Info("Gate: LintFix", "taskID", taskID, "issues", len(issues))
+
+	startTime := time.Now()
+	telemetry.AddEvent(ctx, "gate.start", telemetry.AttrGateName.String("lint_fix"))
+	cellActivities := NewCellActivities()
+	cell := cellActivities.reconstructCell(bootstrap)
+
+	prompt := fmt.Sprintf(`Fix the following lint issues. Formatting has already been applied automatically.
+
+%s
+INSTRUCTIONS:
+- Change only the reported lines unless the fix requires more
+- Do NOT change test behavior or assertions
+- Do NOT add nolint directives to silence the linter`, FormatLintIssuesForFix(issues))
+
+	rendered, err := renderGatePrompt(ctx, PromptLintFix, GatePromptData{
+		TaskID:     taskID,
+		LintIssues: FormatLintIssuesForFix(issues),
+	}, prompt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to render prompt")
+		telemetry.AddEvent(ctx, "gate.failed", telemetry.AttrGateName.String("lint_fix"))
+		return newFailedGateResult("lint_fix", err, startTime), err
+	}
+	prompt = rendered.Text
+
+	result, err := promptInTaskSession(ctx, cell.Client, bootstrap, prompt, &agent.PromptOptions{
+		Title: fmt.Sprintf("LintFix: %s", taskID),
+		Agent: "implementation",
+		Model: model,
+	})
+	reportModelResult(model, err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "lint fix failed")
+		telemetry.AddEvent(ctx, "gate.failed", telemetry.AttrGateName.String("lint_fix"))
+		return newFailedGateResult("lint_fix", err, startTime), err
+	}
+
+	filesChanged := getChangedFiles(ctx, cell)
+	span.SetAttributes(
+		telemetry.AttrGateName.String("lint_fix"),
+		telemetry.AttrGatePassed.Bool(true),
+		attribute.Int("files.changed", len(filesChanged)),
+	)
+	telemetry.AddEvent(ctx, "gate.passed", telemetry.AttrGateName.String("lint_fix"))
+	span.SetStatus(codes.Ok, "lint fix completed")
+
+	return &GateResult{
+		GateName: "lint_fix",
+		Passed:   true,
+		AgentResults: []AgentResult{
+			{
+				AgentName:     "implementation",
+				Model:         model,
+				Prompt:        prompt,
+				Response:      result.GetText(),
+				Success:       true,
+				Duration:      time.Since(startTime),
+				FilesChanged:  filesChanged,
+				SessionID:     result.SessionID,
+				PromptVersion: rendered.Stamp(),
+			},
+		},
+		Duration: time.Since(startTime),
+	}, nil
+}
+
+// formatterCommands returns the formatter invocations for the auto-fix stage.
+// A configured fmtCommand replaces the Go defaults.
+func formatterCommands(fmtCommand string, goFiles []string) [][]string {
+	if strings.TrimSpace(fmtCommand) != "" {
+		return [][]string{{"sh", "-c", fmtCommand}}
+	}
+	if len(goFiles) == 0 {
+		return nil
+	}
+
+	commands := [][]string{append([]string{"gofmt", "-w"}, goFiles...)}
+	if _, err := exec.LookPath("goimports"); err == nil {
+		commands = append(commands, append([]string{"goimports", "-w"}, goFiles...))
+	}
+
+	dirs := make(map[string]bool)
+	for _, file := range goFiles {
+		dirs["./"+filepath.ToSlash(filepath.Dir(file))] = true
+	}
+	return append(commands, append([]string{"golangci-lint", "run", "--fix"}, sortedKeys(dirs)...))
+}
+
+// lintIssueKey identifies an issue independently of its line, which shifts
+// when earlier lines are reformatted
+func lintIssueKey(issue LintIssue) string {
+	return issue.File + "\x00" + issue.Rule + "\x00" + issue.Message
+}
+
+// resolvedLintIssues returns the issues in before that no longer appear in after
+func resolvedLintIssues(before, after []LintIssue) []LintIssue {
+	remaining := make(map[string]int, len(after))
+	for _, issue := range after {
+		remaining[lintIssueKey(issue)]++
+	}
+	resolved := []LintIssue{}
+	for _, issue := range before {
+		key := lintIssueKey(issue)
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		resolved = append(resolved, issue)
+	}
+	return resolved
+}
+
+// FormatLintIssuesForFix renders lint issues as a file-anchored list for a
+// fix prompt, including source lines and linter suggestions when available
+func FormatLintIssuesForFix(issues []LintIssue) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("LINT ISSUES (%d):\n", len(issues)))
+	for _, issue := range issues {
+		location := issue.File
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", issue.File, issue.Line)
+		}
+		rule := issue.Rule
+		if rule == "" {
+			rule = "lint"
+		}
+		sb.WriteString(fmt.Sprintf("- %s [%s] %s\n", location, rule, issue.Message))
+		for _, line := range issue.SourceLines {
+			sb.WriteString("    > " + line + "\n")
+		}
+		if issue.SuggestedFix != "" {
+			sb.WriteString("    Suggested fix: " + strings.ReplaceAll(issue.SuggestedFix, "\n", "\n    ") + "\n")
+		}
+	}
+	return sb.String()
+}
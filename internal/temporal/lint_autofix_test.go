@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestResolvedLintIssues(t *testing.T) {
+	gofmtIssue := LintIssue{File: "pkg/a/a_test.go", Line: 3, Rule: "gofmt", Message: "File is not gofmt-ed"}
+	errcheck := LintIssue{File: "pkg/a/a_test.go", Line: 10, Rule: "errcheck", Message: "unchecked error"}
+
+	// errcheck moved to line 9 after reformatting but is still present
+	moved := errcheck
+	moved.Line = 9
+
+	resolved := resolvedLintIssues([]LintIssue{gofmtIssue, errcheck}, []LintIssue{moved})
+	require.Len(t, resolved, 1)
+	assert.Equal(t, "gofmt", resolved[0].Rule)
+
+	assert.Empty(t, resolvedLintIssues([]LintIssue{errcheck, errcheck}, []LintIssue{errcheck, errcheck}))
+	assert.Len(t, resolvedLintIssues([]LintIssue{errcheck, errcheck}, []LintIssue{errcheck}), 1)
+}
+
+func TestFormatterCommands(t *testing.T) {
+	commands := formatterCommands("make fmt", []string{"main.go"})
+	assert.Equal(t, [][]string{{"sh", "-c", "make fmt"}}, commands)
+
+	assert.Empty(t, formatterCommands("", nil))
+
+	commands = formatterCommands("", []string{"main.go", "pkg/a/a_test.go"})
+	require.GreaterOrEqual(t, len(commands), 2)
+	assert.Equal(t, []string{"gofmt", "-w", "main.go", "pkg/a/a_test.go"}, commands[0])
+	assert.Equal(t, []string{"golangci-lint", "run", "--fix", "./.", "./pkg/a"}, commands[len(commands)-1])
+}
+
+func TestFormatLintIssuesForFix(t *testing.T) {
+	text := FormatLintIssuesForFix([]LintIssue{{
+		File:         "pkg/a/a_test.go",
+		Line:         12,
+		Rule:         "errcheck",
+		Message:      "Error return value is not checked",
+		SourceLines:  []string{"\tf.Close()"},
+		SuggestedFix: "check the error",
+	}})
+
+	assert.Contains(t, text, "LINT ISSUES (1):")
+	assert.Contains(t, text, "- pkg/a/a_test.go:12 [errcheck] Error return value is not checked")
+	assert.Contains(t, text, "    > \tf.Close()")
+	assert.Contains(t, text, "Suggested fix: check the error")
+}
+
+// mockLintWorkflowGates mocks every gate except LintTest as passing
+func mockLintWorkflowGates(env *testsuite.TestWorkflowEnvironment, cellActivities *CellActivities, enhancedActivities *EnhancedActivities) {
+	env.OnActivity(cellActivities.BootstrapCell, mock.Anything, mock.Anything).Return(&BootstrapOutput{
+		CellID: "lint-cell", WorktreePath: "/tmp/lint-cell",
+	}, nil)
+	env.OnActivity(enhancedActivities.AcquireFileLocks, mock.Anything, mock.Anything, mock.Anything).Return([]string{"file.go"}, nil)
+	env.OnActivity(enhancedActivities.ExecuteGenTest, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "GenTest", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteVerifyRED, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "VerifyRED", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "GenImpl", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "VerifyGREEN", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "MultiReview", Passed: true}, nil)
+	env.OnActivity(cellActivities.CommitChanges, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CellHead, mock.Anything, mock.Anything).Return(&CellHeadOutput{}, nil).Maybe()
+	env.OnActivity(cellActivities.RevertChanges, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(enhancedActivities.ReleaseFileLocks, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.TeardownCell, mock.Anything, mock.Anything).Return(nil)
+}
+
+func TestEnhancedTCR_LintAutoFixAvoidsAgent(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	cellActivities := &CellActivities{}
+	enhancedActivities := &EnhancedActivities{}
+	mockLintWorkflowGates(env, cellActivities, enhancedActivities)
+
+	gofmtIssue := LintIssue{File: "pkg/a/a_test.go", Line: 1, Rule: "gofmt", Message: "File is not gofmt-ed", Severity: "error"}
+	env.OnActivity(enhancedActivities.ExecuteLintTest, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "lint_test", Passed: false, LintResult: &LintResult{Issues: []LintIssue{gofmtIssue}}}, nil)
+	env.OnActivity(enhancedActivities.ExecuteLintAutoFix, mock.Anything, mock.Anything, mock.Anything, "gofumpt -w .").Return(
+		&GateResult{GateName: "lint_autofix", Passed: true, LintResult: &LintResult{
+			Passed: true, Issues: []LintIssue{}, MachineFixed: []LintIssue{gofmtIssue},
+		}}, nil)
+
+	env.ExecuteWorkflow(EnhancedTCRWorkflow, EnhancedTCRInput{
+		TaskID: "lint-task", CellID: "lint-cell", FmtCommand: "gofumpt -w .",
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var result *EnhancedTCRResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.True(t, result.Success, result.Error)
+
+	final := finalGateResults(result)
+	require.Equal(t, "lint_test", final[1].GateName)
+	assert.True(t, final[1].Passed)
+	assert.Equal(t, []LintIssue{gofmtIssue}, final[1].LintResult.MachineFixed)
+	assert.Empty(t, final[1].LintResult.AgentFixed)
+	env.AssertNotCalled(t, "ExecuteLintFix", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEnhancedTCR_LintRemainingIssuesGoToAgent(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	cellActivities := &CellActivities{}
+	enhancedActivities := &EnhancedActivities{}
+	mockLintWorkflowGates(env, cellActivities, enhancedActivities)
+
+	gofmtIssue := LintIssue{File: "pkg/a/a_test.go", Line: 1, Rule: "gofmt", Message: "File is not gofmt-ed", Severity: "error"}
+	errcheck := LintIssue{File: "pkg/a/a_test.go", Line: 10, Rule: "errcheck", Message: "unchecked error", Severity: "error"}
+
+	env.OnActivity(enhancedActivities.ExecuteLintTest, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "lint_test", Passed: false, LintResult: &LintResult{Issues: []LintIssue{gofmtIssue, errcheck}}}, nil).Once()
+	env.OnActivity(enhancedActivities.ExecuteLintTest, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "lint_test", Passed: true, LintResult: &LintResult{Passed: true, Issues: []LintIssue{}}}, nil).Once()
+	env.OnActivity(enhancedActivities.ExecuteLintAutoFix, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "lint_autofix", Passed: false, LintResult: &LintResult{
+			Issues: []LintIssue{errcheck}, MachineFixed: []LintIssue{gofmtIssue},
+		}}, nil)
+	// The agent fix runs on the task's first ladder model
+	env.OnActivity(enhancedActivities.ExecuteLintFix, mock.Anything, mock.Anything, mock.Anything, []LintIssue{errcheck}, "openai/gpt-5-mini").Return(
+		&GateResult{GateName: "lint_fix", Passed: true}, nil)
+
+	env.ExecuteWorkflow(EnhancedTCRWorkflow, EnhancedTCRInput{
+		TaskID:      "lint-task",
+		CellID:      "lint-cell",
+		ModelLadder: []string{"openai/gpt-5-mini", "anthropic/claude-sonnet-4-5"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var result *EnhancedTCRResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.True(t, result.Success, result.Error)
+
+	names := make([]string, 0, 5)
+	for _, gate := range result.GateResults[:5] {
+		names = append(names, gate.GateName)
+	}
+	assert.Equal(t, []string{"GenTest", "lint_test", "lint_autofix", "lint_fix", "lint_test"}, names)
+
+	final := result.GateResults[4].LintResult
+	assert.Equal(t, []LintIssue{gofmtIssue}, final.MachineFixed)
+	assert.Equal(t, []LintIssue{errcheck}, final.AgentFixed)
+}
@@ -0,0 +1,401 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Linter describes how to run a linter with machine-readable output and how
+// to parse that output
+type Linter struct {
+	// Name is the registry key, e.g. "golangci-lint"
+	Name string
+	// Command returns the argv to run in the worktree
+	Command func(autoFix bool) []string
+	// Parse converts the linter's output into issues
+	Parse func(output string) ([]LintIssue, error)
+	// ReadsStderr is set for linters that report on stderr (go vet -json)
+	ReadsStderr bool
+}
+
+var (
+	lintRegistryMu sync.RWMutex
+	lintRegistry   = map[string]Linter{}
+)
+
+func init() {
+	RegisterLinter(Linter{
+		Name: "golangci-lint",
+		Command: func(autoFix bool) []string {
+			args := []string{"golangci-lint", "run", "--output.json.path=stdout", "--output.text.path=", "--show-stats=false"}
+			if autoFix {
+				args = append(args, "--fix")
+			}
+			return append(args, "./...")
+		},
+		Parse: ParseGolangciLintJSON,
+	})
+	RegisterLinter(Linter{
+		Name: "go-vet",
+		Command: func(bool) []string {
+			return []string{"go", "vet", "-json", "./..."}
+		},
+		Parse:       ParseGoVetJSON,
+		ReadsStderr: true,
+	})
+	RegisterLinter(Linter{
+		Name: "eslint",
+		Command: func(autoFix bool) []string {
+			args := []string{"npx", "--no-install", "eslint", "--format", "json"}
+			if autoFix {
+				args = append(args, "--fix")
+			}
+			return append(args, ".")
+		},
+		Parse: ParseESLintJSON,
+	})
+	RegisterLinter(Linter{
+		Name: "ruff",
+		Command: func(autoFix bool) []string {
+			args := []string{"ruff", "check", "--output-format=json", "--exit-zero"}
+			if autoFix {
+				args = append(args, "--fix")
+			}
+			return append(args, ".")
+		},
+		Parse: ParseRuffJSON,
+	})
+}
+
+// RegisterLinter adds or replaces a linter in the registry
+func RegisterLinter(linter Linter) {
+	lintRegistryMu.Lock()
+	defer lintRegistryMu.Unlock()
+	lintRegistry[linter.Name] = linter
+}
+
+// LookupLinter returns a registered linter by name ("go vet" and "vet" alias go-vet)
+func LookupLinter(name string) (Linter, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "go vet", "vet":
+		name = "go-vet"
+	}
+	lintRegistryMu.RLock()
+	defer lintRegistryMu.RUnlock()
+	linter, ok := lintRegistry[name]
+	return linter, ok
+}
+
+// RegisteredLinters returns the names of all registered linters, sorted
+func RegisteredLinters() []string {
+	lintRegistryMu.RLock()
+	defer lintRegistryMu.RUnlock()
+	return sortedKeys(lintRegistry)
+}
+
+// golangciReport is the subset of golangci-lint's JSON report we read
+type golangciReport struct {
+	Issues []struct {
+		FromLinter  string   `json:"FromLinter"`
+		Text        string   `json:"Text"`
+		Severity    string   `json:"Severity"`
+		SourceLines []string `json:"SourceLines"`
+		Replacement *struct {
+			NeedOnlyDelete bool     `json:"NeedOnlyDelete"`
+			NewLines       []string `json:"NewLines"`
+		} `json:"Replacement"`
+		Pos struct {
+			Filename string `json:"Filename"`
+			Line     int    `json:"Line"`
+			Column   int    `json:"Column"`
+		} `json:"Pos"`
+	} `json:"Issues"`
+}
+
+// ParseGolangciLintJSON parses `golangci-lint run` JSON output. Text printed
+// around the report (progress, warnings) is ignored.
+func ParseGolangciLintJSON(output string) ([]LintIssue, error) {
+	var report golangciReport
+	found := false
+	for _, candidate := range NewAgentResultParser().ExtractJSONObjects(output) {
+		if !strings.Contains(candidate, `"Issues"`) {
+			continue
+		}
+		if err := json.Unmarshal([]byte(candidate), &report); err != nil {
+			return nil, fmt.Errorf("failed to parse golangci-lint JSON: %w", err)
+		}
+		found = true
+		break
+	}
+	if !found {
+		if strings.TrimSpace(output) == "" {
+			return []LintIssue{}, nil
+		}
+		return nil, fmt.Errorf("failed to parse golangci-lint JSON: no report in output")
+	}
+
+	issues := make([]LintIssue, 0, len(report.Issues))
+	for _, raw := range report.Issues {
+		issue := LintIssue{
+			File:        raw.Pos.Filename,
+			Line:        raw.Pos.Line,
+			Column:      raw.Pos.Column,
+			Severity:    normalizeLintSeverity(raw.Severity),
+			Message:     raw.Text,
+			Rule:        raw.FromLinter,
+			Linter:      "golangci-lint",
+			SourceLines: raw.SourceLines,
+		}
+		if raw.Replacement != nil {
+			if raw.Replacement.NeedOnlyDelete {
+				issue.SuggestedFix = "delete the line"
+			} else if len(raw.Replacement.NewLines) > 0 {
+				issue.SuggestedFix = "replace with:\n" + strings.Join(raw.Replacement.NewLines, "\n")
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// goVetFinding is one diagnostic in `go vet -json` output
+type goVetFinding struct {
+	Posn           string `json:"posn"`
+	Message        string `json:"message"`
+	SuggestedFixes []struct {
+		Message string `json:"message"`
+	} `json:"suggested_fixes"`
+}
+
+// ParseGoVetJSON parses `go vet -json` output: one JSON object per package,
+// keyed by package then analyzer, interleaved with "# pkg" comment lines
+func ParseGoVetJSON(output string) ([]LintIssue, error) {
+	issues := []LintIssue{}
+	for _, candidate := range NewAgentResultParser().ExtractJSONObjects(output) {
+		var packages map[string]map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(candidate), &packages); err != nil {
+			return nil, fmt.Errorf("failed to parse go vet JSON: %w", err)
+		}
+		for _, pkg := range sortedKeys(packages) {
+			analyzers := packages[pkg]
+			for _, analyzer := range sortedKeys(analyzers) {
+				var findings []goVetFinding
+				if err := json.Unmarshal(analyzers[analyzer], &findings); err != nil {
+					// Analyzer errors are reported as {"error": "..."}; skip them
+					continue
+				}
+				for _, finding := range findings {
+					file, line, column := splitPosition(finding.Posn)
+					issue := LintIssue{
+						File:     file,
+						Line:     line,
+						Column:   column,
+						Severity: "error",
+						Message:  finding.Message,
+						Rule:     analyzer,
+						Linter:   "go-vet",
+					}
+					if len(finding.SuggestedFixes) > 0 {
+						issue.SuggestedFix = finding.SuggestedFixes[0].Message
+					}
+					issues = append(issues, issue)
+				}
+			}
+		}
+	}
+	return issues, nil
+}
+
+// eslintFile is one file entry in ESLint's JSON formatter output
+type eslintFile struct {
+	FilePath string `json:"filePath"`
+	Source   string `json:"source"`
+	Messages []struct {
+		RuleID   string `json:"ruleId"`
+		Severity int    `json:"severity"`
+		Message  string `json:"message"`
+		Line     int    `json:"line"`
+		Column   int    `json:"column"`
+		Fix      *struct {
+			Text string `json:"text"`
+		} `json:"fix"`
+		Suggestions []struct {
+			Desc string `json:"desc"`
+		} `json:"suggestions"`
+	} `json:"messages"`
+}
+
+// ParseESLintJSON parses `eslint --format json` output
+func ParseESLintJSON(output string) ([]LintIssue, error) {
+	var files []eslintFile
+	if err := json.Unmarshal([]byte(jsonArray(output)), &files); err != nil {
+		return nil, fmt.Errorf("failed to parse eslint JSON: %w", err)
+	}
+
+	issues := []LintIssue{}
+	for _, file := range files {
+		for _, msg := range file.Messages {
+			severity := "warning"
+			if msg.Severity >= 2 {
+				severity = "error"
+			}
+			rule := msg.RuleID
+			if rule == "" {
+				rule = "eslint"
+			}
+			issue := LintIssue{
+				File:     file.FilePath,
+				Line:     msg.Line,
+				Column:   msg.Column,
+				Severity: severity,
+				Message:  msg.Message,
+				Rule:     rule,
+				Linter:   "eslint",
+			}
+			switch {
+			case msg.Fix != nil:
+				issue.SuggestedFix = "replace with: " + msg.Fix.Text
+			case len(msg.Suggestions) > 0:
+				issue.SuggestedFix = msg.Suggestions[0].Desc
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// ruffDiagnostic is one entry in ruff's JSON output
+type ruffDiagnostic struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Filename string `json:"filename"`
+	Location struct {
+		Row    int `json:"row"`
+		Column int `json:"column"`
+	} `json:"location"`
+	Fix *struct {
+		Message string `json:"message"`
+	} `json:"fix"`
+}
+
+// ParseRuffJSON parses `ruff check --output-format=json` output
+func ParseRuffJSON(output string) ([]LintIssue, error) {
+	var diagnostics []ruffDiagnostic
+	if err := json.Unmarshal([]byte(jsonArray(output)), &diagnostics); err != nil {
+		return nil, fmt.Errorf("failed to parse ruff JSON: %w", err)
+	}
+
+	issues := make([]LintIssue, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		issue := LintIssue{
+			File:     d.Filename,
+			Line:     d.Location.Row,
+			Column:   d.Location.Column,
+			Severity: "error",
+			Message:  d.Message,
+			Rule:     d.Code,
+			Linter:   "ruff",
+		}
+		if d.Fix != nil {
+			issue.SuggestedFix = d.Fix.Message
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// jsonArray trims text around the outermost [...] of output; empty output is []
+func jsonArray(output string) string {
+	start := strings.IndexByte(output, '[')
+	end := strings.LastIndexByte(output, ']')
+	if start < 0 || end < start {
+		if strings.TrimSpace(output) == "" {
+			return "[]"
+		}
+		return output
+	}
+	return output[start : end+1]
+}
+
+// normalizeLintSeverity maps linter severities onto error, warning and info
+func normalizeLintSeverity(severity string) string {
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "warning", "warn", "medium", "low":
+		return "warning"
+	case "info", "note", "hint":
+		return "info"
+	default:
+		return "error"
+	}
+}
+
+// splitPosition splits "file.go:10:2" into its parts
+func splitPosition(posn string) (string, int, int) {
+	parts := strings.Split(posn, ":")
+	if len(parts) < 3 {
+		return posn, 0, 0
+	}
+	line, lineErr := strconv.Atoi(parts[len(parts)-2])
+	column, colErr := strconv.Atoi(parts[len(parts)-1])
+	if lineErr != nil || colErr != nil {
+		return posn, 0, 0
+	}
+	return strings.Join(parts[:len(parts)-2], ":"), line, column
+}
+
+// filterIssuesToChangedLines keeps issues on lines changed in the worktree.
+// Issue paths are made relative to worktreePath first. Issues without a line
+// are kept when their file changed.
+func filterIssuesToChangedLines(issues []LintIssue, worktreePath string, changed map[string][]lineRange) []LintIssue {
+	kept := make([]LintIssue, 0, len(issues))
+	for _, issue := range issues {
+		issue.File = relativeToWorktree(issue.File, worktreePath)
+		ranges, ok := changed[issue.File]
+		if !ok {
+			continue
+		}
+		if issue.Line == 0 {
+			kept = append(kept, issue)
+			continue
+		}
+		for _, r := range ranges {
+			if issue.Line >= r.Start && issue.Line <= r.End {
+				kept = append(kept, issue)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// relativeToWorktree strips the worktree prefix and "./" from a path
+func relativeToWorktree(path, worktreePath string) string {
+	if worktreePath != "" {
+		prefix := strings.TrimSuffix(worktreePath, "/") + "/"
+		path = strings.TrimPrefix(path, prefix)
+	}
+	return strings.TrimPrefix(path, "./")
+}
+
+// sortIssues orders issues by file, line and column
+func sortIssues(issues []LintIssue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+}
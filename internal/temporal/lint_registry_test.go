@@ -0,0 +1,220 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+)
+
+const golangciJSONOutput = `level=warning msg="[config_reader] deprecated option"
+{"Issues":[{"FromLinter":"errcheck","Text":"Error return value of ` + "`f.Close`" + ` is not checked","Severity":"","SourceLines":["\tf.Close()"],"Replacement":null,"Pos":{"Filename":"pkg/store.go","Offset":120,"Line":14,"Column":9}},{"FromLinter":"gofmt","Text":"File is not gofmt-ed","Severity":"warning","SourceLines":["x :=  1"],"Replacement":{"NeedOnlyDelete":false,"NewLines":["x := 1"]},"Pos":{"Filename":"pkg/store.go","Line":20,"Column":1}}],"Report":{"Linters":[{"Name":"errcheck","Enabled":true}]}}
+`
+
+func TestParseGolangciLintJSON(t *testing.T) {
+	issues, err := ParseGolangciLintJSON(golangciJSONOutput)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+
+	assert.Equal(t, LintIssue{
+		File:        "pkg/store.go",
+		Line:        14,
+		Column:      9,
+		Severity:    "error",
+		Message:     "Error return value of `f.Close` is not checked",
+		Rule:        "errcheck",
+		Linter:      "golangci-lint",
+		SourceLines: []string{"\tf.Close()"},
+	}, issues[0])
+
+	assert.Equal(t, "warning", issues[1].Severity)
+	assert.Equal(t, "gofmt", issues[1].Rule)
+	assert.Equal(t, "replace with:\nx := 1", issues[1].SuggestedFix)
+}
+
+func TestParseGolangciLintJSON_EmptyAndInvalid(t *testing.T) {
+	issues, err := ParseGolangciLintJSON("")
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	_, err = ParseGolangciLintJSON("typecheck failed: could not load packages")
+	assert.Error(t, err)
+	assert.True(t, isLintParseError(err))
+}
+
+func TestParseGoVetJSON(t *testing.T) {
+	output := `# example.com/app
+{
+	"example.com/app": {
+		"printf": [
+			{
+				"posn": "/work/app/main.go:12:2",
+				"message": "fmt.Printf format %d has arg s of wrong type string",
+				"suggested_fixes": [{"message": "Use %s"}]
+			}
+		],
+		"unusedresult": {"error": "analysis skipped"}
+	}
+}
+# example.com/app/util
+{}
+`
+	issues, err := ParseGoVetJSON(output)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "/work/app/main.go", issues[0].File)
+	assert.Equal(t, 12, issues[0].Line)
+	assert.Equal(t, 2, issues[0].Column)
+	assert.Equal(t, "printf", issues[0].Rule)
+	assert.Equal(t, "go-vet", issues[0].Linter)
+	assert.Equal(t, "Use %s", issues[0].SuggestedFix)
+}
+
+func TestParseESLintJSON(t *testing.T) {
+	output := `[{"filePath":"/work/app/src/index.js","messages":[
+		{"ruleId":"no-unused-vars","severity":2,"message":"'x' is defined but never used.","line":3,"column":7},
+		{"ruleId":"semi","severity":1,"message":"Missing semicolon.","line":5,"column":12,"fix":{"range":[40,40],"text":";"}}
+	]},{"filePath":"/work/app/src/clean.js","messages":[]}]`
+
+	issues, err := ParseESLintJSON(output)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, "error", issues[0].Severity)
+	assert.Equal(t, "no-unused-vars", issues[0].Rule)
+	assert.Equal(t, "warning", issues[1].Severity)
+	assert.Equal(t, "replace with: ;", issues[1].SuggestedFix)
+}
+
+func TestParseRuffJSON(t *testing.T) {
+	output := `[{"code":"F401","message":"` + "`os`" + ` imported but unused","filename":"/work/app/tool.py","location":{"row":1,"column":8},"end_location":{"row":1,"column":10},"fix":{"applicability":"safe","message":"Remove unused import: ` + "`os`" + `"}}]`
+
+	issues, err := ParseRuffJSON(output)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "F401", issues[0].Rule)
+	assert.Equal(t, 1, issues[0].Line)
+	assert.Equal(t, 8, issues[0].Column)
+	assert.Equal(t, "Remove unused import: `os`", issues[0].SuggestedFix)
+
+	issues, err = ParseRuffJSON("")
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestLookupLinter(t *testing.T) {
+	for _, name := range []string{"golangci-lint", "eslint", "ruff", "go-vet", "go vet"} {
+		linter, ok := LookupLinter(name)
+		require.True(t, ok, name)
+		assert.NotEmpty(t, linter.Command(false))
+	}
+
+	_, ok := LookupLinter("make lint")
+	assert.False(t, ok)
+
+	golangci, _ := LookupLinter("golangci-lint")
+	assert.Contains(t, golangci.Command(true), "--fix")
+	assert.Contains(t, golangci.Command(false), "--output.json.path=stdout")
+}
+
+func TestParseLintLine(t *testing.T) {
+	issue := parseLintLine("internal/app/main.go:10:5: unused variable x (unused)")
+	require.NotNil(t, issue)
+	assert.Equal(t, "internal/app/main.go", issue.File)
+	assert.Equal(t, 10, issue.Line)
+	assert.Equal(t, 5, issue.Column)
+	assert.Equal(t, "unused", issue.Rule)
+
+	issue = parseLintLine("main.go:7: missing return")
+	require.NotNil(t, issue)
+	assert.Equal(t, 7, issue.Line)
+	assert.Zero(t, issue.Column)
+
+	assert.Nil(t, parseLintLine("make: *** [lint] Error 1"))
+	assert.Nil(t, parseLintLine(""))
+}
+
+func TestFilterIssuesToChangedLines(t *testing.T) {
+	changed := map[string][]lineRange{
+		"pkg/store.go": {{Start: 10, End: 15}},
+		"main.go":      {{Start: 1, End: 3}},
+	}
+	issues := []LintIssue{
+		{File: "/work/app/pkg/store.go", Line: 14, Rule: "errcheck"},
+		{File: "/work/app/pkg/store.go", Line: 20, Rule: "gofmt"},
+		{File: "./main.go", Line: 0, Rule: "typecheck"},
+		{File: "other.go", Line: 2, Rule: "unused"},
+	}
+
+	kept := filterIssuesToChangedLines(issues, "/work/app", changed)
+	require.Len(t, kept, 2)
+	assert.Equal(t, "pkg/store.go", kept[0].File)
+	assert.Equal(t, "errcheck", kept[0].Rule)
+	assert.Equal(t, "main.go", kept[1].File)
+}
+
+// runGit runs git in dir and fails the test on error
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestExecuteLintTest_ReportsOnlyChangedLines(t *testing.T) {
+	// Stand in for golangci-lint with a command that prints its JSON report
+	report := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, os.WriteFile(report, []byte(golangciJSONOutput), 0o600))
+	original, ok := LookupLinter("golangci-lint")
+	require.True(t, ok)
+	t.Cleanup(func() { RegisterLinter(original) })
+	fake := original
+	fake.Command = func(bool) []string { return []string{"cat", report} }
+	RegisterLinter(fake)
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--quiet", "--initial-branch=main")
+	runGit(t, dir, "config", "user.name", "Test User")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	lines := make([]string, 25)
+	for i := range lines {
+		lines[i] = "// line"
+	}
+	store := filepath.Join(dir, "pkg", "store.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(store), 0o750))
+	require.NoError(t, os.WriteFile(store, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "--quiet", "-m", "Initial commit")
+
+	// Only line 14 changes, so the gofmt issue on line 20 is pre-existing
+	lines[13] = "// changed"
+	require.NoError(t, os.WriteFile(store, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+
+	var ts testsuite.WorkflowTestSuite
+	env := ts.NewTestActivityEnvironment()
+	activities := &EnhancedActivities{}
+	env.RegisterActivity(activities)
+
+	value, err := env.ExecuteActivity(activities.ExecuteLintTest, &BootstrapOutput{WorktreePath: dir})
+	require.NoError(t, err)
+	var result *GateResult
+	require.NoError(t, value.Get(&result))
+
+	assert.False(t, result.Passed)
+	require.NotNil(t, result.LintResult)
+	require.Len(t, result.LintResult.Issues, 1)
+	issue := result.LintResult.Issues[0]
+	assert.Equal(t, "pkg/store.go", issue.File)
+	assert.Equal(t, 14, issue.Line)
+	assert.Equal(t, "errcheck", issue.Rule)
+	assert.Equal(t, "golangci-lint", issue.Linter)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"errors"
+	"strings"
+
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/workflow"
+)
+
+// modelLadder picks the GenImpl model of each regeneration attempt. Providers
+// that returned provider errors are stepped around for the rest of the run.
+// An empty ladder has the single model "", which leaves the choice to the
+// activity's model routing.
+type modelLadder struct {
+	models []string
+	failed map[string]bool // Providers that failed with provider errors
+}
+
+func newModelLadder(models []string) *modelLadder {
+	if len(models) == 0 {
+		models = []string{""}
+	}
+	return &modelLadder{models: models, failed: make(map[string]bool)}
+}
+
+// forAttempt returns the model of a 1-based regeneration attempt. Attempts
+// past the end of the ladder reuse its last model.
+func (l *modelLadder) forAttempt(attempt int) string {
+	index := min(max(attempt-1, 0), len(l.models)-1)
+	if model, ok := l.healthyFrom(index); ok {
+		return model
+	}
+	return l.models[index]
+}
+
+// switchProvider marks the provider of model as failed and returns the next
+// model of another provider, if the ladder has one
+func (l *modelLadder) switchProvider(model string) (string, bool) {
+	l.failed[modelProvider(model)] = true
+	index := 0
+	for i, m := range l.models {
+		if m == model {
+			index = i
+			break
+		}
+	}
+	return l.healthyFrom(index)
+}
+
+// healthyFrom returns the first model at or after index, wrapping around,
+// whose provider has not failed
+func (l *modelLadder) healthyFrom(index int) (string, bool) {
+	for k := 0; k < len(l.models); k++ {
+		model := l.models[(index+k)%len(l.models)]
+		if !l.failed[modelProvider(model)] {
+			return model, true
+		}
+	}
+	return "", false
+}
+
+// modelProvider returns the provider of a "provider/model" ID
+func modelProvider(model string) string {
+	provider, _, found := strings.Cut(model, "/")
+	if !found {
+		return model
+	}
+	return provider
+}
+
+// runGenImpl runs the GenImpl gate with the ladder's model for the attempt.
+// Provider errors (rate limit, overload, auth) switch to another provider at
+// once instead of using up a regeneration attempt. It returns the failed
+// provider attempts and the final result.
+func runGenImpl(ge *gateExecutor, activities *EnhancedActivities, ladder *modelLadder, input EnhancedTCRInput, feedback string, attempt int) ([]GateResult, *GateResult) {
+	model := ladder.forAttempt(attempt)
+	var failed []GateResult
+	for {
+		gateResult := ge.runGate("GenImpl", activities.ExecuteGenImpl,
+			ge.bootstrap, input.TaskID, input.Description, input.AcceptanceCriteria, feedback, model)
+		if gateResult.Passed {
+			return failed, gateResult
+		}
+		recordGenImplModel(gateResult, model)
+
+		cause := ClassifyError(errors.New(gateResult.Error), gateResult.Duration, int(DefaultStartToCloseTimeout.Seconds()))
+		if !cause.IsProviderError() {
+			return failed, gateResult
+		}
+		next, ok := ladder.switchProvider(model)
+		if !ok {
+			ge.logger.Warn("Provider error and no other provider in the model ladder", "model", model, "cause", cause)
+			return failed, gateResult
+		}
+		ge.logger.Warn("Provider error, switching provider without using a retry",
+			"from", model, "to", next, "cause", cause)
+		failed = append(failed, *gateResult)
+		model = next
+	}
+}
+
+// recordGenImplModel records the model of a failed GenImpl attempt, whose
+// activity error carried no agent result
+func recordGenImplModel(gateResult *GateResult, model string) {
+	if len(gateResult.AgentResults) > 0 {
+		return
+	}
+	gateResult.AgentResults = []AgentResult{{
+		AgentName: "implementation",
+		Model:     model,
+		Error:     gateResult.Error,
+		Duration:  gateResult.Duration,
+	}}
+}
+
+// genImplGate runs GenImpl through the model ladder outside a gateExecutor,
+// as ParallelTCRWorkflow does
+func genImplGate(ctx workflow.Context, logger log.Logger, activities *EnhancedActivities, ladder *modelLadder, bootstrap *BootstrapOutput, input EnhancedTCRInput, feedback string, attempt int) ([]GateResult, *GateResult) {
+	ge := &gateExecutor{ctx: ctx, logger: logger, bootstrap: bootstrap}
+	return runGenImpl(ge, activities, ladder, input, feedback, attempt)
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+var testLadder = []string{"anthropic/claude-haiku-4-5", "anthropic/claude-sonnet-4-5", "openai/gpt-5"}
+
+func TestModelLadder(t *testing.T) {
+	ladder := newModelLadder(testLadder)
+	assert.Equal(t, "anthropic/claude-haiku-4-5", ladder.forAttempt(1))
+	assert.Equal(t, "anthropic/claude-sonnet-4-5", ladder.forAttempt(2))
+	assert.Equal(t, "openai/gpt-5", ladder.forAttempt(3))
+	assert.Equal(t, "openai/gpt-5", ladder.forAttempt(5), "the last model repeats")
+
+	next, ok := ladder.switchProvider("anthropic/claude-haiku-4-5")
+	require.True(t, ok)
+	assert.Equal(t, "openai/gpt-5", next, "a provider error skips the provider's other models")
+	assert.Equal(t, "openai/gpt-5", ladder.forAttempt(2), "later attempts avoid the failed provider")
+
+	_, ok = ladder.switchProvider("openai/gpt-5")
+	assert.False(t, ok)
+	assert.Equal(t, "anthropic/claude-sonnet-4-5", ladder.forAttempt(2), "with every provider failed the ladder is used as is")
+
+	assert.Equal(t, "", newModelLadder(nil).forAttempt(1), "without a ladder the activity routes the model")
+}
+
+func TestClassifyError_ProviderErrors(t *testing.T) {
+	tests := []struct {
+		err  string
+		want ErrorCause
+	}{
+		{`POST "http://localhost:4096/session/x/message": 429 Too Many Requests`, ErrorCauseRateLimit},
+		{"anthropic: rate limit exceeded", ErrorCauseRateLimit},
+		{`{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`, ErrorCauseOverloaded},
+		{"503 Service Unavailable", ErrorCauseOverloaded},
+		{"401 Unauthorized: invalid x-api-key", ErrorCauseAuth},
+		{"connection refused", ErrorCauseNetwork},
+		{"agent execution failed", ErrorCauseAgentError},
+	}
+	for _, tt := range tests {
+		cause := ClassifyError(errors.New(tt.err), time.Second, 600)
+		assert.Equal(t, tt.want, cause, tt.err)
+		assert.Equal(t, tt.want != ErrorCauseNetwork && tt.want != ErrorCauseAgentError, cause.IsProviderError(), tt.err)
+	}
+}
+
+// ladderTestEnv mocks every gate except GenImpl and VerifyGREEN
+func ladderTestEnv(t *testing.T) (*testsuite.TestWorkflowEnvironment, *EnhancedActivities) {
+	t.Helper()
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	cellActivities := &CellActivities{}
+	enhancedActivities := &EnhancedActivities{}
+
+	env.OnActivity(cellActivities.BootstrapCell, mock.Anything, mock.Anything).Return(&BootstrapOutput{CellID: "cell-ladder"}, nil)
+	env.OnActivity(enhancedActivities.AcquireFileLocks, mock.Anything, mock.Anything, mock.Anything).Return([]string{"a.go"}, nil)
+	env.OnActivity(enhancedActivities.ExecuteGenTest, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{GateName: "GenTest", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteLintTest, mock.Anything, mock.Anything).Return(&GateResult{GateName: "LintTest", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteVerifyRED, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{GateName: "VerifyRED", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "multi_review", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteFixFromFeedback, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "Fix", Passed: false}, nil).Maybe()
+	env.OnActivity(cellActivities.RevertChanges, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CommitChanges, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CellHead, mock.Anything, mock.Anything).Return(&CellHeadOutput{}, nil).Maybe()
+	env.OnActivity(enhancedActivities.ReleaseFileLocks, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.TeardownCell, mock.Anything, mock.Anything).Return(nil)
+	return env, enhancedActivities
+}
+
+// genImplModels returns the models recorded for each GenImpl attempt
+func genImplModels(result *EnhancedTCRResult) []string {
+	var models []string
+	for _, gate := range result.GateResults {
+		if gate.GateName != "gen_impl" && gate.GateName != "GenImpl" {
+			continue
+		}
+		for _, agentResult := range gate.AgentResults {
+			models = append(models, agentResult.Model)
+		}
+	}
+	return models
+}
+
+func genImplPassed(model string) *GateResult {
+	return &GateResult{GateName: "gen_impl", Passed: true, AgentResults: []AgentResult{{AgentName: "implementation", Model: model, Success: true}}}
+}
+
+func TestEnhancedTCR_EscalatesModelPerRegeneration(t *testing.T) {
+	env, enhancedActivities := ladderTestEnv(t)
+
+	var calledWith []string
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, _ *BootstrapOutput, _, _, _, _ string, model string) (*GateResult, error) {
+			calledWith = append(calledWith, model)
+			return genImplPassed(model), nil
+		})
+	greens := 0
+	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, _ *BootstrapOutput, _ string) (*GateResult, error) {
+			greens++
+			return &GateResult{GateName: "VerifyGREEN", Passed: greens == 3}, nil
+		})
+
+	env.ExecuteWorkflow(EnhancedTCRWorkflow, EnhancedTCRInput{
+		TaskID:         "task-ladder",
+		CellID:         "cell-ladder",
+		MaxRetries:     3,
+		MaxFixAttempts: 1,
+		ModelLadder:    testLadder,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result *EnhancedTCRResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.True(t, result.Success)
+	assert.Equal(t, testLadder, calledWith)
+	assert.Equal(t, testLadder, genImplModels(result), "each attempt records the model it used")
+}
+
+func TestEnhancedTCR_ProviderErrorSwitchesProviderWithoutRetry(t *testing.T) {
+	env, enhancedActivities := ladderTestEnv(t)
+
+	var calledWith []string
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, _ *BootstrapOutput, _, _, _, _ string, model string) (*GateResult, error) {
+			calledWith = append(calledWith, model)
+			if modelProvider(model) == "anthropic" {
+				return nil, temporal.NewApplicationError("429 Too Many Requests", "ProviderError")
+			}
+			return genImplPassed(model), nil
+		})
+	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "VerifyGREEN", Passed: true}, nil)
+
+	env.ExecuteWorkflow(EnhancedTCRWorkflow, EnhancedTCRInput{
+		TaskID:      "task-provider",
+		CellID:      "cell-provider",
+		MaxRetries:  1,
+		ModelLadder: testLadder,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result *EnhancedTCRResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.True(t, result.Success, "the provider switch does not use up the only attempt")
+	assert.Equal(t, []string{"anthropic/claude-haiku-4-5", "openai/gpt-5"}, calledWith)
+	assert.Equal(t, []string{"anthropic/claude-haiku-4-5", "openai/gpt-5"}, genImplModels(result))
+	env.AssertNumberOfCalls(t, "RevertChanges", 0)
+}
+
+func TestEnhancedTCR_NonProviderGenImplErrorFails(t *testing.T) {
+	env, enhancedActivities := ladderTestEnv(t)
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		nil, temporal.NewApplicationError("agent execution failed", "AgentError"))
+
+	env.ExecuteWorkflow(EnhancedTCRWorkflow, EnhancedTCRInput{
+		TaskID:      "task-agent-error",
+		CellID:      "cell-agent-error",
+		ModelLadder: testLadder,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var result *EnhancedTCRResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.False(t, result.Success)
+	env.AssertNumberOfCalls(t, "ExecuteGenImpl", 1)
+	assert.Equal(t, []string{"anthropic/claude-haiku-4-5"}, genImplModels(result))
+}
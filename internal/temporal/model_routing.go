@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/activity"
+
+	"open-swarm/internal/agent"
+	"open-swarm/internal/opencode"
+)
+
+// DefaultImplModel runs the agent gates when model routing is disabled or
+// cannot pick a model
+const DefaultImplModel = "anthropic/claude-haiku-4-5"
+
+// modelRoutingFor returns the worker's model routing pointed at the cell's
+// OpenCode server, or nil when routing is disabled or the cell has no
+// OpenCode server to discover models from
+func modelRoutingFor(bootstrap *BootstrapOutput) *opencode.ConfigAwareness {
+	routing := GetModelRouting()
+	if routing == nil || bootstrap == nil || bootstrap.BaseURL == "" {
+		return nil
+	}
+	if backend, err := agent.ParseBackend(bootstrap.Backend); err != nil || backend != agent.BackendOpenCode {
+		return nil
+	}
+	routing.SetSource(opencode.NewSDKConfigSource(agent.NewClient(bootstrap.BaseURL, bootstrap.Port).GetSDK()))
+	return routing
+}
+
+// routeModel returns the model a gate runs with. A requested model is used
+// unless routing has taken it out of rotation; without one, or in its place,
+// the routing policy picks a model for the gate. fallback is used when the
+// gate has no requested model and none can be routed.
+func routeModel(ctx context.Context, bootstrap *BootstrapOutput, gate, requested, fallback string) string {
+	if requested != "" {
+		fallback = requested
+	}
+	routing := modelRoutingFor(bootstrap)
+	if routing == nil {
+		return fallback
+	}
+	if _, err := routing.GetAvailableModels(ctx); err != nil {
+		activity.GetLogger(ctx).Warn("Model discovery failed", "gate", gate, "model", fallback, "error", err)
+		return fallback
+	}
+	if requested != "" && !routing.ModelUnavailable(requested) {
+		return requested
+	}
+	model, err := routing.SelectModelForGate(ctx, gate)
+	if err != nil {
+		activity.GetLogger(ctx).Warn("Model routing failed", "gate", gate, "model", fallback, "error", err)
+		return fallback
+	}
+	return model
+}
+
+// routeReviewModels returns the model of each reviewer. Review types the
+// policy assigns a model keep it while it is available; the others get
+// models spread over providers by the routing policy.
+func routeReviewModels(ctx context.Context, bootstrap *BootstrapOutput, policy ReviewPolicy, reviewTypes []ReviewType) []string {
+	models := make([]string, len(reviewTypes))
+	for i, reviewType := range reviewTypes {
+		models[i] = policy.ModelFor(reviewType)
+	}
+	routing := modelRoutingFor(bootstrap)
+	if routing == nil || len(reviewTypes) == 0 {
+		return models
+	}
+	routed, err := routing.SelectModelsForGate(ctx, opencode.GateReview, len(reviewTypes))
+	if err != nil {
+		activity.GetLogger(ctx).Warn("Model routing failed", "gate", opencode.GateReview, "error", err)
+		return models
+	}
+	for i, reviewType := range reviewTypes {
+		if pinned := policy.Models[reviewType]; pinned == "" || routing.ModelUnavailable(pinned) {
+			models[i] = routed[i]
+		}
+	}
+	return models
+}
+
+// reportModelResult feeds the outcome of a prompt to model routing, so
+// models that keep failing with rate limit or server errors leave rotation
+func reportModelResult(model string, err error) {
+	routing := GetModelRouting()
+	if routing == nil || model == "" {
+		return
+	}
+	if err != nil {
+		routing.ReportModelError(model, err)
+		return
+	}
+	routing.ReportModelSuccess(model)
+}
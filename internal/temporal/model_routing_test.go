@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	opencodesdk "github.com/sst/opencode-sdk-go"
+	"github.com/stretchr/testify/assert"
+
+	"open-swarm/internal/config"
+	"open-swarm/internal/opencode"
+)
+
+const routingProvidersJSON = `{
+  "default": {"anthropic": "claude-sonnet-4-5"},
+  "providers": [
+    {"id": "anthropic", "name": "Anthropic", "env": [], "models": {
+      "claude-sonnet-4-5": {"id": "claude-sonnet-4-5", "name": "Claude Sonnet 4.5", "attachment": true, "reasoning": true, "tool_call": true,
+        "temperature": true, "release_date": "2025-09-29", "options": {},
+        "cost": {"input": 3, "output": 15}, "limit": {"context": 200000, "output": 64000}},
+      "claude-haiku-4-5": {"id": "claude-haiku-4-5", "name": "Claude Haiku 4.5", "attachment": false, "reasoning": false, "tool_call": true,
+        "temperature": true, "release_date": "2025-10-01", "options": {},
+        "cost": {"input": 1, "output": 5}, "limit": {"context": 200000, "output": 64000}}
+    }},
+    {"id": "openai", "name": "OpenAI", "env": [], "models": {
+      "gpt-5": {"id": "gpt-5", "name": "GPT-5", "attachment": true, "reasoning": true, "tool_call": true,
+        "temperature": false, "release_date": "2025-08-07", "options": {},
+        "cost": {"input": 1.25, "output": 10}, "limit": {"context": 400000, "output": 128000}}
+    }}
+  ]
+}`
+
+// routedCell enables model routing and returns a cell whose OpenCode server
+// serves routingProvidersJSON
+func routedCell(t *testing.T) *BootstrapOutput {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/config":
+			_, _ = w.Write([]byte(`{}`))
+		case "/config/providers":
+			_, _ = w.Write([]byte(routingProvidersJSON))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	InitializeModelRouting(config.ModelConfig{})
+	t.Cleanup(func() { globalModelRouting = nil })
+	return &BootstrapOutput{CellID: "cell-routing", BaseURL: server.URL}
+}
+
+func TestRouteModel_PerGatePolicy(t *testing.T) {
+	ctx := context.Background()
+	cell := routedCell(t)
+
+	assert.Equal(t, "anthropic/claude-haiku-4-5", routeModel(ctx, cell, opencode.GateLintFix, "", DefaultImplModel), "lint fixes use the cheapest model")
+	assert.Equal(t, "anthropic/claude-sonnet-4-5", routeModel(ctx, cell, opencode.GateImplementation, "", DefaultImplModel), "implementation uses the strongest model")
+	assert.Equal(t, "openai/gpt-5", routeModel(ctx, cell, opencode.GateImplementation, "openai/gpt-5", DefaultImplModel), "a ladder model is kept")
+
+	models := routeReviewModels(ctx, cell, ReviewPolicy{}, []ReviewType{ReviewTypeTesting, ReviewTypeFunctional, ReviewTypeArchitecture})
+	assert.Equal(t, []string{"anthropic/claude-sonnet-4-5", "openai/gpt-5", "anthropic/claude-haiku-4-5"}, models, "reviewers are spread over providers")
+
+	pinned := ReviewPolicy{Models: map[ReviewType]string{ReviewTypeTesting: "openai/gpt-5"}}
+	models = routeReviewModels(ctx, cell, pinned, []ReviewType{ReviewTypeTesting})
+	assert.Equal(t, []string{"openai/gpt-5"}, models, "a policy model is kept")
+}
+
+func TestRouteModel_ProviderErrorsTakeModelOutOfRotation(t *testing.T) {
+	ctx := context.Background()
+	cell := routedCell(t)
+	assert.Equal(t, "openai/gpt-5", routeModel(ctx, cell, opencode.GateImplementation, "openai/gpt-5", DefaultImplModel))
+
+	for i := 0; i < opencode.UnavailableAfterFailures; i++ {
+		reportModelResult("openai/gpt-5", fmt.Errorf("failed to send prompt: %w", &opencodesdk.Error{StatusCode: http.StatusTooManyRequests}))
+	}
+	assert.Equal(t, "anthropic/claude-sonnet-4-5", routeModel(ctx, cell, opencode.GateImplementation, "openai/gpt-5", DefaultImplModel),
+		"a ladder model out of rotation is replaced by the routed one")
+
+	pinned := ReviewPolicy{Models: map[ReviewType]string{ReviewTypeTesting: "openai/gpt-5"}}
+	assert.Equal(t, []string{"anthropic/claude-sonnet-4-5"}, routeReviewModels(ctx, cell, pinned, []ReviewType{ReviewTypeTesting}))
+
+	reportModelResult("openai/gpt-5", nil)
+	assert.Equal(t, "openai/gpt-5", routeModel(ctx, cell, opencode.GateImplementation, "openai/gpt-5", DefaultImplModel), "a success puts it back")
+}
+
+func TestRouteModel_FallsBackWithoutOpenCodeServer(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, DefaultImplModel, routeModel(ctx, &BootstrapOutput{}, opencode.GateLintFix, "", DefaultImplModel), "routing disabled")
+
+	cell := routedCell(t)
+	cell.Backend = "claude-code"
+	assert.Equal(t, DefaultImplModel, routeModel(ctx, cell, opencode.GateLintFix, "", DefaultImplModel), "other backends keep their model")
+	assert.Equal(t, DefaultImplModel, routeModel(ctx, &BootstrapOutput{}, opencode.GateLintFix, "", DefaultImplModel), "serverless cells keep their model")
+	assert.Equal(t, []string{defaultReviewerModel}, routeReviewModels(ctx, &BootstrapOutput{}, ReviewPolicy{}, []ReviewType{ReviewTypeTesting}))
+}
@@ -21,9 +21,32 @@ func NewVoteParser() *VoteParser {
 
 // ParsedVote contains extracted vote information
 type ParsedVote struct {
-	Vote     VoteResult
-	Feedback string
-	Found    bool
+	Vote       VoteResult
+	Feedback   string
+	Found      bool
+	Confidence float64 // 0-1; explicit "CONFIDENCE:" marker, else 1.0 if a vote was found and 0.5 if not
+}
+
+// confidencePattern matches "CONFIDENCE: 0.8", "Confidence: 80%" and similar markers
+var confidencePattern = regexp.MustCompile(`(?i)\bCONFIDENCE\b\s*[:=]?\s*([0-9]+(?:\.[0-9]+)?)\s*(%)?`)
+
+// ParseConfidence extracts an explicit reviewer confidence, normalized to 0-1
+func (vp *VoteParser) ParseConfidence(output string) (float64, bool) {
+	m := confidencePattern.FindStringSubmatch(output)
+	if m == nil {
+		return 0, false
+	}
+	var value float64
+	if _, err := fmt.Sscanf(m[1], "%g", &value); err != nil {
+		return 0, false
+	}
+	if m[2] == "%" || value > 1 {
+		value /= 100
+	}
+	if value < 0 || value > 1 {
+		return 0, false
+	}
+	return value, true
 }
 
 // ParseVote extracts vote decision from reviewer output
@@ -36,25 +59,31 @@ func (vp *VoteParser) ParseVote(output string) ParsedVote {
 		pattern *regexp.Regexp
 	}{
 		{VoteReject, regexp.MustCompile(`(?i)\bREJECT\b`)},
-		{VoteRequestChange, regexp.MustCompile(`(?i)\bREQUEST[_ ]CHANGE\b`)},
+		{VoteRequestChange, regexp.MustCompile(`(?i)\bREQUEST[_ ]CHANGES?\b`)},
 		{VoteApprove, regexp.MustCompile(`(?i)\bAPPROVE\b`)},
 	}
 
 	for _, p := range patterns {
 		if p.pattern.MatchString(output) {
+			confidence, ok := vp.ParseConfidence(output)
+			if !ok {
+				confidence = 1.0
+			}
 			return ParsedVote{
-				Vote:     p.vote,
-				Feedback: output,
-				Found:    true,
+				Vote:       p.vote,
+				Feedback:   output,
+				Found:      true,
+				Confidence: confidence,
 			}
 		}
 	}
 
 	// Default to REQUEST_CHANGE if no explicit vote found
 	return ParsedVote{
-		Vote:     VoteRequestChange,
-		Feedback: output,
-		Found:    false,
+		Vote:       VoteRequestChange,
+		Feedback:   output,
+		Found:      false,
+		Confidence: 0.5,
 	}
 }
 
@@ -97,6 +126,17 @@ func (lp *LintParser) ParseGolangciLint(output string) ParsedLintResult {
 		}
 	}
 
+	// JSON report (--output.json.path=stdout)
+	if strings.HasPrefix(strings.TrimSpace(output), "{") && strings.Contains(output, `"Issues"`) {
+		if issues, err := ParseGolangciLintJSON(output); err == nil {
+			return ParsedLintResult{
+				HasErrors: countErrorIssues(issues) > 0,
+				Issues:    issues,
+				Summary:   lp.FormatLintSummary(issues),
+			}
+		}
+	}
+
 	// golangci-lint format: file:line:col: message (rule)
 	// Example: main.go:10:2: undeclared name: foo (typecheck)
 	pattern := regexp.MustCompile(`([^:]+):(\d+):(\d+):\s*(.+?)\s*\(([^)]+)\)`)
@@ -199,7 +239,7 @@ func (ra *ReviewAggregator) AggregateReviewFeedback(votes []ReviewVote) string {
 	}
 
 	// Format feedback by type
-	reviewTypes := []ReviewType{ReviewTypeTesting, ReviewTypeFunctional, ReviewTypeArchitecture}
+	reviewTypes := append(append([]ReviewType{}, generalReviewTypes...), ReviewTypeSecurity, ReviewTypePerformance, ReviewTypeAPICompatibility)
 	for _, reviewType := range reviewTypes {
 		typeVotes := byType[reviewType]
 		if len(typeVotes) == 0 {
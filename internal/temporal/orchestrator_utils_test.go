@@ -332,3 +332,25 @@ func TestReviewAggregator_GetRejectionSummary(t *testing.T) {
 		})
 	}
 }
+
+// TestVoteParser_ParseConfidence tests reviewer confidence extraction
+func TestVoteParser_ParseConfidence(t *testing.T) {
+	parser := NewVoteParser()
+
+	tests := []struct {
+		output string
+		want   float64
+	}{
+		{"VOTE: APPROVE\nCONFIDENCE: 0.8", 0.8},
+		{"REQUEST_CHANGE. Confidence: 65%", 0.65},
+		{"REJECT (confidence 90)", 0.9},
+		{"APPROVE", 1.0},
+		{"Looks fine I guess", 0.5},
+	}
+	for _, tt := range tests {
+		got := parser.ParseVote(tt.output).Confidence
+		if got < tt.want-0.001 || got > tt.want+0.001 {
+			t.Errorf("ParseVote(%q).Confidence = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
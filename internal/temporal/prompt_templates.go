@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"open-swarm/internal/prompts/templates"
+)
+
+// Template names of the Enhanced TCR gate prompts
+const (
+	PromptGenTest         = "gen_test"
+	PromptGenImpl         = "gen_impl"
+	PromptFixFromFeedback = "fix_from_feedback"
+	PromptLintFix         = "lint_fix"
+	PromptReview          = "review"
+)
+
+// GatePromptData is the data a gate prompt template renders with. Fields a
+// gate has no value for are empty.
+type GatePromptData struct {
+	TaskID             string
+	Package            string
+	Description        string
+	AcceptanceCriteria string
+	Feedback           string // Test failures or reviewer feedback to address
+	CurrentCode        string // Implementation being fixed
+	ImplFile           string // Implementation file path, e.g. pkg/foo/foo.go
+	TestFile           string // Test file path, e.g. pkg/foo/foo_test.go
+	RelatedCode        string // Packed code the tests use
+	LintIssues         string
+	ReviewType         string
+	ReviewFocus        string
+	ReviewSchema       string // Structured review output instructions
+	Hunks              string // Changed files and hunks
+	APIChanges         string // Exported symbol changes
+}
+
+// renderGatePrompt renders a gate prompt from the process-wide template
+// registry. Tasks are split between A/B variants by task ID, so every retry
+// of a task gets the same version. builtin is the prompt used when no
+// template is configured.
+func renderGatePrompt(ctx context.Context, name string, data GatePromptData, builtin string) (templates.Prompt, error) {
+	prompt, err := templates.Default().Render(name, data.TaskID, data, func() string { return builtin })
+	if err != nil {
+		return prompt, err
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("prompt.version", prompt.Stamp()))
+	return prompt, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"open-swarm/internal/prompts/templates"
+)
+
+func TestRenderGatePrompt(t *testing.T) {
+	previous := templates.Default()
+	t.Cleanup(func() { templates.SetDefault(previous) })
+
+	t.Run("builtin without templates", func(t *testing.T) {
+		templates.SetDefault(templates.NewRegistry())
+		prompt, err := renderGatePrompt(context.Background(), PromptGenImpl, GatePromptData{TaskID: "task-1"}, "built-in prompt")
+		require.NoError(t, err)
+		assert.Equal(t, "built-in prompt", prompt.Text)
+		assert.Equal(t, "gen_impl@builtin", prompt.Stamp())
+	})
+
+	t.Run("template renders gate data", func(t *testing.T) {
+		registry := templates.NewRegistry()
+		require.NoError(t, registry.Register(PromptGenImpl, "v2", "Implement {{.TaskID}} in {{.ImplFile}}{{.RelatedCode}}"))
+		templates.SetDefault(registry)
+
+		prompt, err := renderGatePrompt(context.Background(), PromptGenImpl, GatePromptData{
+			TaskID:      "task-1",
+			ImplFile:    "pkg/task-1/task-1.go",
+			RelatedCode: "\n\nRelated Code: ...",
+		}, "built-in prompt")
+		require.NoError(t, err)
+		assert.Equal(t, "Implement task-1 in pkg/task-1/task-1.go\n\nRelated Code: ...", prompt.Text)
+		assert.Equal(t, "gen_impl@v2", prompt.Stamp())
+	})
+
+	t.Run("template error fails the gate", func(t *testing.T) {
+		registry := templates.NewRegistry()
+		require.NoError(t, registry.Register(PromptLintFix, "v1", "Fix {{.Issues}}"))
+		templates.SetDefault(registry)
+
+		_, err := renderGatePrompt(context.Background(), PromptLintFix, GatePromptData{TaskID: "task-1"}, "built-in prompt")
+		assert.ErrorContains(t, err, "lint_fix@v1")
+	})
+}
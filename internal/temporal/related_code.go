@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.temporal.io/sdk/activity"
+
+	"open-swarm/internal/prompts"
+)
+
+// relatedCodeSection packs the code used by a task's generated tests into a
+// prompt section. Packing is best effort: without tests or on errors the
+// prompt goes out without the section.
+func relatedCodeSection(ctx context.Context, worktreePath, packageName, description string, failingTests []string) string {
+	testFiles, _ := filepath.Glob(filepath.Join(worktreePath, "pkg", packageName, "*_test.go"))
+	if len(testFiles) == 0 {
+		return ""
+	}
+	for i, testFile := range testFiles {
+		testFiles[i], _ = filepath.Rel(worktreePath, testFile)
+	}
+
+	pack, err := prompts.NewContextPacker(nil, nil).Pack(ctx, prompts.ContextRequest{
+		Root:            worktreePath,
+		TaskDescription: description,
+		TestFiles:       testFiles,
+		FailingTests:    failingTests,
+	})
+	if err != nil {
+		activity.GetLogger(ctx).Warn("Failed to pack related code", "error", err)
+		return ""
+	}
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Int("context.snippets", len(pack.Snippets)),
+		attribute.Int("context.tokens", pack.Tokens),
+		attribute.Int("context.dropped", pack.Dropped),
+	)
+	if len(pack.Snippets) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n\n%s:\n\n%s", prompts.RelatedCodeHeading, pack.Format())
+}
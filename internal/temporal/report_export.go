@@ -0,0 +1,348 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Report file names written by WriteGateReports
+const (
+	SARIFReportFile      = "tcr.sarif"
+	CheckstyleReportFile = "checkstyle.xml"
+	JUnitReportFile      = "junit.xml"
+	ReviewCommentsFile   = "review-comments.json"
+)
+
+// finalGateResults keeps the last result of each gate, in first-seen order.
+// Retries append a new result per attempt; only the final one reflects the
+// state of the committed (or abandoned) code.
+func finalGateResults(result *EnhancedTCRResult) []GateResult {
+	if result == nil {
+		return nil
+	}
+	index := make(map[string]int)
+	var gates []GateResult
+	for _, gate := range result.GateResults {
+		if i, ok := index[gate.GateName]; ok {
+			gates[i] = gate
+			continue
+		}
+		index[gate.GateName] = len(gates)
+		gates = append(gates, gate)
+	}
+	return gates
+}
+
+// isExpectedFailureGate reports gates whose failing tests are the goal (RED phase)
+func isExpectedFailureGate(gate GateResult) bool {
+	return gate.GateName == "verify_red"
+}
+
+// BuildGateSARIF converts lint issues, test failures and review findings of a
+// workflow result into a SARIF 2.1.0 log with one run per source
+func BuildGateSARIF(result *EnhancedTCRResult) *SARIFLog {
+	lintRun := SARIFRun{Tool: SARIFTool{Driver: SARIFDriver{Name: "open-swarm-lint"}}, Results: []SARIFResult{}}
+	testRun := SARIFRun{Tool: SARIFTool{Driver: SARIFDriver{Name: "open-swarm-test"}}, Results: []SARIFResult{}}
+	var findings []ReviewFinding
+
+	lintRules := make(map[string]bool)
+	parser := NewTestParser()
+	for _, gate := range finalGateResults(result) {
+		if gate.LintResult != nil {
+			for _, issue := range gate.LintResult.Issues {
+				ruleID := issue.Rule
+				if ruleID == "" {
+					ruleID = "lint"
+				}
+				lintRules[ruleID] = true
+				lintRun.Results = append(lintRun.Results, SARIFResult{
+					RuleID:    ruleID,
+					Level:     sarifLevelForLint(issue.Severity),
+					Message:   SARIFText{Text: issue.Message},
+					Locations: lintLocation(issue),
+				})
+			}
+		}
+		if gate.TestResult != nil && !gate.TestResult.Passed && !isExpectedFailureGate(gate) {
+			for _, failure := range parser.ParseTestOutput(gate.TestResult.Output).Failures {
+				line, _ := strconv.Atoi(failure.LineNumber)
+				message := failure.ErrorMessage
+				if message == "" {
+					message = "test failed"
+				}
+				testRun.Results = append(testRun.Results, SARIFResult{
+					RuleID:    "test-failure",
+					Level:     "error",
+					Message:   SARIFText{Text: fmt.Sprintf("%s: %s", failure.TestName, message)},
+					Locations: newSARIFLocation(failure.FileName, line, 0),
+				})
+			}
+		}
+		findings = append(findings, collectFindings(gate.ReviewVotes)...)
+	}
+
+	for _, id := range sortedKeys(lintRules) {
+		lintRun.Tool.Driver.Rules = append(lintRun.Tool.Driver.Rules, SARIFRule{ID: id})
+	}
+	if len(testRun.Results) > 0 {
+		testRun.Tool.Driver.Rules = []SARIFRule{{ID: "test-failure", ShortDescription: &SARIFText{Text: "Go test failure"}}}
+	}
+
+	log := NewSARIFLog()
+	log.Runs = append(log.Runs, lintRun, testRun, ReviewFindingsSARIF(findings))
+	return log
+}
+
+// sarifLevelForLint maps lint severities onto SARIF levels
+func sarifLevelForLint(severity string) string {
+	switch strings.ToLower(severity) {
+	case "info", "note":
+		return "note"
+	case "warning":
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+func lintLocation(issue LintIssue) []SARIFLocation {
+	locations := newSARIFLocation(issue.File, issue.Line, 0)
+	if len(locations) > 0 && locations[0].PhysicalLocation.Region != nil && issue.Column > 0 {
+		locations[0].PhysicalLocation.Region.StartColumn = issue.Column
+	}
+	return locations
+}
+
+// CheckstyleReport is the root of a checkstyle XML document
+type CheckstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []CheckstyleFile `xml:"file"`
+}
+
+// CheckstyleFile groups the errors reported for one file
+type CheckstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []CheckstyleError `xml:"error"`
+}
+
+// CheckstyleError is a single checkstyle finding
+type CheckstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"` // error, warning or info
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// BuildGateCheckstyle converts lint issues and anchored review findings of a
+// workflow result into a checkstyle report, one <file> per path
+func BuildGateCheckstyle(result *EnhancedTCRResult) *CheckstyleReport {
+	byFile := make(map[string][]CheckstyleError)
+	for _, gate := range finalGateResults(result) {
+		if gate.LintResult != nil {
+			for _, issue := range gate.LintResult.Issues {
+				if issue.File == "" {
+					continue
+				}
+				severity := strings.ToLower(issue.Severity)
+				if severity != "warning" && severity != "info" {
+					severity = "error"
+				}
+				byFile[issue.File] = append(byFile[issue.File], CheckstyleError{
+					Line:     issue.Line,
+					Column:   issue.Column,
+					Severity: severity,
+					Message:  issue.Message,
+					Source:   "lint." + issue.Rule,
+				})
+			}
+		}
+		for _, finding := range collectFindings(gate.ReviewVotes) {
+			if finding.File == "" {
+				continue
+			}
+			severity := sarifLevelForSeverity(finding.Severity)
+			if severity == "note" {
+				severity = "info"
+			}
+			byFile[finding.File] = append(byFile[finding.File], CheckstyleError{
+				Line:     finding.StartLine,
+				Severity: severity,
+				Message:  finding.Message,
+				Source:   "review." + findingCategory(finding),
+			})
+		}
+	}
+
+	report := &CheckstyleReport{Version: "4.3"}
+	for _, file := range sortedKeys(byFile) {
+		report.Files = append(report.Files, CheckstyleFile{Name: file, Errors: byFile[file]})
+	}
+	return report
+}
+
+// JUnitTestSuites is the root of a JUnit XML document
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite is one gate's test run
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase is a single test
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+}
+
+// JUnitFailure describes why a test case failed
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// passedTestPattern matches "--- PASS: TestName (0.00s)"
+var passedTestPattern = regexp.MustCompile(`(?m)^\s*--- PASS: (\S+)`)
+
+// BuildGateJUnit converts the final test gate results of a workflow into
+// JUnit XML. The RED phase is omitted because its failures are expected.
+func BuildGateJUnit(result *EnhancedTCRResult) *JUnitTestSuites {
+	suites := &JUnitTestSuites{}
+	parser := NewTestParser()
+
+	for _, gate := range finalGateResults(result) {
+		if gate.TestResult == nil || isExpectedFailureGate(gate) {
+			continue
+		}
+		suite := JUnitTestSuite{
+			Name: gate.GateName,
+			Time: strconv.FormatFloat(gate.TestResult.Duration.Seconds(), 'f', 3, 64),
+		}
+		for _, match := range passedTestPattern.FindAllStringSubmatch(gate.TestResult.Output, -1) {
+			suite.TestCases = append(suite.TestCases, JUnitTestCase{Name: match[1], ClassName: gate.GateName})
+		}
+		if !gate.TestResult.Passed {
+			for _, failure := range parser.ParseTestOutput(gate.TestResult.Output).Failures {
+				className := failure.Package
+				if className == "" {
+					className = gate.GateName
+				}
+				location := failure.FileName
+				if failure.LineNumber != "" {
+					location += ":" + failure.LineNumber
+				}
+				suite.TestCases = append(suite.TestCases, JUnitTestCase{
+					Name:      failure.TestName,
+					ClassName: className,
+					Failure: &JUnitFailure{
+						Message: strings.TrimSpace(location + " " + firstLine(failure.ErrorMessage)),
+						Type:    "failure",
+						Text:    failure.ErrorMessage,
+					},
+				})
+				suite.Failures++
+			}
+		}
+		suite.Tests = len(suite.TestCases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Suites = append(suites.Suites, suite)
+	}
+	return suites
+}
+
+// WriteGateReports writes SARIF, checkstyle and JUnit reports and the review
+// comments of a workflow result into dir, creating it if needed. Returns the
+// paths written.
+func WriteGateReports(dir string, result *EnhancedTCRResult) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	var findings []ReviewFinding
+	for _, gate := range finalGateResults(result) {
+		findings = append(findings, collectFindings(gate.ReviewVotes)...)
+	}
+
+	writers := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{SARIFReportFile, func(w io.Writer) error { return WriteSARIF(w, BuildGateSARIF(result)) }},
+		{CheckstyleReportFile, func(w io.Writer) error { return writeXML(w, BuildGateCheckstyle(result)) }},
+		{JUnitReportFile, func(w io.Writer) error { return writeXML(w, BuildGateJUnit(result)) }},
+		{ReviewCommentsFile, func(w io.Writer) error { return WriteReviewComments(w, findings) }},
+	}
+
+	paths := make([]string, 0, len(writers))
+	for _, writer := range writers {
+		path := filepath.Join(dir, writer.name)
+		file, err := os.Create(path) //nolint:gosec // Report directory is chosen by the operator
+		if err != nil {
+			return paths, fmt.Errorf("failed to create %s: %w", writer.name, err)
+		}
+		err = writer.write(file)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return paths, fmt.Errorf("failed to write %s: %w", writer.name, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// writeXML encodes v as indented XML with a declaration
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode XML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
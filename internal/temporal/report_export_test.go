@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const failingGoTestOutput = `=== RUN   TestAdd
+--- PASS: TestAdd (0.00s)
+=== RUN   TestDivide
+    calc_test.go:21: expected 2, got 0
+--- FAIL: TestDivide (0.00s)
+FAIL
+FAIL	example.com/calc	0.004s
+`
+
+func reportFixture() *EnhancedTCRResult {
+	return &EnhancedTCRResult{GateResults: []GateResult{
+		{GateName: "lint_test", Passed: false, LintResult: &LintResult{Issues: []LintIssue{
+			{File: "calc.go", Line: 3, Column: 2, Severity: "error", Message: "unused variable x", Rule: "unused"},
+		}}},
+		{GateName: "verify_red", Passed: true, TestResult: &TestResult{Passed: false, Output: failingGoTestOutput}},
+		{GateName: "verify_green", Passed: false, TestResult: &TestResult{Passed: false, Output: failingGoTestOutput}},
+		{GateName: "verify_green", Passed: false, TestResult: &TestResult{Passed: false, Output: failingGoTestOutput, Duration: 1500 * time.Millisecond}},
+		{GateName: "multi_review", Passed: false, ReviewVotes: []ReviewVote{
+			{Vote: VoteRequestChange, Findings: []ReviewFinding{
+				{File: "calc.go", StartLine: 10, EndLine: 12, Severity: SeverityMajor, Category: "logic", Message: "division by zero"},
+			}},
+		}},
+	}}
+}
+
+func TestBuildGateSARIF(t *testing.T) {
+	log := BuildGateSARIF(reportFixture())
+	require.Len(t, log.Runs, 3)
+
+	lint := log.Runs[0]
+	require.Len(t, lint.Results, 1)
+	assert.Equal(t, "unused", lint.Results[0].RuleID)
+	assert.Equal(t, 2, lint.Results[0].Locations[0].PhysicalLocation.Region.StartColumn)
+
+	tests := log.Runs[1]
+	require.Len(t, tests.Results, 1, "only the final verify_green attempt is reported; RED is skipped")
+	assert.Contains(t, tests.Results[0].Message.Text, "TestDivide")
+
+	review := log.Runs[2]
+	require.Len(t, review.Results, 1)
+	assert.Equal(t, "review/logic", review.Results[0].RuleID)
+}
+
+func TestBuildGateCheckstyle(t *testing.T) {
+	report := BuildGateCheckstyle(reportFixture())
+	require.Len(t, report.Files, 1)
+	assert.Equal(t, "calc.go", report.Files[0].Name)
+	require.Len(t, report.Files[0].Errors, 2)
+	assert.Equal(t, "lint.unused", report.Files[0].Errors[0].Source)
+	assert.Equal(t, "review.logic", report.Files[0].Errors[1].Source)
+}
+
+func TestBuildGateJUnit(t *testing.T) {
+	suites := BuildGateJUnit(reportFixture())
+	require.Len(t, suites.Suites, 1)
+
+	suite := suites.Suites[0]
+	assert.Equal(t, "verify_green", suite.Name)
+	assert.Equal(t, "1.500", suite.Time)
+	assert.Equal(t, 2, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, "TestAdd", suite.TestCases[0].Name)
+	require.NotNil(t, suite.TestCases[1].Failure)
+	assert.Equal(t, "TestDivide", suite.TestCases[1].Name)
+}
+
+func TestWriteGateReports(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "reports")
+	paths, err := WriteGateReports(dir, reportFixture())
+	require.NoError(t, err)
+	require.Len(t, paths, 4)
+
+	data, err := os.ReadFile(filepath.Join(dir, JUnitReportFile))
+	require.NoError(t, err)
+	var suites JUnitTestSuites
+	require.NoError(t, xml.Unmarshal(data, &suites))
+	assert.Equal(t, 1, suites.Failures)
+
+	data, err = os.ReadFile(filepath.Join(dir, CheckstyleReportFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<checkstyle version="4.3">`)
+
+	data, err = os.ReadFile(filepath.Join(dir, SARIFReportFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"$schema"`)
+
+	// The fixture's finding is not on the diff, so it goes into the body
+	data, err = os.ReadFile(filepath.Join(dir, ReviewCommentsFile))
+	require.NoError(t, err)
+	var review ReviewSubmission
+	require.NoError(t, json.Unmarshal(data, &review))
+	assert.Empty(t, review.Comments)
+	assert.Contains(t, review.Body, "calc.go:10-12 [major/logic] division by zero")
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ReviewComment is a line-anchored comment in the shape accepted by the
+// GitHub pull request review comments API
+type ReviewComment struct {
+	Path      string `json:"path"`
+	Line      int    `json:"line,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	Side      string `json:"side,omitempty"`
+	Body      string `json:"body"`
+}
+
+// ReviewSubmission is a pull request review in the shape accepted by the
+// GitHub create review API: line comments on the diff and a review body
+type ReviewSubmission struct {
+	Body     string          `json:"body"`
+	Comments []ReviewComment `json:"comments"`
+}
+
+// ReviewCommentsFromFindings converts findings on the diff into review
+// comments. GitHub rejects the whole review when one comment targets a line
+// outside the diff, so findings off the diff or without a line are skipped.
+func ReviewCommentsFromFindings(findings []ReviewFinding) []ReviewComment {
+	comments := make([]ReviewComment, 0, len(findings))
+	for _, finding := range findings {
+		if !anchoredOnDiff(finding) {
+			continue
+		}
+		comment := ReviewComment{Path: finding.File, Side: "RIGHT", Line: finding.StartLine, Body: findingBody(finding)}
+		if finding.EndLine > finding.StartLine {
+			comment.StartLine = finding.StartLine
+			comment.Line = finding.EndLine
+		}
+		comments = append(comments, comment)
+	}
+	return comments
+}
+
+// ReviewFromFindings builds a review whose comments are the findings on the
+// diff; the other findings are listed in the body
+func ReviewFromFindings(findings []ReviewFinding) ReviewSubmission {
+	var sb strings.Builder
+	for _, finding := range findings {
+		if anchoredOnDiff(finding) {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("Findings outside the changed lines:\n")
+		}
+		sb.WriteString(fmt.Sprintf("- %s %s\n", finding.Location(), strings.ReplaceAll(findingBody(finding), "\n\n", " ")))
+	}
+	return ReviewSubmission{Body: sb.String(), Comments: ReviewCommentsFromFindings(findings)}
+}
+
+// anchoredOnDiff reports whether a finding can be posted as a line comment
+func anchoredOnDiff(finding ReviewFinding) bool {
+	return finding.File != "" && finding.StartLine > 0 && finding.OnDiff
+}
+
+// WriteReviewComments writes findings as a JSON review submission
+func WriteReviewComments(w io.Writer, findings []ReviewFinding) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(ReviewFromFindings(findings)); err != nil {
+		return fmt.Errorf("failed to encode review comments: %w", err)
+	}
+	return nil
+}
+
+// ReviewFindingsSARIF converts findings into a SARIF run. Rules are derived
+// from finding categories.
+func ReviewFindingsSARIF(findings []ReviewFinding) SARIFRun {
+	run := SARIFRun{
+		Tool:    SARIFTool{Driver: SARIFDriver{Name: "open-swarm-review"}},
+		Results: []SARIFResult{},
+	}
+	rules := make(map[string]bool)
+	for _, finding := range findings {
+		ruleID := "review/" + findingCategory(finding)
+		rules[ruleID] = true
+		run.Results = append(run.Results, SARIFResult{
+			RuleID:    ruleID,
+			Level:     sarifLevelForSeverity(finding.Severity),
+			Message:   SARIFText{Text: findingBody(finding)},
+			Locations: newSARIFLocation(finding.File, finding.StartLine, finding.EndLine),
+		})
+	}
+
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, SARIFRule{
+			ID:         id,
+			Properties: &SARIFBag{Tags: []string{"review", strings.TrimPrefix(id, "review/")}},
+		})
+	}
+	return run
+}
+
+// sarifLevelForSeverity maps finding severities onto SARIF levels
+func sarifLevelForSeverity(severity string) string {
+	switch severity {
+	case SeverityCritical, SeverityMajor:
+		return "error"
+	case SeverityMinor:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func findingCategory(finding ReviewFinding) string {
+	if finding.Category == "" {
+		return "general"
+	}
+	return strings.ToLower(finding.Category)
+}
+
+// findingBody renders the message, severity and suggested fix of a finding
+func findingBody(finding ReviewFinding) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[%s/%s] %s", finding.Severity, findingCategory(finding), finding.Message))
+	if finding.SuggestedFix != "" {
+		sb.WriteString("\n\nSuggested fix: " + finding.SuggestedFix)
+	}
+	if finding.Reviewer != "" {
+		sb.WriteString("\n\n— " + finding.Reviewer)
+	}
+	return sb.String()
+}
@@ -0,0 +1,387 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"open-swarm/internal/git"
+)
+
+// Finding severities, most to least severe
+const (
+	SeverityCritical   = "critical"
+	SeverityMajor      = "major"
+	SeverityMinor      = "minor"
+	SeveritySuggestion = "suggestion"
+)
+
+// ReviewFinding is a single line-anchored issue raised by a reviewer
+type ReviewFinding struct {
+	File         string
+	StartLine    int
+	EndLine      int
+	Severity     string // critical, major, minor or suggestion
+	Category     string // e.g. logic, testing, security
+	Message      string
+	SuggestedFix string
+	Reviewer     string
+	OnDiff       bool // Whether the range overlaps lines changed by the diff
+}
+
+// Location renders the finding anchor as file:start-end
+func (f ReviewFinding) Location() string {
+	switch {
+	case f.File == "":
+		return "(no file)"
+	case f.StartLine == 0:
+		return f.File
+	case f.EndLine <= f.StartLine:
+		return fmt.Sprintf("%s:%d", f.File, f.StartLine)
+	default:
+		return fmt.Sprintf("%s:%d-%d", f.File, f.StartLine, f.EndLine)
+	}
+}
+
+// StructuredReview is the JSON document reviewers are asked to emit
+type StructuredReview struct {
+	Vote       VoteResult
+	Summary    string
+	Confidence float64
+	Findings   []ReviewFinding
+}
+
+// structuredReviewSchema is appended to reviewer prompts
+const structuredReviewSchema = `Respond with a single JSON object in a ` + "```json" + ` block:
+{
+  "vote": "APPROVE | REQUEST_CHANGE | REJECT",
+  "confidence": 0.0-1.0,
+  "summary": "one paragraph overall assessment",
+  "findings": [
+    {
+      "file": "path/relative/to/repo.go",
+      "start_line": 10,
+      "end_line": 14,
+      "severity": "critical | major | minor | suggestion",
+      "category": "logic | testing | design | security | performance | api",
+      "message": "what is wrong",
+      "suggested_fix": "how to fix it"
+    }
+  ]
+}
+Only report findings on lines the change touches. Use REQUEST_CHANGE for fixable issues and REJECT when the approach must be redone.`
+
+// ParseStructuredReview extracts a structured review from reviewer output.
+// It tolerates surrounding prose, code fences, trailing commas, alternate key
+// names and loosely formatted votes. Returns false when no JSON object with a
+// vote, summary or findings is present.
+func (vp *VoteParser) ParseStructuredReview(output string) (*StructuredReview, bool) {
+	parser := NewAgentResultParser()
+	for _, candidate := range parser.ExtractJSONObjects(output) {
+		var raw map[string]interface{}
+		if err := json.Unmarshal([]byte(candidate), &raw); err != nil {
+			if err := json.Unmarshal([]byte(trailingCommaPattern.ReplaceAllString(candidate, "$1")), &raw); err != nil {
+				continue
+			}
+		}
+		raw = lowerKeys(raw)
+
+		voteText, hasVote := stringField(raw, "vote", "decision", "verdict")
+		summary, hasSummary := stringField(raw, "summary", "feedback", "overall")
+		rawFindings, hasFindings := raw["findings"].([]interface{})
+		if !hasFindings {
+			rawFindings, hasFindings = raw["comments"].([]interface{})
+		}
+		if !hasVote && !hasSummary && !hasFindings {
+			continue
+		}
+
+		review := &StructuredReview{Summary: summary, Confidence: 1.0}
+		if hasVote {
+			review.Vote = vp.ParseVote(voteText).Vote
+		} else {
+			review.Vote = vp.ParseVote(output).Vote
+		}
+		if confidence, ok := numberField(raw, "confidence"); ok {
+			if confidence > 1 {
+				confidence /= 100
+			}
+			if confidence >= 0 && confidence <= 1 {
+				review.Confidence = confidence
+			}
+		}
+		for _, item := range rawFindings {
+			if m, ok := item.(map[string]interface{}); ok {
+				if finding, ok := parseFinding(lowerKeys(m)); ok {
+					review.Findings = append(review.Findings, finding)
+				}
+			}
+		}
+		return review, true
+	}
+	return nil, false
+}
+
+// trailingCommaPattern matches a comma before a closing brace or bracket
+var trailingCommaPattern = regexp.MustCompile(`,\s*([}\]])`)
+
+// lineRangePattern matches "12", "12-15" and "L12-L15"
+var lineRangePattern = regexp.MustCompile(`(?i)L?(\d+)(?:\s*[-:]\s*L?(\d+))?`)
+
+func parseFinding(m map[string]interface{}) (ReviewFinding, bool) {
+	finding := ReviewFinding{}
+	finding.File, _ = stringField(m, "file", "path", "filename")
+	finding.Message, _ = stringField(m, "message", "description", "issue", "comment")
+	finding.Category, _ = stringField(m, "category", "type")
+	finding.SuggestedFix, _ = stringField(m, "suggested_fix", "suggestion", "fix")
+	severity, _ := stringField(m, "severity", "level", "priority")
+	finding.Severity = normalizeSeverity(severity)
+
+	if start, ok := numberField(m, "start_line", "line_start", "startline", "line"); ok {
+		finding.StartLine = int(start)
+	} else if text, ok := stringField(m, "line", "lines", "line_range"); ok {
+		if match := lineRangePattern.FindStringSubmatch(text); match != nil {
+			finding.StartLine, _ = strconv.Atoi(match[1])
+			finding.EndLine, _ = strconv.Atoi(match[2])
+		}
+	}
+	if end, ok := numberField(m, "end_line", "line_end", "endline"); ok {
+		finding.EndLine = int(end)
+	}
+	if finding.EndLine < finding.StartLine {
+		finding.EndLine = finding.StartLine
+	}
+
+	finding.File = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(finding.File), "b/"), "./")
+	return finding, finding.Message != ""
+}
+
+// normalizeSeverity maps reviewer wording onto the four severities
+func normalizeSeverity(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "critical", "blocker", "error", "high":
+		return SeverityCritical
+	case "major", "medium", "warning":
+		return SeverityMajor
+	case "suggestion", "nit", "info", "note":
+		return SeveritySuggestion
+	default:
+		return SeverityMinor
+	}
+}
+
+// severityRank orders severities for sorting (lower is more severe)
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 0
+	case SeverityMajor:
+		return 1
+	case SeverityMinor:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func lowerKeys(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		result[strings.ToLower(strings.ReplaceAll(k, "-", "_"))] = v
+	}
+	return result
+}
+
+func stringField(m map[string]interface{}, keys ...string) (string, bool) {
+	for _, key := range keys {
+		if s, ok := m[key].(string); ok && strings.TrimSpace(s) != "" {
+			return strings.TrimSpace(s), true
+		}
+	}
+	return "", false
+}
+
+func numberField(m map[string]interface{}, keys ...string) (float64, bool) {
+	for _, key := range keys {
+		switch v := m[key].(type) {
+		case float64:
+			return v, true
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return f, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// lineRange is an inclusive range of line numbers
+type lineRange = git.LineRange
+
+// parseDiffLines returns, per file, the new-side line ranges added or
+// modified by a unified diff
+func parseDiffLines(diff string) map[string][]lineRange {
+	return (&git.Diff{Files: git.ParsePatch(diff)}).AddedLines()
+}
+
+// ValidateFindings marks which findings overlap the lines added or modified
+// by diff and orders them on-diff first, then by severity. With an empty diff
+// every finding is kept as-is.
+func ValidateFindings(findings []ReviewFinding, diff string) []ReviewFinding {
+	if diff == "" {
+		return findings
+	}
+	return markFindingsOnDiff(findings, parseDiffLines(diff))
+}
+
+// markFindingsOnDiff sets OnDiff from per-file changed line ranges and sorts
+// on-diff findings first, then by severity
+func markFindingsOnDiff(findings []ReviewFinding, changed map[string][]lineRange) []ReviewFinding {
+	result := make([]ReviewFinding, len(findings))
+	for i, finding := range findings {
+		finding.OnDiff = false
+		for _, r := range changed[finding.File] {
+			if finding.StartLine == 0 || (finding.StartLine <= r.End && finding.EndLine >= r.Start) {
+				finding.OnDiff = true
+				break
+			}
+		}
+		result[i] = finding
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].OnDiff != result[j].OnDiff {
+			return result[i].OnDiff
+		}
+		return severityRank(result[i].Severity) < severityRank(result[j].Severity)
+	})
+	return result
+}
+
+// worktreeDiff returns the diff of a worktree relative to HEAD, with
+// untracked files as whole-file additions. Errors yield an empty diff.
+func worktreeDiff(ctx context.Context, worktreePath string) *git.Diff {
+	if worktreePath == "" {
+		return &git.Diff{}
+	}
+	diff, err := git.Open(worktreePath).Diff(ctx, git.DiffOptions{From: "HEAD", IncludeUntracked: true})
+	if err != nil {
+		return &git.Diff{}
+	}
+	return diff
+}
+
+// worktreeChangedLines returns the changed line ranges of a worktree relative
+// to HEAD. Untracked files count as changed in full.
+func worktreeChangedLines(ctx context.Context, worktreePath string) map[string][]lineRange {
+	return worktreeDiff(ctx, worktreePath).AddedLines()
+}
+
+// maxPromptHunks caps the hunk headers listed per file in a reviewer prompt
+const maxPromptHunks = 20
+
+// formatDiffHunks summarizes a diff for reviewer prompts: one line per file
+// with its status and line counts, followed by its hunk headers so findings
+// can be anchored to new-side line numbers
+func formatDiffHunks(diff *git.Diff) string {
+	var sb strings.Builder
+	for _, file := range diff.Files {
+		sb.WriteString(fmt.Sprintf("- %s (%s, +%d -%d)", file.Path(), diffStatusName(file.Status), file.Insertions, file.Deletions))
+		if file.Status == git.FileRenamed || file.Status == git.FileCopied {
+			sb.WriteString(fmt.Sprintf(" from %s", file.OldPath))
+		}
+		if file.Binary {
+			sb.WriteString(" binary")
+		}
+		sb.WriteString("\n")
+		for i, hunk := range file.Hunks {
+			if i == maxPromptHunks {
+				sb.WriteString(fmt.Sprintf("  ... %d more hunks\n", len(file.Hunks)-i))
+				break
+			}
+			header := fmt.Sprintf("  @@ -%d,%d +%d,%d @@ %s", hunk.OldStart, hunk.OldLines, hunk.NewStart, hunk.NewLines, hunk.Section)
+			sb.WriteString(strings.TrimRight(header, " ") + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// diffStatusName spells out a file status for prompts
+func diffStatusName(status git.FileStatus) string {
+	switch status {
+	case git.FileAdded:
+		return "added"
+	case git.FileDeleted:
+		return "deleted"
+	case git.FileRenamed:
+		return "renamed"
+	case git.FileCopied:
+		return "copied"
+	default:
+		return "modified"
+	}
+}
+
+// collectFindings gathers the findings of non-approving votes
+func collectFindings(votes []ReviewVote) []ReviewFinding {
+	var findings []ReviewFinding
+	for _, vote := range votes {
+		if vote.Vote == VoteApprove {
+			continue
+		}
+		findings = append(findings, vote.Findings...)
+	}
+	return findings
+}
+
+// FormatFindingsForFix renders findings as a numbered list of targeted fixes.
+// When some findings are on the diff, the others are listed separately as
+// lower priority.
+func FormatFindingsForFix(findings []ReviewFinding) string {
+	if len(findings) == 0 {
+		return ""
+	}
+	var onDiff, outside []ReviewFinding
+	for _, finding := range findings {
+		if finding.OnDiff {
+			onDiff = append(onDiff, finding)
+		} else {
+			outside = append(outside, finding)
+		}
+	}
+	if len(onDiff) == 0 {
+		onDiff, outside = outside, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Fix these specific findings (file:lines [severity/category]):\n")
+	n := writeFindingList(&sb, onDiff, 0)
+	if len(outside) > 0 {
+		sb.WriteString("\nOutside the changed lines (fix only if directly related):\n")
+		writeFindingList(&sb, outside, n)
+	}
+	return sb.String()
+}
+
+func writeFindingList(sb *strings.Builder, findings []ReviewFinding, n int) int {
+	for _, finding := range findings {
+		n++
+		category := finding.Category
+		if category == "" {
+			category = "general"
+		}
+		sb.WriteString(fmt.Sprintf("%d. %s [%s/%s] %s\n", n, finding.Location(), finding.Severity, category, finding.Message))
+		if finding.SuggestedFix != "" {
+			sb.WriteString(fmt.Sprintf("   Suggested fix: %s\n", finding.SuggestedFix))
+		}
+	}
+	return n
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"open-swarm/internal/git"
+)
+
+const structuredReviewOutput = "Here is my review.\n\n```json\n" + `{
+  "Vote": "request changes",
+  "confidence": 80,
+  "summary": "Nil map write and a missing test.",
+  "findings": [
+    {"file": "b/pkg/cache/cache.go", "start_line": 12, "end_line": 14, "severity": "high",
+     "category": "logic", "message": "writes to a nil map", "suggested_fix": "initialize in New"},
+    {"path": "pkg/cache/cache_test.go", "lines": "L40-L42", "severity": "nit", "message": "assert the error {value}"},
+    {"file": "pkg/cache/cache.go", "line": 3, "message": ""},
+  ]
+}` + "\n```\n"
+
+func TestVoteParser_ParseStructuredReview(t *testing.T) {
+	review, ok := NewVoteParser().ParseStructuredReview(structuredReviewOutput)
+	require.True(t, ok)
+
+	assert.Equal(t, VoteRequestChange, review.Vote)
+	assert.InDelta(t, 0.8, review.Confidence, 0.001)
+	assert.Equal(t, "Nil map write and a missing test.", review.Summary)
+	require.Len(t, review.Findings, 2, "findings without a message are dropped")
+
+	first := review.Findings[0]
+	assert.Equal(t, "pkg/cache/cache.go", first.File)
+	assert.Equal(t, 12, first.StartLine)
+	assert.Equal(t, 14, first.EndLine)
+	assert.Equal(t, SeverityCritical, first.Severity)
+	assert.Equal(t, "initialize in New", first.SuggestedFix)
+
+	second := review.Findings[1]
+	assert.Equal(t, 40, second.StartLine)
+	assert.Equal(t, 42, second.EndLine)
+	assert.Equal(t, SeveritySuggestion, second.Severity)
+	assert.Equal(t, "pkg/cache/cache_test.go:40-42", second.Location())
+}
+
+func TestVoteParser_ParseStructuredReview_FreeText(t *testing.T) {
+	_, ok := NewVoteParser().ParseStructuredReview("VOTE: APPROVE\nLooks good, map{} usage is fine.")
+	assert.False(t, ok)
+}
+
+func TestAgentResultParser_ExtractJSONObjects(t *testing.T) {
+	objects := NewAgentResultParser().ExtractJSONObjects(`a {"x": "}"} b {"y": {"z": 1}} }`)
+	assert.Equal(t, []string{`{"x": "}"}`, `{"y": {"z": 1}}`}, objects)
+}
+
+const reviewDiff = `diff --git a/pkg/cache/cache.go b/pkg/cache/cache.go
+--- a/pkg/cache/cache.go
++++ b/pkg/cache/cache.go
+@@ -10,4 +10,6 @@ type Cache struct {
+ func New() *Cache {
+-	return &Cache{}
++	c := &Cache{}
++	c.items["a"] = 1
++	return c
+ }
+`
+
+func TestValidateFindings(t *testing.T) {
+	changed := parseDiffLines(reviewDiff)
+	assert.Equal(t, []lineRange{{Start: 11, End: 13}}, changed["pkg/cache/cache.go"])
+
+	findings := ValidateFindings([]ReviewFinding{
+		{File: "pkg/cache/cache.go", StartLine: 30, EndLine: 30, Severity: SeverityCritical, Message: "unrelated"},
+		{File: "pkg/cache/cache.go", StartLine: 12, EndLine: 12, Severity: SeverityMinor, Message: "nil map"},
+		{File: "pkg/other/other.go", StartLine: 1, EndLine: 1, Severity: SeverityMajor, Message: "elsewhere"},
+	}, reviewDiff)
+
+	require.Len(t, findings, 3)
+	assert.Equal(t, "nil map", findings[0].Message)
+	assert.True(t, findings[0].OnDiff)
+	assert.False(t, findings[1].OnDiff)
+	assert.Equal(t, "unrelated", findings[1].Message, "off-diff findings are ordered by severity")
+}
+
+func TestFormatDiffHunks(t *testing.T) {
+	diff := &git.Diff{Files: git.ParsePatch(reviewDiff + `diff --git a/old.go b/new.go
+similarity index 100%
+rename from old.go
+rename to new.go
+`)}
+
+	text := formatDiffHunks(diff)
+	assert.Contains(t, text, "- pkg/cache/cache.go (modified, +3 -1)\n  @@ -10,4 +10,6 @@ type Cache struct {\n")
+	assert.Contains(t, text, "- new.go (renamed, +0 -0) from old.go\n")
+	assert.Empty(t, formatDiffHunks(&git.Diff{}))
+}
+
+func TestFormatFindingsForFix(t *testing.T) {
+	text := FormatFindingsForFix([]ReviewFinding{
+		{File: "a.go", StartLine: 3, EndLine: 5, Severity: SeverityMajor, Category: "logic", Message: "off by one", SuggestedFix: "use <=", OnDiff: true},
+		{File: "b.go", StartLine: 9, EndLine: 9, Severity: SeverityMinor, Message: "naming"},
+	})
+
+	assert.Contains(t, text, "1. a.go:3-5 [major/logic] off by one")
+	assert.Contains(t, text, "Suggested fix: use <=")
+	assert.Contains(t, text, "Outside the changed lines")
+	assert.Contains(t, text, "2. b.go:9 [minor/general] naming")
+	assert.Empty(t, FormatFindingsForFix(nil))
+}
+
+func TestExtractReviewerFeedback_UsesFindings(t *testing.T) {
+	result := &GateResult{ReviewVotes: []ReviewVote{
+		{ReviewerName: "reviewer-1", Vote: VoteApprove, Feedback: "fine"},
+		{ReviewerName: "reviewer-2", Vote: VoteRequestChange, Feedback: "{...raw json...}", Summary: "one bug",
+			Findings: []ReviewFinding{{File: "a.go", StartLine: 4, EndLine: 4, Severity: SeverityMajor, Message: "nil deref", OnDiff: true}}},
+		{ReviewerName: "reviewer-3", Vote: VoteRequestChange, Feedback: "please add docs"},
+	}}
+
+	feedback := extractReviewerFeedback(result)
+	assert.Contains(t, feedback, "a.go:4 [major/general] nil deref")
+	assert.Contains(t, feedback, "reviewer-2 (REQUEST_CHANGE): one bug")
+	assert.Contains(t, feedback, "please add docs")
+	assert.NotContains(t, feedback, "raw json")
+	assert.NotContains(t, feedback, "fine")
+}
+
+func TestReviewExport(t *testing.T) {
+	findings := []ReviewFinding{
+		{File: "a.go", StartLine: 3, EndLine: 5, Severity: SeverityCritical, Category: "security", Message: "shell injection", Reviewer: "reviewer-4", OnDiff: true},
+		{File: "b.go", StartLine: 7, EndLine: 7, Severity: SeveritySuggestion, Message: "rename", OnDiff: true},
+		{File: "c.go", StartLine: 40, EndLine: 40, Severity: SeverityMinor, Message: "unrelated"},
+		{Message: "no anchor"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteReviewComments(&buf, findings))
+	var review ReviewSubmission
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &review))
+	comments := review.Comments
+	require.Len(t, comments, 2, "only findings on the diff become line comments")
+	assert.Equal(t, ReviewComment{Path: "a.go", StartLine: 3, Line: 5, Side: "RIGHT", Body: comments[0].Body}, comments[0])
+	assert.Equal(t, 7, comments[1].Line)
+	assert.Zero(t, comments[1].StartLine)
+	assert.Contains(t, review.Body, "- c.go:40 [minor/general] unrelated")
+	assert.Contains(t, review.Body, "- (no file) [/general] no anchor")
+
+	log := NewSARIFLog()
+	log.Runs = append(log.Runs, ReviewFindingsSARIF(findings))
+	buf.Reset()
+	require.NoError(t, WriteSARIF(&buf, log))
+	assert.Contains(t, buf.String(), `"version": "2.1.0"`)
+
+	run := log.Runs[0]
+	require.Len(t, run.Results, 4)
+	assert.Equal(t, "review/security", run.Results[0].RuleID)
+	assert.Equal(t, "error", run.Results[0].Level)
+	assert.Equal(t, 5, run.Results[0].Locations[0].PhysicalLocation.Region.EndLine)
+	assert.Equal(t, "note", run.Results[1].Level)
+	assert.Empty(t, run.Results[3].Locations)
+	assert.Len(t, run.Tool.Driver.Rules, 2)
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReviewPolicyKind selects how reviewer votes are combined
+type ReviewPolicyKind string
+
+const (
+	// ReviewPolicyUnanimous requires every reviewer to approve (default)
+	ReviewPolicyUnanimous ReviewPolicyKind = "unanimous"
+	// ReviewPolicyMajority requires more than half of the reviewers to approve
+	ReviewPolicyMajority ReviewPolicyKind = "majority"
+	// ReviewPolicyWeighted weights votes by review type and reviewer confidence
+	ReviewPolicyWeighted ReviewPolicyKind = "weighted"
+	// ReviewPolicyVeto lets designated review types block; the rest decide by majority
+	ReviewPolicyVeto ReviewPolicyKind = "veto"
+)
+
+// defaultReviewerModel is used when a policy does not pick a model for a review type
+const defaultReviewerModel = "anthropic/claude-haiku-4-5"
+
+// defaultWeightedThreshold is the approval share a weighted review needs
+const defaultWeightedThreshold = 0.6
+
+// ReviewPolicy configures how the review gate reaches a decision
+type ReviewPolicy struct {
+	Kind      ReviewPolicyKind
+	Weights   map[ReviewType]float64 // Weighted: per review type (default 1)
+	Threshold float64                // Weighted: approval share required (default 0.6)
+	VetoTypes []ReviewType           // Veto: review types that can block (default security)
+	Models    map[ReviewType]string  // Model per review type (default: routed, else claude-haiku-4-5)
+}
+
+// ReviewOutcome is the verdict of a review round
+type ReviewOutcome string
+
+const (
+	// ReviewOutcomeApproved lets the change be committed
+	ReviewOutcomeApproved ReviewOutcome = "approved"
+	// ReviewOutcomeRequestChange asks for a targeted fix of the current implementation
+	ReviewOutcomeRequestChange ReviewOutcome = "request_change"
+	// ReviewOutcomeRejected discards the implementation and forces full regeneration
+	ReviewOutcomeRejected ReviewOutcome = "rejected"
+)
+
+// ReviewDecision records how a policy judged a set of votes
+type ReviewDecision struct {
+	Policy         ReviewPolicyKind
+	Outcome        ReviewOutcome
+	ApprovalScore  float64 // Approving share of the (weighted) votes
+	Approvals      int
+	RequestChanges int
+	Rejections     int
+	VetoedBy       string // Reviewer whose veto decided the outcome
+	Reason         string
+}
+
+// Approved reports whether the decision lets the change through
+func (d *ReviewDecision) Approved() bool {
+	return d != nil && d.Outcome == ReviewOutcomeApproved
+}
+
+// ParseReviewPolicyKind parses a policy name; empty selects unanimous
+func ParseReviewPolicyKind(s string) (ReviewPolicyKind, error) {
+	switch kind := ReviewPolicyKind(strings.ToLower(strings.TrimSpace(s))); kind {
+	case "":
+		return ReviewPolicyUnanimous, nil
+	case ReviewPolicyUnanimous, ReviewPolicyMajority, ReviewPolicyWeighted, ReviewPolicyVeto:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("unknown review policy %q (want unanimous, majority, weighted or veto)", s)
+	}
+}
+
+// ModelFor returns the model a reviewer of the given type should use
+func (p ReviewPolicy) ModelFor(reviewType ReviewType) string {
+	if model := p.Models[reviewType]; model != "" {
+		return model
+	}
+	return defaultReviewerModel
+}
+
+// Decide combines votes into a single outcome according to the policy
+func (p ReviewPolicy) Decide(votes []ReviewVote) ReviewDecision {
+	kind := p.Kind
+	if kind == "" {
+		kind = ReviewPolicyUnanimous
+	}
+	decision := ReviewDecision{Policy: kind}
+
+	for _, vote := range votes {
+		switch vote.Vote {
+		case VoteApprove:
+			decision.Approvals++
+		case VoteRequestChange:
+			decision.RequestChanges++
+		case VoteReject:
+			decision.Rejections++
+		}
+	}
+	if len(votes) == 0 {
+		decision.Outcome = ReviewOutcomeRequestChange
+		decision.Reason = "no reviewer votes"
+		return decision
+	}
+
+	switch kind {
+	case ReviewPolicyWeighted:
+		decision.ApprovalScore = p.weightedShare(votes, VoteApprove)
+		threshold := p.Threshold
+		if threshold <= 0 {
+			threshold = defaultWeightedThreshold
+		}
+		if decision.ApprovalScore >= threshold {
+			decision.Outcome = ReviewOutcomeApproved
+			decision.Reason = fmt.Sprintf("weighted approval %.2f meets threshold %.2f", decision.ApprovalScore, threshold)
+			return decision
+		}
+		decision.Outcome = p.failureOutcome(votes, true)
+		decision.Reason = fmt.Sprintf("weighted approval %.2f below threshold %.2f", decision.ApprovalScore, threshold)
+		return decision
+
+	case ReviewPolicyVeto:
+		vetoTypes := p.VetoTypes
+		if len(vetoTypes) == 0 {
+			vetoTypes = []ReviewType{ReviewTypeSecurity}
+		}
+		for _, vote := range votes {
+			if vote.Vote == VoteApprove || !containsReviewType(vetoTypes, vote.ReviewType) {
+				continue
+			}
+			decision.VetoedBy = vote.ReviewerName
+			decision.ApprovalScore = float64(decision.Approvals) / float64(len(votes))
+			decision.Outcome = ReviewOutcomeRequestChange
+			if vote.Vote == VoteReject {
+				decision.Outcome = ReviewOutcomeRejected
+			}
+			decision.Reason = fmt.Sprintf("%s (%s) vetoed with %s", vote.ReviewerName, vote.ReviewType, vote.Vote)
+			return decision
+		}
+		fallthrough
+
+	case ReviewPolicyMajority:
+		decision.ApprovalScore = float64(decision.Approvals) / float64(len(votes))
+		if decision.Approvals*2 > len(votes) {
+			decision.Outcome = ReviewOutcomeApproved
+			decision.Reason = fmt.Sprintf("%d of %d reviewers approved", decision.Approvals, len(votes))
+			return decision
+		}
+		decision.Outcome = p.failureOutcome(votes, false)
+		decision.Reason = fmt.Sprintf("only %d of %d reviewers approved", decision.Approvals, len(votes))
+		return decision
+
+	default:
+		decision.ApprovalScore = float64(decision.Approvals) / float64(len(votes))
+		if decision.Approvals == len(votes) {
+			decision.Outcome = ReviewOutcomeApproved
+			decision.Reason = "all reviewers approved"
+			return decision
+		}
+		decision.Outcome = p.failureOutcome(votes, false)
+		decision.Reason = fmt.Sprintf("%d of %d reviewers did not approve", len(votes)-decision.Approvals, len(votes))
+		return decision
+	}
+}
+
+// failureOutcome picks between a targeted fix and full regeneration: the
+// change is rejected when rejecting votes carry at least as much weight as
+// change requests
+func (p ReviewPolicy) failureOutcome(votes []ReviewVote, weighted bool) ReviewOutcome {
+	var rejects, changes float64
+	for _, vote := range votes {
+		w := 1.0
+		if weighted {
+			w = p.voteWeight(vote)
+		}
+		switch vote.Vote {
+		case VoteReject:
+			rejects += w
+		case VoteRequestChange:
+			changes += w
+		}
+	}
+	if rejects > 0 && rejects >= changes {
+		return ReviewOutcomeRejected
+	}
+	return ReviewOutcomeRequestChange
+}
+
+// weightedShare returns the weighted fraction of votes with the given result
+func (p ReviewPolicy) weightedShare(votes []ReviewVote, result VoteResult) float64 {
+	var total, matched float64
+	for _, vote := range votes {
+		w := p.voteWeight(vote)
+		total += w
+		if vote.Vote == result {
+			matched += w
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return matched / total
+}
+
+// voteWeight is the review type weight scaled by the reviewer's confidence.
+// Failed reviewers weigh nothing; votes without a confidence count in full.
+func (p ReviewPolicy) voteWeight(vote ReviewVote) float64 {
+	if vote.Failed {
+		return 0
+	}
+	w, ok := p.Weights[vote.ReviewType]
+	if !ok {
+		w = 1
+	}
+	if vote.Confidence > 0 {
+		w *= vote.Confidence
+	}
+	return w
+}
+
+func containsReviewType(types []ReviewType, t ReviewType) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+	return false
+}
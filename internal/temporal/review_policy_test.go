@@ -0,0 +1,185 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func reviewVotes(votes ...VoteResult) []ReviewVote {
+	types := []ReviewType{ReviewTypeTesting, ReviewTypeFunctional, ReviewTypeArchitecture, ReviewTypeSecurity}
+	result := make([]ReviewVote, len(votes))
+	for i, v := range votes {
+		result[i] = ReviewVote{ReviewerName: types[i%len(types)].reviewerName(), ReviewType: types[i%len(types)], Vote: v}
+	}
+	return result
+}
+
+func (t ReviewType) reviewerName() string { return "reviewer-" + string(t) }
+
+func TestReviewPolicy_Unanimous(t *testing.T) {
+	policy := ReviewPolicy{}
+
+	decision := policy.Decide(reviewVotes(VoteApprove, VoteApprove, VoteApprove))
+	assert.Equal(t, ReviewOutcomeApproved, decision.Outcome)
+	assert.Equal(t, ReviewPolicyUnanimous, decision.Policy)
+
+	decision = policy.Decide(reviewVotes(VoteApprove, VoteRequestChange, VoteApprove))
+	assert.Equal(t, ReviewOutcomeRequestChange, decision.Outcome, "a nit asks for a targeted fix")
+
+	decision = policy.Decide(reviewVotes(VoteApprove, VoteRequestChange, VoteReject))
+	assert.Equal(t, ReviewOutcomeRejected, decision.Outcome, "a reject forces regeneration")
+
+	assert.Equal(t, ReviewOutcomeRequestChange, policy.Decide(nil).Outcome)
+}
+
+func TestReviewPolicy_Majority(t *testing.T) {
+	policy := ReviewPolicy{Kind: ReviewPolicyMajority}
+
+	decision := policy.Decide(reviewVotes(VoteApprove, VoteRequestChange, VoteApprove))
+	assert.True(t, decision.Approved(), "one flaky reviewer no longer blocks")
+	assert.InDelta(t, 2.0/3.0, decision.ApprovalScore, 0.001)
+
+	decision = policy.Decide(reviewVotes(VoteApprove, VoteReject))
+	assert.Equal(t, ReviewOutcomeRejected, decision.Outcome, "a tie is not a majority")
+}
+
+func TestReviewPolicy_Weighted(t *testing.T) {
+	policy := ReviewPolicy{
+		Kind:    ReviewPolicyWeighted,
+		Weights: map[ReviewType]float64{ReviewTypeTesting: 1, ReviewTypeFunctional: 3, ReviewTypeArchitecture: 1},
+	}
+
+	votes := reviewVotes(VoteRequestChange, VoteApprove, VoteRequestChange)
+	decision := policy.Decide(votes)
+	assert.True(t, decision.Approved())
+	assert.InDelta(t, 0.6, decision.ApprovalScore, 0.001)
+
+	// Low confidence shrinks a vote's weight
+	votes[1].Confidence = 0.2
+	decision = policy.Decide(votes)
+	assert.Equal(t, ReviewOutcomeRequestChange, decision.Outcome)
+	assert.Less(t, decision.ApprovalScore, 0.6)
+}
+
+func TestReviewPolicy_WeightedIgnoresFailedReviewers(t *testing.T) {
+	policy := ReviewPolicy{Kind: ReviewPolicyWeighted}
+
+	votes := reviewVotes(VoteApprove, VoteApprove, VoteRequestChange)
+	votes[2].Failed = true
+	votes[2].Confidence = 0
+	decision := policy.Decide(votes)
+	assert.True(t, decision.Approved(), "a reviewer that errored should not outvote working reviewers")
+	assert.InDelta(t, 1.0, decision.ApprovalScore, 0.001)
+
+	votes = reviewVotes(VoteApprove, VoteRequestChange)
+	votes[0].Failed = true
+	decision = policy.Decide(votes)
+	assert.Equal(t, ReviewOutcomeRequestChange, decision.Outcome)
+	assert.InDelta(t, 0.0, decision.ApprovalScore, 0.001, "a failed approval carries no weight")
+
+	for i := range votes {
+		votes[i].Failed = true
+	}
+	decision = policy.Decide(votes)
+	assert.Equal(t, ReviewOutcomeRequestChange, decision.Outcome, "all reviewers failing asks for another round")
+}
+
+func TestReviewPolicy_SecurityVeto(t *testing.T) {
+	policy := ReviewPolicy{Kind: ReviewPolicyVeto}
+
+	decision := policy.Decide(reviewVotes(VoteApprove, VoteApprove, VoteApprove, VoteReject))
+	assert.Equal(t, ReviewOutcomeRejected, decision.Outcome)
+	assert.Equal(t, "reviewer-security", decision.VetoedBy)
+
+	decision = policy.Decide(reviewVotes(VoteApprove, VoteRequestChange, VoteApprove, VoteApprove))
+	assert.True(t, decision.Approved(), "non-veto reviewers decide by majority")
+}
+
+func TestParseReviewPolicyKind(t *testing.T) {
+	kind, err := ParseReviewPolicyKind("")
+	require.NoError(t, err)
+	assert.Equal(t, ReviewPolicyUnanimous, kind)
+
+	kind, err = ParseReviewPolicyKind(" Veto ")
+	require.NoError(t, err)
+	assert.Equal(t, ReviewPolicyVeto, kind)
+
+	_, err = ParseReviewPolicyKind("dictator")
+	assert.Error(t, err)
+}
+
+func TestReviewPolicy_ModelFor(t *testing.T) {
+	policy := ReviewPolicy{Models: map[ReviewType]string{ReviewTypeSecurity: "anthropic/claude-opus-4"}}
+	assert.Equal(t, "anthropic/claude-opus-4", policy.ModelFor(ReviewTypeSecurity))
+	assert.Equal(t, defaultReviewerModel, policy.ModelFor(ReviewTypeTesting))
+}
+
+// TestEnhancedTCR_RejectForcesRegeneration verifies REJECT skips targeted fixes
+func TestEnhancedTCR_RejectForcesRegeneration(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+
+	cellActivities := &CellActivities{}
+	enhancedActivities := &EnhancedActivities{}
+
+	env.OnActivity(cellActivities.BootstrapCell, mock.Anything, mock.Anything).Return(&BootstrapOutput{CellID: "cell-reject"}, nil)
+	env.OnActivity(enhancedActivities.AcquireFileLocks, mock.Anything, mock.Anything, mock.Anything).Return([]string{"a.go"}, nil)
+	env.OnActivity(enhancedActivities.ExecuteGenTest, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{GateName: "GenTest", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteLintTest, mock.Anything, mock.Anything).Return(&GateResult{GateName: "LintTest", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteVerifyRED, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{GateName: "VerifyRED", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{GateName: "GenImpl", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{GateName: "VerifyGREEN", Passed: true}, nil)
+
+	var policies []ReviewPolicy
+	reviews := 0
+	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, _ *BootstrapOutput, _ string, _ string, _ int, _ int, policy ReviewPolicy) (*GateResult, error) {
+			policies = append(policies, policy)
+			reviews++
+			if reviews == 1 {
+				decision := policy.Decide(reviewVotes(VoteApprove, VoteReject))
+				return &GateResult{GateName: "multi_review", Passed: false, ReviewDecision: &decision}, nil
+			}
+			decision := policy.Decide(reviewVotes(VoteApprove, VoteApprove))
+			return &GateResult{GateName: "multi_review", Passed: true, ReviewDecision: &decision}, nil
+		})
+
+	env.OnActivity(enhancedActivities.ExecuteFixFromFeedback, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "Fix", Passed: true}, nil).Maybe()
+	env.OnActivity(cellActivities.RevertChanges, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CommitChanges, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CellHead, mock.Anything, mock.Anything).Return(&CellHeadOutput{}, nil).Maybe()
+	env.OnActivity(enhancedActivities.ReleaseFileLocks, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.TeardownCell, mock.Anything, mock.Anything).Return(nil)
+
+	env.ExecuteWorkflow(EnhancedTCRWorkflow, EnhancedTCRInput{
+		TaskID:         "task-reject",
+		CellID:         "cell-reject",
+		Description:    "reject then approve",
+		ReviewersCount: 2,
+		ReviewPolicy:   ReviewPolicy{Kind: ReviewPolicyMajority},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result *EnhancedTCRResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.True(t, result.Success)
+	env.AssertNotCalled(t, "ExecuteFixFromFeedback", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	env.AssertNumberOfCalls(t, "ExecuteGenImpl", 2)
+	env.AssertNumberOfCalls(t, "RevertChanges", 1)
+	require.Len(t, policies, 2)
+	assert.Equal(t, ReviewPolicyMajority, policies[0].Kind, "policy is passed to the review activity")
+}
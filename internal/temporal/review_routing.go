@@ -0,0 +1,143 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"open-swarm/internal/git"
+	"open-swarm/internal/opencode"
+)
+
+// generalReviewTypes are assigned round-robin to the configured reviewers
+var generalReviewTypes = []ReviewType{ReviewTypeTesting, ReviewTypeFunctional, ReviewTypeArchitecture}
+
+// securitySensitivePaths mark files whose changes get a security reviewer
+var securitySensitivePaths = []string{
+	"auth", "crypt", "secret", "token", "passw", "credential", "login", "permission",
+	"exec", "shell", "command", "sql", "query", "http", "handler", "middleware", "upload", "sanitiz",
+}
+
+// performanceSensitivePaths mark files whose changes get a performance reviewer
+var performanceSensitivePaths = []string{
+	"cache", "pool", "queue", "worker", "concurren", "parallel", "batch", "buffer",
+	"stream", "index", "bench", "lock", "scheduler",
+}
+
+// specializedReviewTypes returns the extra review types a change calls for:
+// security and performance by path, API compatibility for exported Go packages
+func specializedReviewTypes(files []string) []ReviewType {
+	var security, performance, api bool
+	for _, file := range files {
+		lower := strings.ToLower(filepath.ToSlash(file))
+		security = security || containsAny(lower, securitySensitivePaths)
+		performance = performance || containsAny(lower, performanceSensitivePaths)
+		api = api || isPublicGoFile(lower)
+	}
+
+	var types []ReviewType
+	if security {
+		types = append(types, ReviewTypeSecurity)
+	}
+	if performance {
+		types = append(types, ReviewTypePerformance)
+	}
+	if api {
+		types = append(types, ReviewTypeAPICompatibility)
+	}
+	return types
+}
+
+// reviewTypesForChange assigns a review type to reviewer slots
+// firstReviewer..firstReviewer+reviewersCount-1 of a round: general reviewers,
+// followed by one reviewer per specialization the change needs. The
+// specializations go with slot 0, so a round split across activities gets
+// them once.
+func reviewTypesForChange(firstReviewer, reviewersCount int, files []string) []ReviewType {
+	types := make([]ReviewType, 0, reviewersCount+3)
+	for i := 0; i < reviewersCount; i++ {
+		types = append(types, generalReviewTypes[(firstReviewer+i)%len(generalReviewTypes)])
+	}
+	if firstReviewer != 0 {
+		return types
+	}
+	return append(types, specializedReviewTypes(files)...)
+}
+
+// isPublicGoFile reports whether a file belongs to a package other modules can import
+func isPublicGoFile(path string) bool {
+	if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+		return false
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "internal" || segment == "cmd" || segment == "testdata" {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAny(s string, needles []string) bool {
+	for _, needle := range needles {
+		if strings.Contains(s, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiChangesForFiles diffs the exported symbols of changed public Go files
+// against HEAD in the worktree and returns a summary for the compatibility reviewer
+func apiChangesForFiles(ctx context.Context, worktreePath string, files []string) string {
+	analyzer := &opencode.DefaultCodeAnalyzer{}
+	repo := git.Open(worktreePath)
+	var sb strings.Builder
+
+	for _, file := range files {
+		if !isPublicGoFile(strings.ToLower(filepath.ToSlash(file))) {
+			continue
+		}
+
+		afterPath := filepath.Join(worktreePath, file)
+		if _, err := os.Stat(afterPath); err != nil {
+			afterPath = ""
+		}
+
+		beforePath := ""
+		base, err := repo.ReadFile(ctx, "HEAD", filepath.ToSlash(file))
+		if err == nil {
+			tmp, err := os.CreateTemp("", "api-base-*.go")
+			if err != nil {
+				continue
+			}
+			_, writeErr := tmp.Write(base)
+			tmp.Close()
+			if writeErr != nil {
+				os.Remove(tmp.Name())
+				continue
+			}
+			beforePath = tmp.Name()
+		}
+
+		if beforePath == "" && afterPath == "" {
+			continue
+		}
+		diff, err := analyzer.DiffExportedAPI(ctx, beforePath, afterPath)
+		if beforePath != "" {
+			os.Remove(beforePath)
+		}
+		if err != nil {
+			continue
+		}
+		diff.FilePath = file
+		sb.WriteString(diff.Summary())
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewTypesForChange(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int
+		files []string
+		want  []ReviewType
+	}{
+		{
+			name:  "internal docs only",
+			count: 3,
+			files: []string{"README.md", "internal/foo/foo.go"},
+			want:  []ReviewType{ReviewTypeTesting, ReviewTypeFunctional, ReviewTypeArchitecture},
+		},
+		{
+			name:  "security sensitive",
+			count: 1,
+			files: []string{"internal/auth/session.go"},
+			want:  []ReviewType{ReviewTypeTesting, ReviewTypeSecurity},
+		},
+		{
+			name:  "performance sensitive",
+			count: 2,
+			files: []string{"internal/opencode/server_pool.go"},
+			want:  []ReviewType{ReviewTypeTesting, ReviewTypeFunctional, ReviewTypePerformance},
+		},
+		{
+			name:  "public package",
+			count: 1,
+			files: []string{"pkg/dag/dag.go", "pkg/dag/dag_test.go"},
+			want:  []ReviewType{ReviewTypeTesting, ReviewTypeAPICompatibility},
+		},
+		{
+			name:  "tests in public package",
+			count: 1,
+			files: []string{"pkg/dag/dag_test.go"},
+			want:  []ReviewType{ReviewTypeTesting},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, reviewTypesForChange(0, tt.count, tt.files))
+		})
+	}
+}
+
+func TestReviewTypesForChange_SplitRound(t *testing.T) {
+	files := []string{"internal/auth/session.go", "pkg/dag/dag.go"}
+
+	// A parallel round runs one reviewer slot per activity
+	var round []ReviewType
+	for slot := 0; slot < 3; slot++ {
+		round = append(round, reviewTypesForChange(slot, 1, files)...)
+	}
+	assert.ElementsMatch(t, reviewTypesForChange(0, 3, files), round)
+	assert.Equal(t, []ReviewType{ReviewTypeFunctional}, reviewTypesForChange(1, 1, files))
+}
+
+func TestGetReviewerRouting_SpecializedTypes(t *testing.T) {
+	assert.Equal(t, "reviewer-security", getReviewerAgent(ReviewTypeSecurity))
+	assert.Equal(t, "reviewer-performance", getReviewerAgent(ReviewTypePerformance))
+	assert.Equal(t, "reviewer-api-compatibility", getReviewerAgent(ReviewTypeAPICompatibility))
+	assert.Contains(t, getReviewFocus(ReviewTypeSecurity), "path traversal")
+	assert.Contains(t, getReviewFocus(ReviewTypePerformance), "lock contention")
+}
+
+func TestReviewerAgentsDefinedInOpenCodeConfig(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "opencode.json"))
+	require.NoError(t, err)
+	var config struct {
+		Agent map[string]json.RawMessage `json:"agent"`
+	}
+	require.NoError(t, json.Unmarshal(data, &config))
+
+	// A reviewer routed to an undefined agent fails and blocks the change
+	reviewTypes := append(append([]ReviewType{}, generalReviewTypes...),
+		ReviewTypeSecurity, ReviewTypePerformance, ReviewTypeAPICompatibility)
+	for _, reviewType := range reviewTypes {
+		assert.Contains(t, config.Agent, getReviewerAgent(reviewType), "agent for %s review", reviewType)
+	}
+}
+
+func TestAPIChangesForFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	file := filepath.Join(dir, "pkg", "lib", "lib.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+	require.NoError(t, os.WriteFile(file, []byte("package lib\n\nfunc Old() {}\n\nfunc Keep(a int) {}\n"), 0o600))
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "base")
+
+	require.NoError(t, os.WriteFile(file, []byte("package lib\n\nfunc Keep(a, b int) {}\n\nfunc New() {}\n"), 0o600))
+
+	summary := apiChangesForFiles(context.Background(), dir, []string{"pkg/lib/lib.go", "internal/x/x.go"})
+	assert.Contains(t, summary, "pkg/lib/lib.go:")
+	assert.Contains(t, summary, "- function Old")
+	assert.Contains(t, summary, "+ function New")
+	assert.Contains(t, summary, "~ function Keep")
+}
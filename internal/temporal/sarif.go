@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// SARIF 2.1.0 types, limited to the fields code-scanning tools read
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFLog is the root of a SARIF document
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is the output of a single tool
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool identifies the tool that produced a run
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver describes the tool and the rules it reports
+type SARIFDriver struct {
+	Name  string      `json:"name"`
+	Rules []SARIFRule `json:"rules,omitempty"`
+}
+
+// SARIFRule describes a rule referenced by results
+type SARIFRule struct {
+	ID               string     `json:"id"`
+	ShortDescription *SARIFText `json:"shortDescription,omitempty"`
+	Properties       *SARIFBag  `json:"properties,omitempty"`
+}
+
+// SARIFBag holds free-form properties
+type SARIFBag struct {
+	Tags []string `json:"tags,omitempty"`
+}
+
+// SARIFResult is a single reported problem
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"` // error, warning or note
+	Message   SARIFText       `json:"message"`
+	Locations []SARIFLocation `json:"locations,omitempty"`
+}
+
+// SARIFText is a SARIF message string
+type SARIFText struct {
+	Text string `json:"text"`
+}
+
+// SARIFLocation anchors a result in a file
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation is a file and optional region
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is a repository-relative file URI
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion is a line range within a file
+type SARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	EndLine     int `json:"endLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// NewSARIFLog creates an empty SARIF document
+func NewSARIFLog() *SARIFLog {
+	return &SARIFLog{Version: sarifVersion, Schema: sarifSchema, Runs: []SARIFRun{}}
+}
+
+// newSARIFLocation builds a location; line 0 omits the region
+func newSARIFLocation(file string, startLine, endLine int) []SARIFLocation {
+	if file == "" {
+		return nil
+	}
+	location := SARIFLocation{PhysicalLocation: SARIFPhysicalLocation{
+		ArtifactLocation: SARIFArtifactLocation{URI: file},
+	}}
+	if startLine > 0 {
+		region := &SARIFRegion{StartLine: startLine}
+		if endLine > startLine {
+			region.EndLine = endLine
+		}
+		location.PhysicalLocation.Region = region
+	}
+	return []SARIFLocation{location}
+}
+
+// WriteSARIF encodes the log as indented JSON
+func WriteSARIF(w io.Writer, log *SARIFLog) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(log); err != nil {
+		return fmt.Errorf("failed to encode SARIF: %w", err)
+	}
+	return nil
+}
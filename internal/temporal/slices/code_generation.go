@@ -26,6 +26,8 @@ import (
 	"go.temporal.io/sdk/activity"
 
 	"open-swarm/internal/agent"
+	"open-swarm/internal/prompts"
+	"open-swarm/internal/prompts/templates"
 )
 
 // ============================================================================
@@ -65,12 +67,19 @@ func (c *CodeGenerationActivities) generateCode(ctx context.Context, output Boot
 	startTime := time.Now()
 
 	client := ReconstructClient(output)
-	prompt := params.promptBuilder(taskInput)
 
-	result, err := client.ExecutePrompt(ctx, prompt, &agent.PromptOptions{
-		Agent: "build",
-		Title: fmt.Sprintf("%s - %s", params.titlePrefix, taskInput.TaskID),
+	// Templates are named after the gate and render with the TaskInput
+	rendered, err := templates.Default().Render(params.gateName, taskInput.TaskID, taskInput, func() string {
+		return params.promptBuilder(taskInput)
 	})
+	prompt := rendered.Text
+	var result *agent.PromptResult
+	if err == nil {
+		result, err = client.ExecutePrompt(ctx, prompt, &agent.PromptOptions{
+			Agent: "build",
+			Title: fmt.Sprintf("%s - %s", params.titlePrefix, taskInput.TaskID),
+		})
+	}
 	if err != nil {
 		return &GateResult{
 			GateName:      params.gateName,
@@ -95,12 +104,13 @@ func (c *CodeGenerationActivities) generateCode(ctx context.Context, output Boot
 		Duration: duration,
 		Message:  fmt.Sprintf(params.successMsgFmt, taskInput.TaskID, len(filesModified)),
 		AgentResults: []AgentResult{{
-			AgentName:    params.agentName,
-			Prompt:       prompt,
-			Response:     result.GetText(),
-			Success:      true,
-			Duration:     duration,
-			FilesChanged: filesModified,
+			AgentName:     params.agentName,
+			Prompt:        prompt,
+			Response:      result.GetText(),
+			Success:       true,
+			Duration:      duration,
+			FilesChanged:  filesModified,
+			PromptVersion: rendered.Stamp(),
 		}},
 	}, nil
 }
@@ -145,7 +155,9 @@ func (c *CodeGenerationActivities) GenerateImplementation(ctx context.Context, o
 		logCompleteMsg: "Implementation generation completed",
 		errorMsg:       "failed to generate implementation",
 		successMsgFmt:  "Generated implementation for %s (%d files modified)",
-		promptBuilder:  buildImplementationPrompt,
+		promptBuilder: func(taskInput TaskInput) string {
+			return buildImplementationPrompt(taskInput) + packRelatedCode(ctx, output.WorktreePath, taskInput)
+		},
 	})
 }
 
@@ -286,6 +298,24 @@ Generate the implementation code now.`, taskInput.TaskID, taskInput.Description,
 	return prompt
 }
 
+// packRelatedCode appends the code the task's tests use to an
+// implementation prompt, within the default token budget
+func packRelatedCode(ctx context.Context, worktreePath string, taskInput TaskInput) string {
+	if len(taskInput.TestFiles) == 0 {
+		return ""
+	}
+	pack, err := prompts.NewContextPacker(nil, nil).Pack(ctx, prompts.ContextRequest{
+		Root:            worktreePath,
+		TaskDescription: taskInput.Description,
+		TestFiles:       taskInput.TestFiles,
+		FailingTests:    taskInput.FailingTests,
+	})
+	if err != nil || len(pack.Snippets) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n\n%s:\n\n%s", prompts.RelatedCodeHeading, pack.Format())
+}
+
 // extractModifiedFiles extracts list of modified files from prompt result
 //
 // This examines tool results to find file write operations.
@@ -26,6 +26,7 @@ import (
 	"go.temporal.io/sdk/activity"
 
 	"open-swarm/internal/agent"
+	"open-swarm/internal/prompts/templates"
 )
 
 // ============================================================================
@@ -65,11 +66,16 @@ func (c *CodeReviewActivities) ExecuteReview(ctx context.Context, output Bootstr
 	// Reconstruct SDK client
 	client := ReconstructClient(output)
 
-	// Build review prompt based on specialization
-	prompt := buildReviewPrompt(taskInput, reviewType)
+	// Build review prompt based on specialization; templates are named
+	// code_review_<type> and render with the TaskInput
+	rendered, err := templates.Default().Render("code_review_"+string(reviewType), taskInput.TaskID, taskInput,
+		func() string { return buildReviewPrompt(taskInput, reviewType) })
+	if err != nil {
+		return nil, err
+	}
 
 	// Execute review via SDK
-	result, err := client.ExecutePrompt(ctx, prompt, &agent.PromptOptions{
+	result, err := client.ExecutePrompt(ctx, rendered.Text, &agent.PromptOptions{
 		Agent: "review",
 		Title: fmt.Sprintf("%s Review - %s", reviewType, taskInput.TaskID),
 	})
@@ -83,11 +89,12 @@ func (c *CodeReviewActivities) ExecuteReview(ctx context.Context, output Bootstr
 	parsedVote := parseReviewVote(result.GetText())
 
 	reviewVote := &ReviewVote{
-		ReviewerName: fmt.Sprintf("reviewer-%s", reviewType),
-		ReviewType:   reviewType,
-		Vote:         parsedVote.Vote,
-		Feedback:     parsedVote.Feedback,
-		Duration:     duration,
+		ReviewerName:  fmt.Sprintf("reviewer-%s", reviewType),
+		ReviewType:    reviewType,
+		Vote:          parsedVote.Vote,
+		Feedback:      parsedVote.Feedback,
+		Duration:      duration,
+		PromptVersion: rendered.Stamp(),
 	}
 
 	logger.Info("Review completed",
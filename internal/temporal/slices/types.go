@@ -50,6 +50,10 @@ type TaskInput struct {
 	TaskID      string
 	Prompt      string
 	Description string
+	// Tests the implementation must pass, relative to the worktree. Code
+	// they use is packed into implementation prompts.
+	TestFiles    []string
+	FailingTests []string
 }
 
 // TaskOutput contains task execution results
@@ -110,14 +114,15 @@ type GateResult struct {
 
 // AgentResult contains the result from a single agent execution
 type AgentResult struct {
-	AgentName    string
-	Model        string
-	Prompt       string
-	Response     string
-	Success      bool
-	Duration     time.Duration
-	Error        string
-	FilesChanged []string
+	AgentName     string
+	Model         string
+	Prompt        string
+	Response      string
+	Success       bool
+	Duration      time.Duration
+	Error         string
+	FilesChanged  []string
+	PromptVersion string // Template that rendered the prompt
 }
 
 // ============================================================================
@@ -190,11 +195,12 @@ type ParsedLintResult struct {
 
 // ReviewVote represents a single reviewer's vote
 type ReviewVote struct {
-	ReviewerName string
-	ReviewType   ReviewType
-	Vote         VoteResult
-	Feedback     string
-	Duration     time.Duration
+	ReviewerName  string
+	ReviewType    ReviewType
+	Vote          VoteResult
+	Feedback      string
+	Duration      time.Duration
+	PromptVersion string // Template that rendered the review prompt
 }
 
 // ReviewType categorizes the review focus
@@ -0,0 +1,149 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	opencodesdk "github.com/sst/opencode-sdk-go"
+	"go.temporal.io/sdk/activity"
+
+	"open-swarm/internal/agent"
+	"open-swarm/internal/opencode"
+)
+
+// SessionPolicy decides whether a task's agent gates share one session
+type SessionPolicy string
+
+const (
+	// SessionPerTask keeps one agent session across GenTest, GenImpl and the
+	// fixes of a task, so later gates see what earlier ones did. A full
+	// regeneration starts a new session.
+	SessionPerTask SessionPolicy = "per_task"
+	// SessionPerGate starts a fresh session for every gate
+	SessionPerGate SessionPolicy = "per_gate"
+)
+
+// ParseSessionPolicy parses a session policy name; empty means per_task
+func ParseSessionPolicy(s string) (SessionPolicy, error) {
+	switch policy := SessionPolicy(strings.ToLower(strings.TrimSpace(s))); policy {
+	case "":
+		return SessionPerTask, nil
+	case SessionPerTask, SessionPerGate:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown session policy %q (want per_task or per_gate)", s)
+	}
+}
+
+// compactPrompt asks the agent to summarize a session that reached its turn
+// limit, so the next session can carry on from the summary
+const compactPrompt = `Summarize the work on this task so far for a new session that will continue it:
+- The task and its acceptance criteria
+- Files created or changed and what they contain
+- Decisions made and approaches that failed
+- What remains to be done
+
+Be concise. Do not change any files.`
+
+// startTaskSession enables session reuse on a freshly bootstrapped cell.
+// Under SessionPerGate the bootstrap is left alone and every gate prompts in
+// a new session.
+func startTaskSession(bootstrap *BootstrapOutput, policy SessionPolicy, maxTurns int) {
+	if policy == SessionPerGate {
+		return
+	}
+	if maxTurns <= 0 {
+		maxTurns = opencode.DefaultSessionMaxTurns
+	}
+	bootstrap.SessionMaxTurns = maxTurns
+}
+
+// recordTaskSession stores the session an agent gate prompted in, counting
+// its turns. It runs in the workflow, so the session outlives activity
+// retries and worker restarts. A new session ID restarts the count.
+func recordTaskSession(bootstrap *BootstrapOutput, gateResult *GateResult) {
+	if bootstrap == nil || bootstrap.SessionMaxTurns == 0 || gateResult == nil {
+		return
+	}
+	for _, agentResult := range gateResult.AgentResults {
+		if agentResult.SessionID == "" {
+			continue
+		}
+		if agentResult.SessionID == bootstrap.SessionID {
+			bootstrap.SessionTurns++
+		} else {
+			bootstrap.SessionID = agentResult.SessionID
+			bootstrap.SessionTurns = 1
+		}
+	}
+}
+
+// resetTaskSession drops the task's session, as a full regeneration does
+func resetTaskSession(bootstrap *BootstrapOutput) {
+	bootstrap.SessionID = ""
+	bootstrap.SessionTurns = 0
+}
+
+// promptInTaskSession runs a gate prompt in the task's session when the
+// workflow tracks one. A session at its turn limit is summarized and the
+// prompt continues in a new session seeded with the summary. A session the
+// agent no longer knows, e.g. after its server restarted, is replaced by a
+// new one.
+func promptInTaskSession(ctx context.Context, client agent.ClientInterface, bootstrap *BootstrapOutput, prompt string, opts *agent.PromptOptions) (*agent.PromptResult, error) {
+	if bootstrap.SessionMaxTurns == 0 || bootstrap.SessionID == "" {
+		return client.ExecutePrompt(ctx, prompt, opts)
+	}
+	logger := activity.GetLogger(ctx)
+
+	sessionID := bootstrap.SessionID
+	if bootstrap.SessionTurns >= bootstrap.SessionMaxTurns {
+		logger.Info("Task session reached its turn limit, compacting",
+			"sessionID", sessionID, "turns", bootstrap.SessionTurns)
+		prompt = compactSession(ctx, client, sessionID, opts) + prompt
+		sessionID = ""
+	}
+
+	sessionOpts := *opts
+	sessionOpts.SessionID = sessionID
+	result, err := client.ExecutePrompt(ctx, prompt, &sessionOpts)
+	if err != nil && sessionID != "" && isSessionNotFound(err) {
+		logger.Warn("Task session is gone, starting a new one", "sessionID", sessionID, "error", err)
+		return client.ExecutePrompt(ctx, prompt, opts)
+	}
+	return result, err
+}
+
+// compactSession summarizes a session and returns the summary as a prompt
+// prefix. A failed summary returns no prefix; the new session then starts
+// without the earlier context.
+func compactSession(ctx context.Context, client agent.ClientInterface, sessionID string, opts *agent.PromptOptions) string {
+	summary, err := client.ExecutePrompt(ctx, compactPrompt, &agent.PromptOptions{
+		SessionID: sessionID,
+		Agent:     opts.Agent,
+		Model:     opts.Model,
+	})
+	if err != nil || summary == nil || strings.TrimSpace(summary.GetText()) == "" {
+		activity.GetLogger(ctx).Warn("Failed to summarize task session", "sessionID", sessionID, "error", err)
+		return ""
+	}
+	return fmt.Sprintf("Summary of the earlier session on this task:\n\n%s\n\n---\n\n", strings.TrimSpace(summary.GetText()))
+}
+
+// isSessionNotFound reports whether a prompt failed because the agent does
+// not know its session ID
+func isSessionNotFound(err error) bool {
+	var apiErr *opencodesdk.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "session not found") || strings.Contains(msg, "no conversation found")
+}
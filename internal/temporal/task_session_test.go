@@ -0,0 +1,212 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	opencodesdk "github.com/sst/opencode-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"open-swarm/internal/agent"
+)
+
+// sessionClient answers prompts with the session they ran in, creating
+// numbered sessions for prompts without one
+type sessionClient struct {
+	known    map[string]bool
+	sessions int
+	calls    []agent.PromptOptions
+	prompts  []string
+}
+
+func (c *sessionClient) ExecutePrompt(_ context.Context, prompt string, opts *agent.PromptOptions) (*agent.PromptResult, error) {
+	c.calls = append(c.calls, *opts)
+	c.prompts = append(c.prompts, prompt)
+	sessionID := opts.SessionID
+	if sessionID == "" {
+		c.sessions++
+		sessionID = "ses-new-" + string(rune('0'+c.sessions))
+		c.known[sessionID] = true
+	} else if !c.known[sessionID] {
+		return nil, errors.New(`POST "http://localhost:4096/session/` + sessionID + `/message": 404 Not Found {"name":"NotFoundError","data":{"message":"Session not found"}}`)
+	}
+	return &agent.PromptResult{SessionID: sessionID, Parts: []agent.ResultPart{{Type: "text", Text: "summary of " + sessionID}}}, nil
+}
+
+func (c *sessionClient) ExecuteCommand(context.Context, string, string, []string) (*agent.PromptResult, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *sessionClient) GetFileStatus(context.Context) ([]opencodesdk.File, error) { return nil, nil }
+func (c *sessionClient) GetBaseURL() string                                        { return "" }
+func (c *sessionClient) GetPort() int                                              { return 0 }
+
+// promptInSession runs promptInTaskSession inside a test activity
+func promptInSession(t *testing.T, client *sessionClient, bootstrap *BootstrapOutput) *agent.PromptResult {
+	t.Helper()
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestActivityEnvironment()
+	prompt := func(ctx context.Context, bootstrap *BootstrapOutput) (*agent.PromptResult, error) {
+		return promptInTaskSession(ctx, client, bootstrap, "fix it", &agent.PromptOptions{Title: "Fix", Agent: "implementation"})
+	}
+	env.RegisterActivity(prompt)
+	value, err := env.ExecuteActivity(prompt, bootstrap)
+	require.NoError(t, err)
+	var result *agent.PromptResult
+	require.NoError(t, value.Get(&result))
+	return result
+}
+
+func TestPromptInTaskSession(t *testing.T) {
+	t.Run("reuses the task session", func(t *testing.T) {
+		client := &sessionClient{known: map[string]bool{"ses-1": true}}
+		result := promptInSession(t, client, &BootstrapOutput{SessionID: "ses-1", SessionTurns: 2, SessionMaxTurns: 50})
+		assert.Equal(t, "ses-1", result.SessionID)
+		require.Len(t, client.calls, 1)
+		assert.Equal(t, "ses-1", client.calls[0].SessionID)
+	})
+
+	t.Run("without session reuse every prompt starts a session", func(t *testing.T) {
+		client := &sessionClient{known: map[string]bool{"ses-1": true}}
+		result := promptInSession(t, client, &BootstrapOutput{SessionID: "ses-1"})
+		assert.Equal(t, "ses-new-1", result.SessionID)
+	})
+
+	t.Run("compacts a session at its turn limit", func(t *testing.T) {
+		client := &sessionClient{known: map[string]bool{"ses-1": true}}
+		result := promptInSession(t, client, &BootstrapOutput{SessionID: "ses-1", SessionTurns: 3, SessionMaxTurns: 3})
+		assert.Equal(t, "ses-new-1", result.SessionID)
+		require.Len(t, client.calls, 2)
+		assert.Equal(t, compactPrompt, client.prompts[0])
+		assert.Equal(t, "ses-1", client.calls[0].SessionID, "the old session writes the summary")
+		assert.Empty(t, client.calls[1].SessionID)
+		assert.True(t, strings.HasPrefix(client.prompts[1], "Summary of the earlier session on this task:\n\nsummary of ses-1"))
+		assert.True(t, strings.HasSuffix(client.prompts[1], "fix it"))
+	})
+
+	t.Run("replaces a session the agent lost", func(t *testing.T) {
+		client := &sessionClient{known: map[string]bool{}}
+		result := promptInSession(t, client, &BootstrapOutput{SessionID: "ses-gone", SessionTurns: 1, SessionMaxTurns: 50})
+		assert.Equal(t, "ses-new-1", result.SessionID)
+		require.Len(t, client.calls, 2)
+		assert.Equal(t, "fix it", client.prompts[1])
+	})
+}
+
+func TestParseSessionPolicy(t *testing.T) {
+	policy, err := ParseSessionPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, SessionPerTask, policy)
+
+	policy, err = ParseSessionPolicy(" Per_Gate ")
+	require.NoError(t, err)
+	assert.Equal(t, SessionPerGate, policy)
+
+	_, err = ParseSessionPolicy("per_day")
+	assert.Error(t, err)
+}
+
+// sessionSeen is the task session an activity was called with
+type sessionSeen struct {
+	ID    string
+	Turns int
+	Max   int
+}
+
+func seen(bootstrap *BootstrapOutput) sessionSeen {
+	return sessionSeen{ID: bootstrap.SessionID, Turns: bootstrap.SessionTurns, Max: bootstrap.SessionMaxTurns}
+}
+
+func agentGate(name, sessionID string) *GateResult {
+	return &GateResult{GateName: name, Passed: true, AgentResults: []AgentResult{{AgentName: "implementation", Success: true, SessionID: sessionID}}}
+}
+
+// runSessionWorkflow runs a task whose first implementation fails GREEN
+// twice, once after a fix, and whose regeneration passes. It returns the
+// sessions GenImpl and FixFromFeedback were called with.
+func runSessionWorkflow(t *testing.T, policy SessionPolicy) (genImpl, fixes []sessionSeen) {
+	t.Helper()
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	cellActivities := &CellActivities{}
+	enhancedActivities := &EnhancedActivities{}
+
+	env.OnActivity(cellActivities.BootstrapCell, mock.Anything, mock.Anything).Return(&BootstrapOutput{CellID: "cell-session"}, nil)
+	env.OnActivity(enhancedActivities.AcquireFileLocks, mock.Anything, mock.Anything, mock.Anything).Return([]string{"a.go"}, nil)
+	env.OnActivity(enhancedActivities.ExecuteLintTest, mock.Anything, mock.Anything).Return(&GateResult{GateName: "LintTest", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteVerifyRED, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{GateName: "VerifyRED", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "multi_review", Passed: true}, nil)
+	env.OnActivity(cellActivities.RevertChanges, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CommitChanges, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CellHead, mock.Anything, mock.Anything).Return(&CellHeadOutput{}, nil).Maybe()
+	env.OnActivity(enhancedActivities.ReleaseFileLocks, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.TeardownCell, mock.Anything, mock.Anything).Return(nil)
+
+	env.OnActivity(enhancedActivities.ExecuteGenTest, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		agentGate("gen_test", "ses-1"), nil)
+	sessions := 1
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, bootstrap *BootstrapOutput, _, _, _, _ string, _ string) (*GateResult, error) {
+			genImpl = append(genImpl, seen(bootstrap))
+			sessionID := bootstrap.SessionID
+			if sessionID == "" {
+				sessions++
+				sessionID = "ses-" + string(rune('0'+sessions))
+			}
+			return agentGate("gen_impl", sessionID), nil
+		})
+	env.OnActivity(enhancedActivities.ExecuteFixFromFeedback, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, bootstrap *BootstrapOutput, _ string, _ string) (*GateResult, error) {
+			fixes = append(fixes, seen(bootstrap))
+			return agentGate("fix_from_feedback", bootstrap.SessionID), nil
+		})
+	greens := 0
+	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, _ *BootstrapOutput, _ string) (*GateResult, error) {
+			greens++
+			return &GateResult{GateName: "VerifyGREEN", Passed: greens == 3}, nil
+		})
+
+	env.ExecuteWorkflow(EnhancedTCRWorkflow, EnhancedTCRInput{
+		TaskID:         "task-session",
+		CellID:         "cell-session",
+		MaxRetries:     2,
+		MaxFixAttempts: 2,
+		SessionPolicy:  policy,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result *EnhancedTCRResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.True(t, result.Success)
+	return genImpl, fixes
+}
+
+func TestEnhancedTCR_SessionPerTask(t *testing.T) {
+	genImpl, fixes := runSessionWorkflow(t, "")
+
+	assert.Equal(t, []sessionSeen{
+		{ID: "ses-1", Turns: 1, Max: 50},
+		{ID: "", Turns: 0, Max: 50},
+	}, genImpl, "GenImpl continues the GenTest session; regeneration starts a new one")
+	assert.Equal(t, []sessionSeen{{ID: "ses-1", Turns: 2, Max: 50}}, fixes, "fixes continue the implementation session")
+}
+
+func TestEnhancedTCR_SessionPerGate(t *testing.T) {
+	genImpl, fixes := runSessionWorkflow(t, SessionPerGate)
+
+	assert.Equal(t, []sessionSeen{{}, {}}, genImpl)
+	assert.Equal(t, []sessionSeen{{}}, fixes)
+}
@@ -34,14 +34,26 @@ type BypassEligibility struct {
 type EnhancedTCRInput struct {
 	CellID             string
 	Branch             string
+	MergeCommits       []string // Merged into the cell after bootstrap, e.g. the commits of DAG dependencies
 	TaskID             string
 	Description        string
 	AcceptanceCriteria string
-	ReviewersCount     int // Default: 3 (unanimous vote required)
+	ReviewersCount     int // Default: 3 - votes are combined by ReviewPolicy
 	MaxRetries         int // Default: 2 - max full regeneration attempts
 	MaxFixAttempts     int // Default: 5 - max targeted fix attempts per regeneration
 	FilesChanged       []string // Files changed in this PR (for bypass detection)
 	BypassPath         string   // Path to analyze for bypass eligibility (optional)
+	Backend            string   // Agent backend: opencode (default), claude-code, aider, anthropic
+	ReviewPolicy       ReviewPolicy // How reviewer votes are combined (default: unanimous)
+	FmtCommand         string       // Formatter run on lint failures before the agent (default: gofmt, goimports, golangci-lint --fix)
+	// ModelLadder lists the "provider/model" GenImpl uses per regeneration attempt, e.g. a fast model,
+	// then a stronger one, then another provider. The last model repeats (default: routed per gate).
+	// Provider errors move to the next model of another provider without using up an attempt.
+	ModelLadder []string
+	// SessionPolicy decides whether GenTest, GenImpl and fixes share one agent session (default: per_task).
+	// SessionMaxTurns caps a session's turns before it is summarized into a new one (default: 50).
+	SessionPolicy   SessionPolicy
+	SessionMaxTurns int
 }
 
 // EnhancedTCRResult contains the complete result of the Enhanced TCR workflow
@@ -50,6 +62,8 @@ type EnhancedTCRResult struct {
 	GateResults  []GateResult
 	FilesChanged []string
 	Error        string
+	Branch       string // Cell branch holding the commit, for the merge queue
+	Commit       string // Commit created when all gates passed
 }
 
 // WorkflowState tracks the current state of the workflow
@@ -90,18 +104,21 @@ type GateResult struct {
 	TestResult    *TestResult  // For test gates
 	LintResult    *LintResult  // For lint gates
 	ReviewVotes   []ReviewVote // For review gate
+	ReviewDecision *ReviewDecision // For review gate: policy verdict
 }
 
 // AgentResult contains the result from a single agent execution
 type AgentResult struct {
-	AgentName    string
-	Model        string
-	Prompt       string
-	Response     string
-	Success      bool
-	Duration     time.Duration
-	Error        string
-	FilesChanged []string
+	AgentName     string
+	Model         string
+	Prompt        string
+	Response      string
+	Success       bool
+	Duration      time.Duration
+	Error         string
+	FilesChanged  []string
+	SessionID     string // Agent session the prompt ran in
+	PromptVersion string // Template that rendered the prompt, e.g. "gen_impl@v2"
 }
 
 // TestResult contains test execution results
@@ -121,6 +138,10 @@ type LintResult struct {
 	Issues   []LintIssue
 	Output   string
 	Duration time.Duration
+	// MachineFixed lists issues resolved by formatters and linter auto-fix
+	MachineFixed []LintIssue
+	// AgentFixed lists issues resolved by an agent fix attempt
+	AgentFixed []LintIssue
 }
 
 // LintIssue represents a single linting issue
@@ -131,6 +152,12 @@ type LintIssue struct {
 	Severity string // "error", "warning", "info"
 	Message  string
 	Rule     string
+	// Linter is the registry name of the linter that reported the issue
+	Linter string
+	// SourceLines are the offending source lines, when the linter reports them
+	SourceLines []string
+	// SuggestedFix is the linter's proposed fix, when it offers one
+	SuggestedFix string
 }
 
 // ReviewVote represents a single reviewer's vote
@@ -140,6 +167,12 @@ type ReviewVote struct {
 	Vote         VoteResult
 	Feedback     string
 	Duration     time.Duration
+	Model        string  // Model that produced the review
+	Confidence   float64 // Reviewer confidence (0-1) from VoteParser; 0 when unknown
+	Failed       bool    // Reviewer errored; its vote carries no weight in the weighted policy
+	Summary      string          // Overall assessment from a structured review
+	Findings     []ReviewFinding // Line-anchored issues from a structured review
+	PromptVersion string         // Template that rendered the review prompt
 }
 
 // ReviewType categorizes the review focus
@@ -152,6 +185,12 @@ const (
 	ReviewTypeFunctional ReviewType = "functional"
 	// ReviewTypeArchitecture represents architecture and design review
 	ReviewTypeArchitecture ReviewType = "architecture"
+	// ReviewTypeSecurity represents security review; the default veto holder
+	ReviewTypeSecurity ReviewType = "security"
+	// ReviewTypePerformance represents performance and resource usage review
+	ReviewTypePerformance ReviewType = "performance"
+	// ReviewTypeAPICompatibility represents public API compatibility review
+	ReviewTypeAPICompatibility ReviewType = "api_compatibility"
 )
 
 // VoteResult represents a reviewer's decision
@@ -29,6 +29,9 @@ type BenchmarkInput struct {
 	Prompt      string
 	Description string
 	RepoBranch  string
+	// Backends lists agent backends to compare; runs are assigned round-robin.
+	// Empty means every run uses OpenCode.
+	Backends []string
 }
 
 // BenchmarkResult contains aggregated results from benchmark runs
@@ -40,15 +43,38 @@ type BenchmarkResult struct {
 	TotalDuration time.Duration
 	AvgDuration   time.Duration
 	RunResults    []RunResult
+	BackendStats  []BackendStats
+	TemplateStats []TemplateStats
+}
+
+// BackendStats aggregates run results for a single agent backend
+type BackendStats struct {
+	Backend      string
+	Runs         int
+	SuccessCount int
+	FailureCount int
+	AvgDuration  time.Duration
+}
+
+// TemplateStats aggregates run results for a single prompt template
+// version, so prompt variants can be compared by success rate
+type TemplateStats struct {
+	Template     string // Template stamp, e.g. "gen_impl@v2"
+	Runs         int
+	SuccessCount int
+	FailureCount int
+	SuccessRate  float64
 }
 
 // RunResult contains individual run results
 type RunResult struct {
-	RunID        int
-	Success      bool
-	Error        string
-	Duration     time.Duration
-	FilesChanged []string
+	RunID          int
+	Backend        string
+	Success        bool
+	Error          string
+	Duration       time.Duration
+	FilesChanged   []string
+	PromptVersions []string // Prompt templates the run's agents used
 }
 
 // BenchmarkWorkflow executes N parallel runs of the specified TCR strategy
@@ -78,6 +104,7 @@ func BenchmarkWorkflow(ctx workflow.Context, input BenchmarkInput) (*BenchmarkRe
 
 	// Futures tracker
 	var futures []workflow.Future
+	runBackends := make([]string, 0, input.NumRuns)
 
 	// Launch runs in parallel (Temporal handles concurrency)
 	for i := 0; i < input.NumRuns; i++ {
@@ -85,6 +112,8 @@ func BenchmarkWorkflow(ctx workflow.Context, input BenchmarkInput) (*BenchmarkRe
 		// Unique IDs for each child workflow to prevent collision
 		cellID := fmt.Sprintf("bench-%s-%d-%d", input.Strategy, workflow.Now(ctx).Unix(), runID)
 		taskID := fmt.Sprintf("TASK-%03d", runID)
+		backend := benchmarkBackend(input.Backends, i)
+		runBackends = append(runBackends, backend)
 
 		cwo := workflow.ChildWorkflowOptions{
 			WorkflowID: fmt.Sprintf("%s-%s", cellID, input.Strategy),
@@ -100,6 +129,7 @@ func BenchmarkWorkflow(ctx workflow.Context, input BenchmarkInput) (*BenchmarkRe
 				Description:        input.Description,
 				AcceptanceCriteria: input.Prompt,
 				ReviewersCount:     2, // 2 Judges for speed
+				Backend:            backend,
 			}
 			f = workflow.ExecuteChildWorkflow(childCtx, EnhancedTCRWorkflow, req)
 		} else {
@@ -109,6 +139,7 @@ func BenchmarkWorkflow(ctx workflow.Context, input BenchmarkInput) (*BenchmarkRe
 				TaskID:      taskID,
 				Description: input.Description,
 				Prompt:      input.Prompt,
+				Backend:     backend,
 			}
 			f = workflow.ExecuteChildWorkflow(childCtx, TCRWorkflow, req)
 		}
@@ -120,6 +151,7 @@ func BenchmarkWorkflow(ctx workflow.Context, input BenchmarkInput) (*BenchmarkRe
 		runStart := workflow.Now(ctx)
 		var runRes RunResult
 		runRes.RunID = i + 1
+		runRes.Backend = runBackends[i]
 
 		// Helper to extract success/error from different result types
 		if input.Strategy == StrategyEnhanced {
@@ -132,6 +164,7 @@ func BenchmarkWorkflow(ctx workflow.Context, input BenchmarkInput) (*BenchmarkRe
 				runRes.Success = r.Success
 				runRes.Error = r.Error
 				runRes.FilesChanged = r.FilesChanged
+				runRes.PromptVersions = promptVersions(r.GateResults)
 			}
 		} else {
 			var r TCRWorkflowResult
@@ -159,6 +192,8 @@ func BenchmarkWorkflow(ctx workflow.Context, input BenchmarkInput) (*BenchmarkRe
 	if results.TotalRuns > 0 {
 		results.AvgDuration = results.TotalDuration / time.Duration(results.TotalRuns)
 	}
+	results.BackendStats = aggregateBackendStats(results.RunResults)
+	results.TemplateStats = aggregateTemplateStats(results.RunResults)
 
 	logger.Info("Benchmark Complete",
 		"strategy", input.Strategy,
@@ -168,3 +203,94 @@ func BenchmarkWorkflow(ctx workflow.Context, input BenchmarkInput) (*BenchmarkRe
 
 	return results, nil
 }
+
+// benchmarkBackend picks the backend for run i, cycling through the list
+func benchmarkBackend(backends []string, i int) string {
+	if len(backends) == 0 {
+		return ""
+	}
+	return backends[i%len(backends)]
+}
+
+// aggregateBackendStats groups run results per backend, preserving first-seen order
+func aggregateBackendStats(runs []RunResult) []BackendStats {
+	var stats []BackendStats
+	index := make(map[string]int)
+	totals := make(map[string]time.Duration)
+
+	for _, run := range runs {
+		name := run.Backend
+		if name == "" {
+			name = "opencode"
+		}
+		idx, ok := index[name]
+		if !ok {
+			idx = len(stats)
+			index[name] = idx
+			stats = append(stats, BackendStats{Backend: name})
+		}
+		stats[idx].Runs++
+		if run.Success {
+			stats[idx].SuccessCount++
+		} else {
+			stats[idx].FailureCount++
+		}
+		totals[name] += run.Duration
+	}
+
+	for i := range stats {
+		stats[i].AvgDuration = totals[stats[i].Backend] / time.Duration(stats[i].Runs)
+	}
+	return stats
+}
+
+// promptVersions lists the prompt templates used by a run's gates, in
+// first-use order
+func promptVersions(gates []GateResult) []string {
+	var versions []string
+	seen := make(map[string]bool)
+	add := func(version string) {
+		if version != "" && !seen[version] {
+			seen[version] = true
+			versions = append(versions, version)
+		}
+	}
+	for _, gate := range gates {
+		for _, agentResult := range gate.AgentResults {
+			add(agentResult.PromptVersion)
+		}
+		for _, vote := range gate.ReviewVotes {
+			add(vote.PromptVersion)
+		}
+	}
+	return versions
+}
+
+// aggregateTemplateStats groups run results per prompt template version,
+// preserving first-seen order. A run counts once for each template it used.
+func aggregateTemplateStats(runs []RunResult) []TemplateStats {
+	var stats []TemplateStats
+	index := make(map[string]int)
+
+	for _, run := range runs {
+		for _, version := range run.PromptVersions {
+			idx, ok := index[version]
+			if !ok {
+				idx = len(stats)
+				index[version] = idx
+				stats = append(stats, TemplateStats{Template: version})
+			}
+			stats[idx].Runs++
+			if run.Success {
+				stats[idx].SuccessCount++
+			} else {
+				stats[idx].FailureCount++
+			}
+		}
+	}
+
+	for i := range stats {
+		stats[i].SuccessRate = float64(stats[i].SuccessCount) / float64(stats[i].Runs)
+	}
+	return stats
+}
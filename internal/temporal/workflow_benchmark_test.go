@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
 )
 
 type BenchmarkWorkflowTestSuite struct {
@@ -270,3 +271,80 @@ func (s *BenchmarkWorkflowTestSuite) TestBenchmarkWorkflow_AllFailures() {
 		s.NotEmpty(run.Error)
 	}
 }
+
+// TestBenchmarkWorkflow_BackendComparison tests round-robin backend assignment and per-backend stats
+func (s *BenchmarkWorkflowTestSuite) TestBenchmarkWorkflow_BackendComparison() {
+	input := BenchmarkInput{
+		Strategy:    StrategyEnhanced,
+		NumRuns:     4,
+		Prompt:      "Implement with TDD",
+		Description: "Backend comparison",
+		RepoBranch:  "main",
+		Backends:    []string{"claude-code", "aider"},
+	}
+
+	s.env.OnWorkflow(EnhancedTCRWorkflow, mock.Anything, mock.Anything).Return(
+		func(_ workflow.Context, in EnhancedTCRInput) (*EnhancedTCRResult, error) {
+			return &EnhancedTCRResult{Success: in.Backend == "claude-code"}, nil
+		}).Times(4)
+
+	s.env.ExecuteWorkflow(BenchmarkWorkflow, input)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+
+	var result BenchmarkResult
+	s.NoError(s.env.GetWorkflowResult(&result))
+
+	s.Equal([]string{"claude-code", "aider", "claude-code", "aider"}, []string{
+		result.RunResults[0].Backend, result.RunResults[1].Backend,
+		result.RunResults[2].Backend, result.RunResults[3].Backend,
+	})
+	s.Require().Len(result.BackendStats, 2)
+	s.Equal(BackendStats{Backend: "claude-code", Runs: 2, SuccessCount: 2, AvgDuration: result.BackendStats[0].AvgDuration}, result.BackendStats[0])
+	s.Equal(BackendStats{Backend: "aider", Runs: 2, FailureCount: 2, AvgDuration: result.BackendStats[1].AvgDuration}, result.BackendStats[1])
+}
+
+// TestBenchmarkWorkflow_TemplateStats tests success rates per prompt template version
+func (s *BenchmarkWorkflowTestSuite) TestBenchmarkWorkflow_TemplateStats() {
+	input := BenchmarkInput{
+		Strategy:    StrategyEnhanced,
+		NumRuns:     3,
+		Prompt:      "Implement with TDD",
+		Description: "Template comparison",
+		RepoBranch:  "main",
+	}
+
+	runs := 0
+	s.env.OnWorkflow(EnhancedTCRWorkflow, mock.Anything, mock.Anything).Return(
+		func(_ workflow.Context, _ EnhancedTCRInput) (*EnhancedTCRResult, error) {
+			runs++
+			version := "gen_impl@v1"
+			if runs == 1 {
+				version = "gen_impl@v2"
+			}
+			return &EnhancedTCRResult{
+				Success: runs != 2,
+				GateResults: []GateResult{
+					{GateName: "gen_test", AgentResults: []AgentResult{{PromptVersion: "gen_test@builtin"}}},
+					{GateName: "gen_impl", AgentResults: []AgentResult{{PromptVersion: version}}},
+					{GateName: "gen_impl", AgentResults: []AgentResult{{PromptVersion: version}}},
+				},
+			}, nil
+		}).Times(3)
+
+	s.env.ExecuteWorkflow(BenchmarkWorkflow, input)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+
+	var result BenchmarkResult
+	s.NoError(s.env.GetWorkflowResult(&result))
+
+	s.Equal([]string{"gen_test@builtin", "gen_impl@v2"}, result.RunResults[0].PromptVersions)
+	s.Equal([]TemplateStats{
+		{Template: "gen_test@builtin", Runs: 3, SuccessCount: 2, FailureCount: 1, SuccessRate: 2.0 / 3},
+		{Template: "gen_impl@v2", Runs: 1, SuccessCount: 1, SuccessRate: 1},
+		{Template: "gen_impl@v1", Runs: 2, SuccessCount: 1, FailureCount: 1, SuccessRate: 0.5},
+	}, result.TemplateStats)
+}
@@ -45,7 +45,7 @@ func TestEnhancedTCRWorkflow_SuccessfulPath(t *testing.T) {
 		Passed:   true,
 	}, nil)
 
-	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{
 		GateName: "GenImpl",
 		Passed:   true,
 	}, nil)
@@ -55,12 +55,13 @@ func TestEnhancedTCRWorkflow_SuccessfulPath(t *testing.T) {
 		Passed:   true,
 	}, nil)
 
-	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{
+	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{
 		GateName: "MultiReview",
 		Passed:   true,
 	}, nil)
 
 	env.OnActivity(cellActivities.CommitChanges, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CellHead, mock.Anything, mock.Anything).Return(&CellHeadOutput{}, nil).Maybe()
 
 	env.OnActivity(enhancedActivities.ReleaseFileLocks, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -152,7 +153,7 @@ func TestEnhancedTCRWorkflow_GateFailureWithRetry(t *testing.T) {
 	}, nil)
 
 	// First regeneration fails on GenImpl
-	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&GateResult{
 		GateName: "GenImpl",
 		Passed:   false,
 		Error:    "generation failed",
@@ -230,3 +231,32 @@ func TestWorkflowLifecycle_SagaCleanup(t *testing.T) {
 	assert.True(t, locksReleased, "locks should be released (saga pattern)")
 	assert.True(t, cellTorndown, "cell should be torn down (saga pattern)")
 }
+
+// TestEnhancedTCRWorkflow_MergeConflictStopsBeforeGates tests that a task whose
+// dependency commits conflict fails without running gates
+func TestEnhancedTCRWorkflow_MergeConflictStopsBeforeGates(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+
+	cellActivities := &CellActivities{}
+	env.OnActivity(cellActivities.BootstrapCell, mock.Anything, BootstrapInput{CellID: "test-cell", Branch: "c-b"}).Return(
+		&BootstrapOutput{CellID: "test-cell", WorktreeID: "wt-test"}, nil)
+	env.OnActivity(cellActivities.MergeCommits, mock.Anything, mock.Anything, []string{"c-c"}).Return(
+		errors.New("failed to merge c-c: conflict"))
+	env.OnActivity(cellActivities.TeardownCell, mock.Anything, mock.Anything).Return(nil)
+
+	env.ExecuteWorkflow(EnhancedTCRWorkflow, EnhancedTCRInput{
+		TaskID:       "task-d",
+		CellID:       "test-cell",
+		Branch:       "c-b",
+		MergeCommits: []string{"c-c"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var result *EnhancedTCRResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "failed to merge dependencies")
+	assert.Empty(t, result.GateResults)
+	env.AssertExpectations(t)
+}
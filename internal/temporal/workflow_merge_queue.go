@@ -0,0 +1,344 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// MergeQueueEnqueueSignal adds a MergeRequest to a running merge queue
+	MergeQueueEnqueueSignal = "merge-queue-enqueue"
+	// MergeQueueStatusQuery returns the MergeQueueStatus of a running merge queue
+	MergeQueueStatusQuery = "merge-queue-status"
+)
+
+// MergeFailurePolicy decides what happens when a rebase conflicts or the
+// rebased result fails its gates
+type MergeFailurePolicy string
+
+const (
+	// MergeFailureAgentFix hands conflicts and failures to an agent fix loop,
+	// re-enqueueing only when the fix attempts are exhausted (default)
+	MergeFailureAgentFix MergeFailurePolicy = "agent_fix"
+	// MergeFailureRequeue re-enqueues the task right away
+	MergeFailureRequeue MergeFailurePolicy = "requeue"
+)
+
+// MergeRequest is a finished cell waiting to be integrated
+type MergeRequest struct {
+	TaskID string
+	Branch string // Cell branch holding the task's commits
+	Commit string // Commit to integrate (default: tip of Branch)
+	// Task reruns the task from the new target head when it is re-enqueued.
+	// Without it, the same branch is retried after the rest of the queue.
+	Task    *EnhancedTCRInput
+	Attempt int // Times this task has been re-enqueued
+}
+
+// MergeQueueInput configures MergeQueueWorkflow
+type MergeQueueInput struct {
+	TargetBranch   string             // Branch to integrate into (default: main)
+	Requests       []MergeRequest     // Initial queue; more arrive via MergeQueueEnqueueSignal
+	OnFailure      MergeFailurePolicy // Default: agent_fix
+	MaxFixAttempts int                // Default: 3 - agent fix rounds per request
+	MaxRequeues    int                // Default: 1 - times a task may be re-enqueued
+	Backend        string             // Agent backend of the integration cells
+	FmtCommand     string             // Formatter for lint auto-fix
+	// IdleTimeout keeps the queue open for signals after it drains.
+	// Zero ends the workflow as soon as the queue is empty.
+	IdleTimeout time.Duration
+}
+
+// MergeOutcome records the integration of one request
+type MergeOutcome struct {
+	TaskID      string
+	Branch      string
+	Merged      bool
+	Commit      string   // Target branch head after the merge
+	Conflicts   []string // Files that conflicted during the rebase
+	FixAttempts int
+	Requeued    bool
+	GateResults []GateResult
+	Error       string
+}
+
+// MergeQueueResult summarizes a merge queue run
+type MergeQueueResult struct {
+	TargetBranch string
+	Head         string // Target branch head after the last merge
+	Merged       int
+	Failed       int
+	Outcomes     []MergeOutcome
+}
+
+// MergeQueueStatus is returned by MergeQueueStatusQuery
+type MergeQueueStatus struct {
+	Current  string   // Task being integrated
+	Pending  []string // Queued task IDs in order
+	Outcomes []MergeOutcome
+}
+
+// MergeQueueWorkflow serializes the integration of finished cells. Each
+// request is rebased onto the current target head in an integration cell,
+// VerifyGREEN and lint run again on the rebased result, and the target branch
+// is fast-forwarded. Conflicts and gate failures go to an agent fix loop or
+// re-enqueue the task, per OnFailure.
+func MergeQueueWorkflow(ctx workflow.Context, input MergeQueueInput) (*MergeQueueResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	if input.TargetBranch == "" {
+		input.TargetBranch = "main"
+	}
+	if input.OnFailure == "" {
+		input.OnFailure = MergeFailureAgentFix
+	}
+	if input.MaxFixAttempts == 0 {
+		input.MaxFixAttempts = 3
+	}
+	if input.MaxRequeues == 0 {
+		input.MaxRequeues = 1
+	}
+	logger.Info("Starting Merge Queue Workflow", "target", input.TargetBranch, "requests", len(input.Requests))
+
+	ctx = WithNonIdempotentOptions(ctx)
+
+	result := &MergeQueueResult{TargetBranch: input.TargetBranch, Outcomes: []MergeOutcome{}}
+	queue := append([]MergeRequest{}, input.Requests...)
+	current := ""
+
+	err := workflow.SetQueryHandler(ctx, MergeQueueStatusQuery, func() (MergeQueueStatus, error) {
+		status := MergeQueueStatus{Current: current, Pending: []string{}, Outcomes: result.Outcomes}
+		for _, request := range queue {
+			status.Pending = append(status.Pending, request.TaskID)
+		}
+		return status, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register status query: %w", err)
+	}
+
+	signals := workflow.GetSignalChannel(ctx, MergeQueueEnqueueSignal)
+	for {
+		for {
+			var request MergeRequest
+			if !signals.ReceiveAsync(&request) {
+				break
+			}
+			queue = append(queue, request)
+		}
+
+		if len(queue) == 0 {
+			request, ok := waitForMergeRequest(ctx, signals, input.IdleTimeout)
+			if !ok {
+				break
+			}
+			queue = append(queue, request)
+			continue
+		}
+
+		request := queue[0]
+		queue = queue[1:]
+		current = request.TaskID
+
+		outcome := integrateCell(ctx, logger, input, request)
+		if !outcome.Merged {
+			if next, ok := requeueMergeRequest(ctx, logger, input, request, &outcome); ok {
+				queue = append(queue, next)
+			}
+		}
+		current = ""
+
+		result.Outcomes = append(result.Outcomes, outcome)
+		if outcome.Merged {
+			result.Merged++
+			result.Head = outcome.Commit
+		} else if !outcome.Requeued {
+			result.Failed++
+		}
+	}
+
+	logger.Info("Merge queue drained", "merged", result.Merged, "failed", result.Failed)
+	return result, nil
+}
+
+// waitForMergeRequest blocks until a request is signaled or the idle timeout
+// passes. Returns false on timeout, or right away when timeout is zero.
+func waitForMergeRequest(ctx workflow.Context, signals workflow.ReceiveChannel, timeout time.Duration) (MergeRequest, bool) {
+	var request MergeRequest
+	if timeout <= 0 {
+		return request, false
+	}
+
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+
+	received := false
+	selector := workflow.NewSelector(ctx)
+	selector.AddReceive(signals, func(c workflow.ReceiveChannel, _ bool) {
+		c.Receive(ctx, &request)
+		received = true
+	})
+	selector.AddFuture(workflow.NewTimer(timerCtx, timeout), func(workflow.Future) {})
+	selector.Select(ctx)
+	return request, received
+}
+
+// integrateCell rebases, verifies and fast-forwards a single request in its
+// own integration cell
+func integrateCell(ctx workflow.Context, logger log.Logger, input MergeQueueInput, request MergeRequest) MergeOutcome {
+	logger.Info("Integrating cell", "taskID", request.TaskID, "branch", request.Branch)
+	outcome := MergeOutcome{TaskID: request.TaskID, Branch: request.Branch}
+
+	cellActivities := NewCellActivities()
+	enhancedActivities := NewEnhancedActivities()
+	mergeActivities := NewMergeActivities()
+
+	var bootstrap *BootstrapOutput
+	err := workflow.ExecuteActivity(ctx, cellActivities.BootstrapCell, BootstrapInput{
+		CellID:  fmt.Sprintf("merge-%s-%d", request.TaskID, request.Attempt),
+		Branch:  input.TargetBranch,
+		Backend: input.Backend,
+	}).Get(ctx, &bootstrap)
+	if err != nil {
+		outcome.Error = fmt.Sprintf("bootstrap failed: %v", err)
+		return outcome
+	}
+
+	merged := false
+	defer func() {
+		sagaCtx, _ := NewSagaContext(ctx)
+		if !merged {
+			_ = workflow.ExecuteActivity(sagaCtx, mergeActivities.AbortMerge, bootstrap).Get(sagaCtx, nil)
+		}
+		_ = workflow.ExecuteActivity(sagaCtx, cellActivities.TeardownCell, bootstrap).Get(sagaCtx, nil)
+	}()
+
+	// Rebase, resolving conflicts with the agent while attempts remain
+	var attempt *MergeAttempt
+	err = workflow.ExecuteActivity(ctx, mergeActivities.RebaseCell, bootstrap, request, input.TargetBranch).Get(ctx, &attempt)
+	if err != nil {
+		outcome.Error = fmt.Sprintf("rebase failed: %v", err)
+		return outcome
+	}
+	for len(attempt.Conflicts) > 0 {
+		outcome.Conflicts = appendMissing(outcome.Conflicts, attempt.Conflicts...)
+		if input.OnFailure != MergeFailureAgentFix || outcome.FixAttempts >= input.MaxFixAttempts {
+			outcome.Error = fmt.Sprintf("rebase conflicts in %s", strings.Join(attempt.Conflicts, ", "))
+			return outcome
+		}
+		outcome.FixAttempts++
+		err = workflow.ExecuteActivity(ctx, mergeActivities.ResolveConflicts, bootstrap, attempt).Get(ctx, &attempt)
+		if err != nil {
+			outcome.Error = fmt.Sprintf("conflict resolution failed: %v", err)
+			return outcome
+		}
+	}
+
+	// Re-run the gates on the rebased result
+	executor := &gateExecutor{
+		ctx:            ctx,
+		logger:         logger,
+		result:         &EnhancedTCRResult{},
+		bootstrap:      bootstrap,
+		cellActivities: cellActivities,
+	}
+	green := executor.runGate("VerifyGREEN", enhancedActivities.ExecuteVerifyGREEN, bootstrap, request.TaskID)
+	for !green.Passed && input.OnFailure == MergeFailureAgentFix && outcome.FixAttempts < input.MaxFixAttempts {
+		outcome.GateResults = append(outcome.GateResults, *green)
+		outcome.FixAttempts++
+		fix := executor.runGate("FixFromFeedback", enhancedActivities.ExecuteFixFromFeedback,
+			bootstrap, request.TaskID, extractTestFeedback(green))
+		outcome.GateResults = append(outcome.GateResults, *fix)
+		green = executor.runGate("VerifyGREEN", enhancedActivities.ExecuteVerifyGREEN, bootstrap, request.TaskID)
+	}
+	outcome.GateResults = append(outcome.GateResults, *green)
+	if !green.Passed {
+		outcome.Error = fmt.Sprintf("VerifyGREEN failed after rebase: %s", green.Error)
+		return outcome
+	}
+
+	lint := executor.runGate("LintTest", enhancedActivities.ExecuteLintTest, bootstrap)
+	if !lint.Passed && hasLintIssues(lint) && input.OnFailure == MergeFailureAgentFix {
+		outcome.GateResults = append(outcome.GateResults, *lint)
+		var steps []GateResult
+		steps, lint = remediateLint(ctx, logger, enhancedActivities, bootstrap, request.TaskID, input.FmtCommand, "", lint)
+		outcome.GateResults = append(outcome.GateResults, steps...)
+	}
+	outcome.GateResults = append(outcome.GateResults, *lint)
+	if !lint.Passed {
+		outcome.Error = fmt.Sprintf("LintTest failed after rebase: %s", lint.Error)
+		return outcome
+	}
+
+	// Fast-forward the target branch
+	message := fmt.Sprintf("Task %s: fixes from integration onto %s", request.TaskID, input.TargetBranch)
+	err = workflow.ExecuteActivity(ctx, mergeActivities.FinalizeMerge, bootstrap, attempt, input.TargetBranch, message).Get(ctx, &attempt)
+	if err != nil {
+		outcome.Error = fmt.Sprintf("fast-forward failed: %v", err)
+		return outcome
+	}
+	if attempt.Stale {
+		outcome.Error = fmt.Sprintf("%s moved during integration", input.TargetBranch)
+		return outcome
+	}
+
+	merged = true
+	outcome.Merged = true
+	outcome.Commit = attempt.Merged
+	logger.Info("Cell integrated", "taskID", request.TaskID, "commit", outcome.Commit)
+	return outcome
+}
+
+// requeueMergeRequest returns the request to enqueue again after a failed
+// integration, if the task has re-enqueues left. A request that carries its
+// task is rerun from the current target head first.
+func requeueMergeRequest(ctx workflow.Context, logger log.Logger, input MergeQueueInput, request MergeRequest, outcome *MergeOutcome) (MergeRequest, bool) {
+	if request.Attempt >= input.MaxRequeues {
+		return MergeRequest{}, false
+	}
+
+	next := request
+	next.Attempt++
+	if request.Task != nil {
+		task := *request.Task
+		task.Branch = input.TargetBranch
+		task.CellID = fmt.Sprintf("%s-requeue-%d", request.Task.CellID, next.Attempt)
+
+		logger.Info("Rerunning task from target head", "taskID", request.TaskID, "attempt", next.Attempt)
+		var rerun *EnhancedTCRResult
+		err := workflow.ExecuteChildWorkflow(ctx, EnhancedTCRWorkflow, task).Get(ctx, &rerun)
+		if err != nil || rerun == nil || !rerun.Success || rerun.Branch == "" {
+			outcome.Error += "; rerun from target head failed"
+			if rerun != nil && rerun.Error != "" {
+				outcome.Error += ": " + rerun.Error
+			}
+			return MergeRequest{}, false
+		}
+		next.Branch = rerun.Branch
+		next.Commit = rerun.Commit
+	}
+
+	logger.Info("Re-enqueueing task", "taskID", request.TaskID, "attempt", next.Attempt)
+	outcome.Requeued = true
+	return next, true
+}
+
+// appendMissing appends the values not already in list
+func appendMissing(list []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"open-swarm/internal/git"
+)
+
+// mockMergeQueueCells mocks integration cells whose gates pass
+func mockMergeQueueCells(env *testsuite.TestWorkflowEnvironment, cellActivities *CellActivities, enhancedActivities *EnhancedActivities, mergeActivities *MergeActivities) {
+	env.OnActivity(cellActivities.BootstrapCell, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input BootstrapInput) (*BootstrapOutput, error) {
+			return &BootstrapOutput{CellID: input.CellID, WorktreePath: "/tmp/" + input.CellID}, nil
+		})
+	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "verify_green", Passed: true}, nil)
+	env.OnActivity(enhancedActivities.ExecuteLintTest, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "lint_test", Passed: true}, nil)
+	env.OnActivity(mergeActivities.FinalizeMerge, mock.Anything, mock.Anything, mock.Anything, "main", mock.Anything).Return(
+		func(_ context.Context, _ *BootstrapOutput, attempt *MergeAttempt, _ string, _ string) (*MergeAttempt, error) {
+			merged := *attempt
+			merged.Merged = "merged-" + attempt.TaskID
+			return &merged, nil
+		})
+	env.OnActivity(mergeActivities.AbortMerge, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.TeardownCell, mock.Anything, mock.Anything).Return(nil)
+}
+
+func rebasedCleanly(_ context.Context, _ *BootstrapOutput, request MergeRequest, _ string) (*MergeAttempt, error) {
+	return &MergeAttempt{TaskID: request.TaskID, TargetHead: "head", Rebased: "rebased-" + request.TaskID}, nil
+}
+
+func TestMergeQueue_IntegratesInOrder(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	cellActivities, enhancedActivities, mergeActivities := &CellActivities{}, &EnhancedActivities{}, &MergeActivities{}
+	mockMergeQueueCells(env, cellActivities, enhancedActivities, mergeActivities)
+	env.OnActivity(mergeActivities.RebaseCell, mock.Anything, mock.Anything, mock.Anything, "main").Return(rebasedCleanly)
+
+	env.ExecuteWorkflow(MergeQueueWorkflow, MergeQueueInput{Requests: []MergeRequest{
+		{TaskID: "task-a", Branch: "worktree-a"},
+		{TaskID: "task-b", Branch: "worktree-b"},
+	}})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var result *MergeQueueResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.Equal(t, 2, result.Merged)
+	assert.Equal(t, "merged-task-b", result.Head)
+	require.Len(t, result.Outcomes, 2)
+	assert.Equal(t, "task-a", result.Outcomes[0].TaskID)
+	assert.Len(t, result.Outcomes[0].GateResults, 2, "VerifyGREEN and lint rerun on the rebased result")
+	env.AssertNotCalled(t, "AbortMerge", mock.Anything, mock.Anything)
+}
+
+func TestMergeQueue_ConflictsGoToAgent(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	cellActivities, enhancedActivities, mergeActivities := &CellActivities{}, &EnhancedActivities{}, &MergeActivities{}
+	mockMergeQueueCells(env, cellActivities, enhancedActivities, mergeActivities)
+
+	env.OnActivity(mergeActivities.RebaseCell, mock.Anything, mock.Anything, mock.Anything, "main").Return(
+		&MergeAttempt{TaskID: "task-a", TargetHead: "head", Conflicts: []string{"pkg/a/a.go"}}, nil)
+	env.OnActivity(mergeActivities.ResolveConflicts, mock.Anything, mock.Anything, mock.Anything).Return(
+		&MergeAttempt{TaskID: "task-a", TargetHead: "head", Rebased: "rebased"}, nil).Once()
+
+	env.ExecuteWorkflow(MergeQueueWorkflow, MergeQueueInput{Requests: []MergeRequest{{TaskID: "task-a", Branch: "worktree-a"}}})
+
+	var result *MergeQueueResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Len(t, result.Outcomes, 1)
+	outcome := result.Outcomes[0]
+	assert.True(t, outcome.Merged, outcome.Error)
+	assert.Equal(t, 1, outcome.FixAttempts)
+	assert.Equal(t, []string{"pkg/a/a.go"}, outcome.Conflicts)
+}
+
+func TestMergeQueue_PostRebaseTestFailureIsFixed(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	cellActivities, enhancedActivities, mergeActivities := &CellActivities{}, &EnhancedActivities{}, &MergeActivities{}
+
+	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
+		&GateResult{GateName: "verify_green", Passed: false, Error: "TestA failed"}, nil).Once()
+	env.OnActivity(enhancedActivities.ExecuteFixFromFeedback, mock.Anything, mock.Anything, "task-a", mock.Anything).Return(
+		&GateResult{GateName: "fix_from_feedback", Passed: true}, nil).Once()
+	mockMergeQueueCells(env, cellActivities, enhancedActivities, mergeActivities)
+	env.OnActivity(mergeActivities.RebaseCell, mock.Anything, mock.Anything, mock.Anything, "main").Return(rebasedCleanly)
+
+	env.ExecuteWorkflow(MergeQueueWorkflow, MergeQueueInput{Requests: []MergeRequest{{TaskID: "task-a", Branch: "worktree-a"}}})
+
+	var result *MergeQueueResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	outcome := result.Outcomes[0]
+	assert.True(t, outcome.Merged, outcome.Error)
+	assert.Equal(t, 1, outcome.FixAttempts)
+	assert.Len(t, outcome.GateResults, 4, "failed green, fix, green, lint")
+}
+
+func TestMergeQueue_RequeueRerunsTask(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	cellActivities, enhancedActivities, mergeActivities := &CellActivities{}, &EnhancedActivities{}, &MergeActivities{}
+	mockMergeQueueCells(env, cellActivities, enhancedActivities, mergeActivities)
+
+	env.OnActivity(mergeActivities.RebaseCell, mock.Anything, mock.Anything, mock.Anything, "main").Return(
+		&MergeAttempt{TaskID: "task-a", TargetHead: "head", Conflicts: []string{"go.mod"}}, nil).Once()
+	env.OnActivity(mergeActivities.RebaseCell, mock.Anything, mock.Anything, mock.Anything, "main").Return(rebasedCleanly).Once()
+
+	env.RegisterWorkflow(EnhancedTCRWorkflow)
+	env.OnWorkflow(EnhancedTCRWorkflow, mock.Anything, mock.MatchedBy(func(input EnhancedTCRInput) bool {
+		return input.Branch == "main" && input.CellID == "cell-a-requeue-1"
+	})).Return(&EnhancedTCRResult{Success: true, Branch: "worktree-rerun", Commit: "c2"}, nil).Once()
+
+	task := &EnhancedTCRInput{TaskID: "task-a", CellID: "cell-a", Branch: "main"}
+	env.ExecuteWorkflow(MergeQueueWorkflow, MergeQueueInput{
+		OnFailure: MergeFailureRequeue,
+		Requests:  []MergeRequest{{TaskID: "task-a", Branch: "worktree-a", Task: task}},
+	})
+
+	var result *MergeQueueResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Len(t, result.Outcomes, 2)
+	assert.True(t, result.Outcomes[0].Requeued)
+	assert.Zero(t, result.Outcomes[0].FixAttempts, "requeue policy skips the agent")
+	assert.True(t, result.Outcomes[1].Merged)
+	assert.Equal(t, "worktree-rerun", result.Outcomes[1].Branch)
+	assert.Equal(t, 1, result.Merged)
+	assert.Zero(t, result.Failed)
+}
+
+func TestMergeQueue_AcceptsSignaledRequests(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	cellActivities, enhancedActivities, mergeActivities := &CellActivities{}, &EnhancedActivities{}, &MergeActivities{}
+	mockMergeQueueCells(env, cellActivities, enhancedActivities, mergeActivities)
+	env.OnActivity(mergeActivities.RebaseCell, mock.Anything, mock.Anything, mock.Anything, "main").Return(rebasedCleanly)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(MergeQueueEnqueueSignal, MergeRequest{TaskID: "late", Branch: "worktree-late"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(MergeQueueWorkflow, MergeQueueInput{IdleTimeout: 5 * time.Minute})
+
+	var result *MergeQueueResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Len(t, result.Outcomes, 1)
+	assert.Equal(t, "late", result.Outcomes[0].TaskID)
+	assert.True(t, result.Outcomes[0].Merged)
+}
+
+func TestMergeActivities_RebaseAndFastForward(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--quiet", "--initial-branch=main")
+	runGit(t, dir, "config", "user.name", "Test User")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	write("README.md", "# Test\n")
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "--quiet", "-m", "Initial commit")
+
+	// A finished cell branch, then another task lands on main
+	runGit(t, dir, "checkout", "--quiet", "-b", "worktree-cell-a")
+	write("a.go", "package a\n")
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "--quiet", "-m", "Task a")
+	runGit(t, dir, "checkout", "--quiet", "main")
+	write("b.go", "package b\n")
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "--quiet", "-m", "Task b")
+
+	integration := filepath.Join(t.TempDir(), "merge-cell")
+	runGit(t, dir, "worktree", "add", "--quiet", "--detach", integration, "main")
+	bootstrap := &BootstrapOutput{CellID: "merge-cell", WorktreePath: integration}
+
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestActivityEnvironment()
+	activities := NewMergeActivities()
+	env.RegisterActivity(activities)
+
+	value, err := env.ExecuteActivity(activities.RebaseCell, bootstrap, MergeRequest{TaskID: "task-a", Branch: "worktree-cell-a"}, "main")
+	require.NoError(t, err)
+	var attempt *MergeAttempt
+	require.NoError(t, value.Get(&attempt))
+	require.Empty(t, attempt.Conflicts)
+	require.NotEmpty(t, attempt.Rebased)
+
+	// Gates see the task's change as the diff to HEAD
+	assert.Equal(t, []string{"a.go"}, worktreeDiff(context.Background(), integration).Paths())
+
+	value, err = env.ExecuteActivity(activities.FinalizeMerge, bootstrap, attempt, "main", "Integration fixes")
+	require.NoError(t, err)
+	require.NoError(t, value.Get(&attempt))
+	assert.False(t, attempt.Stale)
+	assert.Equal(t, attempt.Rebased, attempt.Merged, "no fixes means no extra commit")
+
+	status, err := git.Open(dir).Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, attempt.Merged, status.Commit, "main worktree follows the fast-forward")
+	assert.True(t, status.IsClean())
+	_, err = os.Stat(filepath.Join(dir, "a.go"))
+	assert.NoError(t, err)
+
+	exists, err := git.Open(dir).BranchExists(context.Background(), "worktree-cell-a")
+	require.NoError(t, err)
+	assert.False(t, exists, "merged cell branch should be deleted")
+}
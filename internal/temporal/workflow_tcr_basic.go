@@ -18,6 +18,7 @@ type TCRWorkflowInput struct {
 	TaskID      string
 	Description string
 	Prompt      string
+	Backend     string // Agent backend; empty means OpenCode
 }
 
 // TCRWorkflowResult contains the workflow result
@@ -42,8 +43,9 @@ func TCRWorkflow(ctx workflow.Context, input TCRWorkflowInput) (*TCRWorkflowResu
 	// Step 1: Bootstrap Cell
 	var bootstrap *BootstrapOutput
 	err := workflow.ExecuteActivity(ctx, cellActivities.BootstrapCell, BootstrapInput{
-		CellID: input.CellID,
-		Branch: input.Branch,
+		CellID:  input.CellID,
+		Branch:  input.Branch,
+		Backend: input.Backend,
 	}).Get(ctx, &bootstrap)
 
 	if err != nil {
@@ -0,0 +1,483 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"open-swarm/internal/orchestration"
+)
+
+// TCRDAGStatusQuery returns the TCRDAGResult of a running TCRDAGWorkflow so far
+const TCRDAGStatusQuery = "tcr-dag-status"
+
+const (
+	defaultTCRDAGMaxParallel        = 4
+	defaultTCRDAGContinueAsNewAfter = 100
+)
+
+// DAGFailurePolicy decides what a failed node does to the rest of the DAG
+type DAGFailurePolicy string
+
+const (
+	// DAGSkipDependents skips every node downstream of the failure and keeps
+	// running independent branches (default)
+	DAGSkipDependents DAGFailurePolicy = "skip_dependents"
+	// DAGFailFast cancels running nodes and stops scheduling new ones
+	DAGFailFast DAGFailurePolicy = "fail_fast"
+	// DAGContinue does not block dependents; they run as if the node succeeded
+	DAGContinue DAGFailurePolicy = "continue"
+)
+
+// DAGNodeStatus is the state of a node in a TCRDAGWorkflow
+type DAGNodeStatus string
+
+const (
+	DAGNodePending   DAGNodeStatus = "pending"
+	DAGNodeRunning   DAGNodeStatus = "running"
+	DAGNodeSucceeded DAGNodeStatus = "succeeded"
+	DAGNodeFailed    DAGNodeStatus = "failed"
+	DAGNodeSkipped   DAGNodeStatus = "skipped"  // A dependency failed
+	DAGNodeCanceled  DAGNodeStatus = "canceled" // Fail-fast or workflow cancellation
+)
+
+// TCRDAGNode is one task of the DAG, run as an EnhancedTCRWorkflow child
+type TCRDAGNode struct {
+	ID        string
+	DependsOn []string
+	// Task is the node's EnhancedTCRWorkflow input. TaskID and CellID default
+	// to the node ID. A node starts from the commit of its first succeeded
+	// dependency, with the commits of the others merged in; without one it
+	// starts from Task.Branch.
+	Task      EnhancedTCRInput
+	OnFailure DAGFailurePolicy // Default: TCRDAGInput.OnFailure
+}
+
+// TCRDAGInput configures TCRDAGWorkflow
+type TCRDAGInput struct {
+	Nodes       []TCRDAGNode
+	MaxParallel int              // Default: 4 - child workflows running at once
+	OnFailure   DAGFailurePolicy // Default: skip_dependents
+	// ContinueAsNewAfter bounds the history of one run: after this many
+	// nodes finish, running nodes are drained and the workflow continues as
+	// new. Default: 100.
+	ContinueAsNewAfter int
+	// Finished carries the results of earlier runs across continue-as-new
+	Finished map[string]TCRDAGNodeResult
+}
+
+// TCRDAGNodeResult is the outcome of one node. Gate results stay in the
+// child workflow's history to keep the DAG's own history small.
+type TCRDAGNodeResult struct {
+	NodeID       string
+	Status       DAGNodeStatus
+	Branch       string // Cell branch holding the commit, for the merge queue
+	Commit       string
+	FilesChanged []string
+	Error        string
+}
+
+// TCRDAGResult summarizes a TCRDAGWorkflow
+type TCRDAGResult struct {
+	Success   bool // Every node succeeded
+	Succeeded int
+	Failed    int
+	Skipped   int
+	Canceled  int
+	Nodes     []TCRDAGNodeResult // In input order
+}
+
+// TCRDAGWorkflow runs a DAG of tasks as EnhancedTCRWorkflow child workflows.
+// A node starts once its dependencies are done, at most MaxParallel at a time.
+// Failures are handled by the node's DAGFailurePolicy, and cancelling the
+// workflow cancels the running children.
+func TCRDAGWorkflow(ctx workflow.Context, input TCRDAGInput) (*TCRDAGResult, error) {
+	logger := workflow.GetLogger(ctx)
+
+	order, err := validateTCRDAG(input.Nodes)
+	if err != nil {
+		return nil, err
+	}
+	if input.MaxParallel <= 0 {
+		input.MaxParallel = defaultTCRDAGMaxParallel
+	}
+	if input.OnFailure == "" {
+		input.OnFailure = DAGSkipDependents
+	}
+	if input.ContinueAsNewAfter <= 0 {
+		input.ContinueAsNewAfter = defaultTCRDAGContinueAsNewAfter
+	}
+	logger.Info("Starting TCR DAG", "nodes", len(input.Nodes), "finished", len(input.Finished), "maxParallel", input.MaxParallel)
+
+	run := newTCRDAGRun(input)
+	if err := workflow.SetQueryHandler(ctx, TCRDAGStatusQuery, func() (*TCRDAGResult, error) {
+		return run.summary(), nil
+	}); err != nil {
+		return nil, err
+	}
+
+	childCtx, cancelChildren := workflow.WithCancel(ctx)
+	defer cancelChildren()
+
+	selector := workflow.NewSelector(ctx)
+	running, finished := 0, 0
+	stopping := false
+
+	for {
+		if ctx.Err() != nil && !stopping {
+			logger.Info("TCR DAG canceled, waiting for running nodes")
+			stopping = true
+		}
+		if !stopping && finished < input.ContinueAsNewAfter && !workflow.GetInfo(ctx).GetContinueAsNewSuggested() {
+			run.skipBlocked(order)
+			for _, id := range order {
+				if running >= input.MaxParallel {
+					break
+				}
+				if run.results[id].Status != DAGNodePending || !run.ready(id) {
+					continue
+				}
+				run.results[id].Status = DAGNodeRunning
+				running++
+				nodeID := id
+				selector.AddFuture(run.start(childCtx, nodeID), func(f workflow.Future) {
+					running--
+					finished++
+					if run.finish(ctx, nodeID, f) && run.policy(nodeID) == DAGFailFast {
+						logger.Warn("Node failed with fail-fast policy, canceling the DAG", "node", nodeID)
+						stopping = true
+						cancelChildren()
+					}
+				})
+			}
+		}
+		if running == 0 {
+			break
+		}
+		selector.Select(ctx)
+	}
+
+	if stopping {
+		if ctx.Err() == nil {
+			run.skipBlocked(order) // Fail-fast: dependents of the failure are skipped
+		}
+		run.cancelPending()
+	} else if run.hasPending() {
+		run.skipBlocked(order)
+		if run.hasPending() {
+			logger.Info("Continuing TCR DAG as new", "finished", finished)
+			next := input
+			next.Finished = run.finished()
+			return nil, workflow.NewContinueAsNewError(ctx, TCRDAGWorkflow, next)
+		}
+	}
+
+	result := run.summary()
+	logger.Info("TCR DAG complete", "succeeded", result.Succeeded, "failed", result.Failed,
+		"skipped", result.Skipped, "canceled", result.Canceled)
+	if ctx.Err() != nil {
+		return result, temporal.NewCanceledError(result)
+	}
+	return result, nil
+}
+
+// tcrDAGRun is the node state of one TCRDAGWorkflow run
+type tcrDAGRun struct {
+	input   TCRDAGInput
+	nodes   map[string]TCRDAGNode
+	results map[string]*TCRDAGNodeResult
+}
+
+func newTCRDAGRun(input TCRDAGInput) *tcrDAGRun {
+	run := &tcrDAGRun{
+		input:   input,
+		nodes:   make(map[string]TCRDAGNode, len(input.Nodes)),
+		results: make(map[string]*TCRDAGNodeResult, len(input.Nodes)),
+	}
+	for _, node := range input.Nodes {
+		run.nodes[node.ID] = node
+		result := TCRDAGNodeResult{NodeID: node.ID, Status: DAGNodePending}
+		if previous, ok := input.Finished[node.ID]; ok {
+			result = previous
+		}
+		run.results[node.ID] = &result
+	}
+	return run
+}
+
+func (r *tcrDAGRun) policy(id string) DAGFailurePolicy {
+	if policy := r.nodes[id].OnFailure; policy != "" {
+		return policy
+	}
+	return r.input.OnFailure
+}
+
+// satisfied reports whether a dependency lets its dependents run
+func (r *tcrDAGRun) satisfied(id string) bool {
+	switch r.results[id].Status {
+	case DAGNodeSucceeded:
+		return true
+	case DAGNodeFailed:
+		return r.policy(id) == DAGContinue
+	default:
+		return false
+	}
+}
+
+// blocked reports whether a dependency will never let its dependents run
+func (r *tcrDAGRun) blocked(id string) bool {
+	switch r.results[id].Status {
+	case DAGNodeSkipped, DAGNodeCanceled:
+		return true
+	case DAGNodeFailed:
+		return r.policy(id) != DAGContinue
+	default:
+		return false
+	}
+}
+
+func (r *tcrDAGRun) ready(id string) bool {
+	for _, dep := range r.nodes[id].DependsOn {
+		if !r.satisfied(dep) {
+			return false
+		}
+	}
+	return true
+}
+
+// skipBlocked skips pending nodes downstream of a failure. order is
+// topological, so one pass reaches every transitive dependent.
+func (r *tcrDAGRun) skipBlocked(order []string) {
+	for _, id := range order {
+		if r.results[id].Status != DAGNodePending {
+			continue
+		}
+		for _, dep := range r.nodes[id].DependsOn {
+			if r.blocked(dep) {
+				r.results[id].Status = DAGNodeSkipped
+				r.results[id].Error = fmt.Sprintf("dependency %s %s", dep, r.results[dep].Status)
+				break
+			}
+		}
+	}
+}
+
+// start launches the node's child workflow
+func (r *tcrDAGRun) start(ctx workflow.Context, id string) workflow.Future {
+	task := r.nodes[id].Task
+	if task.TaskID == "" {
+		task.TaskID = id
+	}
+	if task.CellID == "" {
+		task.CellID = "dag-" + id
+	}
+	if commits := r.dependencyCommits(id); len(commits) > 0 {
+		task.Branch = commits[0]
+		task.MergeCommits = append(commits[1:], task.MergeCommits...)
+	}
+	ctx = workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID:          fmt.Sprintf("%s-%s", workflow.GetInfo(ctx).WorkflowExecution.ID, id),
+		WaitForCancellation: true,
+	})
+	return workflow.ExecuteChildWorkflow(ctx, EnhancedTCRWorkflow, task)
+}
+
+// dependencyCommits returns the commits of the node's succeeded
+// dependencies, in DependsOn order. Failed dependencies under the continue
+// policy have nothing to build on and are left out.
+func (r *tcrDAGRun) dependencyCommits(id string) []string {
+	var commits []string
+	for _, dep := range r.nodes[id].DependsOn {
+		if result := r.results[dep]; result.Status == DAGNodeSucceeded && result.Commit != "" {
+			commits = append(commits, result.Commit)
+		}
+	}
+	return commits
+}
+
+// finish records the child's outcome and reports whether the node failed
+func (r *tcrDAGRun) finish(ctx workflow.Context, id string, f workflow.Future) bool {
+	result := r.results[id]
+	var child *EnhancedTCRResult
+	err := f.Get(ctx, &child)
+	switch {
+	case temporal.IsCanceledError(err):
+		result.Status = DAGNodeCanceled
+		result.Error = err.Error()
+		return false
+	case err != nil:
+		result.Status = DAGNodeFailed
+		result.Error = err.Error()
+		return true
+	}
+
+	result.Branch = child.Branch
+	result.Commit = child.Commit
+	result.FilesChanged = child.FilesChanged
+	if !child.Success {
+		result.Status = DAGNodeFailed
+		result.Error = child.Error
+		return true
+	}
+	result.Status = DAGNodeSucceeded
+	return false
+}
+
+func (r *tcrDAGRun) hasPending() bool {
+	for _, result := range r.results {
+		if result.Status == DAGNodePending {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *tcrDAGRun) cancelPending() {
+	for _, result := range r.results {
+		if result.Status == DAGNodePending {
+			result.Status = DAGNodeCanceled
+		}
+	}
+}
+
+func (r *tcrDAGRun) finished() map[string]TCRDAGNodeResult {
+	finished := make(map[string]TCRDAGNodeResult, len(r.results))
+	for id, result := range r.results {
+		if result.Status != DAGNodePending && result.Status != DAGNodeRunning {
+			finished[id] = *result
+		}
+	}
+	return finished
+}
+
+func (r *tcrDAGRun) summary() *TCRDAGResult {
+	summary := &TCRDAGResult{Nodes: make([]TCRDAGNodeResult, 0, len(r.input.Nodes))}
+	for _, node := range r.input.Nodes {
+		result := *r.results[node.ID]
+		switch result.Status {
+		case DAGNodeSucceeded:
+			summary.Succeeded++
+		case DAGNodeFailed:
+			summary.Failed++
+		case DAGNodeSkipped:
+			summary.Skipped++
+		case DAGNodeCanceled:
+			summary.Canceled++
+		}
+		summary.Nodes = append(summary.Nodes, result)
+	}
+	summary.Success = summary.Succeeded == len(r.input.Nodes)
+	return summary
+}
+
+// validateTCRDAG checks node IDs, dependencies and policies and returns the
+// node IDs in topological order, ties broken by input order
+func validateTCRDAG(nodes []TCRDAGNode) ([]string, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("invalid DAG: no nodes")
+	}
+	dependents := make(map[string][]string, len(nodes))
+	indegree := make(map[string]int, len(nodes))
+	for _, node := range nodes {
+		if node.ID == "" {
+			return nil, errors.New("invalid DAG: node ID is required")
+		}
+		if _, ok := indegree[node.ID]; ok {
+			return nil, fmt.Errorf("invalid DAG: duplicate node %s", node.ID)
+		}
+		switch node.OnFailure {
+		case "", DAGSkipDependents, DAGFailFast, DAGContinue:
+		default:
+			return nil, fmt.Errorf("invalid DAG: node %s has unknown failure policy %q", node.ID, node.OnFailure)
+		}
+		indegree[node.ID] = len(node.DependsOn)
+	}
+	for _, node := range nodes {
+		for _, dep := range node.DependsOn {
+			if _, ok := indegree[dep]; !ok {
+				return nil, fmt.Errorf("invalid DAG: node %s depends on unknown node %s", node.ID, dep)
+			}
+			dependents[dep] = append(dependents[dep], node.ID)
+		}
+	}
+
+	order := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if indegree[node.ID] == 0 {
+			order = append(order, node.ID)
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		for _, dependent := range dependents[order[i]] {
+			indegree[dependent]--
+			if indegree[dependent] == 0 {
+				order = append(order, dependent)
+			}
+		}
+	}
+	if len(order) != len(nodes) {
+		var cyclic []string
+		for _, node := range nodes {
+			if indegree[node.ID] > 0 {
+				cyclic = append(cyclic, node.ID)
+			}
+		}
+		return nil, fmt.Errorf("invalid DAG: cycle through %s", strings.Join(cyclic, ", "))
+	}
+	return order, nil
+}
+
+// TCRDAGNodesFromAgentConfigs builds DAG nodes from Beads tasks. base holds
+// the settings shared by every node, such as Branch and Backend. Dependencies
+// outside the batch are dropped; they are assumed closed. An
+// "on-failure:<policy>" label sets the node's failure policy, and a
+// "models:<model>,<model>" label its model ladder.
+func TCRDAGNodesFromAgentConfigs(configs []*orchestration.AgentConfig, base EnhancedTCRInput) []TCRDAGNode {
+	inBatch := make(map[string]bool, len(configs))
+	for _, config := range configs {
+		inBatch[config.TaskID] = true
+	}
+
+	nodes := make([]TCRDAGNode, 0, len(configs))
+	for _, config := range configs {
+		task := base
+		task.TaskID = config.TaskID
+		task.CellID = ""
+		task.Description = config.Title
+		if config.Description != "" {
+			task.Description = config.Title + "\n\n" + config.Description
+		}
+		task.AcceptanceCriteria = config.AcceptanceCriteria
+		if config.ReviewersCount > 0 {
+			task.ReviewersCount = config.ReviewersCount
+		}
+		if config.MaxRetries > 0 {
+			task.MaxRetries = config.MaxRetries
+		}
+
+		node := TCRDAGNode{ID: config.TaskID, Task: task}
+		for _, dep := range config.DependsOn {
+			if inBatch[dep] {
+				node.DependsOn = append(node.DependsOn, dep)
+			}
+		}
+		for _, label := range config.Labels {
+			if policy, ok := strings.CutPrefix(label, "on-failure:"); ok {
+				node.OnFailure = DAGFailurePolicy(policy)
+			}
+			if models, ok := strings.CutPrefix(label, "models:"); ok {
+				node.Task.ModelLadder = strings.Split(models, ",")
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
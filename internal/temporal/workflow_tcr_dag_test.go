@@ -0,0 +1,266 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package temporal
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	"open-swarm/internal/orchestration"
+)
+
+// mockDAGChildren mocks EnhancedTCRWorkflow children; tasks in failing
+// fail, and every started task ID is recorded in order
+func mockDAGChildren(env *testsuite.TestWorkflowEnvironment, failing ...string) *[]string {
+	var started []string
+	env.RegisterWorkflow(EnhancedTCRWorkflow)
+	env.OnWorkflow(EnhancedTCRWorkflow, mock.Anything, mock.Anything).Return(
+		func(_ workflow.Context, input EnhancedTCRInput) (*EnhancedTCRResult, error) {
+			started = append(started, input.TaskID)
+			if slices.Contains(failing, input.TaskID) {
+				return &EnhancedTCRResult{Success: false, Error: "gates failed"}, nil
+			}
+			return &EnhancedTCRResult{Success: true, Branch: "worktree-" + input.CellID, Commit: "c-" + input.TaskID}, nil
+		})
+	return &started
+}
+
+// registerSlowDAGChildren registers a stand-in EnhancedTCRWorkflow that runs
+// for an hour, or fails after a minute for tasks in failing. Unlike a mock it
+// runs as a real child workflow, so it sees cancellation.
+func registerSlowDAGChildren(env *testsuite.TestWorkflowEnvironment, failing ...string) {
+	env.RegisterWorkflowWithOptions(func(ctx workflow.Context, input EnhancedTCRInput) (*EnhancedTCRResult, error) {
+		if slices.Contains(failing, input.TaskID) {
+			_ = workflow.Sleep(ctx, time.Minute)
+			return &EnhancedTCRResult{Success: false, Error: "gates failed"}, nil
+		}
+		if err := workflow.Sleep(ctx, time.Hour); err != nil {
+			return nil, err
+		}
+		return &EnhancedTCRResult{Success: true}, nil
+	}, workflow.RegisterOptions{Name: "EnhancedTCRWorkflow"})
+}
+
+func dagStatuses(result *TCRDAGResult) map[string]DAGNodeStatus {
+	statuses := make(map[string]DAGNodeStatus, len(result.Nodes))
+	for _, node := range result.Nodes {
+		statuses[node.NodeID] = node.Status
+	}
+	return statuses
+}
+
+func diamondDAG() []TCRDAGNode {
+	return []TCRDAGNode{
+		{ID: "d", DependsOn: []string{"b", "c"}},
+		{ID: "b", DependsOn: []string{"a"}},
+		{ID: "c", DependsOn: []string{"a"}},
+		{ID: "a"},
+	}
+}
+
+func TestTCRDAGWorkflow_RunsInDependencyOrder(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	started := mockDAGChildren(env)
+
+	env.ExecuteWorkflow(TCRDAGWorkflow, TCRDAGInput{Nodes: diamondDAG(), MaxParallel: 1})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var result *TCRDAGResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.True(t, result.Success)
+	assert.Equal(t, 4, result.Succeeded)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, *started)
+	assert.Equal(t, "d", result.Nodes[0].NodeID, "results keep input order")
+	assert.Equal(t, "worktree-dag-d", result.Nodes[0].Branch)
+}
+
+func TestTCRDAGWorkflow_DependentsStartFromDependencyCommits(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	inputs := make(map[string]EnhancedTCRInput)
+	env.RegisterWorkflow(EnhancedTCRWorkflow)
+	env.OnWorkflow(EnhancedTCRWorkflow, mock.Anything, mock.Anything).Return(
+		func(_ workflow.Context, input EnhancedTCRInput) (*EnhancedTCRResult, error) {
+			inputs[input.TaskID] = input
+			return &EnhancedTCRResult{Success: true, Branch: "worktree-" + input.CellID, Commit: "c-" + input.TaskID}, nil
+		})
+
+	nodes := diamondDAG()
+	for i := range nodes {
+		nodes[i].Task.Branch = "main"
+	}
+	env.ExecuteWorkflow(TCRDAGWorkflow, TCRDAGInput{Nodes: nodes})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	assert.Equal(t, "main", inputs["a"].Branch)
+	assert.Empty(t, inputs["a"].MergeCommits)
+	assert.Equal(t, "c-a", inputs["b"].Branch, "a dependent starts from its dependency's commit")
+	assert.Equal(t, "c-a", inputs["c"].Branch)
+	assert.Equal(t, "c-b", inputs["d"].Branch)
+	assert.Equal(t, []string{"c-c"}, inputs["d"].MergeCommits, "the other dependencies are merged in")
+}
+
+func TestTCRDAGWorkflow_SkipsDependentsOfFailure(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	mockDAGChildren(env, "b")
+
+	env.ExecuteWorkflow(TCRDAGWorkflow, TCRDAGInput{Nodes: append(diamondDAG(), TCRDAGNode{ID: "e", DependsOn: []string{"d"}})})
+
+	var result *TCRDAGResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.False(t, result.Success)
+	assert.Equal(t, map[string]DAGNodeStatus{
+		"a": DAGNodeSucceeded,
+		"b": DAGNodeFailed,
+		"c": DAGNodeSucceeded, // Independent branch keeps running
+		"d": DAGNodeSkipped,
+		"e": DAGNodeSkipped,
+	}, dagStatuses(result))
+	assert.Equal(t, 2, result.Skipped)
+}
+
+func TestTCRDAGWorkflow_ContinuePolicyDoesNotBlockDependents(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	mockDAGChildren(env, "a")
+
+	nodes := []TCRDAGNode{{ID: "a", OnFailure: DAGContinue}, {ID: "b", DependsOn: []string{"a"}}}
+	env.ExecuteWorkflow(TCRDAGWorkflow, TCRDAGInput{Nodes: nodes})
+
+	var result *TCRDAGResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.Equal(t, map[string]DAGNodeStatus{"a": DAGNodeFailed, "b": DAGNodeSucceeded}, dagStatuses(result))
+}
+
+func TestTCRDAGWorkflow_FailFastCancelsRunningNodes(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	registerSlowDAGChildren(env, "a")
+
+	nodes := []TCRDAGNode{
+		{ID: "a", OnFailure: DAGFailFast},
+		{ID: "slow"},
+		{ID: "after-a", DependsOn: []string{"a"}},
+		{ID: "queued"},
+	}
+	env.ExecuteWorkflow(TCRDAGWorkflow, TCRDAGInput{Nodes: nodes, MaxParallel: 2})
+
+	var result *TCRDAGResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.Equal(t, map[string]DAGNodeStatus{
+		"a":       DAGNodeFailed,
+		"slow":    DAGNodeCanceled,
+		"after-a": DAGNodeSkipped,
+		"queued":  DAGNodeCanceled,
+	}, dagStatuses(result))
+}
+
+func TestTCRDAGWorkflow_CancellationPropagatesToChildren(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	registerSlowDAGChildren(env)
+	env.RegisterDelayedCallback(env.CancelWorkflow, time.Minute)
+
+	nodes := []TCRDAGNode{{ID: "a"}, {ID: "b", DependsOn: []string{"a"}}}
+	env.ExecuteWorkflow(TCRDAGWorkflow, TCRDAGInput{Nodes: nodes})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var canceled *temporal.CanceledError
+	require.True(t, errors.As(env.GetWorkflowError(), &canceled))
+	var result *TCRDAGResult
+	require.NoError(t, canceled.Details(&result))
+	assert.Equal(t, map[string]DAGNodeStatus{"a": DAGNodeCanceled, "b": DAGNodeCanceled}, dagStatuses(result))
+}
+
+func TestTCRDAGWorkflow_ContinuesAsNew(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	started := mockDAGChildren(env)
+
+	nodes := []TCRDAGNode{{ID: "a"}, {ID: "b"}, {ID: "c", DependsOn: []string{"a"}}}
+	env.ExecuteWorkflow(TCRDAGWorkflow, TCRDAGInput{Nodes: nodes, MaxParallel: 1, ContinueAsNewAfter: 2})
+
+	var continueAsNew *workflow.ContinueAsNewError
+	require.True(t, errors.As(env.GetWorkflowError(), &continueAsNew))
+	assert.Equal(t, []string{"a", "b"}, *started)
+
+	// The next run starts from the carried-over results
+	env = ts.NewTestWorkflowEnvironment()
+	started = mockDAGChildren(env)
+	env.ExecuteWorkflow(TCRDAGWorkflow, TCRDAGInput{Nodes: nodes, Finished: map[string]TCRDAGNodeResult{
+		"a": {NodeID: "a", Status: DAGNodeSucceeded},
+		"b": {NodeID: "b", Status: DAGNodeSucceeded},
+	}})
+
+	var result *TCRDAGResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.True(t, result.Success)
+	assert.Equal(t, []string{"c"}, *started)
+}
+
+func TestValidateTCRDAG(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   []TCRDAGNode
+		wantErr string
+	}{
+		{"empty", nil, "no nodes"},
+		{"duplicate", []TCRDAGNode{{ID: "a"}, {ID: "a"}}, "duplicate node a"},
+		{"unknown dependency", []TCRDAGNode{{ID: "a", DependsOn: []string{"x"}}}, "unknown node x"},
+		{"unknown policy", []TCRDAGNode{{ID: "a", OnFailure: "retry"}}, `unknown failure policy "retry"`},
+		{"cycle", []TCRDAGNode{
+			{ID: "a", DependsOn: []string{"c"}},
+			{ID: "b", DependsOn: []string{"a"}},
+			{ID: "c", DependsOn: []string{"b"}},
+			{ID: "d"},
+		}, "cycle through a, b, c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validateTCRDAG(tt.nodes)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	order, err := validateTCRDAG(diamondDAG())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, order)
+}
+
+func TestTCRDAGNodesFromAgentConfigs(t *testing.T) {
+	configs := []*orchestration.AgentConfig{
+		{TaskID: "open-swarm-1", Title: "Add parser", Description: "Parse input", AcceptanceCriteria: "tests pass", MaxRetries: 5},
+		{TaskID: "open-swarm-2", Title: "Use parser", DependsOn: []string{"open-swarm-1", "open-swarm-0"},
+			Labels: []string{"on-failure:fail_fast", "models:anthropic/claude-haiku-4-5,openai/gpt-5"}, ReviewersCount: 3},
+	}
+
+	nodes := TCRDAGNodesFromAgentConfigs(configs, EnhancedTCRInput{Branch: "main", Backend: "claude-code", ReviewersCount: 1})
+
+	require.Len(t, nodes, 2)
+	assert.Equal(t, "open-swarm-1", nodes[0].Task.TaskID)
+	assert.Equal(t, "Add parser\n\nParse input", nodes[0].Task.Description)
+	assert.Equal(t, 5, nodes[0].Task.MaxRetries)
+	assert.Equal(t, 1, nodes[0].Task.ReviewersCount)
+	assert.Equal(t, "claude-code", nodes[0].Task.Backend)
+	assert.Equal(t, []string{"open-swarm-1"}, nodes[1].DependsOn, "closed dependency outside the batch is dropped")
+	assert.Equal(t, DAGFailFast, nodes[1].OnFailure)
+	assert.Equal(t, 3, nodes[1].Task.ReviewersCount)
+	assert.Equal(t, []string{"anthropic/claude-haiku-4-5", "openai/gpt-5"}, nodes[1].Task.ModelLadder)
+	assert.Empty(t, nodes[0].Task.ModelLadder)
+}
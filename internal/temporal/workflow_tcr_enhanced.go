@@ -24,6 +24,31 @@ type gateExecutor struct {
 
 // executeGate runs a gate activity and handles result tracking
 func (ge *gateExecutor) executeGate(gateName string, activityFn interface{}, args ...interface{}) error {
+	return ge.finishGate(gateName, ge.runGate(gateName, activityFn, args...))
+}
+
+// executeLintGate runs the LintTest gate. Failures with parsed issues go
+// through deterministic auto-fix, then an agent fix for what remains, before
+// the gate is failed. The agent fix uses model.
+func (ge *gateExecutor) executeLintGate(activities *EnhancedActivities, taskID, fmtCommand, model string) error {
+	gateResult := ge.runGate("LintTest", activities.ExecuteLintTest, ge.bootstrap)
+	if !gateResult.Passed && hasLintIssues(gateResult) {
+		ge.result.GateResults = append(ge.result.GateResults, *gateResult)
+		var steps []GateResult
+		steps, gateResult = remediateLint(ge.ctx, ge.logger, activities, ge.bootstrap, taskID, fmtCommand, model, gateResult)
+		for _, step := range steps {
+			ge.result.GateResults = append(ge.result.GateResults, step)
+			for _, agentResult := range step.AgentResults {
+				ge.result.FilesChanged = append(ge.result.FilesChanged, agentResult.FilesChanged...)
+			}
+		}
+	}
+	return ge.finishGate("LintTest", gateResult)
+}
+
+// runGate executes a gate activity and returns its result; activity errors
+// become a failed result
+func (ge *gateExecutor) runGate(gateName string, activityFn interface{}, args ...interface{}) *GateResult {
 	ge.logger.Info(fmt.Sprintf("Gate: %s", gateName))
 	gateStart := workflow.Now(ge.ctx)
 
@@ -39,8 +64,13 @@ func (ge *gateExecutor) executeGate(gateName string, activityFn interface{}, arg
 		}
 	} else {
 		gateResult.Duration = workflow.Now(ge.ctx).Sub(gateStart)
+		recordTaskSession(ge.bootstrap, gateResult)
 	}
+	return gateResult
+}
 
+// finishGate records a gate result, reverting changes when it failed
+func (ge *gateExecutor) finishGate(gateName string, gateResult *GateResult) error {
 	ge.result.GateResults = append(ge.result.GateResults, *gateResult)
 
 	if !gateResult.Passed {
@@ -103,14 +133,17 @@ func EnhancedTCRWorkflow(ctx workflow.Context, input EnhancedTCRInput) (*Enhance
 	logger.Info("Gate: Bootstrap")
 	var bootstrap *BootstrapOutput
 	err := workflow.ExecuteActivity(ctx, cellActivities.BootstrapCell, BootstrapInput{
-		CellID: input.CellID,
-		Branch: input.Branch,
+		CellID:  input.CellID,
+		Branch:  input.Branch,
+		Backend: input.Backend,
 	}).Get(ctx, &bootstrap)
 
 	if err != nil {
 		result.Error = fmt.Sprintf("bootstrap failed: %v", err)
 		return result, nil
 	}
+	// Agent gates share one session unless the task asks for one per gate
+	startTaskSession(bootstrap, input.SessionPolicy, input.SessionMaxTurns)
 
 	// SAGA PATTERN: Ensure cleanup happens (teardown + lock release)
 	var locksAcquired []string
@@ -129,6 +162,14 @@ func EnhancedTCRWorkflow(ctx workflow.Context, input EnhancedTCRInput) (*Enhance
 		_ = workflow.ExecuteActivity(sagaCtx, cellActivities.TeardownCell, bootstrap).Get(sagaCtx, nil)
 	}()
 
+	// Work this task builds on, e.g. other DAG nodes it depends on
+	if len(input.MergeCommits) > 0 {
+		if err := workflow.ExecuteActivity(ctx, cellActivities.MergeCommits, bootstrap, input.MergeCommits).Get(ctx, nil); err != nil {
+			result.Error = fmt.Sprintf("failed to merge dependencies: %v", err)
+			return result, nil
+		}
+	}
+
 	// STEP 2: Acquire File Locks
 	logger.Info("Acquiring file locks for task files")
 	var filesLocked []string
@@ -158,8 +199,11 @@ func EnhancedTCRWorkflow(ctx workflow.Context, input EnhancedTCRInput) (*Enhance
 		return result, nil
 	}
 
-	// GATE 2: LintTest - Lint Test Files
-	if err := executor.executeGate("LintTest", enhancedActivities.ExecuteLintTest, bootstrap); err != nil {
+	// GATE 2: LintTest - Lint Test Files (auto-fix, then agent fix, before failing)
+	// Lint fixes use the model of the first implementation attempt, or a
+	// routed cheap model when the task has no model ladder
+	lintFixModel := newModelLadder(input.ModelLadder).forAttempt(1)
+	if err := executor.executeLintGate(enhancedActivities, input.TaskID, input.FmtCommand, lintFixModel); err != nil {
 		return result, nil
 	}
 
@@ -173,14 +217,21 @@ func EnhancedTCRWorkflow(ctx workflow.Context, input EnhancedTCRInput) (*Enhance
 	// Inner loop: Targeted fix attempts (preserves working code)
 	var feedback string
 	success := false
+	ladder := newModelLadder(input.ModelLadder)
 
 OuterLoop:
 	for regenAttempt := 1; regenAttempt <= maxRetries; regenAttempt++ {
 		logger.Info("Regeneration attempt", "attempt", regenAttempt, "maxRetries", maxRetries)
+		if regenAttempt > 1 {
+			// A full regeneration discards the approach, and its session with it
+			resetTaskSession(bootstrap)
+		}
 
-		// GATE 4: GenImpl - Generate Implementation (full generation)
-		if err := executor.executeGate("GenImpl", enhancedActivities.ExecuteGenImpl,
-			bootstrap, input.TaskID, input.Description, input.AcceptanceCriteria, feedback); err != nil {
+		// GATE 4: GenImpl - Generate Implementation (full generation) with the
+		// attempt's model; provider errors switch providers without a retry
+		providerFailures, genImplResult := runGenImpl(executor, enhancedActivities, ladder, input, feedback, regenAttempt)
+		result.GateResults = append(result.GateResults, providerFailures...)
+		if err := executor.finishGate("GenImpl", genImplResult); err != nil {
 			// GenImpl itself failed - don't retry, it's a fundamental issue
 			return result, nil
 		}
@@ -221,6 +272,7 @@ OuterLoop:
 						logger.Warn("Targeted fix failed", "error", err)
 					}
 					if fixResult != nil {
+						recordTaskSession(bootstrap, fixResult)
 						result.GateResults = append(result.GateResults, *fixResult)
 					}
 					continue
@@ -238,12 +290,12 @@ OuterLoop:
 				return result, nil
 			}
 
-			// GATE 6: MultiReview - Reviewers with Unanimous Approval
+			// GATE 6: MultiReview - Reviewer votes decided by the review policy
 			var reviewResult *GateResult
 			logger.Info("Gate: MultiReview")
 			gateStart = workflow.Now(ctx)
 			err = workflow.ExecuteActivity(ctx, enhancedActivities.ExecuteMultiReview,
-				bootstrap, input.TaskID, input.Description, reviewersCount).Get(ctx, &reviewResult)
+				bootstrap, input.TaskID, input.Description, 0, reviewersCount, input.ReviewPolicy).Get(ctx, &reviewResult)
 
 			if err != nil {
 				reviewResult = &GateResult{
@@ -258,8 +310,14 @@ OuterLoop:
 			result.GateResults = append(result.GateResults, *reviewResult)
 
 			if !reviewResult.Passed { //nolint:dupl // Similar but contextually different from VerifyGREEN handling
+				// REJECT discards the approach: skip targeted fixes and regenerate
+				rejected := reviewResult.ReviewDecision != nil && reviewResult.ReviewDecision.Outcome == ReviewOutcomeRejected
+				if rejected {
+					logger.Info("MultiReview rejected the implementation", "reason", reviewResult.ReviewDecision.Reason)
+				}
+
 				// Reviewers requested changes - try targeted fix (don't revert!)
-				if fixAttempt < maxFixAttempts {
+				if !rejected && fixAttempt < maxFixAttempts {
 					logger.Info("MultiReview failed, applying targeted fix", "fixAttempt", fixAttempt)
 					reviewFeedback := extractReviewerFeedback(reviewResult)
 
@@ -271,13 +329,14 @@ OuterLoop:
 						logger.Warn("Targeted fix failed", "error", err)
 					}
 					if fixResult != nil {
+						recordTaskSession(bootstrap, fixResult)
 						result.GateResults = append(result.GateResults, *fixResult)
 					}
 					continue
 				}
-				// Max fix attempts reached - try full regeneration
+				// Rejected or max fix attempts reached - try full regeneration
 				if regenAttempt < maxRetries {
-					logger.Info("Max fix attempts reached, reverting for full regeneration")
+					logger.Info("Reverting for full regeneration", "rejected", rejected)
 					feedback = extractReviewerFeedback(reviewResult)
 					_ = workflow.ExecuteActivity(ctx, cellActivities.RevertChanges, bootstrap).Get(ctx, nil)
 					continue OuterLoop
@@ -310,6 +369,9 @@ OuterLoop:
 		return result, nil
 	}
 
+	// Record where the commit lives so the merge queue can integrate it
+	recordCellHead(ctx, logger, cellActivities, bootstrap, result)
+
 	// Success!
 	result.Success = true
 	logger.Info("Enhanced TCR Workflow completed successfully", "taskID", input.TaskID)
@@ -317,6 +379,77 @@ OuterLoop:
 	return result, nil
 }
 
+// recordCellHead stores the cell's branch and commit in the result. A
+// failure only costs merge-queue integration, so it is logged, not fatal.
+func recordCellHead(ctx workflow.Context, logger log.Logger, cellActivities *CellActivities, bootstrap *BootstrapOutput, result *EnhancedTCRResult) {
+	var head *CellHeadOutput
+	if err := workflow.ExecuteActivity(ctx, cellActivities.CellHead, bootstrap).Get(ctx, &head); err != nil || head == nil {
+		logger.Warn("Failed to read cell head", "error", err)
+		return
+	}
+	result.Branch = head.Branch
+	result.Commit = head.Commit
+}
+
+// hasLintIssues reports whether a lint gate failed with parsed issues that
+// remediation can work on
+func hasLintIssues(gateResult *GateResult) bool {
+	return gateResult != nil && gateResult.LintResult != nil && len(gateResult.LintResult.Issues) > 0
+}
+
+// remediateLint tries to clear a failed lint gate without regeneration.
+// Auto-fix runs first; only issues it leaves are sent to the agent on model, after
+// which lint runs again. Returns the intermediate gate results and the final
+// lint result, whose LintResult records MachineFixed and AgentFixed issues.
+func remediateLint(ctx workflow.Context, logger log.Logger, activities *EnhancedActivities, bootstrap *BootstrapOutput, taskID, fmtCommand, model string, failed *GateResult) ([]GateResult, *GateResult) {
+	issues := failed.LintResult.Issues
+	logger.Info("Lint failed, running auto-fix", "issues", len(issues))
+
+	var autoFix *GateResult
+	err := workflow.ExecuteActivity(ctx, activities.ExecuteLintAutoFix, bootstrap, issues, fmtCommand).Get(ctx, &autoFix)
+	if err != nil || autoFix == nil || autoFix.LintResult == nil {
+		logger.Warn("Lint auto-fix failed", "error", err)
+		autoFix = &GateResult{GateName: "lint_autofix", LintResult: &LintResult{Issues: issues}}
+		if err != nil {
+			autoFix.Error = err.Error()
+		}
+	}
+	machineFixed := autoFix.LintResult.MachineFixed
+
+	// The final result stands in for the lint gate in reports
+	final := *autoFix
+	final.GateName = failed.GateName
+	if autoFix.Passed {
+		logger.Info("Lint auto-fix resolved all issues", "machineFixed", len(machineFixed))
+		return nil, &final
+	}
+
+	remaining := autoFix.LintResult.Issues
+	steps := []GateResult{*autoFix}
+	logger.Info("Sending remaining lint issues to agent", "machineFixed", len(machineFixed), "remaining", len(remaining))
+
+	var fixResult *GateResult
+	err = workflow.ExecuteActivity(ctx, activities.ExecuteLintFix, bootstrap, taskID, remaining, model).Get(ctx, &fixResult)
+	if err != nil || fixResult == nil {
+		logger.Warn("Lint fix failed", "error", err)
+		return steps, &final
+	}
+	recordTaskSession(bootstrap, fixResult)
+	steps = append(steps, *fixResult)
+
+	var relint *GateResult
+	if err := workflow.ExecuteActivity(ctx, activities.ExecuteLintTest, bootstrap).Get(ctx, &relint); err != nil || relint == nil {
+		logger.Warn("Lint after agent fix failed", "error", err)
+		return steps, &final
+	}
+	if relint.LintResult == nil {
+		relint.LintResult = &LintResult{Passed: relint.Passed}
+	}
+	relint.LintResult.MachineFixed = machineFixed
+	relint.LintResult.AgentFixed = resolvedLintIssues(remaining, relint.LintResult.Issues)
+	return steps, relint
+}
+
 // executeEnhancedTCRGates executes all 6 gates of the Enhanced TCR workflow in sequence
 // extractTestFeedback extracts structured test failure feedback for targeted fixes
 func extractTestFeedback(testResult *GateResult) string {
@@ -347,15 +480,27 @@ func extractReviewerFeedback(reviewResult *GateResult) string {
 	var feedback strings.Builder
 	feedback.WriteString("Reviewer feedback from previous attempt:\n\n")
 
-	// Extract feedback from review votes
-	if len(reviewResult.ReviewVotes) > 0 {
-		for _, vote := range reviewResult.ReviewVotes {
-			if vote.Vote != VoteApprove {
-				feedback.WriteString(fmt.Sprintf("- %s (%s): %s\n", vote.ReviewerName, vote.Vote, vote.Feedback))
-			}
+	// Line-anchored findings make for targeted fixes; only reviewers without
+	// structured findings contribute their free-text feedback
+	if findings := collectFindings(reviewResult.ReviewVotes); len(findings) > 0 {
+		feedback.WriteString(FormatFindingsForFix(findings))
+		feedback.WriteString("\n")
+	}
+	for _, vote := range reviewResult.ReviewVotes {
+		if vote.Vote == VoteApprove {
+			continue
+		}
+		if vote.Summary != "" {
+			feedback.WriteString(fmt.Sprintf("- %s (%s): %s\n", vote.ReviewerName, vote.Vote, vote.Summary))
+		} else if len(vote.Findings) == 0 {
+			feedback.WriteString(fmt.Sprintf("- %s (%s): %s\n", vote.ReviewerName, vote.Vote, vote.Feedback))
 		}
 	}
 
+	if decision := reviewResult.ReviewDecision; decision != nil {
+		feedback.WriteString(fmt.Sprintf("\nDecision (%s policy): %s - %s\n", decision.Policy, decision.Outcome, decision.Reason))
+	}
+
 	// Include error message if present
 	if reviewResult.Error != "" {
 		feedback.WriteString(fmt.Sprintf("\nError: %s\n", reviewResult.Error))
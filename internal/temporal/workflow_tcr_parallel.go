@@ -118,6 +118,14 @@ func ParallelTCRWorkflow(ctx workflow.Context, input EnhancedTCRInput) (*Enhance
 	}
 	result.GateResults = append(result.GateResults, *lintTestResult)
 
+	if !lintTestResult.Passed && hasLintIssues(lintTestResult) {
+		var steps []GateResult
+		steps, lintTestResult = remediateLint(ctx, logger, enhancedActivities, bootstrap, input.TaskID, input.FmtCommand,
+			newModelLadder(input.ModelLadder).forAttempt(1), lintTestResult)
+		result.GateResults = append(result.GateResults, steps...)
+		result.GateResults = append(result.GateResults, *lintTestResult)
+	}
+
 	if !lintTestResult.Passed {
 		result.Error = "LintTest failed - regenerating tests"
 		_ = workflow.ExecuteActivity(ctx, cellActivities.RevertChanges, bootstrap).Get(ctx, nil)
@@ -145,22 +153,19 @@ func ParallelTCRWorkflow(ctx workflow.Context, input EnhancedTCRInput) (*Enhance
 
 	var feedback string
 	success := false
+	ladder := newModelLadder(input.ModelLadder)
 
 OuterLoop:
 	for regenAttempt := 1; regenAttempt <= maxRetries; regenAttempt++ {
 		logger.Info("Regeneration attempt", "attempt", regenAttempt, "maxRetries", maxRetries)
 
 		// Gate 4: GenImpl
-		var genImplResult *GateResult
-		if err := workflow.ExecuteActivity(ctx, enhancedActivities.ExecuteGenImpl,
-			bootstrap, input.TaskID, input.Description, input.AcceptanceCriteria, feedback).Get(ctx, &genImplResult); err != nil {
-			result.Error = fmt.Sprintf("GenImpl failed: %v", err)
-			return result, nil
-		}
+		providerFailures, genImplResult := genImplGate(ctx, logger, enhancedActivities, ladder, bootstrap, input, feedback, regenAttempt)
+		result.GateResults = append(result.GateResults, providerFailures...)
 		result.GateResults = append(result.GateResults, *genImplResult)
 
 		if !genImplResult.Passed {
-			result.Error = "GenImpl failed"
+			result.Error = fmt.Sprintf("GenImpl failed: %v", genImplResult.Error)
 			return result, nil
 		}
 
@@ -239,12 +244,12 @@ OuterLoop:
 			for i := 0; i < reviewersCount; i++ {
 				reviewFutures[i] = workflow.ExecuteActivity(ctx,
 					enhancedActivities.ExecuteMultiReview,
-					bootstrap, input.TaskID, input.Description, 1) // 1 reviewer per activity
+					bootstrap, input.TaskID, input.Description, i, 1, input.ReviewPolicy) // reviewer slot i; slot 0 adds specialized reviewers
 			}
 
 			// Collect review results
 			var reviews []*GateResult
-			passCount := 0
+			var votes []ReviewVote
 			for i, future := range reviewFutures {
 				var reviewResult *GateResult
 				if err := future.Get(ctx, &reviewResult); err != nil {
@@ -257,20 +262,29 @@ OuterLoop:
 				reviews = append(reviews, reviewResult)
 				result.GateResults = append(result.GateResults, *reviewResult)
 
-				if reviewResult.Passed {
-					passCount++
+				if len(reviewResult.ReviewVotes) > 0 {
+					votes = append(votes, reviewResult.ReviewVotes...)
+				} else {
+					// Reviewers that failed outright or returned no vote count as their gate verdict
+					vote := VoteRequestChange
+					if reviewResult.Passed {
+						vote = VoteApprove
+					}
+					votes = append(votes, ReviewVote{ReviewerName: fmt.Sprintf("reviewer-%d", i+1), Vote: vote, Feedback: reviewResult.Error, Failed: reviewResult.Error != ""})
 				}
 			}
 
-			// Check if all reviewers approved (unanimous)
-			if passCount == reviewersCount {
-				logger.Info("All reviewers approved!")
+			// Combine the independent votes under the task's review policy
+			decision := input.ReviewPolicy.Decide(votes)
+			if decision.Approved() {
+				logger.Info("Reviewers approved", "policy", decision.Policy, "reason", decision.Reason)
 				success = true
 				break OuterLoop
 			}
+			rejected := decision.Outcome == ReviewOutcomeRejected
 
-			// Some reviewers rejected - try targeted fix
-			if fixAttempt < maxFixAttempts {
+			// Reviewers requested changes - try targeted fix
+			if !rejected && fixAttempt < maxFixAttempts {
 				logger.Info("Reviewers requested changes, applying targeted fix")
 				var feedbackParts []string
 				for _, review := range reviews {
@@ -333,6 +347,9 @@ OuterLoop:
 		return result, nil
 	}
 
+	// Record where the commit lives so the merge queue can integrate it
+	recordCellHead(ctx, logger, cellActivities, bootstrap, result)
+
 	result.Success = true
 	logger.Info("Parallel TCR Workflow completed successfully", "taskID", input.TaskID)
 	return result, nil
@@ -34,7 +34,7 @@ func TestParallelTCR_ParallelReviewers(t *testing.T) {
 		&GateResult{GateName: "VerifyRED", Passed: true}, nil)
 
 	// Implementation phase
-	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "GenImpl", Passed: true}, nil)
 
 	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
@@ -42,14 +42,18 @@ func TestParallelTCR_ParallelReviewers(t *testing.T) {
 
 	// Multiple reviewers execute in parallel
 	reviewCount := 0
-	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+	var reviewerSlots []int
+	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 		reviewCount++
+		reviewerSlots = append(reviewerSlots, args.Int(4))
 	}).Return(&GateResult{
 		GateName: "MultiReview",
 		Passed:   true,
 	}, nil)
 
 	env.OnActivity(cellActivities.CommitChanges, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CellHead, mock.Anything, mock.Anything).Return(
+		&CellHeadOutput{Branch: "task/parallel-1", Commit: "11111111"}, nil)
 
 	env.OnActivity(enhancedActivities.ReleaseFileLocks, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -72,7 +76,10 @@ func TestParallelTCR_ParallelReviewers(t *testing.T) {
 	var result *EnhancedTCRResult
 	require.NoError(t, env.GetWorkflowResult(&result))
 	require.True(t, result.Success)
+	require.Equal(t, "task/parallel-1", result.Branch, "branch should come from CellHead")
+	require.Equal(t, "11111111", result.Commit, "commit should come from CellHead")
 	require.Equal(t, 3, reviewCount, "should have called ExecuteMultiReview 3 times (parallel)")
+	require.ElementsMatch(t, []int{0, 1, 2}, reviewerSlots, "each activity should run its own reviewer slot")
 }
 
 // TestParallelTCR_ParallelFixes tests concurrent fix attempts
@@ -101,7 +108,7 @@ func TestParallelTCR_ParallelFixes(t *testing.T) {
 		&GateResult{GateName: "VerifyRED", Passed: true}, nil)
 
 	// Implementation phase
-	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "GenImpl", Passed: true}, nil)
 
 	// VerifyGREEN fails first, then passes after parallel fix attempts
@@ -118,10 +125,12 @@ func TestParallelTCR_ParallelFixes(t *testing.T) {
 	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "VerifyGREEN", Passed: true}, nil)
 
-	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "MultiReview", Passed: true}, nil)
 
 	env.OnActivity(cellActivities.CommitChanges, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CellHead, mock.Anything, mock.Anything).Return(
+		&CellHeadOutput{Branch: "task/parallel-2", Commit: "22222222"}, nil)
 
 	env.OnActivity(enhancedActivities.ReleaseFileLocks, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -142,6 +151,8 @@ func TestParallelTCR_ParallelFixes(t *testing.T) {
 	var result *EnhancedTCRResult
 	require.NoError(t, env.GetWorkflowResult(&result))
 	require.True(t, result.Success)
+	require.Equal(t, "task/parallel-2", result.Branch, "branch should come from CellHead")
+	require.Equal(t, "22222222", result.Commit, "commit should come from CellHead")
 	require.Equal(t, 3, fixCount, "should have attempted 3 parallel fixes")
 }
 
@@ -170,17 +181,19 @@ func TestParallelTCR_HappyPath(t *testing.T) {
 	env.OnActivity(enhancedActivities.ExecuteVerifyRED, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "VerifyRED", Passed: true}, nil)
 
-	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	env.OnActivity(enhancedActivities.ExecuteGenImpl, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "GenImpl", Passed: true}, nil)
 
 	env.OnActivity(enhancedActivities.ExecuteVerifyGREEN, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "VerifyGREEN", Passed: true}, nil)
 
 	// Multiple reviewers in parallel
-	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+	env.OnActivity(enhancedActivities.ExecuteMultiReview, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 		&GateResult{GateName: "MultiReview", Passed: true}, nil)
 
 	env.OnActivity(cellActivities.CommitChanges, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(cellActivities.CellHead, mock.Anything, mock.Anything).Return(
+		&CellHeadOutput{Branch: "task/parallel-3", Commit: "33333333"}, nil)
 
 	env.OnActivity(enhancedActivities.ReleaseFileLocks, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -202,4 +215,6 @@ func TestParallelTCR_HappyPath(t *testing.T) {
 	var result *EnhancedTCRResult
 	require.NoError(t, env.GetWorkflowResult(&result))
 	require.True(t, result.Success)
+	require.Equal(t, "task/parallel-3", result.Branch, "branch should come from CellHead")
+	require.Equal(t, "33333333", result.Commit, "commit should come from CellHead")
 }
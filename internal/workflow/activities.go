@@ -8,18 +8,27 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"open-swarm/internal/agent"
+	"open-swarm/internal/git"
 	"open-swarm/internal/infra"
 )
 
+// maxPortCollisionRetries bounds how often BootstrapCell re-allocates a port
+// that turned out to be bound by a foreign process
+const maxPortCollisionRetries = 3
+
 // Activities contains all workflow activities
 type Activities struct {
 	portManager     infra.PortManagerInterface
 	serverManager   infra.ServerManagerInterface
 	worktreeManager infra.WorktreeManagerInterface
+	warmPool        *WarmPool
+	cassette        *agent.Cassette
+	cassetteMode    agent.CassetteMode
 }
 
 // NewActivities creates a new Activities instance
@@ -31,6 +40,43 @@ func NewActivities(portMgr infra.PortManagerInterface, serverMgr infra.ServerMan
 	}
 }
 
+// SetWarmPool enables serving bootstraps from a pool of pre-booted cells
+func (a *Activities) SetWarmPool(pool *WarmPool) {
+	a.warmPool = pool
+}
+
+// SetCassette records agent interactions into, or replays them from, a cassette.
+// In replay mode cells need no server and prompts never leave the worker.
+func (a *Activities) SetCassette(cassette *agent.Cassette, mode agent.CassetteMode) {
+	a.cassette = cassette
+	a.cassetteMode = mode
+}
+
+// CellClient builds the agent client for a cell, honouring the cassette mode.
+// baseURL and port are only used by the OpenCode backend.
+func (a *Activities) CellClient(backend agent.Backend, baseURL string, port int, workDir string) (agent.ClientInterface, error) {
+	if a.cassette != nil && a.cassetteMode == agent.CassetteReplay {
+		return agent.NewReplayClient(workDir, a.cassette), nil
+	}
+
+	if backend.NeedsServer() {
+		return a.recordClient(agent.NewClient(baseURL, port), workDir), nil
+	}
+	client, err := agent.NewBackendClient(backend, workDir)
+	if err != nil {
+		return nil, err
+	}
+	return a.recordClient(client, workDir), nil
+}
+
+// recordClient wraps client in a recorder when recording is enabled
+func (a *Activities) recordClient(client agent.ClientInterface, workDir string) agent.ClientInterface {
+	if a.cassette != nil && a.cassetteMode == agent.CassetteRecord {
+		return agent.NewRecordingClient(client, workDir, a.cassette)
+	}
+	return client
+}
+
 // CellBootstrap represents the resources allocated for an agent cell
 type CellBootstrap struct {
 	CellID       string
@@ -39,11 +85,37 @@ type CellBootstrap struct {
 	WorktreePath string
 	ServerHandle *infra.ServerHandle
 	Client       agent.ClientInterface
+	Backend      agent.Backend
 }
 
 // BootstrapCell creates a complete isolated cell for agent execution
 // This activity combines port allocation, worktree creation, server boot, and SDK client setup
 func (a *Activities) BootstrapCell(ctx context.Context, cellID string, branch string) (*CellBootstrap, error) {
+	return a.BootstrapCellWithBackend(ctx, cellID, branch, agent.BackendOpenCode)
+}
+
+// BootstrapCellWithBackend creates a cell driven by the given agent backend.
+// Serverless backends (Claude Code, Aider, Anthropic API) only need a worktree;
+// OpenCode cells additionally get a port and an 'opencode serve' process.
+func (a *Activities) BootstrapCellWithBackend(ctx context.Context, cellID string, branch string, backend agent.Backend) (*CellBootstrap, error) {
+	// Replayed cells never talk to an agent, so they skip the server as well
+	replaying := a.cassette != nil && a.cassetteMode == agent.CassetteReplay
+	if !backend.NeedsServer() || replaying {
+		return a.bootstrapServerlessCell(cellID, branch, backend)
+	}
+
+	// 0. Prefer a pre-booted cell; fall back to a cold bootstrap when the pool is empty
+	if a.warmPool != nil {
+		cell, err := a.warmPool.Acquire(ctx, cellID, branch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire pooled cell: %w", err)
+		}
+		if cell != nil {
+			cell.Client = a.recordClient(cell.Client, cell.WorktreePath)
+			return cell, nil
+		}
+	}
+
 	// 1. Allocate Port (INV-001)
 	port, err := a.portManager.Allocate()
 	if err != nil {
@@ -74,7 +146,18 @@ func (a *Activities) BootstrapCell(ctx context.Context, cellID string, branch st
 	}()
 
 	// 3. Boot Server (INV-002, INV-003)
+	// A foreign process may bind the port between allocation and boot,
+	// so retry with a fresh port instead of failing the whole cell
 	serverHandle, err := a.serverManager.BootServer(ctx, worktree.Path, worktreeID, port)
+	for attempt := 1; errors.Is(err, infra.ErrPortInUse) && attempt < maxPortCollisionRetries; attempt++ {
+		newPort, allocErr := a.portManager.Allocate()
+		if allocErr != nil {
+			return nil, fmt.Errorf("failed to allocate replacement port: %w", allocErr)
+		}
+		_ = a.portManager.Release(port)
+		port = newPort
+		serverHandle, err = a.serverManager.BootServer(ctx, worktree.Path, worktreeID, port)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to boot server: %w", err)
 	}
@@ -88,7 +171,7 @@ func (a *Activities) BootstrapCell(ctx context.Context, cellID string, branch st
 	}()
 
 	// 4. Create SDK Client (INV-004)
-	client := agent.NewClient(serverHandle.BaseURL, port)
+	client := a.recordClient(agent.NewClient(serverHandle.BaseURL, port), worktree.Path)
 
 	// Success - disable cleanup
 	cleanupPort = false
@@ -102,12 +185,42 @@ func (a *Activities) BootstrapCell(ctx context.Context, cellID string, branch st
 		WorktreePath: worktree.Path,
 		ServerHandle: serverHandle,
 		Client:       client,
+		Backend:      agent.BackendOpenCode,
+	}, nil
+}
+
+// bootstrapServerlessCell creates a worktree-only cell whose client runs the
+// agent as a subprocess or API loop rooted at the worktree
+func (a *Activities) bootstrapServerlessCell(cellID string, branch string, backend agent.Backend) (*CellBootstrap, error) {
+	worktreeID := fmt.Sprintf("cell-%s-%d", cellID, time.Now().Unix())
+	worktree, err := a.worktreeManager.CreateWorktree(worktreeID, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	client, err := a.CellClient(backend, "", 0, worktree.Path)
+	if err != nil {
+		_ = a.worktreeManager.RemoveWorktree(worktreeID)
+		return nil, fmt.Errorf("failed to create %s client: %w", backend, err)
+	}
+
+	return &CellBootstrap{
+		CellID:       cellID,
+		WorktreeID:   worktreeID,
+		WorktreePath: worktree.Path,
+		Client:       client,
+		Backend:      backend,
 	}, nil
 }
 
 // TeardownCell destroys a cell and releases all resources
 // INV-005: Server Process must be killed when Workflow Activity completes
 func (a *Activities) TeardownCell(_ context.Context, cell *CellBootstrap) error {
+	// Pooled cells are scrubbed and kept warm instead of destroyed
+	if a.warmPool != nil && a.warmPool.Release(cell) {
+		return nil
+	}
+
 	var errs []error
 
 	// 1. Shutdown Server (INV-005)
@@ -149,8 +262,8 @@ func (a *Activities) TeardownCell(_ context.Context, cell *CellBootstrap) error
 // ExecuteTask runs a task within a cell
 // INV-006: Command execution must use SDK
 func (a *Activities) ExecuteTask(ctx context.Context, cell *CellBootstrap, task *agent.TaskContext) (*agent.ExecutionResult, error) {
-	// 1. Verify server is healthy
-	if !a.serverManager.IsHealthy(ctx, cell.ServerHandle) {
+	// 1. Verify server is healthy (serverless backends have no server)
+	if cell.ServerHandle != nil && !a.serverManager.IsHealthy(ctx, cell.ServerHandle) {
 		return nil, fmt.Errorf("server is not healthy")
 	}
 
@@ -212,29 +325,26 @@ func (a *Activities) RunTests(ctx context.Context, cell *CellBootstrap) (bool, e
 	return testsPassed, nil
 }
 
-// CommitChanges commits changes in the worktree
+// CommitChanges commits all changes in the worktree. Git runs directly rather
+// than through the agent, so the commit does not depend on an agent turn and
+// replayed runs commit exactly what the agent wrote.
 func (a *Activities) CommitChanges(ctx context.Context, cell *CellBootstrap, message string) error {
-	// Execute git commands via prompt
-	prompt := fmt.Sprintf("Run these git commands:\ngit add .\ngit commit -m \"%s\"", message)
-	_, err := cell.Client.ExecutePrompt(ctx, prompt, &agent.PromptOptions{
-		Title: "Commit Changes",
-		Agent: "build",
-	})
-	if err != nil {
+	repo := git.Open(cell.WorktreePath)
+	if _, err := repo.Commit(ctx, git.CommitOptions{Message: message}); err != nil && !errors.Is(err, git.ErrNothingToCommit) {
 		return fmt.Errorf("failed to commit changes: %w", err)
 	}
 
 	return nil
 }
 
-// RevertChanges reverts all changes in the worktree
+// RevertChanges discards all changes and untracked files in the worktree.
+// Server logs in .opencode-logs are kept because the server holds them open.
 func (a *Activities) RevertChanges(ctx context.Context, cell *CellBootstrap) error {
-	// Execute git reset via prompt
-	_, err := cell.Client.ExecutePrompt(ctx, "Run: git reset --hard", &agent.PromptOptions{
-		Title: "Revert Changes",
-		Agent: "build",
-	})
-	if err != nil {
+	repo := git.Open(cell.WorktreePath)
+	if err := repo.ResetHard(ctx, ""); err != nil {
+		return fmt.Errorf("failed to revert changes: %w", err)
+	}
+	if err := repo.Clean(ctx, ".opencode-logs"); err != nil {
 		return fmt.Errorf("failed to revert changes: %w", err)
 	}
 
@@ -3,10 +3,14 @@ package workflow
 import (
 	"context"
 	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"open-swarm/internal/agent"
+	"open-swarm/internal/git"
 	"open-swarm/internal/infra"
 
 	"github.com/sst/opencode-sdk-go"
@@ -305,6 +309,119 @@ func TestBootstrapCell_ServerBootFailure(t *testing.T) {
 	}
 }
 
+func TestBootstrapCell_PortCollisionRetry(t *testing.T) {
+	nextPort := 8080
+	var released []int
+	portMgr := &mockPortManager{
+		allocateFunc: func() (int, error) {
+			nextPort++
+			return nextPort, nil
+		},
+		releaseFunc: func(port int) error {
+			released = append(released, port)
+			return nil
+		},
+	}
+	serverMgr := &mockServerManager{
+		bootFunc: func(_ context.Context, _ string, _ string, port int) (*infra.ServerHandle, error) {
+			if port == 8081 {
+				return nil, infra.ErrPortInUse
+			}
+			return &infra.ServerHandle{Port: port, BaseURL: "http://localhost:8082", PID: 12345}, nil
+		},
+	}
+	worktreeMgr := &mockWorktreeManager{}
+
+	activities := NewActivities(portMgr, serverMgr, worktreeMgr)
+
+	cell, err := activities.BootstrapCell(context.Background(), "test-cell", "main")
+	if err != nil {
+		t.Fatalf("BootstrapCell failed: %v", err)
+	}
+	if cell.Port != 8082 {
+		t.Errorf("Expected replacement port 8082, got %d", cell.Port)
+	}
+	if len(released) != 1 || released[0] != 8081 {
+		t.Errorf("Expected colliding port 8081 to be released, got %v", released)
+	}
+}
+
+func TestBootstrapCellWithBackend_Serverless(t *testing.T) {
+	portMgr := &mockPortManager{
+		allocateFunc: func() (int, error) {
+			t.Fatal("serverless backends should not allocate a port")
+			return 0, nil
+		},
+	}
+	serverMgr := &mockServerManager{
+		bootFunc: func(_ context.Context, _ string, _ string, _ int) (*infra.ServerHandle, error) {
+			t.Fatal("serverless backends should not boot a server")
+			return nil, nil
+		},
+	}
+	worktreeMgr := &mockWorktreeManager{}
+
+	activities := NewActivities(portMgr, serverMgr, worktreeMgr)
+
+	cell, err := activities.BootstrapCellWithBackend(context.Background(), "test-cell", "main", agent.BackendClaudeCode)
+	if err != nil {
+		t.Fatalf("BootstrapCellWithBackend failed: %v", err)
+	}
+	if cell.ServerHandle != nil || cell.Port != 0 {
+		t.Errorf("Expected no server resources, got port %d handle %v", cell.Port, cell.ServerHandle)
+	}
+	if _, ok := cell.Client.(*agent.ClaudeCodeClient); !ok {
+		t.Errorf("Expected Claude Code client, got %T", cell.Client)
+	}
+	if cell.Backend != agent.BackendClaudeCode {
+		t.Errorf("Expected backend %s, got %s", agent.BackendClaudeCode, cell.Backend)
+	}
+
+	if err := activities.TeardownCell(context.Background(), cell); err != nil {
+		t.Fatalf("TeardownCell failed: %v", err)
+	}
+}
+
+func TestBootstrapCell_ReplayCassette(t *testing.T) {
+	portMgr := &mockPortManager{
+		allocateFunc: func() (int, error) {
+			t.Fatal("replayed cells should not allocate a port")
+			return 0, nil
+		},
+	}
+	worktreeMgr := &mockWorktreeManager{}
+
+	activities := NewActivities(portMgr, &mockServerManager{}, worktreeMgr)
+	activities.SetCassette(agent.NewCassette(t.TempDir()+"/cassette.json"), agent.CassetteReplay)
+
+	cell, err := activities.BootstrapCell(context.Background(), "test-cell", "main")
+	if err != nil {
+		t.Fatalf("BootstrapCell failed: %v", err)
+	}
+	if _, ok := cell.Client.(*agent.ReplayClient); !ok {
+		t.Errorf("Expected replay client, got %T", cell.Client)
+	}
+	if cell.ServerHandle != nil {
+		t.Error("Replayed cells should not boot a server")
+	}
+}
+
+func TestBootstrapCell_RecordCassette(t *testing.T) {
+	activities := NewActivities(&mockPortManager{}, &mockServerManager{}, &mockWorktreeManager{})
+	activities.SetCassette(agent.NewCassette(t.TempDir()+"/cassette.json"), agent.CassetteRecord)
+
+	cell, err := activities.BootstrapCell(context.Background(), "test-cell", "main")
+	if err != nil {
+		t.Fatalf("BootstrapCell failed: %v", err)
+	}
+	if _, ok := cell.Client.(*agent.RecordingClient); !ok {
+		t.Errorf("Expected recording client, got %T", cell.Client)
+	}
+	if cell.ServerHandle == nil {
+		t.Error("Recorded cells still need a live server")
+	}
+}
+
 // Tests for TeardownCell
 func TestTeardownCell_Success(t *testing.T) {
 	serverShutdown := false
@@ -546,76 +663,154 @@ func TestRunTests_Fail(t *testing.T) {
 	}
 }
 
+// newCellRepo creates a repository with one commit and returns its directory
+func newCellRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# repo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "t@example.com"},
+		{"config", "user.name", "t"},
+		{"add", "-A"},
+		{"commit", "-q", "-m", "base"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+	return dir
+}
+
 // Tests for CommitChanges
 func TestCommitChanges_Success(t *testing.T) {
-	commitCalled := false
+	dir := newCellRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, "add.go"), []byte("package add\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
 	client := &mockClient{
 		executePromptFunc: func(ctx context.Context, prompt string, opts *agent.PromptOptions) (*agent.PromptResult, error) {
-			if strings.Contains(prompt, "git") {
-				commitCalled = true
-			}
-			return &agent.PromptResult{
-				Parts: []agent.ResultPart{
-					{Type: "text", Text: "Changes committed"},
-				},
-			}, nil
+			t.Errorf("commit should not prompt the agent, got %q", prompt)
+			return &agent.PromptResult{}, nil
 		},
 	}
 
-	portMgr := &mockPortManager{}
-	serverMgr := &mockServerManager{}
-	worktreeMgr := &mockWorktreeManager{}
-
-	activities := NewActivities(portMgr, serverMgr, worktreeMgr)
+	activities := NewActivities(&mockPortManager{}, &mockServerManager{}, &mockWorktreeManager{})
+	cell := &CellBootstrap{CellID: "test-cell", WorktreePath: dir, Client: client}
 
-	cell := &CellBootstrap{
-		CellID: "test-cell",
-		Client: client,
+	if err := activities.CommitChanges(context.Background(), cell, "Test commit"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
 	}
 
-	err := activities.CommitChanges(context.Background(), cell, "Test commit")
+	commit, err := git.Open(dir).ShowCommit(context.Background(), "HEAD")
 	if err != nil {
-		t.Fatalf("CommitChanges failed: %v", err)
+		t.Fatal(err)
+	}
+	if commit.Subject != "Test commit" || strings.Join(commit.Files, ",") != "add.go" {
+		t.Errorf("Expected commit of add.go, got %q with %v", commit.Subject, commit.Files)
 	}
 
-	if !commitCalled {
-		t.Error("Git commit should be called")
+	// A second commit with nothing staged is not an error
+	if err := activities.CommitChanges(context.Background(), cell, "Empty"); err != nil {
+		t.Errorf("CommitChanges without changes failed: %v", err)
 	}
 }
 
 // Tests for RevertChanges
 func TestRevertChanges_Success(t *testing.T) {
-	revertCalled := false
-	client := &mockClient{
-		executePromptFunc: func(ctx context.Context, prompt string, opts *agent.PromptOptions) (*agent.PromptResult, error) {
-			if strings.Contains(prompt, "git") {
-				revertCalled = true
-			}
-			return &agent.PromptResult{
-				Parts: []agent.ResultPart{
-					{Type: "text", Text: "Changes reverted"},
-				},
-			}, nil
-		},
+	dir := newCellRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new_test.go"), []byte("package add\n"), 0o600); err != nil {
+		t.Fatal(err)
 	}
 
-	portMgr := &mockPortManager{}
-	serverMgr := &mockServerManager{}
-	worktreeMgr := &mockWorktreeManager{}
-
-	activities := NewActivities(portMgr, serverMgr, worktreeMgr)
+	activities := NewActivities(&mockPortManager{}, &mockServerManager{}, &mockWorktreeManager{})
+	cell := &CellBootstrap{CellID: "test-cell", WorktreePath: dir, Client: &mockClient{}}
 
-	cell := &CellBootstrap{
-		CellID: "test-cell",
-		Client: client,
+	if err := activities.RevertChanges(context.Background(), cell); err != nil {
+		t.Fatalf("RevertChanges failed: %v", err)
 	}
 
-	err := activities.RevertChanges(context.Background(), cell)
+	status, err := git.Open(dir).Status(context.Background())
 	if err != nil {
-		t.Fatalf("RevertChanges failed: %v", err)
+		t.Fatal(err)
+	}
+	if !status.IsClean() {
+		t.Errorf("Expected clean worktree after revert, got %+v", status.Entries)
+	}
+}
+
+// editingClient writes a file into its worktree for every prompt
+type editingClient struct {
+	mockClient
+	workDir string
+}
+
+func (c *editingClient) ExecutePrompt(_ context.Context, prompt string, _ *agent.PromptOptions) (*agent.PromptResult, error) {
+	if err := os.WriteFile(filepath.Join(c.workDir, "add.go"), []byte("package add\n\n// "+prompt+"\n"), 0o600); err != nil {
+		return nil, err
 	}
+	return &agent.PromptResult{SessionID: "live-1", Parts: []agent.ResultPart{{Type: "text", Text: "Implemented"}}}, nil
+}
 
-	if !revertCalled {
-		t.Error("Git revert should be called")
+// TestCassette_ReplayReproducesCommit runs a cell's task and commit against a
+// live agent, then replays the cassette into a fresh cell of the same repository:
+// the replayed commit must hold what the agent wrote.
+func TestCassette_ReplayReproducesCommit(t *testing.T) {
+	ctx := context.Background()
+	task := &agent.TaskContext{TaskID: "task-1", Prompt: "implement add"}
+	cassette := agent.NewCassette(filepath.Join(t.TempDir(), "cassette.json"))
+	worktrees := infra.NewWorktreeManager(newCellRepo(t), t.TempDir())
+
+	run := func(activities *Activities, cell *CellBootstrap) string {
+		t.Helper()
+		if _, err := activities.ExecuteTask(ctx, cell, task); err != nil {
+			t.Fatalf("ExecuteTask failed: %v", err)
+		}
+		if err := activities.CommitChanges(ctx, cell, "Task task-1"); err != nil {
+			t.Fatalf("CommitChanges failed: %v", err)
+		}
+		repo := git.Open(cell.WorktreePath)
+		if files, err := repo.ShowCommit(ctx, "HEAD"); err != nil || strings.Join(files.Files, ",") != "add.go" {
+			t.Fatalf("Expected the task commit at HEAD, got %+v, %v", files, err)
+		}
+		content, err := repo.ReadFile(ctx, "HEAD", "add.go")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	// Record
+	recording := NewActivities(&mockPortManager{}, &mockServerManager{}, worktrees)
+	worktree, err := worktrees.CreateWorktree("record", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := &editingClient{workDir: worktree.Path}
+	recorded := run(recording, &CellBootstrap{
+		CellID:       "record",
+		WorktreePath: worktree.Path,
+		Client:       agent.NewRecordingClient(live, worktree.Path, cassette),
+	})
+
+	// Replay
+	replaying := NewActivities(&mockPortManager{}, &mockServerManager{}, worktrees)
+	replaying.SetCassette(cassette, agent.CassetteReplay)
+	cell, err := replaying.BootstrapCell(ctx, "replay", "HEAD")
+	if err != nil {
+		t.Fatalf("BootstrapCell failed: %v", err)
+	}
+	if replayed := run(replaying, cell); replayed != recorded {
+		t.Errorf("Replayed commit holds %q, recorded %q", replayed, recorded)
 	}
 }
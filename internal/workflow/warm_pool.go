@@ -0,0 +1,323 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"open-swarm/internal/agent"
+	"open-swarm/internal/infra"
+)
+
+const (
+	// defaultWarmPoolBaseRef is the ref idle worktrees are detached at
+	defaultWarmPoolBaseRef = "HEAD"
+	// defaultWarmPoolScaleInterval is how often the autoscaler samples queue depth
+	defaultWarmPoolScaleInterval = 15 * time.Second
+)
+
+// WarmPoolConfig configures the warm cell pool
+type WarmPoolConfig struct {
+	MinSize       int           // Idle cells kept ready even when the queue is empty
+	MaxSize       int           // Upper bound on pooled cells (idle + leased)
+	BaseRef       string        // Ref idle worktrees are detached at (default HEAD)
+	ScaleInterval time.Duration // Autoscaler sampling interval
+}
+
+// QueueDepthFunc reports how many tasks are waiting for a cell
+type QueueDepthFunc func(ctx context.Context) (int, error)
+
+// WarmPoolStats is a snapshot of pool occupancy
+type WarmPoolStats struct {
+	Idle    int
+	Leased  int
+	Booting int
+	Target  int
+	Hits    int // Bootstraps served from the pool
+	Misses  int // Bootstraps that found the pool empty
+}
+
+// pooledCell is a booted server on a detached worktree
+type pooledCell struct {
+	worktreeID   string
+	worktreePath string
+	port         int
+	serverHandle *infra.ServerHandle
+}
+
+// WarmPool keeps pre-booted opencode servers on detached worktrees so that
+// BootstrapCell only has to check out the task branch.
+// Cells are scrubbed and returned to the pool on teardown.
+type WarmPool struct {
+	mu              sync.Mutex
+	portManager     infra.PortManagerInterface
+	serverManager   infra.ServerManagerInterface
+	worktreeManager infra.RecyclableWorktreeManagerInterface
+	config          WarmPoolConfig
+	idle            []*pooledCell
+	leased          map[string]*pooledCell
+	booting         int
+	target          int
+	seq             int
+	hits            int
+	misses          int
+	closed          bool
+}
+
+// NewWarmPool creates an empty warm pool; call Fill or Autoscale to boot cells
+func NewWarmPool(portMgr infra.PortManagerInterface, serverMgr infra.ServerManagerInterface, worktreeMgr infra.RecyclableWorktreeManagerInterface, config WarmPoolConfig) *WarmPool {
+	if config.BaseRef == "" {
+		config.BaseRef = defaultWarmPoolBaseRef
+	}
+	if config.ScaleInterval <= 0 {
+		config.ScaleInterval = defaultWarmPoolScaleInterval
+	}
+	if config.MaxSize < config.MinSize {
+		config.MaxSize = config.MinSize
+	}
+
+	return &WarmPool{
+		portManager:     portMgr,
+		serverManager:   serverMgr,
+		worktreeManager: worktreeMgr,
+		config:          config,
+		leased:          make(map[string]*pooledCell),
+		target:          config.MinSize,
+	}
+}
+
+// Acquire hands out a ready cell with branch checked out.
+// Returns nil without error when no idle cell is available, so callers can
+// fall back to a cold bootstrap.
+func (p *WarmPool) Acquire(ctx context.Context, cellID string, branch string) (*CellBootstrap, error) {
+	for {
+		p.mu.Lock()
+		if p.closed || len(p.idle) == 0 {
+			p.misses++
+			p.mu.Unlock()
+			return nil, nil
+		}
+		pc := p.idle[0]
+		p.idle = p.idle[1:]
+		p.mu.Unlock()
+
+		// Servers can die while idle; discard them rather than hand them out
+		if !p.serverManager.IsHealthy(ctx, pc.serverHandle) {
+			p.destroy(pc)
+			continue
+		}
+
+		taskBranch := fmt.Sprintf("worktree-cell-%s-%d", cellID, time.Now().Unix())
+		if err := p.worktreeManager.CheckoutBranch(pc.worktreeID, taskBranch, branch); err != nil {
+			p.recycle(pc)
+			return nil, fmt.Errorf("failed to check out %s in pooled cell %s: %w", branch, pc.worktreeID, err)
+		}
+
+		p.mu.Lock()
+		p.leased[pc.worktreeID] = pc
+		p.hits++
+		p.mu.Unlock()
+
+		return &CellBootstrap{
+			CellID:       cellID,
+			Port:         pc.port,
+			WorktreeID:   pc.worktreeID,
+			WorktreePath: pc.worktreePath,
+			ServerHandle: pc.serverHandle,
+			Client:       agent.NewClient(pc.serverHandle.BaseURL, pc.port),
+			Backend:      agent.BackendOpenCode,
+		}, nil
+	}
+}
+
+// Release scrubs a leased cell, deleting its task branch unless it holds
+// commits for the merge queue, and returns it to the pool.
+// Returns false if the cell was not handed out by this pool, in which case
+// the caller must tear it down itself.
+func (p *WarmPool) Release(cell *CellBootstrap) bool {
+	p.mu.Lock()
+	pc, ok := p.leased[cell.WorktreeID]
+	if ok {
+		delete(p.leased, cell.WorktreeID)
+	}
+	p.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	p.recycle(pc)
+	return true
+}
+
+// recycle scrubs a cell and puts it back on the idle list, or destroys it
+// if scrubbing fails or the pool has shrunk below its current size
+func (p *WarmPool) recycle(pc *pooledCell) {
+	if err := p.worktreeManager.ScrubWorktree(pc.worktreeID, p.config.BaseRef); err != nil {
+		p.destroy(pc)
+		return
+	}
+
+	p.mu.Lock()
+	if p.closed || len(p.idle)+len(p.leased)+p.booting >= p.target {
+		p.mu.Unlock()
+		p.destroy(pc)
+		return
+	}
+	p.idle = append(p.idle, pc)
+	p.mu.Unlock()
+}
+
+// Resize sets the desired number of pooled cells, clamped to [MinSize, MaxSize].
+// Surplus idle cells are destroyed immediately; missing cells are booted by Fill.
+func (p *WarmPool) Resize(target int) {
+	if target < p.config.MinSize {
+		target = p.config.MinSize
+	}
+	if target > p.config.MaxSize {
+		target = p.config.MaxSize
+	}
+
+	p.mu.Lock()
+	p.target = target
+	var surplus []*pooledCell
+	for len(p.idle) > 0 && len(p.idle)+len(p.leased)+p.booting > p.target {
+		surplus = append(surplus, p.idle[len(p.idle)-1])
+		p.idle = p.idle[:len(p.idle)-1]
+	}
+	p.mu.Unlock()
+
+	for _, pc := range surplus {
+		p.destroy(pc)
+	}
+}
+
+// Fill boots cells until the pool reaches its target size
+func (p *WarmPool) Fill(ctx context.Context) error {
+	for {
+		p.mu.Lock()
+		if p.closed || len(p.idle)+len(p.leased)+p.booting >= p.target {
+			p.mu.Unlock()
+			return nil
+		}
+		p.booting++
+		p.seq++
+		worktreeID := fmt.Sprintf("pool-%d-%d", time.Now().Unix(), p.seq)
+		p.mu.Unlock()
+
+		pc, err := p.boot(ctx, worktreeID)
+
+		p.mu.Lock()
+		p.booting--
+		if err == nil {
+			if p.closed {
+				p.mu.Unlock()
+				p.destroy(pc)
+				return nil
+			}
+			p.idle = append(p.idle, pc)
+		}
+		p.mu.Unlock()
+
+		if err != nil {
+			return fmt.Errorf("failed to boot pooled cell: %w", err)
+		}
+	}
+}
+
+// Autoscale sizes the pool from queue depth until ctx is cancelled.
+// The target is MinSize plus one cell per waiting task, capped at MaxSize.
+func (p *WarmPool) Autoscale(ctx context.Context, queueDepth QueueDepthFunc) {
+	ticker := time.NewTicker(p.config.ScaleInterval)
+	defer ticker.Stop()
+
+	for {
+		if queueDepth != nil {
+			if depth, err := queueDepth(ctx); err == nil {
+				p.Resize(p.config.MinSize + depth)
+			}
+		}
+		_ = p.Fill(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stats returns a snapshot of pool occupancy
+func (p *WarmPool) Stats() WarmPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return WarmPoolStats{
+		Idle:    len(p.idle),
+		Leased:  len(p.leased),
+		Booting: p.booting,
+		Target:  p.target,
+		Hits:    p.hits,
+		Misses:  p.misses,
+	}
+}
+
+// Shutdown destroys all idle cells and stops handing out new ones.
+// Leased cells are destroyed when they are released.
+func (p *WarmPool) Shutdown() {
+	p.mu.Lock()
+	p.closed = true
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	for _, pc := range idle {
+		p.destroy(pc)
+	}
+}
+
+// boot allocates a port, creates a detached worktree and starts a server on it
+func (p *WarmPool) boot(ctx context.Context, worktreeID string) (*pooledCell, error) {
+	port, err := p.portManager.Allocate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate port: %w", err)
+	}
+
+	worktree, err := p.worktreeManager.CreateDetachedWorktree(worktreeID, p.config.BaseRef)
+	if err != nil {
+		_ = p.portManager.Release(port)
+		return nil, fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	serverHandle, err := p.serverManager.BootServer(ctx, worktree.Path, worktreeID, port)
+	if err != nil {
+		_ = p.worktreeManager.RemoveWorktree(worktreeID)
+		_ = p.portManager.Release(port)
+		return nil, fmt.Errorf("failed to boot server: %w", err)
+	}
+
+	return &pooledCell{
+		worktreeID:   worktreeID,
+		worktreePath: worktree.Path,
+		port:         port,
+		serverHandle: serverHandle,
+	}, nil
+}
+
+// destroy shuts down a pooled cell's server and releases its worktree and port
+func (p *WarmPool) destroy(pc *pooledCell) {
+	if pc.serverHandle != nil {
+		if pc.serverHandle.Cmd != nil {
+			_ = p.serverManager.Shutdown(pc.serverHandle)
+		} else if pc.serverHandle.PID != 0 {
+			_ = p.serverManager.ShutdownByPID(pc.serverHandle.PID)
+		}
+	}
+	_ = p.worktreeManager.RemoveWorktree(pc.worktreeID)
+	_ = p.portManager.Release(pc.port)
+}
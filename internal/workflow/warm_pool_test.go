@@ -0,0 +1,186 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"open-swarm/internal/infra"
+)
+
+type mockRecyclableWorktreeManager struct {
+	mockWorktreeManager
+	checkouts []string
+	scrubbed  []string
+	removed   []string
+	branches  map[string]string // Worktree ID to its checked out branch
+}
+
+func (m *mockRecyclableWorktreeManager) CreateDetachedWorktree(id, _ string) (*infra.WorktreeInfo, error) {
+	return &infra.WorktreeInfo{ID: id, Path: "/tmp/worktrees/" + id}, nil
+}
+
+func (m *mockRecyclableWorktreeManager) CheckoutBranch(id, branch string, _ string) error {
+	m.checkouts = append(m.checkouts, id)
+	if m.branches == nil {
+		m.branches = make(map[string]string)
+	}
+	m.branches[id] = branch
+	return nil
+}
+
+func (m *mockRecyclableWorktreeManager) ScrubWorktree(id, _ string) error {
+	m.scrubbed = append(m.scrubbed, id)
+	delete(m.branches, id)
+	return nil
+}
+
+func (m *mockRecyclableWorktreeManager) RemoveWorktree(id string) error {
+	m.removed = append(m.removed, id)
+	return nil
+}
+
+func newTestWarmPool(minSize, maxSize int) (*WarmPool, *mockRecyclableWorktreeManager, *int) {
+	nextPort := 8000
+	portMgr := &mockPortManager{
+		allocateFunc: func() (int, error) {
+			nextPort++
+			return nextPort, nil
+		},
+	}
+	boots := 0
+	serverMgr := &mockServerManager{
+		bootFunc: func(_ context.Context, _ string, _ string, port int) (*infra.ServerHandle, error) {
+			boots++
+			return &infra.ServerHandle{Port: port, BaseURL: "http://localhost:8080", PID: 1000 + port}, nil
+		},
+	}
+	worktreeMgr := &mockRecyclableWorktreeManager{}
+	pool := NewWarmPool(portMgr, serverMgr, worktreeMgr, WarmPoolConfig{MinSize: minSize, MaxSize: maxSize})
+	return pool, worktreeMgr, &boots
+}
+
+func TestWarmPool_FillAndAcquire(t *testing.T) {
+	pool, worktreeMgr, boots := newTestWarmPool(2, 4)
+
+	if err := pool.Fill(context.Background()); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if *boots != 2 {
+		t.Errorf("Expected 2 servers booted, got %d", *boots)
+	}
+
+	cell, err := pool.Acquire(context.Background(), "task-1", "main")
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if cell == nil {
+		t.Fatal("Expected a pooled cell")
+	}
+	if cell.CellID != "task-1" || cell.Client == nil || cell.ServerHandle == nil {
+		t.Errorf("Pooled cell not fully populated: %+v", cell)
+	}
+	if len(worktreeMgr.checkouts) != 1 || worktreeMgr.checkouts[0] != cell.WorktreeID {
+		t.Errorf("Expected task branch checked out in %s, got %v", cell.WorktreeID, worktreeMgr.checkouts)
+	}
+
+	stats := pool.Stats()
+	if stats.Idle != 1 || stats.Leased != 1 || stats.Hits != 1 {
+		t.Errorf("Unexpected stats after acquire: %+v", stats)
+	}
+}
+
+func TestWarmPool_ReleaseScrubsAndReturns(t *testing.T) {
+	pool, worktreeMgr, _ := newTestWarmPool(1, 1)
+	_ = pool.Fill(context.Background())
+
+	cell, _ := pool.Acquire(context.Background(), "task-1", "main")
+	if !pool.Release(cell) {
+		t.Fatal("Expected pooled cell to be accepted back")
+	}
+	if len(worktreeMgr.scrubbed) != 1 {
+		t.Errorf("Expected worktree to be scrubbed, got %v", worktreeMgr.scrubbed)
+	}
+	if len(worktreeMgr.removed) != 0 {
+		t.Errorf("Recycled worktree should not be removed, got %v", worktreeMgr.removed)
+	}
+	if len(worktreeMgr.branches) != 0 {
+		t.Errorf("Expected task branch deleted on release, got %v", worktreeMgr.branches)
+	}
+	if stats := pool.Stats(); stats.Idle != 1 || stats.Leased != 0 {
+		t.Errorf("Expected cell back in idle list, got %+v", stats)
+	}
+
+	foreign := &CellBootstrap{WorktreeID: "cell-foreign"}
+	if pool.Release(foreign) {
+		t.Error("Pool should not accept cells it did not hand out")
+	}
+}
+
+func TestWarmPool_EmptyPoolFallsBack(t *testing.T) {
+	pool, _, _ := newTestWarmPool(0, 2)
+
+	cell, err := pool.Acquire(context.Background(), "task-1", "main")
+	if err != nil || cell != nil {
+		t.Errorf("Expected empty pool to return nil cell, got %v, %v", cell, err)
+	}
+	if stats := pool.Stats(); stats.Misses != 1 {
+		t.Errorf("Expected 1 miss, got %+v", stats)
+	}
+}
+
+func TestWarmPool_DiscardsUnhealthyCells(t *testing.T) {
+	pool, worktreeMgr, _ := newTestWarmPool(1, 1)
+	_ = pool.Fill(context.Background())
+	pool.serverManager.(*mockServerManager).healthyFunc = func(context.Context, *infra.ServerHandle) bool {
+		return false
+	}
+
+	cell, err := pool.Acquire(context.Background(), "task-1", "main")
+	if err != nil || cell != nil {
+		t.Errorf("Expected unhealthy cell to be discarded, got %v, %v", cell, err)
+	}
+	if len(worktreeMgr.removed) != 1 {
+		t.Errorf("Expected unhealthy cell to be destroyed, got %v", worktreeMgr.removed)
+	}
+}
+
+func TestWarmPool_ResizeClampsAndShrinks(t *testing.T) {
+	pool, worktreeMgr, _ := newTestWarmPool(1, 3)
+
+	pool.Resize(10)
+	_ = pool.Fill(context.Background())
+	if stats := pool.Stats(); stats.Idle != 3 || stats.Target != 3 {
+		t.Errorf("Expected pool clamped to max 3, got %+v", stats)
+	}
+
+	pool.Resize(0)
+	if stats := pool.Stats(); stats.Idle != 1 || stats.Target != 1 {
+		t.Errorf("Expected pool shrunk to min 1, got %+v", stats)
+	}
+	if len(worktreeMgr.removed) != 2 {
+		t.Errorf("Expected 2 surplus cells destroyed, got %v", worktreeMgr.removed)
+	}
+}
+
+func TestActivities_BootstrapFromWarmPool(t *testing.T) {
+	pool, _, boots := newTestWarmPool(1, 1)
+	_ = pool.Fill(context.Background())
+
+	activities := NewActivities(pool.portManager, pool.serverManager, pool.worktreeManager)
+	activities.SetWarmPool(pool)
+
+	cell, err := activities.BootstrapCell(context.Background(), "task-1", "main")
+	if err != nil {
+		t.Fatalf("BootstrapCell failed: %v", err)
+	}
+	if *boots != 1 {
+		t.Errorf("Expected pooled cell to be reused without booting, got %d boots", *boots)
+	}
+
+	if err := activities.TeardownCell(context.Background(), cell); err != nil {
+		t.Fatalf("TeardownCell failed: %v", err)
+	}
+	if stats := pool.Stats(); stats.Idle != 1 {
+		t.Errorf("Expected cell returned to pool on teardown, got %+v", stats)
+	}
+}
@@ -0,0 +1,346 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+// Package agentmailtest provides an in-memory stand-in for the Agent Mail
+// MCP server. It speaks newline-delimited JSON-RPC over any reader/writer
+// pair, so it can be served in-process over pipes or as a stdio subprocess.
+package agentmailtest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Agent is a registered agent identity
+type Agent struct {
+	Name            string `json:"name"`
+	Program         string `json:"program"`
+	Model           string `json:"model"`
+	TaskDescription string `json:"task_description"`
+}
+
+// Message is a message delivered to one recipient
+type Message struct {
+	ID          int    `json:"id"`
+	To          string `json:"-"`
+	Subject     string `json:"subject"`
+	From        string `json:"from"`
+	Importance  string `json:"importance"`
+	AckRequired bool   `json:"ack_required"`
+	ThreadID    string `json:"thread_id,omitempty"`
+	CreatedTS   string `json:"created_ts"`
+	Body        string `json:"body_md,omitempty"`
+	Acked       bool   `json:"-"`
+}
+
+// Reservation is an advisory file reservation
+type Reservation struct {
+	ID          int    `json:"id"`
+	Agent       string `json:"agent"`
+	PathPattern string `json:"path_pattern"`
+	Exclusive   bool   `json:"exclusive"`
+	Reason      string `json:"reason,omitempty"`
+	ExpiresTS   string `json:"expires_ts"`
+}
+
+// Server is an in-memory Agent Mail implementation
+type Server struct {
+	mu           sync.Mutex
+	projects     map[string]bool
+	agents       map[string]Agent
+	messages     []*Message
+	reservations []*Reservation
+	nextID       int
+	calls        []string
+}
+
+// NewServer creates an empty server
+func NewServer() *Server {
+	return &Server{
+		projects: make(map[string]bool),
+		agents:   make(map[string]Agent),
+	}
+}
+
+// PostMessage delivers a message to an agent and returns its ID
+func (s *Server) PostMessage(to, from, subject, importance, threadID string, ackRequired bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	s.messages = append(s.messages, &Message{
+		ID:          s.nextID,
+		To:          to,
+		From:        from,
+		Subject:     subject,
+		Importance:  importance,
+		AckRequired: ackRequired,
+		ThreadID:    threadID,
+		CreatedTS:   time.Now().UTC().Format(time.RFC3339),
+	})
+	return s.nextID
+}
+
+// Reserve adds a reservation directly, bypassing conflict checks
+func (s *Server) Reserve(agentName, pattern string, exclusive bool, ttl time.Duration) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reserve(agentName, pattern, exclusive, "", ttl).ID
+}
+
+// Acknowledged reports whether a message has been acknowledged
+func (s *Server) Acknowledged(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range s.messages {
+		if m.ID == id {
+			return m.Acked
+		}
+	}
+	return false
+}
+
+// Agents returns the names of registered agents, sorted
+func (s *Server) Agents() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.agents))
+	for name := range s.agents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Projects returns the registered project keys, sorted
+func (s *Server) Projects() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.projects))
+	for key := range s.projects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Calls returns the tool names called so far, in order
+func (s *Server) Calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+// Serve handles JSON-RPC requests from r until it is closed
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	enc := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		var req struct {
+			ID     *int64          `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			continue
+		}
+		if req.ID == nil {
+			continue // notification
+		}
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": *req.ID}
+		result, rpcErr := s.handle(req.Method, req.Params)
+		if rpcErr != nil {
+			resp["error"] = rpcErr
+		} else {
+			resp["result"] = result
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (s *Server) handle(method string, params json.RawMessage) (interface{}, *rpcError) {
+	switch method {
+	case "initialize":
+		return map[string]interface{}{
+			"protocolVersion": "2024-11-05",
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": "agent-mail-stub", "version": "0.1.0"},
+		}, nil
+	case "tools/call":
+		var call struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(params, &call); err != nil {
+			return nil, &rpcError{Code: -32602, Message: err.Error()}
+		}
+		value, err := s.callTool(call.Name, call.Arguments)
+		if err != nil {
+			return map[string]interface{}{
+				"content": []map[string]string{{"type": "text", "text": err.Error()}},
+				"isError": true,
+			}, nil
+		}
+		data, _ := json.Marshal(value)
+		return map[string]interface{}{
+			"content":           []map[string]string{{"type": "text", "text": string(data)}},
+			"structuredContent": map[string]interface{}{"result": value},
+		}, nil
+	default:
+		return nil, &rpcError{Code: -32601, Message: "method not found: " + method}
+	}
+}
+
+// toolArgs is the union of all tool arguments
+type toolArgs struct {
+	HumanKey        string   `json:"human_key"`
+	ProjectKey      string   `json:"project_key"`
+	Name            string   `json:"name"`
+	Program         string   `json:"program"`
+	Model           string   `json:"model"`
+	TaskDescription string   `json:"task_description"`
+	AgentName       string   `json:"agent_name"`
+	Limit           int      `json:"limit"`
+	MessageID       int      `json:"message_id"`
+	Paths           []string `json:"paths"`
+	TTLSeconds      int      `json:"ttl_seconds"`
+	Exclusive       bool     `json:"exclusive"`
+	Reason          string   `json:"reason"`
+}
+
+func (s *Server) callTool(name string, raw json.RawMessage) (interface{}, error) {
+	var args toolArgs
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, name)
+
+	switch name {
+	case "ensure_project":
+		s.projects[args.HumanKey] = true
+		return map[string]string{"human_key": args.HumanKey}, nil
+
+	case "register_agent":
+		if !s.projects[args.ProjectKey] {
+			return nil, fmt.Errorf("project %s not found", args.ProjectKey)
+		}
+		s.agents[args.Name] = Agent{Name: args.Name, Program: args.Program, Model: args.Model, TaskDescription: args.TaskDescription}
+		return s.agents[args.Name], nil
+
+	case "fetch_inbox":
+		inbox := []Message{}
+		for i := len(s.messages) - 1; i >= 0; i-- {
+			m := s.messages[i]
+			if m.To == args.AgentName && !m.Acked {
+				inbox = append(inbox, *m)
+			}
+			if args.Limit > 0 && len(inbox) >= args.Limit {
+				break
+			}
+		}
+		return inbox, nil
+
+	case "acknowledge_message":
+		for _, m := range s.messages {
+			if m.ID == args.MessageID && m.To == args.AgentName {
+				m.Acked = true
+				return map[string]int{"message_id": m.ID}, nil
+			}
+		}
+		return nil, fmt.Errorf("message %d not found for %s", args.MessageID, args.AgentName)
+
+	case "file_reservation_paths":
+		ttl := time.Duration(args.TTLSeconds) * time.Second
+		granted := []Reservation{}
+		conflicts := []Reservation{}
+		for _, p := range args.Paths {
+			if c := s.conflict(args.AgentName, p, args.Exclusive); c != nil {
+				conflicts = append(conflicts, *c)
+				continue
+			}
+			granted = append(granted, *s.reserve(args.AgentName, p, args.Exclusive, args.Reason, ttl))
+		}
+		return map[string]interface{}{"granted": granted, "conflicts": conflicts}, nil
+
+	case "release_file_reservations":
+		kept := s.reservations[:0]
+		released := 0
+		for _, r := range s.reservations {
+			if r.Agent == args.AgentName && (len(args.Paths) == 0 || contains(args.Paths, r.PathPattern)) {
+				released++
+				continue
+			}
+			kept = append(kept, r)
+		}
+		s.reservations = kept
+		return map[string]int{"released": released}, nil
+
+	case "list_file_reservations":
+		active := []Reservation{}
+		now := time.Now()
+		for _, r := range s.reservations {
+			if exp, err := time.Parse(time.RFC3339, r.ExpiresTS); err == nil && exp.After(now) {
+				active = append(active, *r)
+			}
+		}
+		return active, nil
+
+	default:
+		return nil, fmt.Errorf("unknown tool: %s", name)
+	}
+}
+
+func (s *Server) reserve(agentName, pattern string, exclusive bool, reason string, ttl time.Duration) *Reservation {
+	s.nextID++
+	r := &Reservation{
+		ID:          s.nextID,
+		Agent:       agentName,
+		PathPattern: pattern,
+		Exclusive:   exclusive,
+		Reason:      reason,
+		ExpiresTS:   time.Now().Add(ttl).UTC().Format(time.RFC3339),
+	}
+	s.reservations = append(s.reservations, r)
+	return r
+}
+
+func (s *Server) conflict(agentName, pattern string, exclusive bool) *Reservation {
+	for _, r := range s.reservations {
+		if r.PathPattern == pattern && r.Agent != agentName && (exclusive || r.Exclusive) {
+			return r
+		}
+	}
+	return nil
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
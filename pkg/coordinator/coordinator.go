@@ -6,19 +6,50 @@
 package coordinator
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"open-swarm/internal/config"
+	"open-swarm/internal/filelock"
 	"open-swarm/pkg/agent"
 )
 
+// AgentMailServerName is the preferred config.MCPServers entry for coordination
+const AgentMailServerName = "agent-mail"
+
+const (
+	// defaultInboxLimit bounds messages fetched per agent on each sync
+	defaultInboxLimit = 20
+	// defaultReservationTTL is used when reservations.default_ttl is unset
+	defaultReservationTTL = time.Hour
+	// defaultCheckInterval is used when messages.check_interval is unset
+	defaultCheckInterval = time.Minute
+)
+
+// ErrNoMailServer is returned by Connect when no Agent Mail server is configured
+var ErrNoMailServer = errors.New("no enabled agent mail MCP server configured")
+
 // Coordinator manages multi-agent coordination for the project
 type Coordinator struct {
 	config       *config.Config
 	projectKey   string
 	agentManager *agent.Manager
+	mail         MailClient
+	locks        filelock.LockRegistry
+
+	mu                 sync.Mutex
+	inbox              map[string][]Message
+	mirrored           map[string]filelock.LockRequest
+	activeReservations int
+	activeThreads      int
+	lastSync           time.Time
 }
 
 // Status represents the current coordination state
@@ -31,7 +62,8 @@ type Status struct {
 	MCPServersConnected bool
 }
 
-// New creates a new Coordinator instance
+// New creates a new Coordinator instance.
+// The coordinator runs locally until Connect (or SetMailClient) attaches Agent Mail.
 func New(cfg *config.Config) (*Coordinator, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("configuration is required")
@@ -41,22 +73,83 @@ func New(cfg *config.Config) (*Coordinator, error) {
 		config:       cfg,
 		projectKey:   cfg.Project.WorkingDirectory,
 		agentManager: agent.NewManager(cfg.Project.WorkingDirectory),
+		locks:        filelock.NewMemoryRegistry(),
+		inbox:        make(map[string][]Message),
+		mirrored:     make(map[string]filelock.LockRequest),
 	}
 
 	return coord, nil
 }
 
-// GetStatus returns the current coordination status
+// Connect starts the Agent Mail MCP server from config.MCPServers and
+// attaches it as the coordination backend
+func (c *Coordinator) Connect(ctx context.Context) error {
+	name, server, ok := selectMailServer(c.config.MCPServers)
+	if !ok {
+		return ErrNoMailServer
+	}
+
+	client, err := StartMCPServer(ctx, server.Command)
+	if err != nil {
+		return fmt.Errorf("failed to connect to MCP server %q: %w", name, err)
+	}
+
+	slog.Info("Connected to Agent Mail", "server", name, "project", c.projectKey)
+	c.SetMailClient(NewAgentMailClient(client))
+	return nil
+}
+
+// SetMailClient attaches a coordination backend
+func (c *Coordinator) SetMailClient(mail MailClient) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mail = mail
+}
+
+// SetLockRegistry sets the registry that reservations are mirrored into
+func (c *Coordinator) SetLockRegistry(locks filelock.LockRegistry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.locks = locks
+}
+
+// LockRegistry returns the registry that reservations are mirrored into
+func (c *Coordinator) LockRegistry() filelock.LockRegistry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.locks
+}
+
+// Close disconnects from Agent Mail
+func (c *Coordinator) Close() error {
+	c.mu.Lock()
+	mail := c.mail
+	c.mail = nil
+	c.mu.Unlock()
+
+	if mail == nil {
+		return nil
+	}
+	return mail.Close()
+}
+
+// GetStatus returns the coordination status as of the last sync
 func (c *Coordinator) GetStatus() *Status {
-	// In a real implementation, this would query the Agent Mail MCP server
-	// For now, return placeholder data
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	unread := 0
+	for _, messages := range c.inbox {
+		unread += len(messages)
+	}
+
 	return &Status{
 		ActiveAgents:        c.agentManager.CountActive(),
-		UnreadMessages:      0,
-		ActiveReservations:  0,
-		ActiveThreads:       0,
-		LastSync:            time.Now(),
-		MCPServersConnected: true,
+		UnreadMessages:      unread,
+		ActiveReservations:  c.activeReservations,
+		ActiveThreads:       c.activeThreads,
+		LastSync:            c.lastSync,
+		MCPServersConnected: c.mail != nil,
 	}
 }
 
@@ -65,33 +158,81 @@ func (c *Coordinator) ListAgents() []agent.Agent {
 	return c.agentManager.List()
 }
 
+// Messages returns the unread messages for an agent as of the last sync
+func (c *Coordinator) Messages(agentName string) []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Message(nil), c.inbox[agentName]...)
+}
+
 // Sync synchronizes the coordinator state with Agent Mail
 func (c *Coordinator) Sync() error {
+	return c.SyncContext(context.Background())
+}
+
+// SyncContext registers the project and agents, fetches inboxes (acking
+// when configured) and mirrors active file reservations into the lock registry
+func (c *Coordinator) SyncContext(ctx context.Context) error {
 	slog.Info("Starting coordination sync",
 		"project", c.projectKey,
 		"active_agents", c.agentManager.CountActive())
 
-	// In a real implementation, this would:
-	// 1. Ensure project is registered
-	// 2. Fetch recent messages
-	// 3. Update agent list
-	// 4. Check file reservations
-	// 5. Sync with beads and serena
+	c.mu.Lock()
+	mail := c.mail
+	c.mu.Unlock()
 
-	slog.Info("Project registered in Agent Mail",
-		"project", c.projectKey)
+	if mail == nil {
+		slog.Info("No Agent Mail server connected; coordination is local only",
+			"project", c.projectKey)
+		fmt.Println("\n⚠ Agent Mail not connected (local coordination only)")
+		c.mu.Lock()
+		c.lastSync = time.Now()
+		c.mu.Unlock()
+		return nil
+	}
+
+	// 1. Ensure project is registered
+	if err := mail.EnsureProject(ctx, c.projectKey); err != nil {
+		return fmt.Errorf("failed to register project: %w", err)
+	}
+	slog.Info("Project registered in Agent Mail", "project", c.projectKey)
 	fmt.Println("\n✓ Project registered in Agent Mail")
 
-	slog.Info("Agent list synchronized",
-		"count", c.agentManager.CountActive())
+	// 2. Update agent list
+	agents := c.agentManager.List()
+	sort.Slice(agents, func(i, j int) bool { return agents[i].Name < agents[j].Name })
+	for _, a := range agents {
+		if err := mail.RegisterAgent(ctx, c.projectKey, a); err != nil {
+			return fmt.Errorf("failed to register agent %q: %w", a.Name, err)
+		}
+	}
+	slog.Info("Agent list synchronized", "count", len(agents))
 	fmt.Println("✓ Agent list synchronized")
 
-	slog.Info("Message queue checked")
+	// 3. Fetch recent messages
+	inbox, threads, err := c.syncInboxes(ctx, mail, agents)
+	if err != nil {
+		return err
+	}
+	slog.Info("Message queue checked", "agents", len(inbox))
 	fmt.Println("✓ Message queue checked")
 
-	slog.Info("File reservations updated")
+	// 4. Check file reservations
+	reservations, err := mail.ListReservations(ctx, c.projectKey)
+	if err != nil {
+		return fmt.Errorf("failed to list file reservations: %w", err)
+	}
+	active := c.mirrorReservations(reservations)
+	slog.Info("File reservations updated", "active", active)
 	fmt.Println("✓ File reservations updated")
 
+	c.mu.Lock()
+	c.inbox = inbox
+	c.activeThreads = threads
+	c.activeReservations = active
+	c.lastSync = time.Now()
+	c.mu.Unlock()
+
 	slog.Info("Coordination sync complete",
 		"project", c.projectKey,
 		"status", "success")
@@ -99,6 +240,228 @@ func (c *Coordinator) Sync() error {
 	return nil
 }
 
+// Run syncs every messages.check_interval seconds until ctx is cancelled
+func (c *Coordinator) Run(ctx context.Context) error {
+	interval := time.Duration(c.config.Coordination.Messages.CheckInterval) * time.Second
+	if interval <= 0 {
+		interval = defaultCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.SyncContext(ctx); err != nil {
+			slog.Error("Coordination sync failed", "error", err, "project", c.projectKey)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReserveFiles reserves paths for an agent in Agent Mail and mirrors the
+// granted reservations into the lock registry
+func (c *Coordinator) ReserveFiles(ctx context.Context, agentName string, paths []string, exclusive bool, reason string) ([]Reservation, error) {
+	c.mu.Lock()
+	mail := c.mail
+	c.mu.Unlock()
+	if mail == nil {
+		return nil, ErrNoMailServer
+	}
+
+	granted, err := mail.ReservePaths(ctx, c.projectKey, agentName, paths, c.reservationTTL(), exclusive, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve files: %w", err)
+	}
+	for _, r := range granted {
+		c.mirrorReservation(r)
+	}
+	return granted, nil
+}
+
+// ReleaseFiles releases an agent's reservations and the mirrored locks
+func (c *Coordinator) ReleaseFiles(ctx context.Context, agentName string, paths []string) error {
+	c.mu.Lock()
+	mail := c.mail
+	c.mu.Unlock()
+	if mail == nil {
+		return ErrNoMailServer
+	}
+
+	if err := mail.ReleasePaths(ctx, c.projectKey, agentName, paths); err != nil {
+		return fmt.Errorf("failed to release files: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range paths {
+		key := mirrorKey(c.lockPath(p), agentName)
+		if req, ok := c.mirrored[key]; ok {
+			_ = c.locks.Release(req.Path, req.Holder)
+			delete(c.mirrored, key)
+		}
+	}
+	return nil
+}
+
+// syncInboxes fetches each agent's inbox, acknowledging messages when
+// auto_ack is set, and returns unread messages at or above the importance
+// threshold along with the number of distinct threads seen
+func (c *Coordinator) syncInboxes(ctx context.Context, mail MailClient, agents []agent.Agent) (map[string][]Message, int, error) {
+	msgCfg := c.config.Coordination.Messages
+	threshold := importanceRank(msgCfg.ImportanceThreshold)
+
+	inbox := make(map[string][]Message)
+	threads := make(map[string]struct{})
+
+	for _, a := range agents {
+		messages, err := mail.FetchInbox(ctx, c.projectKey, a.Name, defaultInboxLimit)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to fetch inbox for %q: %w", a.Name, err)
+		}
+
+		for _, m := range messages {
+			if m.ThreadID != "" {
+				threads[m.ThreadID] = struct{}{}
+			}
+			if msgCfg.AutoAck && m.AckRequired {
+				if err := mail.Acknowledge(ctx, c.projectKey, a.Name, m.ID); err != nil {
+					return nil, 0, fmt.Errorf("failed to acknowledge message %d: %w", m.ID, err)
+				}
+				continue
+			}
+			if importanceRank(m.Importance) >= threshold {
+				inbox[a.Name] = append(inbox[a.Name], m)
+			}
+		}
+	}
+	return inbox, len(threads), nil
+}
+
+// mirrorReservations makes the lock registry reflect the active reservations,
+// releasing mirrored locks whose reservation is gone. Returns the number of
+// active reservations.
+func (c *Coordinator) mirrorReservations(reservations []Reservation) int {
+	now := time.Now()
+	wanted := make(map[string]struct{})
+	active := 0
+
+	for _, r := range reservations {
+		if exp := r.ExpiresAt(); !exp.IsZero() && !exp.After(now) {
+			continue
+		}
+		active++
+		wanted[mirrorKey(c.lockPath(r.PathPattern), r.Agent)] = struct{}{}
+		c.mirrorReservation(r)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, req := range c.mirrored {
+		if _, ok := wanted[key]; !ok {
+			_ = c.locks.Release(req.Path, req.Holder)
+			delete(c.mirrored, key)
+		}
+	}
+	return active
+}
+
+// mirrorReservation acquires (or renews) the lock matching a reservation
+func (c *Coordinator) mirrorReservation(r Reservation) {
+	ttl := c.reservationTTL()
+	if exp := r.ExpiresAt(); !exp.IsZero() {
+		ttl = time.Until(exp)
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	req := filelock.LockRequest{
+		Path:      c.lockPath(r.PathPattern),
+		Holder:    r.Agent,
+		Exclusive: r.Exclusive,
+		TTL:       ttl,
+	}
+	key := mirrorKey(req.Path, req.Holder)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.mirrored[key]; ok {
+		if err := c.locks.RenewLock(req.Path, req.Holder, ttl); err == nil {
+			return
+		}
+	}
+	if _, err := c.locks.Acquire(req); err != nil {
+		slog.Warn("Reservation conflicts with local lock",
+			"path", r.PathPattern,
+			"agent", r.Agent,
+			"error", err)
+		return
+	}
+	c.mirrored[key] = req
+}
+
+// lockPath resolves a project-relative reservation pattern to an absolute lock path
+func (c *Coordinator) lockPath(pattern string) string {
+	if filepath.IsAbs(pattern) {
+		return pattern
+	}
+	return filepath.Join(c.projectKey, pattern)
+}
+
+// reservationTTL returns the configured default reservation lifetime
+func (c *Coordinator) reservationTTL() time.Duration {
+	if ttl := c.config.Coordination.Reservations.DefaultTTL; ttl > 0 {
+		return time.Duration(ttl) * time.Second
+	}
+	return defaultReservationTTL
+}
+
+func mirrorKey(path, holder string) string {
+	return path + "\x00" + holder
+}
+
+// importanceRank orders Agent Mail importance levels; unknown values rank as normal
+func importanceRank(importance string) int {
+	switch strings.ToLower(importance) {
+	case "low":
+		return 0
+	case "", "normal":
+		return 1
+	case "high":
+		return 2
+	case "urgent":
+		return 3
+	default:
+		return 1
+	}
+}
+
+// selectMailServer picks the MCP server used for coordination: the enabled
+// "agent-mail" entry if present, otherwise the enabled entry with a name
+// containing "mail" and the highest priority
+func selectMailServer(servers config.MCPServersConfig) (string, config.MCPServerConfig, bool) {
+	if s, ok := servers[AgentMailServerName]; ok && s.Enabled && s.Command != "" {
+		return AgentMailServerName, s, true
+	}
+
+	bestName := ""
+	var best config.MCPServerConfig
+	for name, s := range servers {
+		if !s.Enabled || s.Command == "" || !strings.Contains(strings.ToLower(name), "mail") {
+			continue
+		}
+		if bestName == "" || s.Priority > best.Priority || (s.Priority == best.Priority && name < bestName) {
+			bestName, best = name, s
+		}
+	}
+	return bestName, best, bestName != ""
+}
+
 // RegisterAgent registers a new agent in the project
 func (c *Coordinator) RegisterAgent(name, program, model, taskDesc string) error {
 	slog.Info("Registering agent via coordinator",
@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package coordinator
+
+import (
+	"context"
+	"time"
+
+	"open-swarm/pkg/agent"
+)
+
+// Agent Mail MCP tool names
+const (
+	toolEnsureProject       = "ensure_project"
+	toolRegisterAgent       = "register_agent"
+	toolFetchInbox          = "fetch_inbox"
+	toolAcknowledgeMessage  = "acknowledge_message"
+	toolReservePaths        = "file_reservation_paths"
+	toolReleaseReservations = "release_file_reservations"
+	toolListReservations    = "list_file_reservations"
+)
+
+// Message is an Agent Mail inbox entry
+type Message struct {
+	ID          int    `json:"id"`
+	Subject     string `json:"subject"`
+	From        string `json:"from"`
+	Importance  string `json:"importance"`
+	AckRequired bool   `json:"ack_required"`
+	ThreadID    string `json:"thread_id,omitempty"`
+	CreatedTS   string `json:"created_ts,omitempty"`
+	Body        string `json:"body_md,omitempty"`
+}
+
+// Reservation is an advisory file reservation held by an agent
+type Reservation struct {
+	ID          int    `json:"id"`
+	Agent       string `json:"agent"`
+	PathPattern string `json:"path_pattern"`
+	Exclusive   bool   `json:"exclusive"`
+	Reason      string `json:"reason,omitempty"`
+	ExpiresTS   string `json:"expires_ts"`
+}
+
+// ExpiresAt parses the reservation expiry, returning the zero time if unset or invalid
+func (r Reservation) ExpiresAt() time.Time {
+	t, err := time.Parse(time.RFC3339, r.ExpiresTS)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// MailClient is the coordination backend used by Coordinator
+type MailClient interface {
+	EnsureProject(ctx context.Context, projectKey string) error
+	RegisterAgent(ctx context.Context, projectKey string, a agent.Agent) error
+	FetchInbox(ctx context.Context, projectKey, agentName string, limit int) ([]Message, error)
+	Acknowledge(ctx context.Context, projectKey, agentName string, messageID int) error
+	ReservePaths(ctx context.Context, projectKey, agentName string, paths []string, ttl time.Duration, exclusive bool, reason string) ([]Reservation, error)
+	ReleasePaths(ctx context.Context, projectKey, agentName string, paths []string) error
+	ListReservations(ctx context.Context, projectKey string) ([]Reservation, error)
+	Close() error
+}
+
+// Ensure AgentMailClient implements MailClient
+var _ MailClient = (*AgentMailClient)(nil)
+
+// AgentMailClient implements MailClient with Agent Mail tools over MCP
+type AgentMailClient struct {
+	mcp *MCPClient
+}
+
+// NewAgentMailClient wraps an initialized MCP connection
+func NewAgentMailClient(mcp *MCPClient) *AgentMailClient {
+	return &AgentMailClient{mcp: mcp}
+}
+
+// EnsureProject creates the project in Agent Mail if it does not exist
+func (m *AgentMailClient) EnsureProject(ctx context.Context, projectKey string) error {
+	return m.mcp.CallTool(ctx, toolEnsureProject, map[string]interface{}{
+		"human_key": projectKey,
+	}, nil)
+}
+
+// RegisterAgent creates or updates an agent identity
+func (m *AgentMailClient) RegisterAgent(ctx context.Context, projectKey string, a agent.Agent) error {
+	return m.mcp.CallTool(ctx, toolRegisterAgent, map[string]interface{}{
+		"project_key":      projectKey,
+		"name":             a.Name,
+		"program":          a.Program,
+		"model":            a.Model,
+		"task_description": a.TaskDescription,
+	}, nil)
+}
+
+// FetchInbox returns the most recent messages addressed to an agent
+func (m *AgentMailClient) FetchInbox(ctx context.Context, projectKey, agentName string, limit int) ([]Message, error) {
+	var messages []Message
+	err := m.mcp.CallTool(ctx, toolFetchInbox, map[string]interface{}{
+		"project_key": projectKey,
+		"agent_name":  agentName,
+		"limit":       limit,
+	}, &messages)
+	return messages, err
+}
+
+// Acknowledge marks a message as acknowledged by an agent
+func (m *AgentMailClient) Acknowledge(ctx context.Context, projectKey, agentName string, messageID int) error {
+	return m.mcp.CallTool(ctx, toolAcknowledgeMessage, map[string]interface{}{
+		"project_key": projectKey,
+		"agent_name":  agentName,
+		"message_id":  messageID,
+	}, nil)
+}
+
+// ReservePaths requests file reservations and returns the granted ones
+func (m *AgentMailClient) ReservePaths(ctx context.Context, projectKey, agentName string, paths []string, ttl time.Duration, exclusive bool, reason string) ([]Reservation, error) {
+	var result struct {
+		Granted []Reservation `json:"granted"`
+	}
+	err := m.mcp.CallTool(ctx, toolReservePaths, map[string]interface{}{
+		"project_key": projectKey,
+		"agent_name":  agentName,
+		"paths":       paths,
+		"ttl_seconds": int(ttl.Seconds()),
+		"exclusive":   exclusive,
+		"reason":      reason,
+	}, &result)
+	return result.Granted, err
+}
+
+// ReleasePaths releases an agent's reservations on the given paths
+func (m *AgentMailClient) ReleasePaths(ctx context.Context, projectKey, agentName string, paths []string) error {
+	return m.mcp.CallTool(ctx, toolReleaseReservations, map[string]interface{}{
+		"project_key": projectKey,
+		"agent_name":  agentName,
+		"paths":       paths,
+	}, nil)
+}
+
+// ListReservations returns all active reservations in the project
+func (m *AgentMailClient) ListReservations(ctx context.Context, projectKey string) ([]Reservation, error) {
+	var reservations []Reservation
+	err := m.mcp.CallTool(ctx, toolListReservations, map[string]interface{}{
+		"project_key": projectKey,
+		"active_only": true,
+	}, &reservations)
+	return reservations, err
+}
+
+// Close shuts down the MCP connection
+func (m *AgentMailClient) Close() error {
+	return m.mcp.Close()
+}
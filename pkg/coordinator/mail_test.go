@@ -0,0 +1,198 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package coordinator
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"open-swarm/internal/config"
+	"open-swarm/pkg/coordinator/agentmailtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubEnv makes the test binary act as a stdio Agent Mail server; "hang"
+// keeps it running after stdin closes
+const stubEnv = "OPEN_SWARM_AGENT_MAIL_STUB"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(stubEnv) {
+	case "1":
+		_ = agentmailtest.NewServer().Serve(os.Stdin, os.Stdout)
+		os.Exit(0)
+	case "hang":
+		_ = agentmailtest.NewServer().Serve(os.Stdin, os.Stdout)
+		select {}
+	}
+	os.Exit(m.Run())
+}
+
+// newStubCoordinator connects a coordinator to an in-process stand-in server over pipes
+func newStubCoordinator(t *testing.T, cfg *config.Config) (*Coordinator, *agentmailtest.Server) {
+	t.Helper()
+
+	server := agentmailtest.NewServer()
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+	go func() {
+		_ = server.Serve(serverR, serverW)
+		_ = serverW.Close()
+	}()
+
+	mcp := NewMCPClient(clientR, clientW, clientW)
+	require.NoError(t, mcp.Initialize(context.Background()))
+
+	coord, err := New(cfg)
+	require.NoError(t, err)
+	coord.SetMailClient(NewAgentMailClient(mcp))
+	t.Cleanup(func() { _ = coord.Close() })
+
+	return coord, server
+}
+
+func stubConfig() *config.Config {
+	return &config.Config{
+		Project: config.ProjectConfig{Name: "test", WorkingDirectory: "/tmp/project"},
+	}
+}
+
+func TestCoordinator_ConnectStdio(t *testing.T) {
+	t.Setenv(stubEnv, "1")
+	cfg := stubConfig()
+	cfg.MCPServers = config.MCPServersConfig{
+		AgentMailServerName: {Command: os.Args[0], Enabled: true},
+	}
+
+	coord, err := New(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, coord.Connect(ctx))
+	defer coord.Close()
+
+	require.NoError(t, coord.RegisterAgent("BlueLake", "opencode", "claude-sonnet-4-5", "Backend"))
+	require.NoError(t, coord.SyncContext(ctx))
+
+	status := coord.GetStatus()
+	assert.True(t, status.MCPServersConnected)
+	assert.Equal(t, 1, status.ActiveAgents)
+	assert.False(t, status.LastSync.IsZero())
+}
+
+func TestCoordinator_ConnectWithoutServer(t *testing.T) {
+	coord, err := New(stubConfig())
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, coord.Connect(context.Background()), ErrNoMailServer)
+}
+
+func TestCoordinator_SyncMessages(t *testing.T) {
+	cfg := stubConfig()
+	cfg.Coordination.Messages = config.MessagesConfig{AutoAck: true, ImportanceThreshold: "high"}
+	coord, server := newStubCoordinator(t, cfg)
+
+	require.NoError(t, coord.RegisterAgent("BlueLake", "opencode", "m", "Backend"))
+	require.NoError(t, coord.RegisterAgent("GreenCastle", "opencode", "m", "Frontend"))
+
+	ackID := server.PostMessage("BlueLake", "GreenCastle", "Please review", "normal", "T-1", true)
+	server.PostMessage("BlueLake", "GreenCastle", "Build broken", "urgent", "T-2", false)
+	server.PostMessage("BlueLake", "GreenCastle", "FYI", "low", "T-2", false)
+	server.PostMessage("GreenCastle", "BlueLake", "API changed", "high", "", false)
+
+	require.NoError(t, coord.Sync())
+
+	assert.Equal(t, []string{"BlueLake", "GreenCastle"}, server.Agents())
+	assert.Equal(t, []string{"/tmp/project"}, server.Projects())
+	assert.True(t, server.Acknowledged(ackID))
+
+	status := coord.GetStatus()
+	assert.Equal(t, 2, status.UnreadMessages, "only high and urgent messages count as unread")
+	assert.Equal(t, 2, status.ActiveThreads)
+	require.Len(t, coord.Messages("BlueLake"), 1)
+	assert.Equal(t, "Build broken", coord.Messages("BlueLake")[0].Subject)
+}
+
+func TestCoordinator_MirrorsReservations(t *testing.T) {
+	coord, server := newStubCoordinator(t, stubConfig())
+	require.NoError(t, coord.RegisterAgent("BlueLake", "opencode", "m", "Backend"))
+
+	server.Reserve("GreenCastle", "internal/api/*.go", true, time.Hour)
+	require.NoError(t, coord.Sync())
+
+	locks := coord.LockRegistry().Check("/tmp/project/internal/api/*.go")
+	require.Len(t, locks, 1)
+	assert.Equal(t, "GreenCastle", locks[0].Holder)
+	assert.True(t, locks[0].Exclusive)
+	assert.Equal(t, 1, coord.GetStatus().ActiveReservations)
+
+	// Reserving through the coordinator mirrors immediately
+	granted, err := coord.ReserveFiles(context.Background(), "BlueLake", []string{"cmd/main.go"}, true, "bd-42")
+	require.NoError(t, err)
+	require.Len(t, granted, 1)
+	assert.Len(t, coord.LockRegistry().Check("/tmp/project/cmd/main.go"), 1)
+
+	// Released reservations disappear from the registry
+	require.NoError(t, coord.ReleaseFiles(context.Background(), "BlueLake", []string{"cmd/main.go"}))
+	assert.Empty(t, coord.LockRegistry().Check("/tmp/project/cmd/main.go"))
+
+	require.NoError(t, coord.Sync())
+	assert.Len(t, coord.LockRegistry().Check("/tmp/project/internal/api/*.go"), 1, "renewed, not duplicated")
+}
+
+func TestMCPClient_ToolError(t *testing.T) {
+	coord, _ := newStubCoordinator(t, stubConfig())
+
+	err := coord.mail.Acknowledge(context.Background(), "/tmp/project", "Nobody", 99)
+	var toolErr *ToolError
+	require.ErrorAs(t, err, &toolErr)
+	assert.Equal(t, toolAcknowledgeMessage, toolErr.Tool)
+}
+
+func TestMCPClient_CloseKillsHungServer(t *testing.T) {
+	t.Setenv(stubEnv, "hang")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := StartMCPServer(ctx, os.Args[0])
+	require.NoError(t, err)
+	client.shutdownTimeout = 100 * time.Millisecond
+
+	closed := make(chan error, 1)
+	go func() { closed <- client.Close() }()
+	select {
+	case err := <-closed:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close hung on a server that ignores EOF")
+	}
+	assert.NotNil(t, client.cmd.ProcessState, "server process should have been reaped")
+}
+
+func TestSelectMailServer(t *testing.T) {
+	servers := config.MCPServersConfig{
+		"serena":       {Command: "serena", Enabled: true, Priority: 10},
+		"mail-backup":  {Command: "mail-b", Enabled: true, Priority: 1},
+		"mail-primary": {Command: "mail-a", Enabled: true, Priority: 5},
+		"mail-off":     {Command: "mail-c", Enabled: false, Priority: 9},
+	}
+
+	name, server, ok := selectMailServer(servers)
+	require.True(t, ok)
+	assert.Equal(t, "mail-primary", name)
+	assert.Equal(t, "mail-a", server.Command)
+
+	servers[AgentMailServerName] = config.MCPServerConfig{Command: "agent-mail", Enabled: true}
+	name, _, _ = selectMailServer(servers)
+	assert.Equal(t, AgentMailServerName, name)
+
+	_, _, ok = selectMailServer(config.MCPServersConfig{"serena": {Command: "serena", Enabled: true}})
+	assert.False(t, ok)
+}
@@ -0,0 +1,308 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package coordinator
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mcpProtocolVersion is the MCP revision negotiated during initialize
+const mcpProtocolVersion = "2024-11-05"
+
+// mcpShutdownTimeout is how long Close waits for a server to exit after
+// its stdin is closed before killing it
+const mcpShutdownTimeout = 5 * time.Second
+
+// ErrMCPClosed is returned for calls made after the server connection closed
+var ErrMCPClosed = errors.New("mcp connection closed")
+
+// RPCError is a JSON-RPC error returned by an MCP server
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("mcp error %d: %s", e.Code, e.Message)
+}
+
+// ToolError is returned when a tool call completes with isError set
+type ToolError struct {
+	Tool    string
+	Message string
+}
+
+func (e *ToolError) Error() string {
+	return fmt.Sprintf("tool %s failed: %s", e.Tool, e.Message)
+}
+
+// rpcMessage is a JSON-RPC 2.0 request, response or notification
+type rpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int64          `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  interface{}     `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// toolResult is the result of a tools/call request
+type toolResult struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
+	IsError           bool            `json:"isError"`
+}
+
+// MCPClient speaks newline-delimited JSON-RPC to an MCP server over stdio.
+// Requests may be issued concurrently; responses are matched by ID.
+type MCPClient struct {
+	writer          io.Writer
+	closer          io.Closer
+	cmd             *exec.Cmd
+	shutdownTimeout time.Duration
+
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan rpcMessage
+	err     error
+	done    chan struct{}
+}
+
+// NewMCPClient creates a client over an established stream (useful for testing).
+// closer, if non-nil, is closed by Close.
+func NewMCPClient(r io.Reader, w io.Writer, closer io.Closer) *MCPClient {
+	c := &MCPClient{
+		writer:          w,
+		closer:          closer,
+		shutdownTimeout: mcpShutdownTimeout,
+		pending:         make(map[int64]chan rpcMessage),
+		done:            make(chan struct{}),
+	}
+	go c.readLoop(r)
+	return c
+}
+
+// StartMCPServer launches a stdio MCP server from a command line and
+// completes the initialize handshake
+func StartMCPServer(ctx context.Context, command string) (*MCPClient, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("mcp server command is empty")
+	}
+
+	cmd := exec.Command(fields[0], fields[1:]...) //nolint:gosec // Command comes from the project configuration
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mcp stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mcp stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start mcp server %q: %w", fields[0], err)
+	}
+
+	client := NewMCPClient(stdout, stdin, stdin)
+	client.cmd = cmd
+	if err := client.Initialize(ctx); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// Initialize performs the MCP initialize handshake
+func (c *MCPClient) Initialize(ctx context.Context) error {
+	params := map[string]interface{}{
+		"protocolVersion": mcpProtocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]string{"name": "open-swarm", "version": "1.0.0"},
+	}
+	if _, err := c.call(ctx, "initialize", params); err != nil {
+		return fmt.Errorf("mcp initialize failed: %w", err)
+	}
+	return c.notify("notifications/initialized", nil)
+}
+
+// CallTool invokes a tool and decodes its JSON result into out (if non-nil)
+func (c *MCPClient) CallTool(ctx context.Context, name string, args interface{}, out interface{}) error {
+	raw, err := c.call(ctx, "tools/call", map[string]interface{}{
+		"name":      name,
+		"arguments": args,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", name, err)
+	}
+
+	var result toolResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return fmt.Errorf("failed to decode %s result: %w", name, err)
+	}
+
+	text := ""
+	for _, content := range result.Content {
+		if content.Type == "text" {
+			text += content.Text
+		}
+	}
+	if result.IsError {
+		return &ToolError{Tool: name, Message: text}
+	}
+	if out == nil {
+		return nil
+	}
+
+	payload := result.StructuredContent
+	if len(payload) == 0 {
+		payload = json.RawMessage(text)
+	}
+	// Servers commonly wrap non-object results as {"result": ...}
+	var wrapped struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if json.Unmarshal(payload, &wrapped) == nil && len(wrapped.Result) > 0 {
+		payload = wrapped.Result
+	}
+	if err := json.Unmarshal(payload, out); err != nil {
+		return fmt.Errorf("failed to decode %s result: %w", name, err)
+	}
+	return nil
+}
+
+// Close terminates the connection and the server process, if any. A server
+// that does not exit on EOF within the shutdown timeout is killed.
+func (c *MCPClient) Close() error {
+	var err error
+	if c.closer != nil {
+		err = c.closer.Close()
+	}
+	if c.cmd != nil {
+		exited := make(chan error, 1)
+		go func() { exited <- c.cmd.Wait() }()
+
+		var waitErr error
+		select {
+		case waitErr = <-exited:
+		case <-time.After(c.shutdownTimeout):
+			slog.Warn("MCP server ignored EOF, killing it", "pid", c.cmd.Process.Pid)
+			_ = c.cmd.Process.Kill()
+			waitErr = <-exited
+		}
+		if waitErr != nil {
+			slog.Debug("MCP server exited", "error", waitErr)
+		}
+	}
+	return err
+}
+
+// call sends a request and waits for its response
+func (c *MCPClient) call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return nil, c.err
+	}
+	c.nextID++
+	id := c.nextID
+	ch := make(chan rpcMessage, 1)
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	if err := c.send(rpcMessage{JSONRPC: "2.0", ID: &id, Method: method, Params: params}); err != nil {
+		return nil, err
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.Error != nil {
+			return nil, resp.Error
+		}
+		return resp.Result, nil
+	case <-c.done:
+		return nil, c.closedErr()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// notify sends a notification (no response expected)
+func (c *MCPClient) notify(method string, params interface{}) error {
+	return c.send(rpcMessage{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (c *MCPClient) send(msg rpcMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", msg.Method, err)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if _, err := c.writer.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to send %s: %w", msg.Method, err)
+	}
+	return nil
+}
+
+// readLoop dispatches responses to waiting callers until the stream ends
+func (c *MCPClient) readLoop(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		var msg rpcMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			slog.Warn("Ignoring malformed MCP message", "error", err)
+			continue
+		}
+		// Server-initiated requests and notifications are not used
+		if msg.ID == nil || msg.Method != "" {
+			continue
+		}
+
+		c.mu.Lock()
+		ch, ok := c.pending[*msg.ID]
+		c.mu.Unlock()
+		if ok {
+			ch <- msg
+		}
+	}
+
+	c.mu.Lock()
+	c.err = ErrMCPClosed
+	if err := scanner.Err(); err != nil {
+		c.err = fmt.Errorf("%w: %v", ErrMCPClosed, err)
+	}
+	c.mu.Unlock()
+	close(c.done)
+}
+
+func (c *MCPClient) closedErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
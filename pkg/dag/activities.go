@@ -1,13 +1,28 @@
 package dag
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
 	"github.com/bitfield/script"
 	"go.temporal.io/sdk/activity"
 )
 
-type ShellActivities struct{}
+// MaxArtifactBytes caps the outputs one task passes to its dependents.
+// Artifacts travel through workflow history, so they must stay small.
+const MaxArtifactBytes = 1 << 20
+
+type ShellActivities struct {
+	// CacheDir holds the action cache of tasks with Cache set
+	// (default: DefaultCacheDir)
+	CacheDir string
+}
 
 // RunDAGScript executes a shell command using bitfield/script
 func (sa *ShellActivities) RunDAGScript(ctx context.Context, command string) (string, error) {
@@ -26,3 +41,173 @@ func (sa *ShellActivities) RunDAGScript(ctx context.Context, command string) (st
 	logger.Info("Command succeeded", "output", output)
 	return output, nil
 }
+
+// TaskRun is the input of RunDAGTask
+type TaskRun struct {
+	Name      string
+	Command   string
+	Env       map[string]string
+	Dir       string
+	If        string
+	Outputs   []string
+	Artifacts map[string][]byte // Outputs of the dependencies, written to Dir first
+
+	Inputs      []string // Hashed into the cache key
+	Cache       bool
+	Fingerprint string
+}
+
+// TaskRunResult is the result of RunDAGTask
+type TaskRunResult struct {
+	Output    string
+	Skipped   bool              // The If condition failed
+	CacheHit  bool              // Restored from the action cache without running
+	Artifacts map[string][]byte // The task's Outputs
+}
+
+// RunDAGTask runs a task that needs more than RunDAGScript: environment,
+// working directory, a condition, artifacts, or caching. Commands run through
+// sh, so pipes and redirects work. Cached tasks whose key matches an earlier
+// success get its output and artifacts back without running.
+func (sa *ShellActivities) RunDAGTask(ctx context.Context, run TaskRun) (*TaskRunResult, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Executing task", "name", run.Name, "cmd", run.Command, "dir", run.Dir)
+
+	stop := heartbeatEvery(ctx, 10*time.Second)
+	defer stop()
+
+	for path, data := range run.Artifacts {
+		if err := writeArtifact(run.Dir, path, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if run.If != "" {
+		if _, err := runShell(ctx, run, run.If); err != nil {
+			var exitErr *exec.ExitError
+			if !errors.As(err, &exitErr) {
+				return nil, fmt.Errorf("condition failed to run: %w", err)
+			}
+			logger.Info("Condition not met, skipping task", "name", run.Name, "if", run.If)
+			return &TaskRunResult{Skipped: true}, nil
+		}
+	}
+
+	var cache *ActionCache
+	var key string
+	if run.Cache {
+		cache = sa.cache()
+		var err error
+		if key, err = cache.Key(ctx, run); err != nil {
+			return nil, fmt.Errorf("failed to compute cache key: %w", err)
+		}
+		result, ok, err := cache.Get(key, run.Dir)
+		if err != nil {
+			logger.Warn("Action cache read failed, running task", "name", run.Name, "error", err)
+		} else if ok {
+			logger.Info("Action cache hit", "name", run.Name, "key", key)
+			return result, nil
+		}
+	}
+
+	output, err := runShell(ctx, run, run.Command)
+	if err != nil {
+		logger.Error("Command failed", "error", err, "output", output)
+		return nil, fmt.Errorf("shell command failed: %w\n%s", err, output)
+	}
+
+	artifacts, err := readArtifacts(run.Dir, run.Outputs)
+	if err != nil {
+		return nil, err
+	}
+	result := &TaskRunResult{Output: output, Artifacts: artifacts}
+	if cache != nil {
+		if err := cache.Put(key, result); err != nil {
+			logger.Warn("Action cache write failed", "name", run.Name, "error", err)
+		}
+	}
+	logger.Info("Command succeeded", "name", run.Name, "artifacts", len(artifacts))
+	return result, nil
+}
+
+func (sa *ShellActivities) cache() *ActionCache {
+	dir := sa.CacheDir
+	if dir == "" {
+		dir = DefaultCacheDir()
+	}
+	return &ActionCache{Dir: dir}
+}
+
+// runShell runs command through sh with the task's directory and environment
+func runShell(ctx context.Context, run TaskRun, command string) (string, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = run.Dir
+	cmd.Env = os.Environ()
+	for key, value := range run.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := cmd.Run()
+	return output.String(), err
+}
+
+func writeArtifact(dir, path string, data []byte) error {
+	if !filepath.IsLocal(path) {
+		return fmt.Errorf("artifact %s is outside the task directory", path)
+	}
+	target := filepath.Join(dir, path)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("failed to write artifact %s: %w", path, err)
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write artifact %s: %w", path, err)
+	}
+	return nil
+}
+
+func readArtifacts(dir string, outputs []string) (map[string][]byte, error) {
+	if len(outputs) == 0 {
+		return nil, nil
+	}
+	artifacts := make(map[string][]byte, len(outputs))
+	total := 0
+	for _, path := range outputs {
+		if !filepath.IsLocal(path) {
+			return nil, fmt.Errorf("output %s is outside the task directory", path)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			return nil, fmt.Errorf("declared output %s was not produced: %w", path, err)
+		}
+		total += len(data)
+		if total > MaxArtifactBytes {
+			return nil, fmt.Errorf("outputs exceed %d bytes; write large artifacts to shared storage instead", MaxArtifactBytes)
+		}
+		artifacts[path] = data
+	}
+	return artifacts, nil
+}
+
+// heartbeatEvery records heartbeats until the returned stop is called, so
+// long commands outlive the heartbeat timeout
+func heartbeatEvery(ctx context.Context, interval time.Duration) func() {
+	done := make(chan struct{})
+	activity.RecordHeartbeat(ctx, "executing")
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				activity.RecordHeartbeat(ctx, "executing")
+			}
+		}
+	}()
+	return func() { close(done) }
+}
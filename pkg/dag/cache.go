@@ -0,0 +1,241 @@
+package dag
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// cacheKeyVersion changes whenever the key derivation does, so old entries
+// are never misread
+const cacheKeyVersion = "dag-cache-v1"
+
+// ActionCache stores successful task results on local disk, keyed by a
+// content hash of everything that determines them
+type ActionCache struct {
+	Dir string
+}
+
+// cacheEntry is the result.json of a cache entry; outputs sit next to it
+type cacheEntry struct {
+	Output  string
+	Outputs []string
+}
+
+// DefaultCacheDir is the action cache directory used when ShellActivities
+// has none: $OPEN_SWARM_DAG_CACHE, or open-swarm/dag under the user cache dir
+func DefaultCacheDir() string {
+	if dir := os.Getenv("OPEN_SWARM_DAG_CACHE"); dir != "" {
+		return dir
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, "open-swarm", "dag")
+}
+
+// Key hashes the task definition, the fingerprint output, the dependency
+// artifacts and the content of every input file
+func (c *ActionCache) Key(ctx context.Context, run TaskRun) (string, error) {
+	h := sha256.New()
+	field := func(name, value string) {
+		fmt.Fprintf(h, "%s %d\n%s\n", name, len(value), value)
+	}
+	field("version", cacheKeyVersion)
+	field("command", run.Command)
+	field("dir", run.Dir)
+	field("outputs", strings.Join(run.Outputs, "\n"))
+	for _, key := range sortedKeys(run.Env) {
+		field("env", key+"="+run.Env[key])
+	}
+
+	if run.Fingerprint != "" {
+		output, err := runShell(ctx, run, run.Fingerprint)
+		if err != nil {
+			return "", fmt.Errorf("fingerprint failed: %w", err)
+		}
+		field("fingerprint", output)
+	}
+
+	for _, path := range sortedKeys(run.Artifacts) {
+		field("artifact", path)
+		field("artifact-content", string(run.Artifacts[path]))
+	}
+
+	files, err := inputFiles(run.Dir, run.Inputs)
+	if err != nil {
+		return "", err
+	}
+	for _, file := range files {
+		if err := hashFile(h, run.Dir, file); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Get restores a cached result, writing its outputs into dir. ok is false
+// on a miss.
+func (c *ActionCache) Get(key, dir string) (result *TaskRunResult, ok bool, err error) {
+	entryDir := c.entryDir(key)
+	data, err := os.ReadFile(filepath.Join(entryDir, "result.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false, nil // Corrupt entry: treat as a miss and overwrite
+	}
+
+	result = &TaskRunResult{Output: entry.Output, CacheHit: true}
+	if len(entry.Outputs) > 0 {
+		result.Artifacts = make(map[string][]byte, len(entry.Outputs))
+	}
+	for _, path := range entry.Outputs {
+		content, err := os.ReadFile(filepath.Join(entryDir, "outputs", path))
+		if err != nil {
+			return nil, false, nil
+		}
+		if err := writeArtifact(dir, path, content); err != nil {
+			return nil, false, err
+		}
+		result.Artifacts[path] = content
+	}
+	return result, true, nil
+}
+
+// Put stores a successful result. Entries are written to a temporary
+// directory and renamed into place, so readers never see partial entries.
+func (c *ActionCache) Put(key string, result *TaskRunResult) error {
+	entryDir := c.entryDir(key)
+	if err := os.MkdirAll(filepath.Dir(entryDir), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(entryDir), key+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	entry := cacheEntry{Output: result.Output, Outputs: sortedKeys(result.Artifacts)}
+	for path, content := range result.Artifacts {
+		if err := writeArtifact(filepath.Join(tmp, "outputs"), path, content); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "result.json"), data, 0o644); err != nil {
+		return err
+	}
+
+	_ = os.RemoveAll(entryDir) // Replace a corrupt entry
+	if err := os.Rename(tmp, entryDir); err != nil && !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+	return nil
+}
+
+func (c *ActionCache) entryDir(key string) string {
+	return filepath.Join(c.Dir, key[:2], key)
+}
+
+// inputFiles expands task inputs into a sorted list of files relative to
+// dir. Directories ending in "/" are walked; other inputs are globs.
+// Patterns matching nothing are kept, so creating such a file changes the key.
+func inputFiles(dir string, inputs []string) ([]string, error) {
+	seen := make(map[string]bool)
+	for _, input := range inputs {
+		if strings.HasSuffix(input, "/") {
+			root := filepath.Join(dir, input)
+			err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					if errors.Is(err, fs.ErrNotExist) {
+						return nil
+					}
+					return err
+				}
+				if d.Type().IsRegular() {
+					rel, err := filepath.Rel(dir, path)
+					if err != nil {
+						return err
+					}
+					seen[filepath.ToSlash(rel)] = true
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to read input %s: %w", input, err)
+			}
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(dir, input))
+		if err != nil {
+			return nil, fmt.Errorf("bad input pattern %s: %w", input, err)
+		}
+		if len(matches) == 0 {
+			seen[input] = true
+		}
+		for _, match := range matches {
+			rel, err := filepath.Rel(dir, match)
+			if err != nil {
+				return nil, err
+			}
+			seen[filepath.ToSlash(rel)] = true
+		}
+	}
+	return sortedKeys(seen), nil
+}
+
+// hashFile writes a file's path and content to h; a missing file hashes as
+// absent
+func hashFile(h io.Writer, dir, file string) error {
+	f, err := os.Open(filepath.Join(dir, file))
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintf(h, "missing %s\n", file)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read input %s: %w", file, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		fmt.Fprintf(h, "dir %s\n", file)
+		return nil
+	}
+	fmt.Fprintf(h, "file %s %d\n", file, info.Size())
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("failed to read input %s: %w", file, err)
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package dag_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"open-swarm/pkg/dag"
+)
+
+// countingRun is a cached task that appends to runs.log each time it runs
+func countingRun(dir string) dag.TaskRun {
+	return dag.TaskRun{
+		Name:    "build",
+		Command: "echo ran >> runs.log && cat src.txt > out.txt",
+		Dir:     dir,
+		Inputs:  []string{"src.txt"},
+		Outputs: []string{"out.txt"},
+		Cache:   true,
+	}
+}
+
+func runCount(t *testing.T, dir string) int {
+	data, err := os.ReadFile(filepath.Join(dir, "runs.log"))
+	if os.IsNotExist(err) {
+		return 0
+	}
+	require.NoError(t, err)
+	return strings.Count(string(data), "ran")
+}
+
+func TestRunDAGTask_Cache(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src.txt"), []byte("v1"), 0o644))
+
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestActivityEnvironment()
+	env.RegisterActivity(&dag.ShellActivities{CacheDir: t.TempDir()})
+	run := func(task dag.TaskRun) *dag.TaskRunResult {
+		value, err := env.ExecuteActivity("RunDAGTask", task)
+		require.NoError(t, err)
+		var result dag.TaskRunResult
+		require.NoError(t, value.Get(&result))
+		return &result
+	}
+
+	first := run(countingRun(dir))
+	assert.False(t, first.CacheHit)
+	assert.Equal(t, 1, runCount(t, dir))
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "out.txt")))
+	second := run(countingRun(dir))
+	assert.True(t, second.CacheHit, "unchanged inputs hit the cache")
+	assert.Equal(t, 1, runCount(t, dir))
+	assert.Equal(t, map[string][]byte{"out.txt": []byte("v1")}, second.Artifacts)
+	data, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(data), "a hit restores the outputs")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src.txt"), []byte("v2"), 0o644))
+	assert.False(t, run(countingRun(dir)).CacheHit, "a changed input misses")
+	assert.Equal(t, 2, runCount(t, dir))
+
+	withEnv := countingRun(dir)
+	withEnv.Env = map[string]string{"MODE": "release"}
+	assert.False(t, run(withEnv).CacheHit, "a changed environment misses")
+
+	withFingerprint := countingRun(dir)
+	withFingerprint.Fingerprint = "echo toolchain-1"
+	assert.False(t, run(withFingerprint).CacheHit)
+	assert.True(t, run(withFingerprint).CacheHit)
+	withFingerprint.Fingerprint = "echo toolchain-2"
+	assert.False(t, run(withFingerprint).CacheHit, "a changed fingerprint misses")
+	assert.Equal(t, 5, runCount(t, dir))
+}
+
+func TestTddDagWorkflow_SkipsCachedTasks(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src.txt"), []byte("v1"), 0o644))
+	task := countingRun(dir)
+	tasks := []dag.Task{
+		{Name: task.Name, Command: task.Command, Dir: dir, Inputs: task.Inputs, Outputs: task.Outputs, Cache: true},
+		{Name: "check", Command: "grep -q v1 out.txt", Dir: dir, Deps: []string{"build"}},
+	}
+
+	execute := func() dag.CacheStats {
+		ts := &testsuite.WorkflowTestSuite{}
+		env := ts.NewTestWorkflowEnvironment()
+		env.RegisterActivity(&dag.ShellActivities{CacheDir: cacheDir})
+		env.ExecuteWorkflow(dag.TddDagWorkflow, dag.WorkflowInput{Tasks: tasks})
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		value, err := env.QueryWorkflow(dag.CacheStatsQuery)
+		require.NoError(t, err)
+		var stats dag.CacheStats
+		require.NoError(t, value.Get(&stats))
+		return stats
+	}
+
+	assert.Equal(t, dag.CacheStats{Misses: 1}, execute())
+	assert.Equal(t, dag.CacheStats{Hits: 1}, execute())
+	assert.Equal(t, 1, runCount(t, dir), "the second run reuses the cached build")
+}
@@ -20,6 +20,7 @@ const (
 // Engine manages the execution of a DAG
 type Engine struct {
 	Scheduler *Scheduler
+	state     *State
 }
 
 func NewEngine() *Engine {
@@ -28,9 +29,15 @@ func NewEngine() *Engine {
 	}
 }
 
+// State returns the state of the current or last run, nil before the first
+func (e *Engine) State() *State {
+	return e.state
+}
+
 // Run executes the DAG tasks within the given workflow context.
 // It manages the state and returns the final state and an error if any task fails.
-// If prevState is provided, it will resume from that state.
+// Tasks already running when one fails are allowed to finish, so their results
+// are kept. If prevState is provided, it will resume from that state.
 func (e *Engine) Run(ctx workflow.Context, tasks []Task, prevState *State) (*State, error) {
 	logger := workflow.GetLogger(ctx)
 
@@ -41,6 +48,7 @@ func (e *Engine) Run(ctx workflow.Context, tasks []Task, prevState *State) (*Sta
 		// Reset transient fields for the new run
 		state.PendingFutures = make(map[string]workflow.Future)
 		state.FailedTasks = make([]string, 0)
+		state.initMaps()
 	} else {
 		// 1. Plan
 		logger.Info("Starting new DAG execution")
@@ -52,6 +60,7 @@ func (e *Engine) Run(ctx workflow.Context, tasks []Task, prevState *State) (*Sta
 		// 2. Initialize State
 		state = NewState(tasks, flatOrder)
 	}
+	e.state = state
 
 	// 3. Configure Activity Options
 	ao := workflow.ActivityOptions{
@@ -70,17 +79,21 @@ func (e *Engine) Run(ctx workflow.Context, tasks []Task, prevState *State) (*Sta
 	shellActivities := &ShellActivities{}
 
 	for len(state.Completed) < len(tasks) {
-		e.scheduleRunnableTasks(ctx, logger, state, shellActivities)
+		// After a failure, only drain the tasks already running
+		if len(state.FailedTasks) == 0 {
+			e.scheduleRunnableTasks(ctx, logger, state, shellActivities)
+		}
 
 		// Wait logic
 		if len(state.PendingFutures) > 0 {
-			if err := e.waitForTaskCompletion(ctx, logger, state); err != nil {
-				return state, err // Return state on failure
-			}
-		} else if len(state.Completed) < len(tasks) {
-			// This condition indicates a stall, which is a failure.
-			return state, fmt.Errorf("DAG stalled - no tasks runnable (check dependencies)")
+			e.waitForTaskCompletion(ctx, logger, state)
+			continue
+		}
+		if len(state.FailedTasks) > 0 {
+			return state, fmt.Errorf("tasks failed: %v", state.FailedTasks) // Return state on failure
 		}
+		// This condition indicates a stall, which is a failure.
+		return state, fmt.Errorf("DAG stalled - no tasks runnable (check dependencies)")
 	}
 
 	logger.Info("DAG Execution Complete", "tasksCompleted", len(state.Completed))
@@ -96,11 +109,18 @@ func (e *Engine) scheduleRunnableTasks(ctx workflow.Context, logger log.Logger,
 
 		if e.allDependenciesMet(state, taskName) {
 			logger.Info("Starting task", "name", taskName)
-			cmd := state.TaskMap[taskName].Command
+			task := state.TaskMap[taskName]
+			taskCtx := workflow.WithActivityOptions(ctx, taskActivityOptions(ctx, task))
 
-			// Execute
-			f := workflow.ExecuteActivity(ctx, activities.RunDAGScript, cmd)
+			// Execute; plain commands keep using RunDAGScript
+			var f workflow.Future
+			if state.usesTaskActivity(taskName) {
+				f = workflow.ExecuteActivity(taskCtx, activities.RunDAGTask, state.taskRun(taskName))
+			} else {
+				f = workflow.ExecuteActivity(taskCtx, activities.RunDAGScript, task.Command)
+			}
 			state.PendingFutures[taskName] = f
+			state.Runs[taskName]++
 		}
 	}
 }
@@ -114,21 +134,28 @@ func (e *Engine) allDependenciesMet(state *State, taskName string) bool {
 	return true
 }
 
-func (e *Engine) waitForTaskCompletion(ctx workflow.Context, logger log.Logger, state *State) error {
+// waitForTaskCompletion waits for one running task to finish. Futures are
+// added in FlatOrder, not map order, to keep the workflow deterministic.
+func (e *Engine) waitForTaskCompletion(ctx workflow.Context, logger log.Logger, state *State) {
 	selector := workflow.NewSelector(ctx)
 
-	for name, future := range state.PendingFutures {
+	for _, name := range state.FlatOrder {
+		future := state.PendingFutures[name]
+		if future == nil {
+			continue
+		}
 		taskName := name // Capture for closure
 		selector.AddFuture(future, func(f workflow.Future) {
-			var output string
-			err := f.Get(ctx, &output)
+			output, err := e.taskOutput(ctx, state, taskName, f)
 
 			if err != nil {
 				logger.Error("Task failed", "name", taskName, "error", err)
 				state.FailedTasks = append(state.FailedTasks, taskName)
+				state.Errors[taskName] = err.Error()
 			} else {
 				logger.Info("Task completed", "name", taskName, "output", output)
 				state.Completed[taskName] = true
+				delete(state.Errors, taskName)
 			}
 
 			// Remove from pending
@@ -137,9 +164,51 @@ func (e *Engine) waitForTaskCompletion(ctx workflow.Context, logger log.Logger,
 	}
 
 	selector.Select(ctx)
+}
 
-	if len(state.FailedTasks) > 0 {
-		return fmt.Errorf("tasks failed: %v", state.FailedTasks)
+// taskOutput reads the result of either task activity, recording the
+// artifacts and skip of RunDAGTask
+func (e *Engine) taskOutput(ctx workflow.Context, state *State, taskName string, f workflow.Future) (string, error) {
+	if !state.usesTaskActivity(taskName) {
+		var output string
+		err := f.Get(ctx, &output)
+		return output, err
+	}
+
+	var result TaskRunResult
+	if err := f.Get(ctx, &result); err != nil {
+		return "", err
+	}
+	if result.Skipped {
+		state.Skipped[taskName] = true
+		return "skipped: condition not met", nil
+	}
+	delete(state.Skipped, taskName)
+	if state.TaskMap[taskName].Cache {
+		state.Cached[taskName] = result.CacheHit
+		if result.CacheHit {
+			state.CacheStats.Hits++
+		} else {
+			state.CacheStats.Misses++
+		}
+	}
+	if len(result.Artifacts) > 0 {
+		state.Artifacts[taskName] = result.Artifacts
+	}
+	return result.Output, nil
+}
+
+// taskActivityOptions applies the task's timeout and attempts to the
+// activity options of the run
+func taskActivityOptions(ctx workflow.Context, task Task) workflow.ActivityOptions {
+	ao := workflow.GetActivityOptions(ctx)
+	if task.Timeout > 0 {
+		ao.StartToCloseTimeout = task.Timeout
+	}
+	if task.MaxAttempts > 0 && ao.RetryPolicy != nil {
+		policy := *ao.RetryPolicy
+		policy.MaximumAttempts = int32(task.MaxAttempts)
+		ao.RetryPolicy = &policy
 	}
-	return nil
+	return ao
 }
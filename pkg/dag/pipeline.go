@@ -0,0 +1,502 @@
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PipelineVersion is the pipeline file format version this package reads
+const PipelineVersion = 1
+
+// Pipeline is a parsed pipeline file. A pipeline file declares tasks in YAML:
+//
+//	version: 1
+//	name: go-tdd
+//	env: {GOFLAGS: -count=1}
+//	tasks:
+//	  - name: lint
+//	    run: golangci-lint run ./...
+//	    inputs: ["*.go", "internal/"]
+//	  - name: unit
+//	    run: go test ./${matrix.pkg}/... -coverprofile=cover.out
+//	    deps: [lint]
+//	    matrix: {pkg: [internal/git, pkg/dag]}
+//	    timeout: 10m
+//	    retries: 1
+//	    outputs: [cover.out]
+//	  - name: e2e
+//	    run: go test ./test/...
+//	    deps: [unit]
+//	    if: test -n "$RUN_E2E"
+//
+// A matrix task expands into one task per combination, named like
+// unit[pkg=pkg/dag]; depending on "unit" depends on every expansion.
+type Pipeline struct {
+	Version int
+	Name    string
+	Tasks   []Task // Matrix tasks expanded, in file order
+}
+
+// PipelineError is a problem at a line of a pipeline file
+type PipelineError struct {
+	File string
+	Line int
+	Msg  string
+}
+
+func (e *PipelineError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+}
+
+// LoadPipeline reads and validates a pipeline file
+func LoadPipeline(path string) (*Pipeline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pipeline: %w", err)
+	}
+	return ParsePipeline(path, data)
+}
+
+// ParsePipeline parses and validates a pipeline file. All problems found are
+// returned together, each as a *PipelineError; filename only labels them.
+func ParsePipeline(filename string, data []byte) (*Pipeline, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	p := &pipelineParser{file: filename, lines: make(map[string]int)}
+	pipeline := p.parse(&doc)
+	if len(p.errs) == 0 {
+		p.checkCycles(pipeline.Tasks)
+	}
+	if len(p.errs) > 0 {
+		return nil, errors.Join(p.errs...)
+	}
+	return pipeline, nil
+}
+
+var (
+	pipelineKeys = []string{"version", "name", "env", "dir", "tasks"}
+	taskKeys     = []string{"name", "run", "deps", "env", "dir", "if", "timeout", "retries", "inputs", "outputs", "cache", "fingerprint", "matrix"}
+)
+
+// pipelineParser collects errors while walking the YAML tree
+type pipelineParser struct {
+	file  string
+	errs  []error
+	lines map[string]int // Line of each task, by expanded name
+}
+
+func (p *pipelineParser) errorf(node *yaml.Node, format string, args ...any) {
+	line := 1
+	if node != nil {
+		line = node.Line
+	}
+	p.errs = append(p.errs, &PipelineError{File: p.file, Line: line, Msg: fmt.Sprintf(format, args...)})
+}
+
+// decode decodes node into out, recording a line-numbered error on failure
+func (p *pipelineParser) decode(node *yaml.Node, field string, out any) bool {
+	if err := node.Decode(out); err != nil {
+		p.errorf(node, "%s: %v", field, typeErrorText(err))
+		return false
+	}
+	return true
+}
+
+// fields returns the key/value pairs of a mapping, reporting unknown keys
+func (p *pipelineParser) fields(node *yaml.Node, what string, known []string) map[string][2]*yaml.Node {
+	fields := make(map[string][2]*yaml.Node)
+	if node.Kind != yaml.MappingNode {
+		p.errorf(node, "%s must be a mapping", what)
+		return fields
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !slices.Contains(known, key.Value) {
+			p.errorf(key, "unknown %s field %q", what, key.Value)
+			continue
+		}
+		fields[key.Value] = [2]*yaml.Node{key, value}
+	}
+	return fields
+}
+
+func (p *pipelineParser) parse(doc *yaml.Node) *Pipeline {
+	pipeline := &Pipeline{}
+	if len(doc.Content) == 0 {
+		p.errorf(nil, "empty pipeline")
+		return pipeline
+	}
+	fields := p.fields(doc.Content[0], "pipeline", pipelineKeys)
+
+	if version, ok := fields["version"]; !ok {
+		p.errorf(doc.Content[0], "version is required (current: %d)", PipelineVersion)
+	} else if p.decode(version[1], "version", &pipeline.Version) && pipeline.Version != PipelineVersion {
+		p.errorf(version[1], "unsupported version %d (current: %d)", pipeline.Version, PipelineVersion)
+	}
+	if name, ok := fields["name"]; ok {
+		p.decode(name[1], "name", &pipeline.Name)
+	}
+	var defaults Task
+	if env, ok := fields["env"]; ok {
+		p.decode(env[1], "env", &defaults.Env)
+	}
+	if dir, ok := fields["dir"]; ok {
+		p.decode(dir[1], "dir", &defaults.Dir)
+	}
+
+	tasks, ok := fields["tasks"]
+	if !ok || len(tasks[1].Content) == 0 {
+		p.errorf(doc.Content[0], "at least one task is required")
+		return pipeline
+	}
+	if tasks[1].Kind != yaml.SequenceNode {
+		p.errorf(tasks[1], "tasks must be a list")
+		return pipeline
+	}
+
+	// Expand every task first so deps can name any task
+	var parsed []parsedTask
+	expansions := make(map[string][]string)
+	for _, node := range tasks[1].Content {
+		// Invalid tasks still take part in the duplicate and deps checks
+		task := p.parseTask(node, defaults)
+		if task.base == "" {
+			continue
+		}
+		if _, dup := expansions[task.base]; dup {
+			p.errorf(node, "duplicate task %q", task.base)
+			continue
+		}
+		expansions[task.base] = nil
+		for _, expanded := range task.tasks {
+			expansions[task.base] = append(expansions[task.base], expanded.Name)
+			p.lines[expanded.Name] = node.Line
+		}
+		parsed = append(parsed, task)
+	}
+
+	for _, task := range parsed {
+		var deps []string
+		for _, dep := range task.deps {
+			names, ok := expansions[dep.Value]
+			if !ok && p.lines[dep.Value] != 0 {
+				names, ok = []string{dep.Value}, true // One expansion of a matrix task
+			}
+			if !ok {
+				p.errorf(dep, "task %q depends on unknown task %q", task.base, dep.Value)
+				continue
+			}
+			deps = append(deps, names...)
+		}
+		for _, expanded := range task.tasks {
+			expanded.Deps = deps
+			pipeline.Tasks = append(pipeline.Tasks, expanded)
+		}
+	}
+	return pipeline
+}
+
+// parsedTask is a task entry before its deps are resolved
+type parsedTask struct {
+	base  string
+	deps  []*yaml.Node
+	tasks []Task // One per matrix combination
+}
+
+// parseTask parses one task entry. On errors, tasks is empty.
+func (p *pipelineParser) parseTask(node *yaml.Node, defaults Task) parsedTask {
+	errCount := len(p.errs)
+	fields := p.fields(node, "task", taskKeys)
+	var parsed parsedTask
+	task := Task{Dir: defaults.Dir}
+
+	name, ok := fields["name"]
+	if !ok {
+		p.errorf(node, "task name is required")
+		return parsed
+	}
+	p.decode(name[1], "name", &parsed.base)
+	if parsed.base == "" || strings.ContainsAny(parsed.base, "[]") {
+		p.errorf(name[1], "task name %q must be non-empty and must not contain [ or ]", parsed.base)
+	}
+	task.Name = parsed.base
+
+	if run, ok := fields["run"]; ok {
+		p.decode(run[1], "run", &task.Command)
+	}
+	if task.Command == "" {
+		p.errorf(node, "task %q: run is required", parsed.base)
+	}
+	if deps, ok := fields["deps"]; ok {
+		if deps[1].Kind != yaml.SequenceNode {
+			p.errorf(deps[1], "task %q: deps must be a list", parsed.base)
+		} else {
+			parsed.deps = deps[1].Content
+		}
+	}
+	env := make(map[string]string, len(defaults.Env))
+	for key, value := range defaults.Env {
+		env[key] = value
+	}
+	if taskEnv, ok := fields["env"]; ok {
+		var values map[string]string
+		if p.decode(taskEnv[1], "env", &values) {
+			for key, value := range values {
+				env[key] = value
+			}
+		}
+	}
+	if len(env) > 0 {
+		task.Env = env
+	}
+	if dir, ok := fields["dir"]; ok {
+		var value string
+		p.decode(dir[1], "dir", &value)
+		if defaults.Dir != "" && !filepath.IsAbs(value) {
+			value = filepath.Join(defaults.Dir, value)
+		}
+		task.Dir = value
+	}
+	if cond, ok := fields["if"]; ok {
+		p.decode(cond[1], "if", &task.If)
+	}
+	if timeout, ok := fields["timeout"]; ok {
+		var value string
+		if p.decode(timeout[1], "timeout", &value) {
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				p.errorf(timeout[1], "task %q: timeout %q is not a positive duration like 90s or 10m", parsed.base, value)
+			}
+			task.Timeout = d
+		}
+	}
+	if retries, ok := fields["retries"]; ok {
+		var value int
+		if p.decode(retries[1], "retries", &value) {
+			if value < 0 {
+				p.errorf(retries[1], "task %q: retries must not be negative", parsed.base)
+			}
+			task.MaxAttempts = value + 1
+		}
+	}
+	if inputs, ok := fields["inputs"]; ok {
+		p.decode(inputs[1], "inputs", &task.Inputs)
+	}
+	if outputs, ok := fields["outputs"]; ok && p.decode(outputs[1], "outputs", &task.Outputs) {
+		for i, output := range task.Outputs {
+			if !filepath.IsLocal(output) {
+				p.errorf(outputs[1].Content[i], "task %q: output %q must be a relative path inside the task directory", parsed.base, output)
+			}
+		}
+	}
+	if cache, ok := fields["cache"]; ok && p.decode(cache[1], "cache", &task.Cache) {
+		if task.Cache && len(task.Inputs) == 0 {
+			p.errorf(cache[1], "task %q: cache needs inputs to hash", parsed.base)
+		}
+	}
+	if fingerprint, ok := fields["fingerprint"]; ok {
+		p.decode(fingerprint[1], "fingerprint", &task.Fingerprint)
+	}
+
+	matrix := map[string][]string{}
+	if m, ok := fields["matrix"]; ok && p.decode(m[1], "matrix", &matrix) {
+		for key, values := range matrix {
+			if len(values) == 0 {
+				p.errorf(m[1], "task %q: matrix %q has no values", parsed.base, key)
+			}
+		}
+	}
+	if len(p.errs) > errCount {
+		return parsed
+	}
+
+	for _, combination := range matrixCombinations(matrix) {
+		expanded, err := expandMatrix(task, combination)
+		if err != nil {
+			p.errorf(node, "task %q: %v", parsed.base, err)
+			parsed.tasks = nil
+			return parsed
+		}
+		parsed.tasks = append(parsed.tasks, expanded)
+	}
+	return parsed
+}
+
+// checkCycles validates the order with the scheduler and, on a cycle,
+// reports its path at the line of the first task on it
+func (p *pipelineParser) checkCycles(tasks []Task) {
+	if _, err := (&Scheduler{}).BuildExecutionOrder(tasks); err == nil {
+		return
+	}
+	cycle := findCycle(tasks)
+	if len(cycle) == 0 {
+		p.errs = append(p.errs, &PipelineError{File: p.file, Line: 1, Msg: "dependency cycle"})
+		return
+	}
+	p.errs = append(p.errs, &PipelineError{
+		File: p.file,
+		Line: p.lines[cycle[0]],
+		Msg:  "dependency cycle: " + strings.Join(cycle, " -> "),
+	})
+}
+
+// findCycle returns a dependency cycle as a path that starts and ends at
+// the same task, or nil
+func findCycle(tasks []Task) []string {
+	deps := make(map[string][]string, len(tasks))
+	for _, task := range tasks {
+		deps[task.Name] = task.Deps
+	}
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(tasks))
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			start := slices.Index(path, name)
+			return append(slices.Clone(path[start:]), name)
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range deps[name] {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+	for _, task := range tasks {
+		if cycle := visit(task.Name); cycle != nil {
+			// Report in execution direction: dependency -> dependent
+			slices.Reverse(cycle)
+			return cycle
+		}
+	}
+	return nil
+}
+
+// matrixCombinations returns every assignment of the matrix values, keys
+// varying slowest in sorted order. An empty matrix has one empty assignment.
+func matrixCombinations(matrix map[string][]string) []map[string]string {
+	keys := make([]string, 0, len(matrix))
+	for key := range matrix {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	combinations := []map[string]string{{}}
+	for _, key := range keys {
+		var next []map[string]string
+		for _, combination := range combinations {
+			for _, value := range matrix[key] {
+				extended := make(map[string]string, len(combination)+1)
+				for k, v := range combination {
+					extended[k] = v
+				}
+				extended[key] = value
+				next = append(next, extended)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// expandMatrix names the task after its matrix values and substitutes
+// ${matrix.KEY} in its command, condition, directory, fingerprint, env and
+// paths
+func expandMatrix(task Task, values map[string]string) (Task, error) {
+	if len(values) == 0 {
+		return task, nil
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	labels := make([]string, 0, len(keys))
+	for _, key := range keys {
+		labels = append(labels, key+"="+values[key])
+	}
+	task.Name = fmt.Sprintf("%s[%s]", task.Name, strings.Join(labels, ","))
+
+	var err error
+	substitute := func(s string) string {
+		for {
+			start := strings.Index(s, "${matrix.")
+			if start < 0 {
+				return s
+			}
+			end := strings.Index(s[start:], "}")
+			if end < 0 {
+				err = fmt.Errorf("unterminated %q", s[start:])
+				return s
+			}
+			key := s[start+len("${matrix.") : start+end]
+			value, ok := values[key]
+			if !ok {
+				err = fmt.Errorf("unknown matrix key %q", key)
+				return s
+			}
+			s = s[:start] + value + s[start+end+1:]
+		}
+	}
+
+	task.Command = substitute(task.Command)
+	task.If = substitute(task.If)
+	task.Dir = substitute(task.Dir)
+	task.Fingerprint = substitute(task.Fingerprint)
+	if task.Env != nil {
+		env := make(map[string]string, len(task.Env))
+		for key, value := range task.Env {
+			env[key] = substitute(value)
+		}
+		task.Env = env
+	}
+	task.Inputs = substituteAll(task.Inputs, substitute)
+	task.Outputs = substituteAll(task.Outputs, substitute)
+	return task, err
+}
+
+func substituteAll(values []string, substitute func(string) string) []string {
+	if values == nil {
+		return nil
+	}
+	out := make([]string, len(values))
+	for i, value := range values {
+		out[i] = substitute(value)
+	}
+	return out
+}
+
+// typeErrorText strips yaml's "yaml: unmarshal errors:" wrapping, whose line
+// numbers duplicate the PipelineError's
+func typeErrorText(err error) string {
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		msg := typeErr.Errors[0]
+		if i := strings.Index(msg, ": "); strings.HasPrefix(msg, "line ") && i >= 0 {
+			msg = msg[i+2:]
+		}
+		return msg
+	}
+	return err.Error()
+}
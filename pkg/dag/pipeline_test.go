@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package dag_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"open-swarm/pkg/dag"
+)
+
+const examplePipeline = `version: 1
+name: go-tdd
+env:
+  GOFLAGS: -count=1
+tasks:
+  - name: lint
+    run: golangci-lint run ./...
+    inputs: ["*.go"]
+  - name: unit
+    run: go test ./${matrix.pkg}/... -coverprofile=${matrix.pkg}.out
+    deps: [lint]
+    matrix:
+      pkg: [git, dag]
+    env: {CGO_ENABLED: "0"}
+    timeout: 5m
+    retries: 0
+    outputs: ["${matrix.pkg}.out"]
+  - name: e2e
+    run: go test ./test/...
+    deps: [unit]
+    if: test -n "$RUN_E2E"
+`
+
+func TestParsePipeline(t *testing.T) {
+	pipeline, err := dag.ParsePipeline("pipeline.yaml", []byte(examplePipeline))
+	require.NoError(t, err)
+
+	assert.Equal(t, "go-tdd", pipeline.Name)
+	require.Len(t, pipeline.Tasks, 4)
+	lint, unitGit, unitDag, e2e := pipeline.Tasks[0], pipeline.Tasks[1], pipeline.Tasks[2], pipeline.Tasks[3]
+
+	assert.Equal(t, map[string]string{"GOFLAGS": "-count=1"}, lint.Env)
+	assert.Zero(t, lint.MaxAttempts, "unset retries keeps the engine default")
+
+	assert.Equal(t, "unit[pkg=git]", unitGit.Name)
+	assert.Equal(t, "unit[pkg=dag]", unitDag.Name)
+	assert.Equal(t, "go test ./git/... -coverprofile=git.out", unitGit.Command)
+	assert.Equal(t, []string{"git.out"}, unitGit.Outputs)
+	assert.Equal(t, []string{"lint"}, unitGit.Deps)
+	assert.Equal(t, map[string]string{"GOFLAGS": "-count=1", "CGO_ENABLED": "0"}, unitGit.Env)
+	assert.Equal(t, 5*time.Minute, unitGit.Timeout)
+	assert.Equal(t, 1, unitGit.MaxAttempts)
+
+	assert.Equal(t, []string{"unit[pkg=git]", "unit[pkg=dag]"}, e2e.Deps, "depending on a matrix task depends on every expansion")
+	assert.Equal(t, `test -n "$RUN_E2E"`, e2e.If)
+}
+
+func TestParsePipeline_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr []string
+	}{
+		{"missing version", "tasks:\n  - {name: a, run: echo}\n", []string{"pipeline.yaml:1: version is required"}},
+		{"unsupported version", "version: 2\ntasks:\n  - {name: a, run: echo}\n", []string{"pipeline.yaml:1: unsupported version 2"}},
+		{"no tasks", "version: 1\n", []string{"at least one task is required"}},
+		{"unknown fields", "version: 1\nstages: []\ntasks:\n  - name: a\n    run: echo\n    command: echo\n", []string{
+			`pipeline.yaml:2: unknown pipeline field "stages"`,
+			`pipeline.yaml:6: unknown task field "command"`,
+		}},
+		{"task problems", `version: 1
+tasks:
+  - name: a
+    run: echo
+    deps: [b, lnt]
+    timeout: soon
+  - name: b
+    retries: many
+  - name: a
+    run: echo
+`, []string{
+			`pipeline.yaml:5: task "a" depends on unknown task "lnt"`,
+			`pipeline.yaml:6: task "a": timeout "soon" is not a positive duration`,
+			`pipeline.yaml:7: task "b": run is required`,
+			"pipeline.yaml:8: retries: cannot unmarshal !!str `many` into int",
+			`pipeline.yaml:9: duplicate task "a"`,
+		}},
+		{"escaping output", "version: 1\ntasks:\n  - name: a\n    run: echo\n    outputs:\n      - ../secret\n", []string{
+			`pipeline.yaml:6: task "a": output "../secret" must be a relative path`,
+		}},
+		{"cache without inputs", "version: 1\ntasks:\n  - name: a\n    run: echo\n    cache: true\n", []string{
+			`pipeline.yaml:5: task "a": cache needs inputs to hash`,
+		}},
+		{"unknown matrix key", "version: 1\ntasks:\n  - name: a\n    run: echo ${matrix.os}\n    matrix: {go: [\"1.25\"]}\n", []string{
+			`pipeline.yaml:3: task "a": unknown matrix key "os"`,
+		}},
+		{"cycle", `version: 1
+tasks:
+  - {name: setup, run: echo}
+  - {name: build, run: echo, deps: [setup, test]}
+  - {name: test, run: echo, deps: [build]}
+`, []string{"pipeline.yaml:4: dependency cycle: build -> test -> build"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := dag.ParsePipeline("pipeline.yaml", []byte(tt.yaml))
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestPipeline_PassesArtifactsBetweenTasks(t *testing.T) {
+	root := t.TempDir()
+	writeFile := func(name string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, name), 0o755))
+	}
+	writeFile("build")
+	writeFile("deploy")
+
+	pipeline, err := dag.ParsePipeline("pipeline.yaml", []byte(`version: 1
+dir: `+root+`
+tasks:
+  - name: build
+    dir: build
+    run: echo "$VERSION" > out/version.txt
+    env: {VERSION: v1.2.3}
+    if: mkdir -p out
+    outputs: [out/version.txt]
+  - name: deploy
+    dir: deploy
+    run: grep -q v1.2.3 out/version.txt
+    deps: [build]
+  - name: notify
+    run: echo never
+    deps: [deploy]
+    if: "false"
+`))
+	require.NoError(t, err)
+
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterActivity(&dag.ShellActivities{})
+
+	env.ExecuteWorkflow(dag.TddDagWorkflow, dag.WorkflowInput{Tasks: pipeline.Tasks})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	data, err := os.ReadFile(filepath.Join(root, "deploy", "out", "version.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.3\n", string(data))
+
+	states := taskStates(t, env)
+	assert.Equal(t, dag.TaskCompleted, states["deploy"].Status)
+	assert.Equal(t, dag.TaskSkipped, states["notify"].Status)
+}
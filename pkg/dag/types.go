@@ -1,6 +1,9 @@
 package dag
 
 import (
+	"path/filepath"
+	"strings"
+
 	"go.temporal.io/sdk/workflow"
 	"open-swarm/pkg/types"
 )
@@ -9,6 +12,47 @@ import (
 type Task = types.Task
 type WorkflowInput = types.DAGWorkflowInput
 
+// Signal and query names of TddDagWorkflow
+const (
+	FixAppliedSignal = "FixApplied"
+	StateQuery       = "dag-state"
+	CacheStatsQuery  = "dag-cache-stats"
+)
+
+// FixApplied is the FixApplied signal payload. It names what the fix
+// changed so only the affected tasks re-run. A plain string payload is
+// accepted as a Message-only fix.
+type FixApplied struct {
+	Message string
+	Tasks   []string // Tasks to re-run, even if they completed
+	Files   []string // Changed files, matched against Task.Inputs
+	Restart bool     // Discard all results and re-run the whole DAG
+}
+
+// Task statuses reported by StateQuery
+const (
+	TaskPending   = "pending"
+	TaskRunning   = "running"
+	TaskCompleted = "completed"
+	TaskFailed    = "failed"
+	TaskSkipped   = "skipped" // Its If condition was not met
+)
+
+// TaskState is the StateQuery view of one task
+type TaskState struct {
+	Name   string
+	Status string
+	Runs   int    // Times the task has been started
+	Error  string // Last failure
+	Cached bool   // Last completion came from the action cache
+}
+
+// CacheStats counts action cache lookups of cached tasks over all cycles
+type CacheStats struct {
+	Hits   int
+	Misses int
+}
+
 // State holds the mutable state of a running DAG.
 // Exploring this out allows for checkpointing in the future.
 type State struct {
@@ -17,6 +61,12 @@ type State struct {
 	Completed      map[string]bool
 	PendingFutures map[string]workflow.Future
 	FailedTasks    []string
+	Runs           map[string]int
+	Errors         map[string]string
+	Skipped        map[string]bool
+	Artifacts      map[string]map[string][]byte // Task outputs by task and path
+	Cached         map[string]bool
+	CacheStats     CacheStats
 }
 
 func NewState(tasks []Task, order []string) *State {
@@ -25,11 +75,152 @@ func NewState(tasks []Task, order []string) *State {
 		taskMap[t.Name] = t
 	}
 
-	return &State{
+	state := &State{
 		TaskMap:        taskMap,
 		FlatOrder:      order,
 		Completed:      make(map[string]bool),
 		PendingFutures: make(map[string]workflow.Future),
 		FailedTasks:    make([]string, 0),
 	}
+	state.initMaps()
+	return state
+}
+
+// initMaps creates the maps a state saved before they existed lacks
+func (s *State) initMaps() {
+	if s.Runs == nil {
+		s.Runs = make(map[string]int)
+	}
+	if s.Errors == nil {
+		s.Errors = make(map[string]string)
+	}
+	if s.Skipped == nil {
+		s.Skipped = make(map[string]bool)
+	}
+	if s.Artifacts == nil {
+		s.Artifacts = make(map[string]map[string][]byte)
+	}
+	if s.Cached == nil {
+		s.Cached = make(map[string]bool)
+	}
+}
+
+// usesTaskActivity reports whether the task needs RunDAGTask rather than
+// RunDAGScript: it has settings RunDAGScript lacks or receives artifacts
+func (s *State) usesTaskActivity(name string) bool {
+	task := s.TaskMap[name]
+	if len(task.Env) > 0 || task.Dir != "" || task.If != "" || len(task.Outputs) > 0 || task.Cache {
+		return true
+	}
+	for _, dep := range task.Deps {
+		if len(s.TaskMap[dep].Outputs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// taskRun builds the RunDAGTask input, passing on the outputs of the
+// task's dependencies
+func (s *State) taskRun(name string) TaskRun {
+	task := s.TaskMap[name]
+	run := TaskRun{
+		Name:    task.Name,
+		Command: task.Command,
+		Env:     task.Env,
+		Dir:     task.Dir,
+		If:      task.If,
+		Outputs: task.Outputs,
+
+		Inputs:      task.Inputs,
+		Cache:       task.Cache,
+		Fingerprint: task.Fingerprint,
+	}
+	for _, dep := range task.Deps {
+		for path, data := range s.Artifacts[dep] {
+			if run.Artifacts == nil {
+				run.Artifacts = make(map[string][]byte)
+			}
+			run.Artifacts[path] = data
+		}
+	}
+	return run
+}
+
+// Invalidate marks the fixed tasks and everything downstream of them as not
+// completed, so the next run re-executes them. Completed upstream results are
+// kept. Failed tasks re-run anyway since they never completed. It returns the
+// invalidated tasks in execution order.
+func (s *State) Invalidate(fix FixApplied) []string {
+	dirty := make(map[string]bool)
+	for _, name := range fix.Tasks {
+		dirty[name] = true
+	}
+	for _, name := range s.FlatOrder {
+		if matchesAny(s.TaskMap[name].Inputs, fix.Files) {
+			dirty[name] = true
+		}
+	}
+
+	// FlatOrder is topological, so one pass reaches every transitive dependent
+	var invalidated []string
+	for _, name := range s.FlatOrder {
+		if !dirty[name] {
+			for _, dep := range s.TaskMap[name].Deps {
+				if dirty[dep] {
+					dirty[name] = true
+					break
+				}
+			}
+		}
+		if dirty[name] {
+			delete(s.Completed, name)
+			delete(s.Skipped, name)
+			delete(s.Artifacts, name)
+			delete(s.Cached, name)
+			invalidated = append(invalidated, name)
+		}
+	}
+	return invalidated
+}
+
+// Snapshot returns the state of every task in execution order
+func (s *State) Snapshot() []TaskState {
+	failed := make(map[string]bool, len(s.FailedTasks))
+	for _, name := range s.FailedTasks {
+		failed[name] = true
+	}
+
+	states := make([]TaskState, 0, len(s.FlatOrder))
+	for _, name := range s.FlatOrder {
+		state := TaskState{Name: name, Status: TaskPending, Runs: s.Runs[name], Error: s.Errors[name], Cached: s.Cached[name]}
+		switch {
+		case s.Skipped[name]:
+			state.Status = TaskSkipped
+		case s.Completed[name]:
+			state.Status = TaskCompleted
+		case s.PendingFutures[name] != nil:
+			state.Status = TaskRunning
+		case failed[name]:
+			state.Status = TaskFailed
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
+// matchesAny reports whether a changed file matches one of the task inputs
+func matchesAny(inputs, files []string) bool {
+	for _, input := range inputs {
+		for _, file := range files {
+			file = filepath.ToSlash(filepath.Clean(file))
+			if strings.HasSuffix(input, "/") && strings.HasPrefix(file, input) {
+				return true
+			}
+			if ok, _ := filepath.Match(input, file); ok || input == file {
+				return true
+			}
+		}
+	}
+	return false
 }
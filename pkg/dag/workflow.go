@@ -1,6 +1,8 @@
 package dag
 
 import (
+	"encoding/json"
+
 	"go.temporal.io/sdk/workflow"
 )
 
@@ -15,6 +17,23 @@ func TddDagWorkflow(ctx workflow.Context, input WorkflowInput) error {
 	attempt := 1
 	var state *State // This variable will hold the state across retries.
 
+	if err := workflow.SetQueryHandler(ctx, StateQuery, func() ([]TaskState, error) {
+		if engine.State() == nil {
+			return nil, nil
+		}
+		return engine.State().Snapshot(), nil
+	}); err != nil {
+		return err
+	}
+	if err := workflow.SetQueryHandler(ctx, CacheStatsQuery, func() (CacheStats, error) {
+		if engine.State() == nil {
+			return CacheStats{}, nil
+		}
+		return engine.State().CacheStats, nil
+	}); err != nil {
+		return err
+	}
+
 	for {
 		logger.Info("TDD Cycle Start", "attempt", attempt)
 
@@ -33,12 +52,31 @@ func TddDagWorkflow(ctx workflow.Context, input WorkflowInput) error {
 
 		// 3. Wait for 'FixApplied' signal
 		logger.Info("Waiting for 'FixApplied' signal to retry...")
-		var signalVal string
-		workflow.GetSignalChannel(ctx, "FixApplied").Receive(ctx, &signalVal)
-
-		logger.Info("Received FixApplied signal, will restart from the beginning.", "message", signalVal)
+		var payload json.RawMessage
+		workflow.GetSignalChannel(ctx, FixAppliedSignal).Receive(ctx, &payload)
+		fix := decodeFixApplied(payload)
 		attempt++
-		// Reset state to nil to force a fresh run after a code fix has been applied.
-		state = nil
+
+		// 4. Resume: re-run the failed tasks plus whatever the fix touched.
+		// Without a plan (e.g. a cycle) there is nothing to keep.
+		if fix.Restart || state == nil {
+			logger.Info("Received FixApplied signal, will restart from the beginning.", "message", fix.Message)
+			state = nil
+			continue
+		}
+		invalidated := state.Invalidate(fix)
+		logger.Info("Received FixApplied signal, resuming from failed tasks.",
+			"message", fix.Message, "failed", state.FailedTasks, "invalidated", invalidated)
+	}
+}
+
+// decodeFixApplied reads a FixApplied payload, accepting the plain message
+// string older senders use
+func decodeFixApplied(payload json.RawMessage) FixApplied {
+	var fix FixApplied
+	if err := json.Unmarshal(payload, &fix); err == nil {
+		return fix
 	}
+	_ = json.Unmarshal(payload, &fix.Message)
+	return fix
 }
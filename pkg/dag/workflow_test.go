@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Open Swarm Contributors
+//
+// This software is released under the MIT License.
+// See LICENSE file in the repository for details.
+
+package dag_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+
+	"open-swarm/pkg/dag"
+)
+
+// pipeline is lint -> build -> test -> e2e, with docs on its own
+func pipeline() []dag.Task {
+	return []dag.Task{
+		{Name: "lint", Command: "lint", Inputs: []string{"*.go"}},
+		{Name: "build", Command: "build", Deps: []string{"lint"}, Inputs: []string{"internal/"}},
+		{Name: "test", Command: "test", Deps: []string{"build"}},
+		{Name: "e2e", Command: "e2e", Deps: []string{"test"}},
+		{Name: "docs", Command: "docs", Inputs: []string{"docs/"}},
+	}
+}
+
+// mockScripts fails each command in failing once, without activity retries;
+// runs counts every call
+func mockScripts(env *testsuite.TestWorkflowEnvironment, failing ...string) map[string]int {
+	runs := make(map[string]int)
+	activities := &dag.ShellActivities{}
+	env.RegisterActivity(activities)
+	env.OnActivity(activities.RunDAGScript, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, command string) (string, error) {
+			runs[command]++
+			for i, name := range failing {
+				if name == command {
+					failing = append(failing[:i], failing[i+1:]...)
+					return "", temporal.NewNonRetryableApplicationError(command+" failed", "ScriptFailed", nil)
+				}
+			}
+			return command + " ok", nil
+		})
+	return runs
+}
+
+func taskStates(t *testing.T, env *testsuite.TestWorkflowEnvironment) map[string]dag.TaskState {
+	t.Helper()
+	value, err := env.QueryWorkflow(dag.StateQuery)
+	require.NoError(t, err)
+	var states []dag.TaskState
+	require.NoError(t, value.Get(&states))
+	byName := make(map[string]dag.TaskState, len(states))
+	for _, state := range states {
+		byName[state.Name] = state
+	}
+	return byName
+}
+
+func TestTddDagWorkflow_ResumesFromFailedTask(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	runs := mockScripts(env, "test")
+
+	env.RegisterDelayedCallback(func() {
+		states := taskStates(t, env)
+		assert.Equal(t, dag.TaskFailed, states["test"].Status)
+		assert.Contains(t, states["test"].Error, "test failed")
+		assert.Equal(t, dag.TaskCompleted, states["build"].Status)
+		assert.Equal(t, dag.TaskPending, states["e2e"].Status)
+
+		env.SignalWorkflow(dag.FixAppliedSignal, dag.FixApplied{Message: "fix flaky assertion"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(dag.TddDagWorkflow, dag.WorkflowInput{Tasks: pipeline()})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	assert.Equal(t, map[string]int{"lint": 1, "build": 1, "test": 2, "e2e": 1, "docs": 1}, runs)
+	assert.Equal(t, 2, taskStates(t, env)["test"].Runs)
+}
+
+func TestTddDagWorkflow_FixInvalidatesAffectedTasks(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	runs := mockScripts(env, "test")
+
+	// The fix touched internal/, so build re-runs too, but lint and docs do not
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(dag.FixAppliedSignal, dag.FixApplied{Files: []string{"internal/parser/parse.go"}})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(dag.TddDagWorkflow, dag.WorkflowInput{Tasks: pipeline()})
+
+	require.NoError(t, env.GetWorkflowError())
+	assert.Equal(t, map[string]int{"lint": 1, "build": 2, "test": 2, "e2e": 1, "docs": 1}, runs)
+}
+
+func TestTddDagWorkflow_LegacyStringSignalAndRestart(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	runs := mockScripts(env, "test", "test")
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(dag.FixAppliedSignal, "Attempted fix")
+	}, time.Minute)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(dag.FixAppliedSignal, dag.FixApplied{Restart: true})
+	}, 2*time.Minute)
+
+	env.ExecuteWorkflow(dag.TddDagWorkflow, dag.WorkflowInput{Tasks: pipeline()})
+
+	require.NoError(t, env.GetWorkflowError())
+	assert.Equal(t, map[string]int{"lint": 2, "build": 2, "test": 3, "e2e": 1, "docs": 2}, runs)
+}
+
+func TestState_Invalidate(t *testing.T) {
+	tasks := pipeline()
+	state := dag.NewState(tasks, []string{"docs", "lint", "build", "test", "e2e"})
+	for _, task := range tasks {
+		state.Completed[task.Name] = true
+	}
+
+	assert.Equal(t, []string{"build", "test", "e2e"}, state.Invalidate(dag.FixApplied{Tasks: []string{"build"}}))
+	assert.True(t, state.Completed["lint"])
+	assert.True(t, state.Completed["docs"])
+
+	assert.Equal(t, []string{"docs", "lint", "build", "test", "e2e"},
+		state.Invalidate(dag.FixApplied{Files: []string{"main.go", "./docs/guide.md"}}))
+	assert.Empty(t, state.Invalidate(dag.FixApplied{Files: []string{"cmd/tool/main.go"}}),
+		"*.go does not cross directories")
+}
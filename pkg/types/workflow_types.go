@@ -17,6 +17,8 @@
 // - Composable: Types can be combined and extended
 package types
 
+import "time"
+
 // ============================================================================
 // DAG WORKFLOW TYPES
 // ============================================================================
@@ -33,6 +35,40 @@ type Task struct {
 
 	// Deps is the list of task names that must complete before this task runs
 	Deps []string // Dependencies (task names)
+
+	// Inputs lists the files the task depends on: exact paths, directories
+	// ending in "/", or glob patterns. A fix touching one of them re-runs the task.
+	Inputs []string
+
+	// Outputs lists files the task produces, relative to Dir. They are
+	// passed to the tasks that depend on this one.
+	Outputs []string
+
+	// Env holds extra environment variables for the command
+	Env map[string]string
+
+	// Dir is the working directory of the command (default: worker's)
+	Dir string
+
+	// If is a shell condition; when it exits non-zero the task is skipped
+	// and counts as completed for its dependents
+	If string
+
+	// Timeout bounds one attempt of the command (default: 10 minutes)
+	Timeout time.Duration
+
+	// MaxAttempts bounds the attempts of the command, the first included
+	// (default: 3)
+	MaxAttempts int
+
+	// Cache reuses the result of an earlier successful run whose inputs,
+	// command, environment and fingerprint hash the same. Inputs must list
+	// everything the command reads.
+	Cache bool
+
+	// Fingerprint is a shell command whose output joins the cache key, for
+	// state outside Inputs such as the toolchain version ("go version")
+	Fingerprint string
 }
 
 // DAGWorkflowInput defines input for DAG workflow execution.